@@ -38,6 +38,14 @@ type NAS struct {
 	LimitProxyState string                 `protobuf:"bytes,11,opt,name=limit_proxy_state,json=limitProxyState,proto3" json:"limit_proxy_state,omitempty"`
 	CreatedAt       *timestamp.Timestamp   `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       *timestamp.Timestamp   `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SecretRotatedAt *timestamp.Timestamp   `protobuf:"bytes,14,opt,name=secret_rotated_at,json=secretRotatedAt,proto3" json:"secret_rotated_at,omitempty"`
+	HealthCheck     bool                   `protobuf:"varint,15,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	HealthCheckPort int32                  `protobuf:"varint,16,opt,name=health_check_port,json=healthCheckPort,proto3" json:"health_check_port,omitempty"`
+	HealthStatus    string                 `protobuf:"bytes,17,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	LastSeenAt      *timestamp.Timestamp   `protobuf:"bytes,18,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastCheckedAt   *timestamp.Timestamp   `protobuf:"bytes,19,opt,name=last_checked_at,json=lastCheckedAt,proto3" json:"last_checked_at,omitempty"`
+	LastRttMs       int64                  `protobuf:"varint,20,opt,name=last_rtt_ms,json=lastRttMs,proto3" json:"last_rtt_ms,omitempty"`
+	TenantId        *uint32                `protobuf:"varint,21,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -163,6 +171,62 @@ func (x *NAS) GetUpdatedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (x *NAS) GetSecretRotatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.SecretRotatedAt
+	}
+	return nil
+}
+
+func (x *NAS) GetHealthCheck() bool {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return false
+}
+
+func (x *NAS) GetHealthCheckPort() int32 {
+	if x != nil {
+		return x.HealthCheckPort
+	}
+	return 0
+}
+
+func (x *NAS) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *NAS) GetLastSeenAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *NAS) GetLastCheckedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastCheckedAt
+	}
+	return nil
+}
+
+func (x *NAS) GetLastRttMs() int64 {
+	if x != nil {
+		return x.LastRttMs
+	}
+	return 0
+}
+
+func (x *NAS) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
 // Create NAS request
 type CreateNASRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -176,6 +240,8 @@ type CreateNASRequest struct {
 	Description     string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
 	RequireMa       string                 `protobuf:"bytes,9,opt,name=require_ma,json=requireMa,proto3" json:"require_ma,omitempty"`
 	LimitProxyState string                 `protobuf:"bytes,10,opt,name=limit_proxy_state,json=limitProxyState,proto3" json:"limit_proxy_state,omitempty"`
+	HealthCheck     bool                   `protobuf:"varint,11,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	HealthCheckPort int32                  `protobuf:"varint,12,opt,name=health_check_port,json=healthCheckPort,proto3" json:"health_check_port,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -280,6 +346,20 @@ func (x *CreateNASRequest) GetLimitProxyState() string {
 	return ""
 }
 
+func (x *CreateNASRequest) GetHealthCheck() bool {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return false
+}
+
+func (x *CreateNASRequest) GetHealthCheckPort() int32 {
+	if x != nil {
+		return x.HealthCheckPort
+	}
+	return 0
+}
+
 // Create NAS response
 type CreateNASResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -415,12 +495,20 @@ func (x *GetNASResponse) GetNas() *NAS {
 	return nil
 }
 
-// NAS filter for list operations
+// NAS filter for list operations.
+// Fields take the filter grammar of the REST lists: exact values, or
+// prefix:, contains:, in:a,b and from..to ranges. sort lists fields,
+// descending with a leading -.
 type NASFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Nasname       string                 `protobuf:"bytes,1,opt,name=nasname,proto3" json:"nasname,omitempty"`
 	Shortname     string                 `protobuf:"bytes,2,opt,name=shortname,proto3" json:"shortname,omitempty"`
 	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	HealthStatus  string                 `protobuf:"bytes,4,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    string                 `protobuf:"bytes,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	Sort          string                 `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -476,12 +564,52 @@ func (x *NASFilter) GetType() string {
 	return ""
 }
 
+func (x *NASFilter) GetHealthStatus() string {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return ""
+}
+
+func (x *NASFilter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *NASFilter) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *NASFilter) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *NASFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
 // List NAS request
 type ListNASRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Filter        *NASFilter             `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Filter   *NASFilter             `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// next_cursor of the previous page; page is ignored when set.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// How total is counted: exact (the default for pages), estimated or
+	// none (the default for cursors).
+	Count         string `protobuf:"bytes,5,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -537,15 +665,33 @@ func (x *ListNASRequest) GetFilter() *NASFilter {
 	return nil
 }
 
+func (x *ListNASRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListNASRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
 // List NAS response
 type ListNASResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nas           []*NAS                 `protobuf:"bytes,1,rep,name=nas,proto3" json:"nas,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nas   []*NAS                 `protobuf:"bytes,1,rep,name=nas,proto3" json:"nas,omitempty"`
+	// Zero when the list is not counted.
+	Total    int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor     string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,6,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListNASResponse) Reset() {
@@ -606,6 +752,20 @@ func (x *ListNASResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListNASResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListNASResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
 // Update NAS request
 type UpdateNASRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -620,6 +780,8 @@ type UpdateNASRequest struct {
 	Description     string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
 	RequireMa       string                 `protobuf:"bytes,10,opt,name=require_ma,json=requireMa,proto3" json:"require_ma,omitempty"`
 	LimitProxyState string                 `protobuf:"bytes,11,opt,name=limit_proxy_state,json=limitProxyState,proto3" json:"limit_proxy_state,omitempty"`
+	HealthCheck     *bool                  `protobuf:"varint,12,opt,name=health_check,json=healthCheck,proto3,oneof" json:"health_check,omitempty"`
+	HealthCheckPort *int32                 `protobuf:"varint,13,opt,name=health_check_port,json=healthCheckPort,proto3,oneof" json:"health_check_port,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -731,6 +893,20 @@ func (x *UpdateNASRequest) GetLimitProxyState() string {
 	return ""
 }
 
+func (x *UpdateNASRequest) GetHealthCheck() bool {
+	if x != nil && x.HealthCheck != nil {
+		return *x.HealthCheck
+	}
+	return false
+}
+
+func (x *UpdateNASRequest) GetHealthCheckPort() int32 {
+	if x != nil && x.HealthCheckPort != nil {
+		return *x.HealthCheckPort
+	}
+	return 0
+}
+
 // Update NAS response
 type UpdateNASResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -866,88 +1042,842 @@ func (x *DeleteNASResponse) GetSuccess() bool {
 	return false
 }
 
-var File_api_proto_nas_nas_proto protoreflect.FileDescriptor
+// Reveal NAS secret request
+type RevealNASSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_nas_nas_proto_rawDesc = "" +
-	"\n" +
-	"\x17api/proto/nas/nas.proto\x12\x03nas\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x03\n" +
-	"\x03NAS\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
-	"\anasname\x18\x02 \x01(\tR\anasname\x12\x1c\n" +
-	"\tshortname\x18\x03 \x01(\tR\tshortname\x12\x12\n" +
-	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
-	"\x05ports\x18\x05 \x01(\x05R\x05ports\x12\x16\n" +
-	"\x06secret\x18\x06 \x01(\tR\x06secret\x12\x16\n" +
-	"\x06server\x18\a \x01(\tR\x06server\x12\x1c\n" +
-	"\tcommunity\x18\b \x01(\tR\tcommunity\x12 \n" +
-	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1d\n" +
-	"\n" +
-	"require_ma\x18\n" +
-	" \x01(\tR\trequireMa\x12*\n" +
-	"\x11limit_proxy_state\x18\v \x01(\tR\x0flimitProxyState\x129\n" +
-	"\n" +
-	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaf\x02\n" +
-	"\x10CreateNASRequest\x12\x18\n" +
-	"\anasname\x18\x01 \x01(\tR\anasname\x12\x1c\n" +
-	"\tshortname\x18\x02 \x01(\tR\tshortname\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
-	"\x05ports\x18\x04 \x01(\x05R\x05ports\x12\x16\n" +
-	"\x06secret\x18\x05 \x01(\tR\x06secret\x12\x16\n" +
-	"\x06server\x18\x06 \x01(\tR\x06server\x12\x1c\n" +
-	"\tcommunity\x18\a \x01(\tR\tcommunity\x12 \n" +
-	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
-	"\n" +
-	"require_ma\x18\t \x01(\tR\trequireMa\x12*\n" +
-	"\x11limit_proxy_state\x18\n" +
-	" \x01(\tR\x0flimitProxyState\"/\n" +
-	"\x11CreateNASResponse\x12\x1a\n" +
-	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"\x1f\n" +
-	"\rGetNASRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\",\n" +
-	"\x0eGetNASResponse\x12\x1a\n" +
-	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"W\n" +
-	"\tNASFilter\x12\x18\n" +
-	"\anasname\x18\x01 \x01(\tR\anasname\x12\x1c\n" +
-	"\tshortname\x18\x02 \x01(\tR\tshortname\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\"i\n" +
-	"\x0eListNASRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12&\n" +
-	"\x06filter\x18\x03 \x01(\v2\x0e.nas.NASFilterR\x06filter\"t\n" +
-	"\x0fListNASResponse\x12\x1a\n" +
-	"\x03nas\x18\x01 \x03(\v2\b.nas.NASR\x03nas\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xbf\x02\n" +
-	"\x10UpdateNASRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
-	"\anasname\x18\x02 \x01(\tR\anasname\x12\x1c\n" +
-	"\tshortname\x18\x03 \x01(\tR\tshortname\x12\x12\n" +
-	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
-	"\x05ports\x18\x05 \x01(\x05R\x05ports\x12\x16\n" +
-	"\x06secret\x18\x06 \x01(\tR\x06secret\x12\x16\n" +
-	"\x06server\x18\a \x01(\tR\x06server\x12\x1c\n" +
-	"\tcommunity\x18\b \x01(\tR\tcommunity\x12 \n" +
-	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1d\n" +
-	"\n" +
-	"require_ma\x18\n" +
-	" \x01(\tR\trequireMa\x12*\n" +
-	"\x11limit_proxy_state\x18\v \x01(\tR\x0flimitProxyState\"/\n" +
-	"\x11UpdateNASResponse\x12\x1a\n" +
-	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"\"\n" +
-	"\x10DeleteNASRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"-\n" +
-	"\x11DeleteNASResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xa9\x02\n" +
+func (x *RevealNASSecretRequest) Reset() {
+	*x = RevealNASSecretRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealNASSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealNASSecretRequest) ProtoMessage() {}
+
+func (x *RevealNASSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealNASSecretRequest.ProtoReflect.Descriptor instead.
+func (*RevealNASSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevealNASSecretRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Rotate NAS secret request
+type RotateNASSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateNASSecretRequest) Reset() {
+	*x = RotateNASSecretRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateNASSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateNASSecretRequest) ProtoMessage() {}
+
+func (x *RotateNASSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateNASSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateNASSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RotateNASSecretRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// NAS secret response, the only message carrying a plaintext secret
+type NASSecretResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nasname         string                 `protobuf:"bytes,2,opt,name=nasname,proto3" json:"nasname,omitempty"`
+	Secret          string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	SecretRotatedAt *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=secret_rotated_at,json=secretRotatedAt,proto3" json:"secret_rotated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NASSecretResponse) Reset() {
+	*x = NASSecretResponse{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NASSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NASSecretResponse) ProtoMessage() {}
+
+func (x *NASSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NASSecretResponse.ProtoReflect.Descriptor instead.
+func (*NASSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NASSecretResponse) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NASSecretResponse) GetNasname() string {
+	if x != nil {
+		return x.Nasname
+	}
+	return ""
+}
+
+func (x *NASSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *NASSecretResponse) GetSecretRotatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.SecretRotatedAt
+	}
+	return nil
+}
+
+// Match NAS request
+type MatchNASRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchNASRequest) Reset() {
+	*x = MatchNASRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchNASRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchNASRequest) ProtoMessage() {}
+
+func (x *MatchNASRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchNASRequest.ProtoReflect.Descriptor instead.
+func (*MatchNASRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MatchNASRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+// Match NAS response
+type MatchNASResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	MatchedPrefix string                 `protobuf:"bytes,2,opt,name=matched_prefix,json=matchedPrefix,proto3" json:"matched_prefix,omitempty"`
+	Nas           *NAS                   `protobuf:"bytes,3,opt,name=nas,proto3" json:"nas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchNASResponse) Reset() {
+	*x = MatchNASResponse{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchNASResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchNASResponse) ProtoMessage() {}
+
+func (x *MatchNASResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchNASResponse.ProtoReflect.Descriptor instead.
+func (*MatchNASResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MatchNASResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *MatchNASResponse) GetMatchedPrefix() string {
+	if x != nil {
+		return x.MatchedPrefix
+	}
+	return ""
+}
+
+func (x *MatchNASResponse) GetNas() *NAS {
+	if x != nil {
+		return x.Nas
+	}
+	return nil
+}
+
+// Get clients.conf request
+type GetClientsConfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClientsConfRequest) Reset() {
+	*x = GetClientsConfRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClientsConfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClientsConfRequest) ProtoMessage() {}
+
+func (x *GetClientsConfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClientsConfRequest.ProtoReflect.Descriptor instead.
+func (*GetClientsConfRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{17}
+}
+
+// Get clients.conf response
+type GetClientsConfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClientsConfResponse) Reset() {
+	*x = GetClientsConfResponse{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClientsConfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClientsConfResponse) ProtoMessage() {}
+
+func (x *GetClientsConfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClientsConfResponse.ProtoReflect.Descriptor instead.
+func (*GetClientsConfResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetClientsConfResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Import clients.conf request
+type ImportClientsConfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportClientsConfRequest) Reset() {
+	*x = ImportClientsConfRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportClientsConfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportClientsConfRequest) ProtoMessage() {}
+
+func (x *ImportClientsConfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportClientsConfRequest.ProtoReflect.Descriptor instead.
+func (*ImportClientsConfRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportClientsConfRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ImportClientsConfRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// A field an import changes, with its old and new value
+type NASFieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Old           string                 `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NASFieldChange) Reset() {
+	*x = NASFieldChange{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NASFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NASFieldChange) ProtoMessage() {}
+
+func (x *NASFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NASFieldChange.ProtoReflect.Descriptor instead.
+func (*NASFieldChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NASFieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *NASFieldChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *NASFieldChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+// What an import does to one NAS
+type NASImportChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nasname       string                 `protobuf:"bytes,1,opt,name=nasname,proto3" json:"nasname,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Fields        []*NASFieldChange      `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NASImportChange) Reset() {
+	*x = NASImportChange{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NASImportChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NASImportChange) ProtoMessage() {}
+
+func (x *NASImportChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NASImportChange.ProtoReflect.Descriptor instead.
+func (*NASImportChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NASImportChange) GetNasname() string {
+	if x != nil {
+		return x.Nasname
+	}
+	return ""
+}
+
+func (x *NASImportChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *NASImportChange) GetFields() []*NASFieldChange {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Import clients.conf response
+type ImportClientsConfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Changes       []*NASImportChange     `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportClientsConfResponse) Reset() {
+	*x = ImportClientsConfResponse{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportClientsConfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportClientsConfResponse) ProtoMessage() {}
+
+func (x *ImportClientsConfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportClientsConfResponse.ProtoReflect.Descriptor instead.
+func (*ImportClientsConfResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ImportClientsConfResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportClientsConfResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportClientsConfResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportClientsConfResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportClientsConfResponse) GetChanges() []*NASImportChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Re-encrypt NAS secrets request
+type ReencryptNASSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReencryptNASSecretsRequest) Reset() {
+	*x = ReencryptNASSecretsRequest{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReencryptNASSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReencryptNASSecretsRequest) ProtoMessage() {}
+
+func (x *ReencryptNASSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReencryptNASSecretsRequest.ProtoReflect.Descriptor instead.
+func (*ReencryptNASSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{23}
+}
+
+// Re-encrypt NAS secrets response
+type ReencryptNASSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reencrypted   int32                  `protobuf:"varint,1,opt,name=reencrypted,proto3" json:"reencrypted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReencryptNASSecretsResponse) Reset() {
+	*x = ReencryptNASSecretsResponse{}
+	mi := &file_api_proto_nas_nas_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReencryptNASSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReencryptNASSecretsResponse) ProtoMessage() {}
+
+func (x *ReencryptNASSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nas_nas_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReencryptNASSecretsResponse.ProtoReflect.Descriptor instead.
+func (*ReencryptNASSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nas_nas_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReencryptNASSecretsResponse) GetReencrypted() int32 {
+	if x != nil {
+		return x.Reencrypted
+	}
+	return 0
+}
+
+var File_api_proto_nas_nas_proto protoreflect.FileDescriptor
+
+const file_api_proto_nas_nas_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/proto/nas/nas.proto\x12\x03nas\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x06\n" +
+	"\x03NAS\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\anasname\x18\x02 \x01(\tR\anasname\x12\x1c\n" +
+	"\tshortname\x18\x03 \x01(\tR\tshortname\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05ports\x18\x05 \x01(\x05R\x05ports\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06server\x18\a \x01(\tR\x06server\x12\x1c\n" +
+	"\tcommunity\x18\b \x01(\tR\tcommunity\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"require_ma\x18\n" +
+	" \x01(\tR\trequireMa\x12*\n" +
+	"\x11limit_proxy_state\x18\v \x01(\tR\x0flimitProxyState\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12F\n" +
+	"\x11secret_rotated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0fsecretRotatedAt\x12!\n" +
+	"\fhealth_check\x18\x0f \x01(\bR\vhealthCheck\x12*\n" +
+	"\x11health_check_port\x18\x10 \x01(\x05R\x0fhealthCheckPort\x12#\n" +
+	"\rhealth_status\x18\x11 \x01(\tR\fhealthStatus\x12<\n" +
+	"\flast_seen_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x12B\n" +
+	"\x0flast_checked_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\rlastCheckedAt\x12\x1e\n" +
+	"\vlast_rtt_ms\x18\x14 \x01(\x03R\tlastRttMs\x12 \n" +
+	"\ttenant_id\x18\x15 \x01(\rH\x00R\btenantId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tenant_id\"\xfe\x02\n" +
+	"\x10CreateNASRequest\x12\x18\n" +
+	"\anasname\x18\x01 \x01(\tR\anasname\x12\x1c\n" +
+	"\tshortname\x18\x02 \x01(\tR\tshortname\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05ports\x18\x04 \x01(\x05R\x05ports\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06server\x18\x06 \x01(\tR\x06server\x12\x1c\n" +
+	"\tcommunity\x18\a \x01(\tR\tcommunity\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"require_ma\x18\t \x01(\tR\trequireMa\x12*\n" +
+	"\x11limit_proxy_state\x18\n" +
+	" \x01(\tR\x0flimitProxyState\x12!\n" +
+	"\fhealth_check\x18\v \x01(\bR\vhealthCheck\x12*\n" +
+	"\x11health_check_port\x18\f \x01(\x05R\x0fhealthCheckPort\"/\n" +
+	"\x11CreateNASResponse\x12\x1a\n" +
+	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"\x1f\n" +
+	"\rGetNASRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\",\n" +
+	"\x0eGetNASResponse\x12\x1a\n" +
+	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"\xf3\x01\n" +
+	"\tNASFilter\x12\x18\n" +
+	"\anasname\x18\x01 \x01(\tR\anasname\x12\x1c\n" +
+	"\tshortname\x18\x02 \x01(\tR\tshortname\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12#\n" +
+	"\rhealth_status\x18\x04 \x01(\tR\fhealthStatus\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12 \n" +
+	"\flast_seen_at\x18\a \x01(\tR\n" +
+	"lastSeenAt\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sort\"\x97\x01\n" +
+	"\x0eListNASRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12&\n" +
+	"\x06filter\x18\x03 \x01(\v2\x0e.nas.NASFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\tR\x05count\"\xbe\x01\n" +
+	"\x0fListNASResponse\x12\x1a\n" +
+	"\x03nas\x18\x01 \x03(\v2\b.nas.NASR\x03nas\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12'\n" +
+	"\x0ftotal_estimated\x18\x06 \x01(\bR\x0etotalEstimated\"\xbf\x03\n" +
+	"\x10UpdateNASRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\anasname\x18\x02 \x01(\tR\anasname\x12\x1c\n" +
+	"\tshortname\x18\x03 \x01(\tR\tshortname\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05ports\x18\x05 \x01(\x05R\x05ports\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06server\x18\a \x01(\tR\x06server\x12\x1c\n" +
+	"\tcommunity\x18\b \x01(\tR\tcommunity\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"require_ma\x18\n" +
+	" \x01(\tR\trequireMa\x12*\n" +
+	"\x11limit_proxy_state\x18\v \x01(\tR\x0flimitProxyState\x12&\n" +
+	"\fhealth_check\x18\f \x01(\bH\x00R\vhealthCheck\x88\x01\x01\x12/\n" +
+	"\x11health_check_port\x18\r \x01(\x05H\x01R\x0fhealthCheckPort\x88\x01\x01B\x0f\n" +
+	"\r_health_checkB\x14\n" +
+	"\x12_health_check_port\"/\n" +
+	"\x11UpdateNASResponse\x12\x1a\n" +
+	"\x03nas\x18\x01 \x01(\v2\b.nas.NASR\x03nas\"\"\n" +
+	"\x10DeleteNASRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"-\n" +
+	"\x11DeleteNASResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"(\n" +
+	"\x16RevealNASSecretRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"(\n" +
+	"\x16RotateNASSecretRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x9d\x01\n" +
+	"\x11NASSecretResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\anasname\x18\x02 \x01(\tR\anasname\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12F\n" +
+	"\x11secret_rotated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fsecretRotatedAt\"!\n" +
+	"\x0fMatchNASRequest\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\"e\n" +
+	"\x10MatchNASResponse\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12%\n" +
+	"\x0ematched_prefix\x18\x02 \x01(\tR\rmatchedPrefix\x12\x1a\n" +
+	"\x03nas\x18\x03 \x01(\v2\b.nas.NASR\x03nas\"\x17\n" +
+	"\x15GetClientsConfRequest\"2\n" +
+	"\x16GetClientsConfResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"M\n" +
+	"\x18ImportClientsConfRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"J\n" +
+	"\x0eNASFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x10\n" +
+	"\x03old\x18\x02 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x03 \x01(\tR\x03new\"p\n" +
+	"\x0fNASImportChange\x12\x18\n" +
+	"\anasname\x18\x01 \x01(\tR\anasname\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12+\n" +
+	"\x06fields\x18\x03 \x03(\v2\x13.nas.NASFieldChangeR\x06fields\"\xb6\x01\n" +
+	"\x19ImportClientsConfResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12.\n" +
+	"\achanges\x18\x05 \x03(\v2\x14.nas.NASImportChangeR\achanges\"\x1c\n" +
+	"\x1aReencryptNASSecretsRequest\"?\n" +
+	"\x1bReencryptNASSecretsResponse\x12 \n" +
+	"\vreencrypted\x18\x01 \x01(\x05R\vreencrypted2\xeb\x05\n" +
 	"\n" +
 	"NASService\x12:\n" +
 	"\tCreateNAS\x12\x15.nas.CreateNASRequest\x1a\x16.nas.CreateNASResponse\x121\n" +
 	"\x06GetNAS\x12\x12.nas.GetNASRequest\x1a\x13.nas.GetNASResponse\x124\n" +
 	"\aListNAS\x12\x13.nas.ListNASRequest\x1a\x14.nas.ListNASResponse\x12:\n" +
 	"\tUpdateNAS\x12\x15.nas.UpdateNASRequest\x1a\x16.nas.UpdateNASResponse\x12:\n" +
-	"\tDeleteNAS\x12\x15.nas.DeleteNASRequest\x1a\x16.nas.DeleteNASResponseB;Z9github.com/novriyantoAli/freeradius-service/api/proto/nasb\x06proto3"
+	"\tDeleteNAS\x12\x15.nas.DeleteNASRequest\x1a\x16.nas.DeleteNASResponse\x12F\n" +
+	"\x0fRevealNASSecret\x12\x1b.nas.RevealNASSecretRequest\x1a\x16.nas.NASSecretResponse\x12F\n" +
+	"\x0fRotateNASSecret\x12\x1b.nas.RotateNASSecretRequest\x1a\x16.nas.NASSecretResponse\x127\n" +
+	"\bMatchNAS\x12\x14.nas.MatchNASRequest\x1a\x15.nas.MatchNASResponse\x12I\n" +
+	"\x0eGetClientsConf\x12\x1a.nas.GetClientsConfRequest\x1a\x1b.nas.GetClientsConfResponse\x12R\n" +
+	"\x11ImportClientsConf\x12\x1d.nas.ImportClientsConfRequest\x1a\x1e.nas.ImportClientsConfResponse\x12X\n" +
+	"\x13ReencryptNASSecrets\x12\x1f.nas.ReencryptNASSecretsRequest\x1a .nas.ReencryptNASSecretsResponseB;Z9github.com/novriyantoAli/freeradius-service/api/proto/nasb\x06proto3"
 
 var (
 	file_api_proto_nas_nas_proto_rawDescOnce sync.Once
@@ -961,45 +1891,77 @@ func file_api_proto_nas_nas_proto_rawDescGZIP() []byte {
 	return file_api_proto_nas_nas_proto_rawDescData
 }
 
-var file_api_proto_nas_nas_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_nas_nas_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_proto_nas_nas_proto_goTypes = []any{
-	(*NAS)(nil),                 // 0: nas.NAS
-	(*CreateNASRequest)(nil),    // 1: nas.CreateNASRequest
-	(*CreateNASResponse)(nil),   // 2: nas.CreateNASResponse
-	(*GetNASRequest)(nil),       // 3: nas.GetNASRequest
-	(*GetNASResponse)(nil),      // 4: nas.GetNASResponse
-	(*NASFilter)(nil),           // 5: nas.NASFilter
-	(*ListNASRequest)(nil),      // 6: nas.ListNASRequest
-	(*ListNASResponse)(nil),     // 7: nas.ListNASResponse
-	(*UpdateNASRequest)(nil),    // 8: nas.UpdateNASRequest
-	(*UpdateNASResponse)(nil),   // 9: nas.UpdateNASResponse
-	(*DeleteNASRequest)(nil),    // 10: nas.DeleteNASRequest
-	(*DeleteNASResponse)(nil),   // 11: nas.DeleteNASResponse
-	(*timestamp.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*NAS)(nil),                         // 0: nas.NAS
+	(*CreateNASRequest)(nil),            // 1: nas.CreateNASRequest
+	(*CreateNASResponse)(nil),           // 2: nas.CreateNASResponse
+	(*GetNASRequest)(nil),               // 3: nas.GetNASRequest
+	(*GetNASResponse)(nil),              // 4: nas.GetNASResponse
+	(*NASFilter)(nil),                   // 5: nas.NASFilter
+	(*ListNASRequest)(nil),              // 6: nas.ListNASRequest
+	(*ListNASResponse)(nil),             // 7: nas.ListNASResponse
+	(*UpdateNASRequest)(nil),            // 8: nas.UpdateNASRequest
+	(*UpdateNASResponse)(nil),           // 9: nas.UpdateNASResponse
+	(*DeleteNASRequest)(nil),            // 10: nas.DeleteNASRequest
+	(*DeleteNASResponse)(nil),           // 11: nas.DeleteNASResponse
+	(*RevealNASSecretRequest)(nil),      // 12: nas.RevealNASSecretRequest
+	(*RotateNASSecretRequest)(nil),      // 13: nas.RotateNASSecretRequest
+	(*NASSecretResponse)(nil),           // 14: nas.NASSecretResponse
+	(*MatchNASRequest)(nil),             // 15: nas.MatchNASRequest
+	(*MatchNASResponse)(nil),            // 16: nas.MatchNASResponse
+	(*GetClientsConfRequest)(nil),       // 17: nas.GetClientsConfRequest
+	(*GetClientsConfResponse)(nil),      // 18: nas.GetClientsConfResponse
+	(*ImportClientsConfRequest)(nil),    // 19: nas.ImportClientsConfRequest
+	(*NASFieldChange)(nil),              // 20: nas.NASFieldChange
+	(*NASImportChange)(nil),             // 21: nas.NASImportChange
+	(*ImportClientsConfResponse)(nil),   // 22: nas.ImportClientsConfResponse
+	(*ReencryptNASSecretsRequest)(nil),  // 23: nas.ReencryptNASSecretsRequest
+	(*ReencryptNASSecretsResponse)(nil), // 24: nas.ReencryptNASSecretsResponse
+	(*timestamp.Timestamp)(nil),         // 25: google.protobuf.Timestamp
 }
 var file_api_proto_nas_nas_proto_depIdxs = []int32{
-	12, // 0: nas.NAS.created_at:type_name -> google.protobuf.Timestamp
-	12, // 1: nas.NAS.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: nas.CreateNASResponse.nas:type_name -> nas.NAS
-	0,  // 3: nas.GetNASResponse.nas:type_name -> nas.NAS
-	5,  // 4: nas.ListNASRequest.filter:type_name -> nas.NASFilter
-	0,  // 5: nas.ListNASResponse.nas:type_name -> nas.NAS
-	0,  // 6: nas.UpdateNASResponse.nas:type_name -> nas.NAS
-	1,  // 7: nas.NASService.CreateNAS:input_type -> nas.CreateNASRequest
-	3,  // 8: nas.NASService.GetNAS:input_type -> nas.GetNASRequest
-	6,  // 9: nas.NASService.ListNAS:input_type -> nas.ListNASRequest
-	8,  // 10: nas.NASService.UpdateNAS:input_type -> nas.UpdateNASRequest
-	10, // 11: nas.NASService.DeleteNAS:input_type -> nas.DeleteNASRequest
-	2,  // 12: nas.NASService.CreateNAS:output_type -> nas.CreateNASResponse
-	4,  // 13: nas.NASService.GetNAS:output_type -> nas.GetNASResponse
-	7,  // 14: nas.NASService.ListNAS:output_type -> nas.ListNASResponse
-	9,  // 15: nas.NASService.UpdateNAS:output_type -> nas.UpdateNASResponse
-	11, // 16: nas.NASService.DeleteNAS:output_type -> nas.DeleteNASResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	25, // 0: nas.NAS.created_at:type_name -> google.protobuf.Timestamp
+	25, // 1: nas.NAS.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 2: nas.NAS.secret_rotated_at:type_name -> google.protobuf.Timestamp
+	25, // 3: nas.NAS.last_seen_at:type_name -> google.protobuf.Timestamp
+	25, // 4: nas.NAS.last_checked_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: nas.CreateNASResponse.nas:type_name -> nas.NAS
+	0,  // 6: nas.GetNASResponse.nas:type_name -> nas.NAS
+	5,  // 7: nas.ListNASRequest.filter:type_name -> nas.NASFilter
+	0,  // 8: nas.ListNASResponse.nas:type_name -> nas.NAS
+	0,  // 9: nas.UpdateNASResponse.nas:type_name -> nas.NAS
+	25, // 10: nas.NASSecretResponse.secret_rotated_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: nas.MatchNASResponse.nas:type_name -> nas.NAS
+	20, // 12: nas.NASImportChange.fields:type_name -> nas.NASFieldChange
+	21, // 13: nas.ImportClientsConfResponse.changes:type_name -> nas.NASImportChange
+	1,  // 14: nas.NASService.CreateNAS:input_type -> nas.CreateNASRequest
+	3,  // 15: nas.NASService.GetNAS:input_type -> nas.GetNASRequest
+	6,  // 16: nas.NASService.ListNAS:input_type -> nas.ListNASRequest
+	8,  // 17: nas.NASService.UpdateNAS:input_type -> nas.UpdateNASRequest
+	10, // 18: nas.NASService.DeleteNAS:input_type -> nas.DeleteNASRequest
+	12, // 19: nas.NASService.RevealNASSecret:input_type -> nas.RevealNASSecretRequest
+	13, // 20: nas.NASService.RotateNASSecret:input_type -> nas.RotateNASSecretRequest
+	15, // 21: nas.NASService.MatchNAS:input_type -> nas.MatchNASRequest
+	17, // 22: nas.NASService.GetClientsConf:input_type -> nas.GetClientsConfRequest
+	19, // 23: nas.NASService.ImportClientsConf:input_type -> nas.ImportClientsConfRequest
+	23, // 24: nas.NASService.ReencryptNASSecrets:input_type -> nas.ReencryptNASSecretsRequest
+	2,  // 25: nas.NASService.CreateNAS:output_type -> nas.CreateNASResponse
+	4,  // 26: nas.NASService.GetNAS:output_type -> nas.GetNASResponse
+	7,  // 27: nas.NASService.ListNAS:output_type -> nas.ListNASResponse
+	9,  // 28: nas.NASService.UpdateNAS:output_type -> nas.UpdateNASResponse
+	11, // 29: nas.NASService.DeleteNAS:output_type -> nas.DeleteNASResponse
+	14, // 30: nas.NASService.RevealNASSecret:output_type -> nas.NASSecretResponse
+	14, // 31: nas.NASService.RotateNASSecret:output_type -> nas.NASSecretResponse
+	16, // 32: nas.NASService.MatchNAS:output_type -> nas.MatchNASResponse
+	18, // 33: nas.NASService.GetClientsConf:output_type -> nas.GetClientsConfResponse
+	22, // 34: nas.NASService.ImportClientsConf:output_type -> nas.ImportClientsConfResponse
+	24, // 35: nas.NASService.ReencryptNASSecrets:output_type -> nas.ReencryptNASSecretsResponse
+	25, // [25:36] is the sub-list for method output_type
+	14, // [14:25] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_nas_nas_proto_init() }
@@ -1007,13 +1969,15 @@ func file_api_proto_nas_nas_proto_init() {
 	if File_api_proto_nas_nas_proto != nil {
 		return
 	}
+	file_api_proto_nas_nas_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_proto_nas_nas_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_nas_nas_proto_rawDesc), len(file_api_proto_nas_nas_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
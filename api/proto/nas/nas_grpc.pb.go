@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	NASService_CreateNAS_FullMethodName = "/nas.NASService/CreateNAS"
-	NASService_GetNAS_FullMethodName    = "/nas.NASService/GetNAS"
-	NASService_ListNAS_FullMethodName   = "/nas.NASService/ListNAS"
-	NASService_UpdateNAS_FullMethodName = "/nas.NASService/UpdateNAS"
-	NASService_DeleteNAS_FullMethodName = "/nas.NASService/DeleteNAS"
+	NASService_CreateNAS_FullMethodName           = "/nas.NASService/CreateNAS"
+	NASService_GetNAS_FullMethodName              = "/nas.NASService/GetNAS"
+	NASService_ListNAS_FullMethodName             = "/nas.NASService/ListNAS"
+	NASService_UpdateNAS_FullMethodName           = "/nas.NASService/UpdateNAS"
+	NASService_DeleteNAS_FullMethodName           = "/nas.NASService/DeleteNAS"
+	NASService_RevealNASSecret_FullMethodName     = "/nas.NASService/RevealNASSecret"
+	NASService_RotateNASSecret_FullMethodName     = "/nas.NASService/RotateNASSecret"
+	NASService_MatchNAS_FullMethodName            = "/nas.NASService/MatchNAS"
+	NASService_GetClientsConf_FullMethodName      = "/nas.NASService/GetClientsConf"
+	NASService_ImportClientsConf_FullMethodName   = "/nas.NASService/ImportClientsConf"
+	NASService_ReencryptNASSecrets_FullMethodName = "/nas.NASService/ReencryptNASSecrets"
 )
 
 // NASServiceClient is the client API for NASService service.
@@ -40,6 +46,18 @@ type NASServiceClient interface {
 	UpdateNAS(ctx context.Context, in *UpdateNASRequest, opts ...grpc.CallOption) (*UpdateNASResponse, error)
 	// Delete a NAS
 	DeleteNAS(ctx context.Context, in *DeleteNASRequest, opts ...grpc.CallOption) (*DeleteNASResponse, error)
+	// Reveal the plaintext secret of a NAS
+	RevealNASSecret(ctx context.Context, in *RevealNASSecretRequest, opts ...grpc.CallOption) (*NASSecretResponse, error)
+	// Rotate the secret of a NAS to a newly generated one
+	RotateNASSecret(ctx context.Context, in *RotateNASSecretRequest, opts ...grpc.CallOption) (*NASSecretResponse, error)
+	// Find the NAS FreeRADIUS would use for a request from a source IP
+	MatchNAS(ctx context.Context, in *MatchNASRequest, opts ...grpc.CallOption) (*MatchNASResponse, error)
+	// Render every NAS as a FreeRADIUS clients.conf document
+	GetClientsConf(ctx context.Context, in *GetClientsConfRequest, opts ...grpc.CallOption) (*GetClientsConfResponse, error)
+	// Upsert the client blocks of a clients.conf, or only report the changes
+	ImportClientsConf(ctx context.Context, in *ImportClientsConfRequest, opts ...grpc.CallOption) (*ImportClientsConfResponse, error)
+	// Rewrite every NAS secret not encrypted under the active key and bound to its row
+	ReencryptNASSecrets(ctx context.Context, in *ReencryptNASSecretsRequest, opts ...grpc.CallOption) (*ReencryptNASSecretsResponse, error)
 }
 
 type nASServiceClient struct {
@@ -95,6 +113,60 @@ func (c *nASServiceClient) DeleteNAS(ctx context.Context, in *DeleteNASRequest,
 	return out, nil
 }
 
+func (c *nASServiceClient) RevealNASSecret(ctx context.Context, in *RevealNASSecretRequest, opts ...grpc.CallOption) (*NASSecretResponse, error) {
+	out := new(NASSecretResponse)
+	err := c.cc.Invoke(ctx, NASService_RevealNASSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nASServiceClient) RotateNASSecret(ctx context.Context, in *RotateNASSecretRequest, opts ...grpc.CallOption) (*NASSecretResponse, error) {
+	out := new(NASSecretResponse)
+	err := c.cc.Invoke(ctx, NASService_RotateNASSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nASServiceClient) MatchNAS(ctx context.Context, in *MatchNASRequest, opts ...grpc.CallOption) (*MatchNASResponse, error) {
+	out := new(MatchNASResponse)
+	err := c.cc.Invoke(ctx, NASService_MatchNAS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nASServiceClient) GetClientsConf(ctx context.Context, in *GetClientsConfRequest, opts ...grpc.CallOption) (*GetClientsConfResponse, error) {
+	out := new(GetClientsConfResponse)
+	err := c.cc.Invoke(ctx, NASService_GetClientsConf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nASServiceClient) ImportClientsConf(ctx context.Context, in *ImportClientsConfRequest, opts ...grpc.CallOption) (*ImportClientsConfResponse, error) {
+	out := new(ImportClientsConfResponse)
+	err := c.cc.Invoke(ctx, NASService_ImportClientsConf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nASServiceClient) ReencryptNASSecrets(ctx context.Context, in *ReencryptNASSecretsRequest, opts ...grpc.CallOption) (*ReencryptNASSecretsResponse, error) {
+	out := new(ReencryptNASSecretsResponse)
+	err := c.cc.Invoke(ctx, NASService_ReencryptNASSecrets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NASServiceServer is the server API for NASService service.
 // All implementations should embed UnimplementedNASServiceServer
 // for forward compatibility
@@ -109,6 +181,18 @@ type NASServiceServer interface {
 	UpdateNAS(context.Context, *UpdateNASRequest) (*UpdateNASResponse, error)
 	// Delete a NAS
 	DeleteNAS(context.Context, *DeleteNASRequest) (*DeleteNASResponse, error)
+	// Reveal the plaintext secret of a NAS
+	RevealNASSecret(context.Context, *RevealNASSecretRequest) (*NASSecretResponse, error)
+	// Rotate the secret of a NAS to a newly generated one
+	RotateNASSecret(context.Context, *RotateNASSecretRequest) (*NASSecretResponse, error)
+	// Find the NAS FreeRADIUS would use for a request from a source IP
+	MatchNAS(context.Context, *MatchNASRequest) (*MatchNASResponse, error)
+	// Render every NAS as a FreeRADIUS clients.conf document
+	GetClientsConf(context.Context, *GetClientsConfRequest) (*GetClientsConfResponse, error)
+	// Upsert the client blocks of a clients.conf, or only report the changes
+	ImportClientsConf(context.Context, *ImportClientsConfRequest) (*ImportClientsConfResponse, error)
+	// Rewrite every NAS secret not encrypted under the active key and bound to its row
+	ReencryptNASSecrets(context.Context, *ReencryptNASSecretsRequest) (*ReencryptNASSecretsResponse, error)
 }
 
 // UnimplementedNASServiceServer should be embedded to have forward compatible implementations.
@@ -130,6 +214,24 @@ func (UnimplementedNASServiceServer) UpdateNAS(context.Context, *UpdateNASReques
 func (UnimplementedNASServiceServer) DeleteNAS(context.Context, *DeleteNASRequest) (*DeleteNASResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteNAS not implemented")
 }
+func (UnimplementedNASServiceServer) RevealNASSecret(context.Context, *RevealNASSecretRequest) (*NASSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevealNASSecret not implemented")
+}
+func (UnimplementedNASServiceServer) RotateNASSecret(context.Context, *RotateNASSecretRequest) (*NASSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateNASSecret not implemented")
+}
+func (UnimplementedNASServiceServer) MatchNAS(context.Context, *MatchNASRequest) (*MatchNASResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchNAS not implemented")
+}
+func (UnimplementedNASServiceServer) GetClientsConf(context.Context, *GetClientsConfRequest) (*GetClientsConfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientsConf not implemented")
+}
+func (UnimplementedNASServiceServer) ImportClientsConf(context.Context, *ImportClientsConfRequest) (*ImportClientsConfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportClientsConf not implemented")
+}
+func (UnimplementedNASServiceServer) ReencryptNASSecrets(context.Context, *ReencryptNASSecretsRequest) (*ReencryptNASSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReencryptNASSecrets not implemented")
+}
 
 // UnsafeNASServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to NASServiceServer will
@@ -232,6 +334,114 @@ func _NASService_DeleteNAS_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NASService_RevealNASSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevealNASSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).RevealNASSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_RevealNASSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).RevealNASSecret(ctx, req.(*RevealNASSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NASService_RotateNASSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateNASSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).RotateNASSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_RotateNASSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).RotateNASSecret(ctx, req.(*RotateNASSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NASService_MatchNAS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchNASRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).MatchNAS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_MatchNAS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).MatchNAS(ctx, req.(*MatchNASRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NASService_GetClientsConf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientsConfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).GetClientsConf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_GetClientsConf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).GetClientsConf(ctx, req.(*GetClientsConfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NASService_ImportClientsConf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportClientsConfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).ImportClientsConf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_ImportClientsConf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).ImportClientsConf(ctx, req.(*ImportClientsConfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NASService_ReencryptNASSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptNASSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NASServiceServer).ReencryptNASSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NASService_ReencryptNASSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NASServiceServer).ReencryptNASSecrets(ctx, req.(*ReencryptNASSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NASService_ServiceDesc is the grpc.ServiceDesc for NASService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +469,30 @@ var NASService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteNAS",
 			Handler:    _NASService_DeleteNAS_Handler,
 		},
+		{
+			MethodName: "RevealNASSecret",
+			Handler:    _NASService_RevealNASSecret_Handler,
+		},
+		{
+			MethodName: "RotateNASSecret",
+			Handler:    _NASService_RotateNASSecret_Handler,
+		},
+		{
+			MethodName: "MatchNAS",
+			Handler:    _NASService_MatchNAS_Handler,
+		},
+		{
+			MethodName: "GetClientsConf",
+			Handler:    _NASService_GetClientsConf_Handler,
+		},
+		{
+			MethodName: "ImportClientsConf",
+			Handler:    _NASService_ImportClientsConf_Handler,
+		},
+		{
+			MethodName: "ReencryptNASSecrets",
+			Handler:    _NASService_ReencryptNASSecrets_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/nas/nas.proto",
@@ -386,20 +386,107 @@ func (x *GetPaymentResponse) GetPayment() *Payment {
 	return nil
 }
 
+// Payment filter for list operations.
+// Fields take the filter grammar of the REST lists: exact values, or
+// prefix:, contains:, in:a,b and from..to ranges. sort lists fields,
+// descending with a leading -.
+type PaymentFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Sort          string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentFilter) Reset() {
+	*x = PaymentFilter{}
+	mi := &file_api_proto_payment_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentFilter) ProtoMessage() {}
+
+func (x *PaymentFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_payment_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentFilter.ProtoReflect.Descriptor instead.
+func (*PaymentFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PaymentFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentFilter) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentFilter) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *PaymentFilter) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *PaymentFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
 // List payments request
 type ListPaymentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Status        PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payment.PaymentStatus" json:"status,omitempty"`
-	UserId        uint32                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Status   PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payment.PaymentStatus" json:"status,omitempty"`
+	UserId   uint32                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// next_cursor of the previous page; page is ignored when set.
+	Cursor string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// How total is counted: exact (the default for pages), estimated or
+	// none (the default for cursors).
+	Count string `protobuf:"bytes,6,opt,name=count,proto3" json:"count,omitempty"`
+	// Takes precedence over status when its status is set.
+	Filter        *PaymentFilter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListPaymentsRequest) Reset() {
 	*x = ListPaymentsRequest{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -411,7 +498,7 @@ func (x *ListPaymentsRequest) String() string {
 func (*ListPaymentsRequest) ProtoMessage() {}
 
 func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[5]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +511,7 @@ func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
 func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListPaymentsRequest) GetPage() int32 {
@@ -455,20 +542,45 @@ func (x *ListPaymentsRequest) GetUserId() uint32 {
 	return 0
 }
 
+func (x *ListPaymentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetFilter() *PaymentFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 // List payments response
 type ListPaymentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Payments []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	// Zero when the list is not counted.
+	Total    int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor     string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,6,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListPaymentsResponse) Reset() {
 	*x = ListPaymentsResponse{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -480,7 +592,7 @@ func (x *ListPaymentsResponse) String() string {
 func (*ListPaymentsResponse) ProtoMessage() {}
 
 func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[6]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +605,7 @@ func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
 func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListPaymentsResponse) GetPayments() []*Payment {
@@ -524,6 +636,20 @@ func (x *ListPaymentsResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListPaymentsResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
 // Update payment request
 type UpdatePaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -538,7 +664,7 @@ type UpdatePaymentRequest struct {
 
 func (x *UpdatePaymentRequest) Reset() {
 	*x = UpdatePaymentRequest{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[7]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -550,7 +676,7 @@ func (x *UpdatePaymentRequest) String() string {
 func (*UpdatePaymentRequest) ProtoMessage() {}
 
 func (x *UpdatePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[7]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +689,7 @@ func (x *UpdatePaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePaymentRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdatePaymentRequest) GetId() uint32 {
@@ -611,7 +737,7 @@ type UpdatePaymentResponse struct {
 
 func (x *UpdatePaymentResponse) Reset() {
 	*x = UpdatePaymentResponse{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[8]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +749,7 @@ func (x *UpdatePaymentResponse) String() string {
 func (*UpdatePaymentResponse) ProtoMessage() {}
 
 func (x *UpdatePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[8]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +762,7 @@ func (x *UpdatePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePaymentResponse.ProtoReflect.Descriptor instead.
 func (*UpdatePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdatePaymentResponse) GetPayment() *Payment {
@@ -656,7 +782,7 @@ type DeletePaymentRequest struct {
 
 func (x *DeletePaymentRequest) Reset() {
 	*x = DeletePaymentRequest{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[9]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -668,7 +794,7 @@ func (x *DeletePaymentRequest) String() string {
 func (*DeletePaymentRequest) ProtoMessage() {}
 
 func (x *DeletePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[9]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -681,7 +807,7 @@ func (x *DeletePaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePaymentRequest.ProtoReflect.Descriptor instead.
 func (*DeletePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeletePaymentRequest) GetId() uint32 {
@@ -701,7 +827,7 @@ type DeletePaymentResponse struct {
 
 func (x *DeletePaymentResponse) Reset() {
 	*x = DeletePaymentResponse{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[10]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -713,7 +839,7 @@ func (x *DeletePaymentResponse) String() string {
 func (*DeletePaymentResponse) ProtoMessage() {}
 
 func (x *DeletePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[10]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,7 +852,7 @@ func (x *DeletePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePaymentResponse.ProtoReflect.Descriptor instead.
 func (*DeletePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DeletePaymentResponse) GetSuccess() bool {
@@ -738,17 +864,23 @@ func (x *DeletePaymentResponse) GetSuccess() bool {
 
 // Get user payments request
 type GetUserPaymentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_cursor of the previous page; page is ignored when set.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// How total is counted: exact (the default for pages), estimated or
+	// none (the default for cursors).
+	Count         string         `protobuf:"bytes,5,opt,name=count,proto3" json:"count,omitempty"`
+	Filter        *PaymentFilter `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserPaymentsRequest) Reset() {
 	*x = GetUserPaymentsRequest{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[11]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -760,7 +892,7 @@ func (x *GetUserPaymentsRequest) String() string {
 func (*GetUserPaymentsRequest) ProtoMessage() {}
 
 func (x *GetUserPaymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[11]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -773,7 +905,7 @@ func (x *GetUserPaymentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPaymentsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserPaymentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetUserPaymentsRequest) GetUserId() uint32 {
@@ -797,20 +929,45 @@ func (x *GetUserPaymentsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetUserPaymentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUserPaymentsRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+func (x *GetUserPaymentsRequest) GetFilter() *PaymentFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 // Get user payments response
 type GetUserPaymentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Payments []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	// Zero when the list is not counted.
+	Total    int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor     string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,6,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetUserPaymentsResponse) Reset() {
 	*x = GetUserPaymentsResponse{}
-	mi := &file_api_proto_payment_payment_proto_msgTypes[12]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +979,7 @@ func (x *GetUserPaymentsResponse) String() string {
 func (*GetUserPaymentsResponse) ProtoMessage() {}
 
 func (x *GetUserPaymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_payment_payment_proto_msgTypes[12]
+	mi := &file_api_proto_payment_payment_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +992,7 @@ func (x *GetUserPaymentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPaymentsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserPaymentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_payment_payment_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetUserPaymentsResponse) GetPayments() []*Payment {
@@ -866,6 +1023,20 @@ func (x *GetUserPaymentsResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetUserPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetUserPaymentsResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
 var File_api_proto_payment_payment_proto protoreflect.FileDescriptor
 
 const file_api_proto_payment_payment_proto_rawDesc = "" +
@@ -892,17 +1063,30 @@ const file_api_proto_payment_payment_proto_rawDesc = "" +
 	"\x11GetPaymentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\"@\n" +
 	"\x12GetPaymentResponse\x12*\n" +
-	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\"\x8f\x01\n" +
+	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\"\x8e\x01\n" +
+	"\rPaymentFilter\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\"\xed\x01\n" +
 	"\x13ListPaymentsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12.\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\rR\x06userId\"\x8b\x01\n" +
+	"\auser_id\x18\x04 \x01(\rR\x06userId\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\tR\x05count\x12.\n" +
+	"\x06filter\x18\a \x01(\v2\x16.payment.PaymentFilterR\x06filter\"\xd5\x01\n" +
 	"\x14ListPaymentsResponse\x12,\n" +
 	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xac\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12'\n" +
+	"\x0ftotal_estimated\x18\x06 \x01(\bR\x0etotalEstimated\"\xac\x01\n" +
 	"\x14UpdatePaymentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
@@ -914,16 +1098,22 @@ const file_api_proto_payment_payment_proto_rawDesc = "" +
 	"\x14DeletePaymentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\"1\n" +
 	"\x15DeletePaymentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"b\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc0\x01\n" +
 	"\x16GetUserPaymentsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x8e\x01\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\tR\x05count\x12.\n" +
+	"\x06filter\x18\x06 \x01(\v2\x16.payment.PaymentFilterR\x06filter\"\xd8\x01\n" +
 	"\x17GetUserPaymentsResponse\x12,\n" +
 	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize*\xc0\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12'\n" +
+	"\x0ftotal_estimated\x18\x06 \x01(\bR\x0etotalEstimated*\xc0\x01\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
@@ -953,7 +1143,7 @@ func file_api_proto_payment_payment_proto_rawDescGZIP() []byte {
 }
 
 var file_api_proto_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_api_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_api_proto_payment_payment_proto_goTypes = []any{
 	(PaymentStatus)(0),              // 0: payment.PaymentStatus
 	(*Payment)(nil),                 // 1: payment.Payment
@@ -961,44 +1151,47 @@ var file_api_proto_payment_payment_proto_goTypes = []any{
 	(*CreatePaymentResponse)(nil),   // 3: payment.CreatePaymentResponse
 	(*GetPaymentRequest)(nil),       // 4: payment.GetPaymentRequest
 	(*GetPaymentResponse)(nil),      // 5: payment.GetPaymentResponse
-	(*ListPaymentsRequest)(nil),     // 6: payment.ListPaymentsRequest
-	(*ListPaymentsResponse)(nil),    // 7: payment.ListPaymentsResponse
-	(*UpdatePaymentRequest)(nil),    // 8: payment.UpdatePaymentRequest
-	(*UpdatePaymentResponse)(nil),   // 9: payment.UpdatePaymentResponse
-	(*DeletePaymentRequest)(nil),    // 10: payment.DeletePaymentRequest
-	(*DeletePaymentResponse)(nil),   // 11: payment.DeletePaymentResponse
-	(*GetUserPaymentsRequest)(nil),  // 12: payment.GetUserPaymentsRequest
-	(*GetUserPaymentsResponse)(nil), // 13: payment.GetUserPaymentsResponse
-	(*timestamp.Timestamp)(nil),     // 14: google.protobuf.Timestamp
+	(*PaymentFilter)(nil),           // 6: payment.PaymentFilter
+	(*ListPaymentsRequest)(nil),     // 7: payment.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),    // 8: payment.ListPaymentsResponse
+	(*UpdatePaymentRequest)(nil),    // 9: payment.UpdatePaymentRequest
+	(*UpdatePaymentResponse)(nil),   // 10: payment.UpdatePaymentResponse
+	(*DeletePaymentRequest)(nil),    // 11: payment.DeletePaymentRequest
+	(*DeletePaymentResponse)(nil),   // 12: payment.DeletePaymentResponse
+	(*GetUserPaymentsRequest)(nil),  // 13: payment.GetUserPaymentsRequest
+	(*GetUserPaymentsResponse)(nil), // 14: payment.GetUserPaymentsResponse
+	(*timestamp.Timestamp)(nil),     // 15: google.protobuf.Timestamp
 }
 var file_api_proto_payment_payment_proto_depIdxs = []int32{
 	0,  // 0: payment.Payment.status:type_name -> payment.PaymentStatus
-	14, // 1: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
-	14, // 2: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 1: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 3: payment.CreatePaymentResponse.payment:type_name -> payment.Payment
 	1,  // 4: payment.GetPaymentResponse.payment:type_name -> payment.Payment
 	0,  // 5: payment.ListPaymentsRequest.status:type_name -> payment.PaymentStatus
-	1,  // 6: payment.ListPaymentsResponse.payments:type_name -> payment.Payment
-	0,  // 7: payment.UpdatePaymentRequest.status:type_name -> payment.PaymentStatus
-	1,  // 8: payment.UpdatePaymentResponse.payment:type_name -> payment.Payment
-	1,  // 9: payment.GetUserPaymentsResponse.payments:type_name -> payment.Payment
-	2,  // 10: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
-	4,  // 11: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
-	6,  // 12: payment.PaymentService.ListPayments:input_type -> payment.ListPaymentsRequest
-	8,  // 13: payment.PaymentService.UpdatePayment:input_type -> payment.UpdatePaymentRequest
-	10, // 14: payment.PaymentService.DeletePayment:input_type -> payment.DeletePaymentRequest
-	12, // 15: payment.PaymentService.GetUserPayments:input_type -> payment.GetUserPaymentsRequest
-	3,  // 16: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
-	5,  // 17: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
-	7,  // 18: payment.PaymentService.ListPayments:output_type -> payment.ListPaymentsResponse
-	9,  // 19: payment.PaymentService.UpdatePayment:output_type -> payment.UpdatePaymentResponse
-	11, // 20: payment.PaymentService.DeletePayment:output_type -> payment.DeletePaymentResponse
-	13, // 21: payment.PaymentService.GetUserPayments:output_type -> payment.GetUserPaymentsResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	6,  // 6: payment.ListPaymentsRequest.filter:type_name -> payment.PaymentFilter
+	1,  // 7: payment.ListPaymentsResponse.payments:type_name -> payment.Payment
+	0,  // 8: payment.UpdatePaymentRequest.status:type_name -> payment.PaymentStatus
+	1,  // 9: payment.UpdatePaymentResponse.payment:type_name -> payment.Payment
+	6,  // 10: payment.GetUserPaymentsRequest.filter:type_name -> payment.PaymentFilter
+	1,  // 11: payment.GetUserPaymentsResponse.payments:type_name -> payment.Payment
+	2,  // 12: payment.PaymentService.CreatePayment:input_type -> payment.CreatePaymentRequest
+	4,  // 13: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
+	7,  // 14: payment.PaymentService.ListPayments:input_type -> payment.ListPaymentsRequest
+	9,  // 15: payment.PaymentService.UpdatePayment:input_type -> payment.UpdatePaymentRequest
+	11, // 16: payment.PaymentService.DeletePayment:input_type -> payment.DeletePaymentRequest
+	13, // 17: payment.PaymentService.GetUserPayments:input_type -> payment.GetUserPaymentsRequest
+	3,  // 18: payment.PaymentService.CreatePayment:output_type -> payment.CreatePaymentResponse
+	5,  // 19: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
+	8,  // 20: payment.PaymentService.ListPayments:output_type -> payment.ListPaymentsResponse
+	10, // 21: payment.PaymentService.UpdatePayment:output_type -> payment.UpdatePaymentResponse
+	12, // 22: payment.PaymentService.DeletePayment:output_type -> payment.DeletePaymentResponse
+	14, // 23: payment.PaymentService.GetUserPayments:output_type -> payment.GetUserPaymentsResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_payment_payment_proto_init() }
@@ -1012,7 +1205,7 @@ func file_api_proto_payment_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_payment_payment_proto_rawDesc), len(file_api_proto_payment_payment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,879 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.12.4
+// source: api/proto/radcheck/radcheck.proto
+
+package radcheck
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Radcheck message
+type Radcheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,3,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Radcheck) Reset() {
+	*x = Radcheck{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Radcheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Radcheck) ProtoMessage() {}
+
+func (x *Radcheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Radcheck.ProtoReflect.Descriptor instead.
+func (*Radcheck) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Radcheck) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Radcheck) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Radcheck) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *Radcheck) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Radcheck) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Create radcheck request
+type CreateRadcheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRadcheckRequest) Reset() {
+	*x = CreateRadcheckRequest{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRadcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRadcheckRequest) ProtoMessage() {}
+
+func (x *CreateRadcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRadcheckRequest.ProtoReflect.Descriptor instead.
+func (*CreateRadcheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRadcheckRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateRadcheckRequest) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *CreateRadcheckRequest) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *CreateRadcheckRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Create radcheck response
+type CreateRadcheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radcheck      *Radcheck              `protobuf:"bytes,1,opt,name=radcheck,proto3" json:"radcheck,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRadcheckResponse) Reset() {
+	*x = CreateRadcheckResponse{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRadcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRadcheckResponse) ProtoMessage() {}
+
+func (x *CreateRadcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRadcheckResponse.ProtoReflect.Descriptor instead.
+func (*CreateRadcheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRadcheckResponse) GetRadcheck() *Radcheck {
+	if x != nil {
+		return x.Radcheck
+	}
+	return nil
+}
+
+// Get radcheck request
+type GetRadcheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRadcheckRequest) Reset() {
+	*x = GetRadcheckRequest{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRadcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRadcheckRequest) ProtoMessage() {}
+
+func (x *GetRadcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRadcheckRequest.ProtoReflect.Descriptor instead.
+func (*GetRadcheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRadcheckRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Get radcheck response
+type GetRadcheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radcheck      *Radcheck              `protobuf:"bytes,1,opt,name=radcheck,proto3" json:"radcheck,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRadcheckResponse) Reset() {
+	*x = GetRadcheckResponse{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRadcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRadcheckResponse) ProtoMessage() {}
+
+func (x *GetRadcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRadcheckResponse.ProtoReflect.Descriptor instead.
+func (*GetRadcheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRadcheckResponse) GetRadcheck() *Radcheck {
+	if x != nil {
+		return x.Radcheck
+	}
+	return nil
+}
+
+// Radcheck filter for list operations.
+// Fields take the filter grammar of the REST lists: exact values, or
+// prefix:, contains:, in:a,b and from..to ranges. sort lists fields,
+// descending with a leading -.
+type RadcheckFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RadcheckFilter) Reset() {
+	*x = RadcheckFilter{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RadcheckFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RadcheckFilter) ProtoMessage() {}
+
+func (x *RadcheckFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RadcheckFilter.ProtoReflect.Descriptor instead.
+func (*RadcheckFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RadcheckFilter) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RadcheckFilter) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *RadcheckFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// List radcheck request
+type ListRadcheckRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Filter   *RadcheckFilter        `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// next_cursor of the previous page; page is ignored when set.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// How total is counted: exact (the default for pages), estimated or
+	// none (the default for cursors).
+	Count         string `protobuf:"bytes,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRadcheckRequest) Reset() {
+	*x = ListRadcheckRequest{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRadcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRadcheckRequest) ProtoMessage() {}
+
+func (x *ListRadcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRadcheckRequest.ProtoReflect.Descriptor instead.
+func (*ListRadcheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRadcheckRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRadcheckRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRadcheckRequest) GetFilter() *RadcheckFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRadcheckRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRadcheckRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+// List radcheck response
+type ListRadcheckResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Radchecks []*Radcheck            `protobuf:"bytes,1,rep,name=radchecks,proto3" json:"radchecks,omitempty"`
+	// Zero when the list is not counted.
+	Total    int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor     string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,6,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListRadcheckResponse) Reset() {
+	*x = ListRadcheckResponse{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRadcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRadcheckResponse) ProtoMessage() {}
+
+func (x *ListRadcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRadcheckResponse.ProtoReflect.Descriptor instead.
+func (*ListRadcheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRadcheckResponse) GetRadchecks() []*Radcheck {
+	if x != nil {
+		return x.Radchecks
+	}
+	return nil
+}
+
+func (x *ListRadcheckResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListRadcheckResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRadcheckResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRadcheckResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListRadcheckResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+// Update radcheck request
+type UpdateRadcheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,3,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRadcheckRequest) Reset() {
+	*x = UpdateRadcheckRequest{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRadcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRadcheckRequest) ProtoMessage() {}
+
+func (x *UpdateRadcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRadcheckRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRadcheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRadcheckRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRadcheckRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateRadcheckRequest) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *UpdateRadcheckRequest) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *UpdateRadcheckRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Update radcheck response
+type UpdateRadcheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radcheck      *Radcheck              `protobuf:"bytes,1,opt,name=radcheck,proto3" json:"radcheck,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRadcheckResponse) Reset() {
+	*x = UpdateRadcheckResponse{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRadcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRadcheckResponse) ProtoMessage() {}
+
+func (x *UpdateRadcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRadcheckResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRadcheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateRadcheckResponse) GetRadcheck() *Radcheck {
+	if x != nil {
+		return x.Radcheck
+	}
+	return nil
+}
+
+// Delete radcheck request
+type DeleteRadcheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRadcheckRequest) Reset() {
+	*x = DeleteRadcheckRequest{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRadcheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRadcheckRequest) ProtoMessage() {}
+
+func (x *DeleteRadcheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRadcheckRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRadcheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRadcheckRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Delete radcheck response
+type DeleteRadcheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRadcheckResponse) Reset() {
+	*x = DeleteRadcheckResponse{}
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRadcheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRadcheckResponse) ProtoMessage() {}
+
+func (x *DeleteRadcheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radcheck_radcheck_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRadcheckResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRadcheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radcheck_radcheck_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteRadcheckResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_api_proto_radcheck_radcheck_proto protoreflect.FileDescriptor
+
+const file_api_proto_radcheck_radcheck_proto_rawDesc = "" +
+	"\n" +
+	"!api/proto/radcheck/radcheck.proto\x12\bradcheck\"z\n" +
+	"\bRadcheck\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x03 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"w\n" +
+	"\x15CreateRadcheckRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x02 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"H\n" +
+	"\x16CreateRadcheckResponse\x12.\n" +
+	"\bradcheck\x18\x01 \x01(\v2\x12.radcheck.RadcheckR\bradcheck\"$\n" +
+	"\x12GetRadcheckRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"E\n" +
+	"\x13GetRadcheckResponse\x12.\n" +
+	"\bradcheck\x18\x01 \x01(\v2\x12.radcheck.RadcheckR\bradcheck\"^\n" +
+	"\x0eRadcheckFilter\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x02 \x01(\tR\tattribute\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"\xa6\x01\n" +
+	"\x13ListRadcheckRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x120\n" +
+	"\x06filter\x18\x03 \x01(\v2\x18.radcheck.RadcheckFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\tR\x05count\"\xd9\x01\n" +
+	"\x14ListRadcheckResponse\x120\n" +
+	"\tradchecks\x18\x01 \x03(\v2\x12.radcheck.RadcheckR\tradchecks\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12'\n" +
+	"\x0ftotal_estimated\x18\x06 \x01(\bR\x0etotalEstimated\"\x87\x01\n" +
+	"\x15UpdateRadcheckRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x03 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"H\n" +
+	"\x16UpdateRadcheckResponse\x12.\n" +
+	"\bradcheck\x18\x01 \x01(\v2\x12.radcheck.RadcheckR\bradcheck\"'\n" +
+	"\x15DeleteRadcheckRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"2\n" +
+	"\x16DeleteRadcheckResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xab\x03\n" +
+	"\x0fRadcheckService\x12S\n" +
+	"\x0eCreateRadcheck\x12\x1f.radcheck.CreateRadcheckRequest\x1a .radcheck.CreateRadcheckResponse\x12J\n" +
+	"\vGetRadcheck\x12\x1c.radcheck.GetRadcheckRequest\x1a\x1d.radcheck.GetRadcheckResponse\x12M\n" +
+	"\fListRadcheck\x12\x1d.radcheck.ListRadcheckRequest\x1a\x1e.radcheck.ListRadcheckResponse\x12S\n" +
+	"\x0eUpdateRadcheck\x12\x1f.radcheck.UpdateRadcheckRequest\x1a .radcheck.UpdateRadcheckResponse\x12S\n" +
+	"\x0eDeleteRadcheck\x12\x1f.radcheck.DeleteRadcheckRequest\x1a .radcheck.DeleteRadcheckResponseB@Z>github.com/novriyantoAli/freeradius-service/api/proto/radcheckb\x06proto3"
+
+var (
+	file_api_proto_radcheck_radcheck_proto_rawDescOnce sync.Once
+	file_api_proto_radcheck_radcheck_proto_rawDescData []byte
+)
+
+func file_api_proto_radcheck_radcheck_proto_rawDescGZIP() []byte {
+	file_api_proto_radcheck_radcheck_proto_rawDescOnce.Do(func() {
+		file_api_proto_radcheck_radcheck_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_radcheck_radcheck_proto_rawDesc), len(file_api_proto_radcheck_radcheck_proto_rawDesc)))
+	})
+	return file_api_proto_radcheck_radcheck_proto_rawDescData
+}
+
+var file_api_proto_radcheck_radcheck_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_radcheck_radcheck_proto_goTypes = []any{
+	(*Radcheck)(nil),               // 0: radcheck.Radcheck
+	(*CreateRadcheckRequest)(nil),  // 1: radcheck.CreateRadcheckRequest
+	(*CreateRadcheckResponse)(nil), // 2: radcheck.CreateRadcheckResponse
+	(*GetRadcheckRequest)(nil),     // 3: radcheck.GetRadcheckRequest
+	(*GetRadcheckResponse)(nil),    // 4: radcheck.GetRadcheckResponse
+	(*RadcheckFilter)(nil),         // 5: radcheck.RadcheckFilter
+	(*ListRadcheckRequest)(nil),    // 6: radcheck.ListRadcheckRequest
+	(*ListRadcheckResponse)(nil),   // 7: radcheck.ListRadcheckResponse
+	(*UpdateRadcheckRequest)(nil),  // 8: radcheck.UpdateRadcheckRequest
+	(*UpdateRadcheckResponse)(nil), // 9: radcheck.UpdateRadcheckResponse
+	(*DeleteRadcheckRequest)(nil),  // 10: radcheck.DeleteRadcheckRequest
+	(*DeleteRadcheckResponse)(nil), // 11: radcheck.DeleteRadcheckResponse
+}
+var file_api_proto_radcheck_radcheck_proto_depIdxs = []int32{
+	0,  // 0: radcheck.CreateRadcheckResponse.radcheck:type_name -> radcheck.Radcheck
+	0,  // 1: radcheck.GetRadcheckResponse.radcheck:type_name -> radcheck.Radcheck
+	5,  // 2: radcheck.ListRadcheckRequest.filter:type_name -> radcheck.RadcheckFilter
+	0,  // 3: radcheck.ListRadcheckResponse.radchecks:type_name -> radcheck.Radcheck
+	0,  // 4: radcheck.UpdateRadcheckResponse.radcheck:type_name -> radcheck.Radcheck
+	1,  // 5: radcheck.RadcheckService.CreateRadcheck:input_type -> radcheck.CreateRadcheckRequest
+	3,  // 6: radcheck.RadcheckService.GetRadcheck:input_type -> radcheck.GetRadcheckRequest
+	6,  // 7: radcheck.RadcheckService.ListRadcheck:input_type -> radcheck.ListRadcheckRequest
+	8,  // 8: radcheck.RadcheckService.UpdateRadcheck:input_type -> radcheck.UpdateRadcheckRequest
+	10, // 9: radcheck.RadcheckService.DeleteRadcheck:input_type -> radcheck.DeleteRadcheckRequest
+	2,  // 10: radcheck.RadcheckService.CreateRadcheck:output_type -> radcheck.CreateRadcheckResponse
+	4,  // 11: radcheck.RadcheckService.GetRadcheck:output_type -> radcheck.GetRadcheckResponse
+	7,  // 12: radcheck.RadcheckService.ListRadcheck:output_type -> radcheck.ListRadcheckResponse
+	9,  // 13: radcheck.RadcheckService.UpdateRadcheck:output_type -> radcheck.UpdateRadcheckResponse
+	11, // 14: radcheck.RadcheckService.DeleteRadcheck:output_type -> radcheck.DeleteRadcheckResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_radcheck_radcheck_proto_init() }
+func file_api_proto_radcheck_radcheck_proto_init() {
+	if File_api_proto_radcheck_radcheck_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_radcheck_radcheck_proto_rawDesc), len(file_api_proto_radcheck_radcheck_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_radcheck_radcheck_proto_goTypes,
+		DependencyIndexes: file_api_proto_radcheck_radcheck_proto_depIdxs,
+		MessageInfos:      file_api_proto_radcheck_radcheck_proto_msgTypes,
+	}.Build()
+	File_api_proto_radcheck_radcheck_proto = out.File
+	file_api_proto_radcheck_radcheck_proto_goTypes = nil
+	file_api_proto_radcheck_radcheck_proto_depIdxs = nil
+}
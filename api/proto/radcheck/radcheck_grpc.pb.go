@@ -0,0 +1,265 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: api/proto/radcheck/radcheck.proto
+
+package radcheck
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RadcheckService_CreateRadcheck_FullMethodName = "/radcheck.RadcheckService/CreateRadcheck"
+	RadcheckService_GetRadcheck_FullMethodName    = "/radcheck.RadcheckService/GetRadcheck"
+	RadcheckService_ListRadcheck_FullMethodName   = "/radcheck.RadcheckService/ListRadcheck"
+	RadcheckService_UpdateRadcheck_FullMethodName = "/radcheck.RadcheckService/UpdateRadcheck"
+	RadcheckService_DeleteRadcheck_FullMethodName = "/radcheck.RadcheckService/DeleteRadcheck"
+)
+
+// RadcheckServiceClient is the client API for RadcheckService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RadcheckServiceClient interface {
+	// Create a new radcheck attribute
+	CreateRadcheck(ctx context.Context, in *CreateRadcheckRequest, opts ...grpc.CallOption) (*CreateRadcheckResponse, error)
+	// Get a radcheck attribute by ID
+	GetRadcheck(ctx context.Context, in *GetRadcheckRequest, opts ...grpc.CallOption) (*GetRadcheckResponse, error)
+	// List radcheck attributes with pagination and filtering
+	ListRadcheck(ctx context.Context, in *ListRadcheckRequest, opts ...grpc.CallOption) (*ListRadcheckResponse, error)
+	// Update a radcheck attribute
+	UpdateRadcheck(ctx context.Context, in *UpdateRadcheckRequest, opts ...grpc.CallOption) (*UpdateRadcheckResponse, error)
+	// Delete a radcheck attribute
+	DeleteRadcheck(ctx context.Context, in *DeleteRadcheckRequest, opts ...grpc.CallOption) (*DeleteRadcheckResponse, error)
+}
+
+type radcheckServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRadcheckServiceClient(cc grpc.ClientConnInterface) RadcheckServiceClient {
+	return &radcheckServiceClient{cc}
+}
+
+func (c *radcheckServiceClient) CreateRadcheck(ctx context.Context, in *CreateRadcheckRequest, opts ...grpc.CallOption) (*CreateRadcheckResponse, error) {
+	out := new(CreateRadcheckResponse)
+	err := c.cc.Invoke(ctx, RadcheckService_CreateRadcheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radcheckServiceClient) GetRadcheck(ctx context.Context, in *GetRadcheckRequest, opts ...grpc.CallOption) (*GetRadcheckResponse, error) {
+	out := new(GetRadcheckResponse)
+	err := c.cc.Invoke(ctx, RadcheckService_GetRadcheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radcheckServiceClient) ListRadcheck(ctx context.Context, in *ListRadcheckRequest, opts ...grpc.CallOption) (*ListRadcheckResponse, error) {
+	out := new(ListRadcheckResponse)
+	err := c.cc.Invoke(ctx, RadcheckService_ListRadcheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radcheckServiceClient) UpdateRadcheck(ctx context.Context, in *UpdateRadcheckRequest, opts ...grpc.CallOption) (*UpdateRadcheckResponse, error) {
+	out := new(UpdateRadcheckResponse)
+	err := c.cc.Invoke(ctx, RadcheckService_UpdateRadcheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radcheckServiceClient) DeleteRadcheck(ctx context.Context, in *DeleteRadcheckRequest, opts ...grpc.CallOption) (*DeleteRadcheckResponse, error) {
+	out := new(DeleteRadcheckResponse)
+	err := c.cc.Invoke(ctx, RadcheckService_DeleteRadcheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RadcheckServiceServer is the server API for RadcheckService service.
+// All implementations should embed UnimplementedRadcheckServiceServer
+// for forward compatibility
+type RadcheckServiceServer interface {
+	// Create a new radcheck attribute
+	CreateRadcheck(context.Context, *CreateRadcheckRequest) (*CreateRadcheckResponse, error)
+	// Get a radcheck attribute by ID
+	GetRadcheck(context.Context, *GetRadcheckRequest) (*GetRadcheckResponse, error)
+	// List radcheck attributes with pagination and filtering
+	ListRadcheck(context.Context, *ListRadcheckRequest) (*ListRadcheckResponse, error)
+	// Update a radcheck attribute
+	UpdateRadcheck(context.Context, *UpdateRadcheckRequest) (*UpdateRadcheckResponse, error)
+	// Delete a radcheck attribute
+	DeleteRadcheck(context.Context, *DeleteRadcheckRequest) (*DeleteRadcheckResponse, error)
+}
+
+// UnimplementedRadcheckServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRadcheckServiceServer struct {
+}
+
+func (UnimplementedRadcheckServiceServer) CreateRadcheck(context.Context, *CreateRadcheckRequest) (*CreateRadcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRadcheck not implemented")
+}
+func (UnimplementedRadcheckServiceServer) GetRadcheck(context.Context, *GetRadcheckRequest) (*GetRadcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRadcheck not implemented")
+}
+func (UnimplementedRadcheckServiceServer) ListRadcheck(context.Context, *ListRadcheckRequest) (*ListRadcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRadcheck not implemented")
+}
+func (UnimplementedRadcheckServiceServer) UpdateRadcheck(context.Context, *UpdateRadcheckRequest) (*UpdateRadcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRadcheck not implemented")
+}
+func (UnimplementedRadcheckServiceServer) DeleteRadcheck(context.Context, *DeleteRadcheckRequest) (*DeleteRadcheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRadcheck not implemented")
+}
+
+// UnsafeRadcheckServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RadcheckServiceServer will
+// result in compilation errors.
+type UnsafeRadcheckServiceServer interface {
+	mustEmbedUnimplementedRadcheckServiceServer()
+}
+
+func RegisterRadcheckServiceServer(s grpc.ServiceRegistrar, srv RadcheckServiceServer) {
+	s.RegisterService(&RadcheckService_ServiceDesc, srv)
+}
+
+func _RadcheckService_CreateRadcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRadcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadcheckServiceServer).CreateRadcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadcheckService_CreateRadcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadcheckServiceServer).CreateRadcheck(ctx, req.(*CreateRadcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadcheckService_GetRadcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRadcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadcheckServiceServer).GetRadcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadcheckService_GetRadcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadcheckServiceServer).GetRadcheck(ctx, req.(*GetRadcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadcheckService_ListRadcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRadcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadcheckServiceServer).ListRadcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadcheckService_ListRadcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadcheckServiceServer).ListRadcheck(ctx, req.(*ListRadcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadcheckService_UpdateRadcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRadcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadcheckServiceServer).UpdateRadcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadcheckService_UpdateRadcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadcheckServiceServer).UpdateRadcheck(ctx, req.(*UpdateRadcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadcheckService_DeleteRadcheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRadcheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadcheckServiceServer).DeleteRadcheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadcheckService_DeleteRadcheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadcheckServiceServer).DeleteRadcheck(ctx, req.(*DeleteRadcheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RadcheckService_ServiceDesc is the grpc.ServiceDesc for RadcheckService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RadcheckService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "radcheck.RadcheckService",
+	HandlerType: (*RadcheckServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRadcheck",
+			Handler:    _RadcheckService_CreateRadcheck_Handler,
+		},
+		{
+			MethodName: "GetRadcheck",
+			Handler:    _RadcheckService_GetRadcheck_Handler,
+		},
+		{
+			MethodName: "ListRadcheck",
+			Handler:    _RadcheckService_ListRadcheck_Handler,
+		},
+		{
+			MethodName: "UpdateRadcheck",
+			Handler:    _RadcheckService_UpdateRadcheck_Handler,
+		},
+		{
+			MethodName: "DeleteRadcheck",
+			Handler:    _RadcheckService_DeleteRadcheck_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/radcheck/radcheck.proto",
+}
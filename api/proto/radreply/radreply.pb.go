@@ -0,0 +1,881 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.12.4
+// source: api/proto/radreply/radreply.proto
+
+package radreply
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Radreply message
+type Radreply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,3,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Radreply) Reset() {
+	*x = Radreply{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Radreply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Radreply) ProtoMessage() {}
+
+func (x *Radreply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Radreply.ProtoReflect.Descriptor instead.
+func (*Radreply) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Radreply) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Radreply) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Radreply) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *Radreply) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Radreply) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Create radreply request
+type CreateRadreplyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRadreplyRequest) Reset() {
+	*x = CreateRadreplyRequest{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRadreplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRadreplyRequest) ProtoMessage() {}
+
+func (x *CreateRadreplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRadreplyRequest.ProtoReflect.Descriptor instead.
+func (*CreateRadreplyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRadreplyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateRadreplyRequest) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *CreateRadreplyRequest) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *CreateRadreplyRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Create radreply response
+type CreateRadreplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radreply      *Radreply              `protobuf:"bytes,1,opt,name=radreply,proto3" json:"radreply,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRadreplyResponse) Reset() {
+	*x = CreateRadreplyResponse{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRadreplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRadreplyResponse) ProtoMessage() {}
+
+func (x *CreateRadreplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRadreplyResponse.ProtoReflect.Descriptor instead.
+func (*CreateRadreplyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRadreplyResponse) GetRadreply() *Radreply {
+	if x != nil {
+		return x.Radreply
+	}
+	return nil
+}
+
+// Get radreply request
+type GetRadreplyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRadreplyRequest) Reset() {
+	*x = GetRadreplyRequest{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRadreplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRadreplyRequest) ProtoMessage() {}
+
+func (x *GetRadreplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRadreplyRequest.ProtoReflect.Descriptor instead.
+func (*GetRadreplyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRadreplyRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Get radreply response
+type GetRadreplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radreply      *Radreply              `protobuf:"bytes,1,opt,name=radreply,proto3" json:"radreply,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRadreplyResponse) Reset() {
+	*x = GetRadreplyResponse{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRadreplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRadreplyResponse) ProtoMessage() {}
+
+func (x *GetRadreplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRadreplyResponse.ProtoReflect.Descriptor instead.
+func (*GetRadreplyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRadreplyResponse) GetRadreply() *Radreply {
+	if x != nil {
+		return x.Radreply
+	}
+	return nil
+}
+
+// Radreply filter for list operations.
+// Fields take the filter grammar of the REST lists: exact values, or
+// prefix:, contains:, in:a,b and from..to ranges. sort lists fields,
+// descending with a leading -.
+type RadreplyFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RadreplyFilter) Reset() {
+	*x = RadreplyFilter{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RadreplyFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RadreplyFilter) ProtoMessage() {}
+
+func (x *RadreplyFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RadreplyFilter.ProtoReflect.Descriptor instead.
+func (*RadreplyFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RadreplyFilter) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RadreplyFilter) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *RadreplyFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// List radreply request
+type ListRadreplyRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Filter   *RadreplyFilter        `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// next_cursor of the previous page; page is ignored when set.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// How total is counted: exact (the default for pages), estimated or
+	// none (the default for cursors).
+	Count         string `protobuf:"bytes,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRadreplyRequest) Reset() {
+	*x = ListRadreplyRequest{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRadreplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRadreplyRequest) ProtoMessage() {}
+
+func (x *ListRadreplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRadreplyRequest.ProtoReflect.Descriptor instead.
+func (*ListRadreplyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRadreplyRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRadreplyRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRadreplyRequest) GetFilter() *RadreplyFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRadreplyRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRadreplyRequest) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+// List radreply response
+type ListRadreplyResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Radreplies []*Radreply            `protobuf:"bytes,1,rep,name=radreplies,proto3" json:"radreplies,omitempty"`
+	// Zero when the list is not counted.
+	Total    int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor     string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalEstimated bool   `protobuf:"varint,6,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListRadreplyResponse) Reset() {
+	*x = ListRadreplyResponse{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRadreplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRadreplyResponse) ProtoMessage() {}
+
+func (x *ListRadreplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRadreplyResponse.ProtoReflect.Descriptor instead.
+func (*ListRadreplyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRadreplyResponse) GetRadreplies() []*Radreply {
+	if x != nil {
+		return x.Radreplies
+	}
+	return nil
+}
+
+func (x *ListRadreplyResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListRadreplyResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRadreplyResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRadreplyResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListRadreplyResponse) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+// Update radreply request
+type UpdateRadreplyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Attribute     string                 `protobuf:"bytes,3,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRadreplyRequest) Reset() {
+	*x = UpdateRadreplyRequest{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRadreplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRadreplyRequest) ProtoMessage() {}
+
+func (x *UpdateRadreplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRadreplyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRadreplyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRadreplyRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRadreplyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateRadreplyRequest) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *UpdateRadreplyRequest) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *UpdateRadreplyRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Update radreply response
+type UpdateRadreplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Radreply      *Radreply              `protobuf:"bytes,1,opt,name=radreply,proto3" json:"radreply,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRadreplyResponse) Reset() {
+	*x = UpdateRadreplyResponse{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRadreplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRadreplyResponse) ProtoMessage() {}
+
+func (x *UpdateRadreplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRadreplyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRadreplyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateRadreplyResponse) GetRadreply() *Radreply {
+	if x != nil {
+		return x.Radreply
+	}
+	return nil
+}
+
+// Delete radreply request
+type DeleteRadreplyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRadreplyRequest) Reset() {
+	*x = DeleteRadreplyRequest{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRadreplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRadreplyRequest) ProtoMessage() {}
+
+func (x *DeleteRadreplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRadreplyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRadreplyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRadreplyRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Delete radreply response
+type DeleteRadreplyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRadreplyResponse) Reset() {
+	*x = DeleteRadreplyResponse{}
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRadreplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRadreplyResponse) ProtoMessage() {}
+
+func (x *DeleteRadreplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_radreply_radreply_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRadreplyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRadreplyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_radreply_radreply_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteRadreplyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_api_proto_radreply_radreply_proto protoreflect.FileDescriptor
+
+const file_api_proto_radreply_radreply_proto_rawDesc = "" +
+	"\n" +
+	"!api/proto/radreply/radreply.proto\x12\bradreply\"z\n" +
+	"\bRadreply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x03 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"w\n" +
+	"\x15CreateRadreplyRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x02 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"H\n" +
+	"\x16CreateRadreplyResponse\x12.\n" +
+	"\bradreply\x18\x01 \x01(\v2\x12.radreply.RadreplyR\bradreply\"$\n" +
+	"\x12GetRadreplyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"E\n" +
+	"\x13GetRadreplyResponse\x12.\n" +
+	"\bradreply\x18\x01 \x01(\v2\x12.radreply.RadreplyR\bradreply\"^\n" +
+	"\x0eRadreplyFilter\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x02 \x01(\tR\tattribute\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"\xa6\x01\n" +
+	"\x13ListRadreplyRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x120\n" +
+	"\x06filter\x18\x03 \x01(\v2\x18.radreply.RadreplyFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\tR\x05count\"\xdb\x01\n" +
+	"\x14ListRadreplyResponse\x122\n" +
+	"\n" +
+	"radreplies\x18\x01 \x03(\v2\x12.radreply.RadreplyR\n" +
+	"radreplies\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12'\n" +
+	"\x0ftotal_estimated\x18\x06 \x01(\bR\x0etotalEstimated\"\x87\x01\n" +
+	"\x15UpdateRadreplyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\tattribute\x18\x03 \x01(\tR\tattribute\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"H\n" +
+	"\x16UpdateRadreplyResponse\x12.\n" +
+	"\bradreply\x18\x01 \x01(\v2\x12.radreply.RadreplyR\bradreply\"'\n" +
+	"\x15DeleteRadreplyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"2\n" +
+	"\x16DeleteRadreplyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xab\x03\n" +
+	"\x0fRadreplyService\x12S\n" +
+	"\x0eCreateRadreply\x12\x1f.radreply.CreateRadreplyRequest\x1a .radreply.CreateRadreplyResponse\x12J\n" +
+	"\vGetRadreply\x12\x1c.radreply.GetRadreplyRequest\x1a\x1d.radreply.GetRadreplyResponse\x12M\n" +
+	"\fListRadreply\x12\x1d.radreply.ListRadreplyRequest\x1a\x1e.radreply.ListRadreplyResponse\x12S\n" +
+	"\x0eUpdateRadreply\x12\x1f.radreply.UpdateRadreplyRequest\x1a .radreply.UpdateRadreplyResponse\x12S\n" +
+	"\x0eDeleteRadreply\x12\x1f.radreply.DeleteRadreplyRequest\x1a .radreply.DeleteRadreplyResponseB@Z>github.com/novriyantoAli/freeradius-service/api/proto/radreplyb\x06proto3"
+
+var (
+	file_api_proto_radreply_radreply_proto_rawDescOnce sync.Once
+	file_api_proto_radreply_radreply_proto_rawDescData []byte
+)
+
+func file_api_proto_radreply_radreply_proto_rawDescGZIP() []byte {
+	file_api_proto_radreply_radreply_proto_rawDescOnce.Do(func() {
+		file_api_proto_radreply_radreply_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_radreply_radreply_proto_rawDesc), len(file_api_proto_radreply_radreply_proto_rawDesc)))
+	})
+	return file_api_proto_radreply_radreply_proto_rawDescData
+}
+
+var file_api_proto_radreply_radreply_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_radreply_radreply_proto_goTypes = []any{
+	(*Radreply)(nil),               // 0: radreply.Radreply
+	(*CreateRadreplyRequest)(nil),  // 1: radreply.CreateRadreplyRequest
+	(*CreateRadreplyResponse)(nil), // 2: radreply.CreateRadreplyResponse
+	(*GetRadreplyRequest)(nil),     // 3: radreply.GetRadreplyRequest
+	(*GetRadreplyResponse)(nil),    // 4: radreply.GetRadreplyResponse
+	(*RadreplyFilter)(nil),         // 5: radreply.RadreplyFilter
+	(*ListRadreplyRequest)(nil),    // 6: radreply.ListRadreplyRequest
+	(*ListRadreplyResponse)(nil),   // 7: radreply.ListRadreplyResponse
+	(*UpdateRadreplyRequest)(nil),  // 8: radreply.UpdateRadreplyRequest
+	(*UpdateRadreplyResponse)(nil), // 9: radreply.UpdateRadreplyResponse
+	(*DeleteRadreplyRequest)(nil),  // 10: radreply.DeleteRadreplyRequest
+	(*DeleteRadreplyResponse)(nil), // 11: radreply.DeleteRadreplyResponse
+}
+var file_api_proto_radreply_radreply_proto_depIdxs = []int32{
+	0,  // 0: radreply.CreateRadreplyResponse.radreply:type_name -> radreply.Radreply
+	0,  // 1: radreply.GetRadreplyResponse.radreply:type_name -> radreply.Radreply
+	5,  // 2: radreply.ListRadreplyRequest.filter:type_name -> radreply.RadreplyFilter
+	0,  // 3: radreply.ListRadreplyResponse.radreplies:type_name -> radreply.Radreply
+	0,  // 4: radreply.UpdateRadreplyResponse.radreply:type_name -> radreply.Radreply
+	1,  // 5: radreply.RadreplyService.CreateRadreply:input_type -> radreply.CreateRadreplyRequest
+	3,  // 6: radreply.RadreplyService.GetRadreply:input_type -> radreply.GetRadreplyRequest
+	6,  // 7: radreply.RadreplyService.ListRadreply:input_type -> radreply.ListRadreplyRequest
+	8,  // 8: radreply.RadreplyService.UpdateRadreply:input_type -> radreply.UpdateRadreplyRequest
+	10, // 9: radreply.RadreplyService.DeleteRadreply:input_type -> radreply.DeleteRadreplyRequest
+	2,  // 10: radreply.RadreplyService.CreateRadreply:output_type -> radreply.CreateRadreplyResponse
+	4,  // 11: radreply.RadreplyService.GetRadreply:output_type -> radreply.GetRadreplyResponse
+	7,  // 12: radreply.RadreplyService.ListRadreply:output_type -> radreply.ListRadreplyResponse
+	9,  // 13: radreply.RadreplyService.UpdateRadreply:output_type -> radreply.UpdateRadreplyResponse
+	11, // 14: radreply.RadreplyService.DeleteRadreply:output_type -> radreply.DeleteRadreplyResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_radreply_radreply_proto_init() }
+func file_api_proto_radreply_radreply_proto_init() {
+	if File_api_proto_radreply_radreply_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_radreply_radreply_proto_rawDesc), len(file_api_proto_radreply_radreply_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_radreply_radreply_proto_goTypes,
+		DependencyIndexes: file_api_proto_radreply_radreply_proto_depIdxs,
+		MessageInfos:      file_api_proto_radreply_radreply_proto_msgTypes,
+	}.Build()
+	File_api_proto_radreply_radreply_proto = out.File
+	file_api_proto_radreply_radreply_proto_goTypes = nil
+	file_api_proto_radreply_radreply_proto_depIdxs = nil
+}
@@ -0,0 +1,265 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: api/proto/radreply/radreply.proto
+
+package radreply
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RadreplyService_CreateRadreply_FullMethodName = "/radreply.RadreplyService/CreateRadreply"
+	RadreplyService_GetRadreply_FullMethodName    = "/radreply.RadreplyService/GetRadreply"
+	RadreplyService_ListRadreply_FullMethodName   = "/radreply.RadreplyService/ListRadreply"
+	RadreplyService_UpdateRadreply_FullMethodName = "/radreply.RadreplyService/UpdateRadreply"
+	RadreplyService_DeleteRadreply_FullMethodName = "/radreply.RadreplyService/DeleteRadreply"
+)
+
+// RadreplyServiceClient is the client API for RadreplyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RadreplyServiceClient interface {
+	// Create a new radreply attribute
+	CreateRadreply(ctx context.Context, in *CreateRadreplyRequest, opts ...grpc.CallOption) (*CreateRadreplyResponse, error)
+	// Get a radreply attribute by ID
+	GetRadreply(ctx context.Context, in *GetRadreplyRequest, opts ...grpc.CallOption) (*GetRadreplyResponse, error)
+	// List radreply attributes with pagination and filtering
+	ListRadreply(ctx context.Context, in *ListRadreplyRequest, opts ...grpc.CallOption) (*ListRadreplyResponse, error)
+	// Update a radreply attribute
+	UpdateRadreply(ctx context.Context, in *UpdateRadreplyRequest, opts ...grpc.CallOption) (*UpdateRadreplyResponse, error)
+	// Delete a radreply attribute
+	DeleteRadreply(ctx context.Context, in *DeleteRadreplyRequest, opts ...grpc.CallOption) (*DeleteRadreplyResponse, error)
+}
+
+type radreplyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRadreplyServiceClient(cc grpc.ClientConnInterface) RadreplyServiceClient {
+	return &radreplyServiceClient{cc}
+}
+
+func (c *radreplyServiceClient) CreateRadreply(ctx context.Context, in *CreateRadreplyRequest, opts ...grpc.CallOption) (*CreateRadreplyResponse, error) {
+	out := new(CreateRadreplyResponse)
+	err := c.cc.Invoke(ctx, RadreplyService_CreateRadreply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radreplyServiceClient) GetRadreply(ctx context.Context, in *GetRadreplyRequest, opts ...grpc.CallOption) (*GetRadreplyResponse, error) {
+	out := new(GetRadreplyResponse)
+	err := c.cc.Invoke(ctx, RadreplyService_GetRadreply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radreplyServiceClient) ListRadreply(ctx context.Context, in *ListRadreplyRequest, opts ...grpc.CallOption) (*ListRadreplyResponse, error) {
+	out := new(ListRadreplyResponse)
+	err := c.cc.Invoke(ctx, RadreplyService_ListRadreply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radreplyServiceClient) UpdateRadreply(ctx context.Context, in *UpdateRadreplyRequest, opts ...grpc.CallOption) (*UpdateRadreplyResponse, error) {
+	out := new(UpdateRadreplyResponse)
+	err := c.cc.Invoke(ctx, RadreplyService_UpdateRadreply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *radreplyServiceClient) DeleteRadreply(ctx context.Context, in *DeleteRadreplyRequest, opts ...grpc.CallOption) (*DeleteRadreplyResponse, error) {
+	out := new(DeleteRadreplyResponse)
+	err := c.cc.Invoke(ctx, RadreplyService_DeleteRadreply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RadreplyServiceServer is the server API for RadreplyService service.
+// All implementations should embed UnimplementedRadreplyServiceServer
+// for forward compatibility
+type RadreplyServiceServer interface {
+	// Create a new radreply attribute
+	CreateRadreply(context.Context, *CreateRadreplyRequest) (*CreateRadreplyResponse, error)
+	// Get a radreply attribute by ID
+	GetRadreply(context.Context, *GetRadreplyRequest) (*GetRadreplyResponse, error)
+	// List radreply attributes with pagination and filtering
+	ListRadreply(context.Context, *ListRadreplyRequest) (*ListRadreplyResponse, error)
+	// Update a radreply attribute
+	UpdateRadreply(context.Context, *UpdateRadreplyRequest) (*UpdateRadreplyResponse, error)
+	// Delete a radreply attribute
+	DeleteRadreply(context.Context, *DeleteRadreplyRequest) (*DeleteRadreplyResponse, error)
+}
+
+// UnimplementedRadreplyServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRadreplyServiceServer struct {
+}
+
+func (UnimplementedRadreplyServiceServer) CreateRadreply(context.Context, *CreateRadreplyRequest) (*CreateRadreplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRadreply not implemented")
+}
+func (UnimplementedRadreplyServiceServer) GetRadreply(context.Context, *GetRadreplyRequest) (*GetRadreplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRadreply not implemented")
+}
+func (UnimplementedRadreplyServiceServer) ListRadreply(context.Context, *ListRadreplyRequest) (*ListRadreplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRadreply not implemented")
+}
+func (UnimplementedRadreplyServiceServer) UpdateRadreply(context.Context, *UpdateRadreplyRequest) (*UpdateRadreplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRadreply not implemented")
+}
+func (UnimplementedRadreplyServiceServer) DeleteRadreply(context.Context, *DeleteRadreplyRequest) (*DeleteRadreplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRadreply not implemented")
+}
+
+// UnsafeRadreplyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RadreplyServiceServer will
+// result in compilation errors.
+type UnsafeRadreplyServiceServer interface {
+	mustEmbedUnimplementedRadreplyServiceServer()
+}
+
+func RegisterRadreplyServiceServer(s grpc.ServiceRegistrar, srv RadreplyServiceServer) {
+	s.RegisterService(&RadreplyService_ServiceDesc, srv)
+}
+
+func _RadreplyService_CreateRadreply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRadreplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadreplyServiceServer).CreateRadreply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadreplyService_CreateRadreply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadreplyServiceServer).CreateRadreply(ctx, req.(*CreateRadreplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadreplyService_GetRadreply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRadreplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadreplyServiceServer).GetRadreply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadreplyService_GetRadreply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadreplyServiceServer).GetRadreply(ctx, req.(*GetRadreplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadreplyService_ListRadreply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRadreplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadreplyServiceServer).ListRadreply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadreplyService_ListRadreply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadreplyServiceServer).ListRadreply(ctx, req.(*ListRadreplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadreplyService_UpdateRadreply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRadreplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadreplyServiceServer).UpdateRadreply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadreplyService_UpdateRadreply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadreplyServiceServer).UpdateRadreply(ctx, req.(*UpdateRadreplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RadreplyService_DeleteRadreply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRadreplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RadreplyServiceServer).DeleteRadreply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RadreplyService_DeleteRadreply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RadreplyServiceServer).DeleteRadreply(ctx, req.(*DeleteRadreplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RadreplyService_ServiceDesc is the grpc.ServiceDesc for RadreplyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RadreplyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "radreply.RadreplyService",
+	HandlerType: (*RadreplyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRadreply",
+			Handler:    _RadreplyService_CreateRadreply_Handler,
+		},
+		{
+			MethodName: "GetRadreply",
+			Handler:    _RadreplyService_GetRadreply_Handler,
+		},
+		{
+			MethodName: "ListRadreply",
+			Handler:    _RadreplyService_ListRadreply_Handler,
+		},
+		{
+			MethodName: "UpdateRadreply",
+			Handler:    _RadreplyService_UpdateRadreply_Handler,
+		},
+		{
+			MethodName: "DeleteRadreply",
+			Handler:    _RadreplyService_DeleteRadreply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/radreply/radreply.proto",
+}
@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.12.4
+// source: api/proto/session/session.proto
+
+package session
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SessionEvent message
+type SessionEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Monotonic event id, used as the resume cursor
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// One of "start", "interim" or "stop"
+	EventType       string               `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	AcctSessionId   string               `protobuf:"bytes,3,opt,name=acct_session_id,json=acctSessionId,proto3" json:"acct_session_id,omitempty"`
+	AcctUniqueId    string               `protobuf:"bytes,4,opt,name=acct_unique_id,json=acctUniqueId,proto3" json:"acct_unique_id,omitempty"`
+	Username        string               `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	GroupName       string               `protobuf:"bytes,6,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	NasIpAddress    string               `protobuf:"bytes,7,opt,name=nas_ip_address,json=nasIpAddress,proto3" json:"nas_ip_address,omitempty"`
+	NasPortId       string               `protobuf:"bytes,8,opt,name=nas_port_id,json=nasPortId,proto3" json:"nas_port_id,omitempty"`
+	FramedIpAddress string               `protobuf:"bytes,9,opt,name=framed_ip_address,json=framedIpAddress,proto3" json:"framed_ip_address,omitempty"`
+	SessionTime     int64                `protobuf:"varint,10,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	InputOctets     int64                `protobuf:"varint,11,opt,name=input_octets,json=inputOctets,proto3" json:"input_octets,omitempty"`
+	OutputOctets    int64                `protobuf:"varint,12,opt,name=output_octets,json=outputOctets,proto3" json:"output_octets,omitempty"`
+	TerminateCause  string               `protobuf:"bytes,13,opt,name=terminate_cause,json=terminateCause,proto3" json:"terminate_cause,omitempty"`
+	EventTime       *timestamp.Timestamp `protobuf:"bytes,14,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SessionEvent) Reset() {
+	*x = SessionEvent{}
+	mi := &file_api_proto_session_session_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEvent) ProtoMessage() {}
+
+func (x *SessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_session_session_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEvent.ProtoReflect.Descriptor instead.
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_session_session_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SessionEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetAcctSessionId() string {
+	if x != nil {
+		return x.AcctSessionId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetAcctUniqueId() string {
+	if x != nil {
+		return x.AcctUniqueId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetNasIpAddress() string {
+	if x != nil {
+		return x.NasIpAddress
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetNasPortId() string {
+	if x != nil {
+		return x.NasPortId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetFramedIpAddress() string {
+	if x != nil {
+		return x.FramedIpAddress
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetSessionTime() int64 {
+	if x != nil {
+		return x.SessionTime
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetInputOctets() int64 {
+	if x != nil {
+		return x.InputOctets
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetOutputOctets() int64 {
+	if x != nil {
+		return x.OutputOctets
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetTerminateCause() string {
+	if x != nil {
+		return x.TerminateCause
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetEventTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+// Empty fields match every event
+type SessionFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NasIpAddress  string                 `protobuf:"bytes,1,opt,name=nas_ip_address,json=nasIpAddress,proto3" json:"nas_ip_address,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	GroupName     string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionFilter) Reset() {
+	*x = SessionFilter{}
+	mi := &file_api_proto_session_session_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionFilter) ProtoMessage() {}
+
+func (x *SessionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_session_session_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionFilter.ProtoReflect.Descriptor instead.
+func (*SessionFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_session_session_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionFilter) GetNasIpAddress() string {
+	if x != nil {
+		return x.NasIpAddress
+	}
+	return ""
+}
+
+func (x *SessionFilter) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SessionFilter) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+type WatchSessionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *SessionFilter         `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Resume after this event id; 0 streams only new events
+	Cursor        uint64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSessionsRequest) Reset() {
+	*x = WatchSessionsRequest{}
+	mi := &file_api_proto_session_session_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSessionsRequest) ProtoMessage() {}
+
+func (x *WatchSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_session_session_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSessionsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_session_session_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchSessionsRequest) GetFilter() *SessionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchSessionsRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+var File_api_proto_session_session_proto protoreflect.FileDescriptor
+
+const file_api_proto_session_session_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapi/proto/session/session.proto\x12\asession\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x04\n" +
+	"\fSessionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12&\n" +
+	"\x0facct_session_id\x18\x03 \x01(\tR\racctSessionId\x12$\n" +
+	"\x0eacct_unique_id\x18\x04 \x01(\tR\facctUniqueId\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x06 \x01(\tR\tgroupName\x12$\n" +
+	"\x0enas_ip_address\x18\a \x01(\tR\fnasIpAddress\x12\x1e\n" +
+	"\vnas_port_id\x18\b \x01(\tR\tnasPortId\x12*\n" +
+	"\x11framed_ip_address\x18\t \x01(\tR\x0fframedIpAddress\x12!\n" +
+	"\fsession_time\x18\n" +
+	" \x01(\x03R\vsessionTime\x12!\n" +
+	"\finput_octets\x18\v \x01(\x03R\vinputOctets\x12#\n" +
+	"\routput_octets\x18\f \x01(\x03R\foutputOctets\x12'\n" +
+	"\x0fterminate_cause\x18\r \x01(\tR\x0eterminateCause\x129\n" +
+	"\n" +
+	"event_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\"p\n" +
+	"\rSessionFilter\x12$\n" +
+	"\x0enas_ip_address\x18\x01 \x01(\tR\fnasIpAddress\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\"^\n" +
+	"\x14WatchSessionsRequest\x12.\n" +
+	"\x06filter\x18\x01 \x01(\v2\x16.session.SessionFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x04R\x06cursor2Y\n" +
+	"\x0eSessionService\x12G\n" +
+	"\rWatchSessions\x12\x1d.session.WatchSessionsRequest\x1a\x15.session.SessionEvent0\x01B?Z=github.com/novriyantoAli/freeradius-service/api/proto/sessionb\x06proto3"
+
+var (
+	file_api_proto_session_session_proto_rawDescOnce sync.Once
+	file_api_proto_session_session_proto_rawDescData []byte
+)
+
+func file_api_proto_session_session_proto_rawDescGZIP() []byte {
+	file_api_proto_session_session_proto_rawDescOnce.Do(func() {
+		file_api_proto_session_session_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_session_session_proto_rawDesc), len(file_api_proto_session_session_proto_rawDesc)))
+	})
+	return file_api_proto_session_session_proto_rawDescData
+}
+
+var file_api_proto_session_session_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_proto_session_session_proto_goTypes = []any{
+	(*SessionEvent)(nil),         // 0: session.SessionEvent
+	(*SessionFilter)(nil),        // 1: session.SessionFilter
+	(*WatchSessionsRequest)(nil), // 2: session.WatchSessionsRequest
+	(*timestamp.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_api_proto_session_session_proto_depIdxs = []int32{
+	3, // 0: session.SessionEvent.event_time:type_name -> google.protobuf.Timestamp
+	1, // 1: session.WatchSessionsRequest.filter:type_name -> session.SessionFilter
+	2, // 2: session.SessionService.WatchSessions:input_type -> session.WatchSessionsRequest
+	0, // 3: session.SessionService.WatchSessions:output_type -> session.SessionEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_session_session_proto_init() }
+func file_api_proto_session_session_proto_init() {
+	if File_api_proto_session_session_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_session_session_proto_rawDesc), len(file_api_proto_session_session_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_session_session_proto_goTypes,
+		DependencyIndexes: file_api_proto_session_session_proto_depIdxs,
+		MessageInfos:      file_api_proto_session_session_proto_msgTypes,
+	}.Build()
+	File_api_proto_session_session_proto = out.File
+	file_api_proto_session_session_proto_goTypes = nil
+	file_api_proto_session_session_proto_depIdxs = nil
+}
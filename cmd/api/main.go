@@ -8,8 +8,15 @@ import (
 	"syscall"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 	"github.com/novriyantoAli/freeradius-service/internal/server/api"
 
 	"go.uber.org/fx"
@@ -33,7 +40,15 @@ import (
 // @host      localhost:8080
 // @BasePath  /api/v1
 
-// @securityDefinitions.basic  BasicAuth
+// @securityDefinitions.apikey BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Access token from POST /auth/login, sent as "Bearer <token>"
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 API key from POST /api-keys
 
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
@@ -43,8 +58,15 @@ func main() {
 		fx.Provide(
 			config.NewConfig,
 			logger.NewLogger,
+			tracing.NewTracerProvider,
 			database.NewDatabase,
 			database.NewTransactionManager,
+			outbox.NewOutbox,
+			audit.NewRecorder,
+			secretbox.NewKeyring,
+			metrics.NewRegistry,
+			ratelimit.NewLimiter,
+			queue.NewClient,
 		),
 		api.Module,
 		fx.Invoke(Run),
@@ -10,9 +10,11 @@ import (
 	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
 	"github.com/novriyantoAli/freeradius-service/internal/server/api"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -31,6 +33,11 @@ func NewServer(cfg *config.Config, logger *zap.Logger, apiServer *api.Server) *S
 	}
 
 	router := gin.New()
+	// API keys restricted to source ranges rely on the client IP, which must
+	// not be taken from headers set by anyone but our own proxies
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid api.trusted_proxies", zap.Error(err))
+	}
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
@@ -49,13 +56,16 @@ func NewServer(cfg *config.Config, logger *zap.Logger, apiServer *api.Server) *S
 	}
 }
 
-func (s *Server) setupRoutes() {
+func (s *Server) setupRoutes(registry *prometheus.Registry) {
 	s.apiServer.SetupRoutes(s.router)
+	if s.config.Metrics.Enabled {
+		s.router.GET(metrics.Path, gin.WrapH(metrics.Handler(registry)))
+	}
 }
 
-func Run(lifecycle fx.Lifecycle, cfg *config.Config, logger *zap.Logger, apiServer *api.Server) {
+func Run(lifecycle fx.Lifecycle, cfg *config.Config, logger *zap.Logger, apiServer *api.Server, registry *prometheus.Registry) {
 	server := NewServer(cfg, logger, apiServer)
-	server.setupRoutes()
+	server.setupRoutes(registry)
 
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
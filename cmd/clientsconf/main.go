@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas"
+	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
+	nasService "github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
+
+	"go.uber.org/fx"
+)
+
+func main() {
+	var (
+		action = flag.String("action", "export", "Action to perform: export, import")
+		output = flag.String("output", "", "Path to write clients.conf to atomically (default: stdout)")
+		input  = flag.String("input", "", "Path of the clients.conf to import (default: stdin)")
+		dryRun = flag.Bool("dry-run", false, "Only show what an import would create, change or leave alone")
+	)
+	flag.Parse()
+
+	ctx := context.Background()
+
+	app := fx.New(
+		fx.Provide(
+			newConfig,
+			logger.NewLogger,
+			tracing.NewTracerProvider,
+			database.NewDatabase,
+			database.NewTransactionManager,
+			outbox.NewOutbox,
+			audit.NewRecorder,
+			secretbox.NewKeyring,
+		),
+		nas.WorkerModule,
+		fx.Invoke(func(service nasService.NASService) {
+			runAction(service, *action, *output, *input, *dryRun)
+		}),
+	)
+
+	if err := app.Start(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start clients.conf application: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := app.Stop(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to stop clients.conf application gracefully: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newConfig loads the regular configuration but sends logs to stderr so that
+// stdout only carries the generated file.
+func newConfig() (*config.Config, error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Logger.OutputPath = "stderr"
+	return cfg, nil
+}
+
+func runAction(service nasService.NASService, action, output, input string, dryRun bool) {
+	ctx := audit.WithMetadata(context.Background(), audit.Metadata{
+		Actor:  os.Getenv("USER"),
+		Source: audit.SourceCLI,
+	})
+	var err error
+
+	switch action {
+	case "export":
+		err = export(ctx, service, output)
+	case "import":
+		err = importFile(ctx, service, input, dryRun)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown action: %s. Available actions: export, import\n", action)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "clients.conf %s failed: %v\n", action, err)
+		os.Exit(1)
+	}
+}
+
+func export(ctx context.Context, service nasService.NASService, output string) error {
+	if output != "" {
+		if err := service.WriteClientsConf(ctx, output); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "clients.conf written to %s\n", output)
+		return nil
+	}
+
+	data, err := service.GenerateClientsConf(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+func importFile(ctx context.Context, service nasService.NASService, input string, dryRun bool) error {
+	var (
+		data []byte
+		err  error
+	)
+	if input != "" {
+		data, err = os.ReadFile(input)
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := service.ImportClientsConf(ctx, data, dryRun)
+	if err != nil {
+		return err
+	}
+
+	printImportResult(result)
+	return nil
+}
+
+// printImportResult writes a diff-like summary: "+" for new clients, "~" for
+// changed ones followed by their field changes and "=" for unchanged ones.
+func printImportResult(result *nasDto.ImportClientsConfResponse) {
+	for _, change := range result.Changes {
+		switch change.Action {
+		case nasDto.ImportActionCreate:
+			fmt.Printf("+ %s\n", change.NASName)
+		case nasDto.ImportActionUpdate:
+			fmt.Printf("~ %s\n", change.NASName)
+		default:
+			fmt.Printf("= %s\n", change.NASName)
+			continue
+		}
+		for _, field := range change.Fields {
+			fmt.Printf("    %s: %q -> %q\n", field.Field, field.Old, field.New)
+		}
+	}
+
+	mode := "applied"
+	if result.DryRun {
+		mode = "dry run, nothing written"
+	}
+	fmt.Printf("\n%d to create, %d to update, %d unchanged (%s)\n",
+		result.Created, result.Updated, result.Unchanged, mode)
+}
@@ -9,8 +9,14 @@ import (
 	"syscall"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 	"github.com/novriyantoAli/freeradius-service/internal/server/grpc"
 
 	"go.uber.org/fx"
@@ -26,7 +32,14 @@ func main() {
 		fx.Provide(
 			config.NewConfig,
 			logger.NewLogger,
+			tracing.NewTracerProvider,
 			database.NewDatabase,
+			database.NewTransactionManager,
+			outbox.NewOutbox,
+			audit.NewRecorder,
+			secretbox.NewKeyring,
+			metrics.NewRegistry,
+			ratelimit.NewLimiter,
 		),
 		grpc.Module,
 		fx.Invoke(func(lifecycle fx.Lifecycle, grpcServer *grpc.Server) {
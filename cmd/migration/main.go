@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 	"github.com/novriyantoAli/freeradius-service/internal/server/migration"
 
 	"go.uber.org/fx"
@@ -18,10 +22,23 @@ import (
 
 func main() {
 	var (
-		action = flag.String("action", "migrate", "Action to perform: migrate, seed, drop")
+		action     = flag.String("action", "migrate", "Action to perform: migrate, up, down, status, force, unlock, verify, seed, drop")
+		staleAfter = flag.Duration("stale-after", time.Hour, "Age past which unlock releases a SQLite migrations lock")
 	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-action=]<up|down [N]|status|force VERSION|unlock|verify|seed|drop>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	// The action may also be given as the first argument, as in "down 2"
+	args := flag.Args()
+	if len(args) > 0 {
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			*action, args = args[0], args[1:]
+		}
+	}
+
 	// Setup graceful shutdown for long-running operations
 	ctx, cancel := context.WithCancel(context.Background())
 	sigChan := make(chan os.Signal, 1)
@@ -37,11 +54,12 @@ func main() {
 		fx.Provide(
 			config.NewConfig,
 			logger.NewLogger,
+			tracing.NewTracerProvider,
 			database.NewDatabase,
 		),
 		migration.Module,
 		fx.Invoke(func(migrationServer *migration.Server) {
-			runMigration(ctx, migrationServer, *action)
+			runMigration(ctx, migrationServer, *action, args, *staleAfter)
 		}),
 	)
 
@@ -56,21 +74,52 @@ func main() {
 	}
 }
 
-func runMigration(ctx context.Context, server *migration.Server, action string) {
+func runMigration(ctx context.Context, server *migration.Server, action string, args []string, staleAfter time.Duration) {
 	var err error
 
 	switch action {
-	case "migrate":
+	case "migrate", "up":
 		fmt.Println("Running database migrations...")
-		err = server.RunMigrations()
+		err = server.RunMigrations(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				fmt.Fprintf(os.Stderr, "down takes a number of migrations to revert, got %q\n", args[0])
+				os.Exit(1)
+			}
+		}
+		fmt.Printf("Reverting %d database migration(s)...\n", steps)
+		err = server.Rollback(ctx, steps)
+	case "status":
+		err = printStatus(ctx, server)
+	case "force":
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "force takes the version to record the database at")
+			os.Exit(1)
+		}
+		version, parseErr := strconv.ParseInt(args[0], 10, 64)
+		if parseErr != nil || version < 0 {
+			fmt.Fprintf(os.Stderr, "force takes a version, got %q\n", args[0])
+			os.Exit(1)
+		}
+		fmt.Printf("Forcing database migration version %d...\n", version)
+		err = server.ForceVersion(ctx, version)
+	case "unlock":
+		fmt.Printf("Releasing a migrations lock taken over %s ago...\n", staleAfter)
+		err = server.ReleaseLock(ctx, staleAfter)
+	case "verify":
+		fmt.Println("Comparing the database with the FreeRADIUS schema...")
+		err = verifySchema(ctx, server)
 	case "seed":
 		fmt.Println("Seeding database...")
 		err = server.SeedData()
 	case "drop":
 		fmt.Println("Dropping database tables...")
-		err = server.DropTables()
+		err = server.DropTables(ctx)
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown action: %s. Available actions: migrate, seed, drop\n", action)
+		fmt.Fprintf(os.Stderr, "Unknown action: %s. Available actions: migrate, up, down, status, force, unlock, verify, seed, drop\n", action)
 		os.Exit(1)
 	}
 
@@ -89,3 +138,44 @@ func runMigration(ctx context.Context, server *migration.Server, action string)
 
 	fmt.Printf("Migration action '%s' completed successfully\n", action)
 }
+
+func verifySchema(ctx context.Context, server *migration.Server) error {
+	drifts, err := server.VerifySchema(ctx)
+	if err != nil {
+		return err
+	}
+	for _, drift := range drifts {
+		fmt.Println("  " + drift.String())
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("the schema differs from FreeRADIUS in %d place(s)", len(drifts))
+	}
+	fmt.Println("The schema matches FreeRADIUS")
+	return nil
+}
+
+func printStatus(ctx context.Context, server *migration.Server) error {
+	statuses, err := server.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		switch {
+		case status.Dirty:
+			state = "dirty"
+		case status.Unknown:
+			state = "unknown"
+		case status.Applied:
+			state = "applied"
+		}
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
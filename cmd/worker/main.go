@@ -8,9 +8,14 @@ import (
 	"syscall"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 	"github.com/novriyantoAli/freeradius-service/internal/server/worker"
 
 	"go.uber.org/fx"
@@ -21,9 +26,16 @@ func main() {
 		fx.Provide(
 			config.NewConfig,
 			logger.NewLogger,
+			tracing.NewTracerProvider,
 			database.NewDatabase,
+			database.NewTransactionManager,
+			outbox.NewOutbox,
+			audit.NewRecorder,
+			secretbox.NewKeyring,
+			metrics.NewRegistry,
 			queue.NewClient,
 			queue.NewServer,
+			queue.NewScheduler,
 		),
 		worker.Module,
 		fx.Invoke(runWorker),
@@ -53,10 +65,26 @@ func main() {
 	fmt.Println("Worker stopped successfully")
 }
 
-func runWorker(lifecycle fx.Lifecycle, workerServer *worker.Server, queueServer *queue.Server) {
+func runWorker(
+	lifecycle fx.Lifecycle,
+	workerServer *worker.Server,
+	queueServer *queue.Server,
+	scheduler *queue.Scheduler,
+	relay *outbox.Relay,
+) error {
 	// Register worker handlers
 	workerServer.RegisterHandlers()
 
-	// Start the queue api (it manages its own lifecycle)
+	// Register periodic tasks such as the NAS health check
+	if err := workerServer.RegisterPeriodicTasks(scheduler); err != nil {
+		return err
+	}
+
+	// Start the queue api and scheduler (they manage their own lifecycle)
 	queueServer.Start(lifecycle)
+	scheduler.Start(lifecycle)
+
+	// Relay domain events recorded by the services
+	relay.Start(lifecycle)
+	return nil
 }
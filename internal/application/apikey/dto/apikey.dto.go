@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+)
+
+// CreateAPIKeyRequest describes a new key. Scopes are permissions such as
+// "radcheck:write" or wildcards such as "nas:*", and may not exceed those of
+// the caller. AllowedCIDRs takes CIDR ranges or single addresses.
+type CreateAPIKeyRequest struct {
+	Name         string     `json:"name" binding:"required,max=100"`
+	Scopes       []string   `json:"scopes" binding:"required,min=1,dive,required,max=64"`
+	AllowedCIDRs []string   `json:"allowed_cidrs" binding:"omitempty,max=20,dive,required,max=49"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+	Status       string   `json:"status"`
+	CreatedBy    uint     `json:"created_by"`
+	TenantID     *uint    `json:"tenant_id,omitempty"`
+	ExpiresAt    *string  `json:"expires_at,omitempty"`
+	LastUsedAt   *string  `json:"last_used_at,omitempty"`
+	LastUsedIP   string   `json:"last_used_ip,omitempty"`
+	RevokedAt    *string  `json:"revoked_at,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// CreateAPIKeyResponse carries the key itself, which cannot be retrieved
+// again.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type ListAPIKeysResponse struct {
+	Data           []APIKeyResponse `json:"data"`
+	Total          *int64           `json:"total,omitempty"`
+	TotalEstimated bool             `json:"total_estimated,omitempty"`
+	Page           int              `json:"page"`
+	PageSize       int              `json:"page_size"`
+	TotalPage      *int             `json:"total_page,omitempty"`
+	NextCursor     string           `json:"next_cursor,omitempty"`
+}
+
+type APIKeyFilter struct {
+	Status   string `json:"status" form:"status" binding:"omitempty,oneof=active expired revoked"`
+	Page     int    `json:"page" form:"page" binding:"min=1"`
+	PageSize int    `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
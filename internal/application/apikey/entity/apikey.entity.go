@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// APIKey authenticates a machine client. Keys read "frs_<prefix>_<secret>":
+// Prefix identifies the key and only the SHA-256 of the secret is stored.
+// Scopes and AllowedCIDRs are comma separated lists; without CIDRs the key is
+// accepted from any source address.
+type APIKey struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Name         string     `json:"name" gorm:"not null;size:100"`
+	Prefix       string     `json:"prefix" gorm:"not null;size:16;uniqueIndex"`
+	SecretHash   string     `json:"-" gorm:"not null;size:64"`
+	Scopes       string     `json:"scopes" gorm:"not null;size:1024"`
+	AllowedCIDRs string     `json:"allowed_cidrs" gorm:"size:1024"`
+	CreatedBy    uint       `json:"created_by" gorm:"index"`
+	TenantID     *uint      `json:"tenant_id" gorm:"index"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	LastUsedIP   string     `json:"last_used_ip" gorm:"size:45"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// States of a key, derived from RevokedAt and ExpiresAt.
+const (
+	StatusActive  = "active"
+	StatusExpired = "expired"
+	StatusRevoked = "revoked"
+)
+
+func (k APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Status returns the state of the key at now.
+func (k APIKey) Status(now time.Time) string {
+	switch {
+	case k.RevokedAt != nil:
+		return StatusRevoked
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		return StatusExpired
+	}
+	return StatusActive
+}
+
+// ScopeList returns the granted permissions.
+func (k APIKey) ScopeList() []string {
+	return splitList(k.Scopes)
+}
+
+// CIDRList returns the allowed source ranges.
+func (k APIKey) CIDRList() []string {
+	return splitList(k.AllowedCIDRs)
+}
+
+// AllowsIP reports whether requests from ip may use the key.
+func (k APIKey) AllowsIP(ip string) bool {
+	cidrs := k.CIDRList()
+	if len(cidrs) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
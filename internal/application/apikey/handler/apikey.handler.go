@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	logger        *zap.Logger
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	keys := r.Group("/api-keys")
+	{
+		keys.POST("", h.CreateAPIKey)
+		keys.GET("", h.ListAPIKeys)
+		keys.GET("/:id", h.GetAPIKey)
+		keys.DELETE("/:id", h.RevokeAPIKey)
+	}
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Issue a key for a machine client. The key is sent in the X-API-Key header and is only returned by this call. Scopes may not exceed the permissions of the caller's role.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Create API Key Request"
+// @Success 201 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	principal, ok := identity.PrincipalFrom(c.Request.Context())
+	if !ok {
+		apperror.Respond(c, identity.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.apiKeyService.CreateKey(c.Request.Context(), principal, &req)
+	if err != nil {
+		h.logger.Error("Failed to create API key", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Get a paginated list of API keys
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param status query string false "Filter by status" Enums(active, expired, revoked)
+// @Success 200 {object} dto.ListAPIKeysResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	var filter dto.APIKeyFilter
+	filter.Page = 1
+	filter.PageSize = 10
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.apiKeyService.ListKeys(c.Request.Context(), &filter)
+	if err != nil {
+		h.logger.Error("Failed to list API keys", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetAPIKey godoc
+// @Summary Get API key by ID
+// @Description Get an API key by ID, including when and from where it was last used
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.apiKeyService.GetKey(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get API key", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke API key
+// @Description Revoke an API key. It stops authenticating immediately and stays listed as revoked.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to revoke API key", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseID parses the id path parameter, answering 400 when it is invalid.
+func (h *APIKeyHandler) parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid ID", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
+		return 0, false
+	}
+	return uint(id), true
+}
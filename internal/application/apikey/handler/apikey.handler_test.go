@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apikeyDto "github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testPrincipal = &identity.Principal{UserID: 1, Email: "john@example.com", Role: "admin"}
+
+func setupAPIKeyHandler() (*gin.Engine, *testutil.MockAPIKeyService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockAPIKeyService{}
+	handler := NewAPIKeyHandler(mockService, testutil.NewSilentLogger())
+
+	router := gin.New()
+	protected := router.Group("/api/v1", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(identity.WithPrincipal(c.Request.Context(), testPrincipal))
+	})
+	handler.RegisterRoutes(protected)
+	return router, mockService
+}
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	t.Run("should create the key on behalf of the caller", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+		response := &apikeyDto.CreateAPIKeyResponse{
+			APIKeyResponse: apikeyDto.APIKeyResponse{ID: 1, Name: "Provisioning", Prefix: "a1b2c3d4e5f6", Scopes: []string{"radcheck:write"}},
+			Key:            testutil.APIKeyFixtureKey,
+		}
+
+		// Mock expectations
+		mockService.On("CreateKey", mock.Anything, testPrincipal, mock.AnythingOfType("*dto.CreateAPIKeyRequest")).Return(response, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+			bytes.NewBufferString(`{"name":"Provisioning","scopes":["radcheck:write"]}`)))
+
+		// Then
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var result apikeyDto.CreateAPIKeyResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, testutil.APIKeyFixtureKey, result.Key)
+		assert.Equal(t, "a1b2c3d4e5f6", result.Prefix)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return bad request without scopes", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+			bytes.NewBufferString(`{"name":"Provisioning","scopes":[]}`)))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return forbidden for scopes the caller does not hold", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+
+		// Mock expectations
+		mockService.On("CreateKey", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: nas:secrets", service.ErrScopeNotHeld))
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+			bytes.NewBufferString(`{"name":"Provisioning","scopes":["nas:secrets"]}`)))
+
+		// Then
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{
+			"type": "urn:freeradius-service:problem:permission_denied",
+			"title": "Forbidden",
+			"status": 403,
+			"detail": "cannot grant a scope you do not hold: nas:secrets",
+			"instance": "/api/v1/api-keys"
+		}`, w.Body.String())
+		assert.Equal(t, apperror.ProblemContentType, w.Header().Get("Content-Type"))
+	})
+}
+
+func TestAPIKeyHandler_ListAPIKeys(t *testing.T) {
+	t.Run("should return bad request for an unknown status", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/api-keys?status=lost", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListKeys", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	t.Run("should revoke the key", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+
+		// Mock expectations
+		mockService.On("RevokeKey", mock.Anything, uint(1)).Return(nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/1", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found error", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAPIKeyHandler()
+
+		// Mock expectations
+		mockService.On("RevokeKey", mock.Anything, uint(999)).Return(service.ErrAPIKeyNotFound)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/999", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
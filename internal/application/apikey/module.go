@@ -0,0 +1,23 @@
+package apikey
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+
+	"go.uber.org/fx"
+)
+
+// Module provides all API key domain dependencies
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewAPIKeyRepository,
+		service.NewAPIKeyService,
+		handler.NewAPIKeyHandler,
+		// The servers authenticate X-API-Key with the service
+		func(s service.APIKeyService) identity.KeyAuthenticator {
+			return s
+		},
+	),
+)
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByID(ctx context.Context, id uint) (*entity.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	List(ctx context.Context, filter *dto.APIKeyFilter, now time.Time) ([]entity.APIKey, pagination.Page, error)
+	Update(ctx context.Context, key *entity.APIKey) error
+	// Touch records a use of the key without overwriting concurrent changes
+	// such as a revocation.
+	Touch(ctx context.Context, id uint, at time.Time, ip string) error
+}
+
+type apiKeyRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewAPIKeyRepository(db *gorm.DB, logger *zap.Logger) APIKeyRepository {
+	return &apiKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating API key", zap.String("name", key.Name), zap.String("prefix", key.Prefix))
+	key.TenantID = tenant.ID(ctx)
+	return db.Create(key).Error
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*entity.APIKey, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var key entity.APIKey
+	if err := db.Scopes(tenant.Filter(ctx)).First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByPrefix looks across all tenants, since it authenticates the key that
+// determines the tenant.
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var key entity.APIKey
+	if err := db.First(&key, "prefix = ?", prefix).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context, filter *dto.APIKeyFilter, now time.Time) ([]entity.APIKey, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+
+	query := db.Model(&entity.APIKey{}).Scopes(tenant.Filter(ctx))
+
+	switch filter.Status {
+	case entity.StatusActive:
+		query = query.Where("revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now)
+	case entity.StatusExpired:
+		query = query.Where("revoked_at IS NULL AND expires_at <= ?", now)
+	case entity.StatusRevoked:
+		query = query.Where("revoked_at IS NOT NULL")
+	}
+
+	keys, page, err := pagination.Find[entity.APIKey](query, filter.Params, filter.Page, filter.PageSize, pagination.Order{})
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list API keys", zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+
+	return keys, page, nil
+}
+
+func (r *apiKeyRepository) Update(ctx context.Context, key *entity.APIKey) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	return db.Save(key).Error
+}
+
+func (r *apiKeyRepository) Touch(ctx context.Context, id uint, at time.Time, ip string) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	return db.Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"last_used_at": at, "last_used_ip": ip}).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apikeyDto "github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	apikeyEntity "github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewAPIKeyRepository(db, logger)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should find a key by its prefix", func(t *testing.T) {
+		// Given
+		key := testutil.CreateAPIKeyFixture()
+		key.ID = 0
+		require.NoError(t, repo.Create(context.Background(), key))
+
+		// When
+		found, err := repo.GetByPrefix(context.Background(), key.Prefix)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, key.ID, found.ID)
+		assert.Equal(t, key.SecretHash, found.SecretHash)
+
+		_, err = repo.GetByPrefix(context.Background(), "000000000000")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("should filter keys by status", func(t *testing.T) {
+		// Given
+		expiresAt := now.Add(-time.Hour)
+		expired := testutil.CreateAPIKeyFixture()
+		expired.ID = 0
+		expired.Prefix = "0a0b0c0d0e0f"
+		expired.ExpiresAt = &expiresAt
+		require.NoError(t, repo.Create(context.Background(), expired))
+
+		revoked := testutil.CreateAPIKeyFixture()
+		revoked.ID = 0
+		revoked.Prefix = "f0e0d0c0b0a0"
+		revoked.RevokedAt = &expiresAt
+		require.NoError(t, repo.Create(context.Background(), revoked))
+
+		for status, prefix := range map[string]string{
+			apikeyEntity.StatusActive:  "a1b2c3d4e5f6",
+			apikeyEntity.StatusExpired: expired.Prefix,
+			apikeyEntity.StatusRevoked: revoked.Prefix,
+		} {
+			// When
+			keys, page, err := repo.List(context.Background(), &apikeyDto.APIKeyFilter{Status: status, Page: 1, PageSize: 10}, now)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), *page.Total, status)
+			require.Len(t, keys, 1)
+			assert.Equal(t, prefix, keys[0].Prefix)
+		}
+
+		_, page, err := repo.List(context.Background(), &apikeyDto.APIKeyFilter{Page: 1, PageSize: 10}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *page.Total)
+	})
+
+	t.Run("should record a use without overwriting a revocation", func(t *testing.T) {
+		// Given
+		key, err := repo.GetByPrefix(context.Background(), "a1b2c3d4e5f6")
+		require.NoError(t, err)
+		require.NoError(t, db.Model(key).Update("revoked_at", now).Error)
+
+		// When
+		err = repo.Touch(context.Background(), key.ID, now, "10.1.2.3")
+
+		// Then
+		assert.NoError(t, err)
+		found, err := repo.GetByID(context.Background(), key.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "10.1.2.3", found.LastUsedIP)
+		require.NotNil(t, found.LastUsedAt)
+		assert.True(t, now.Equal(*found.LastUsedAt))
+		assert.NotNil(t, found.RevokedAt)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
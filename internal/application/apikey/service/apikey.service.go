@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type APIKeyService interface {
+	identity.KeyAuthenticator
+	CreateKey(ctx context.Context, principal *identity.Principal, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error)
+	GetKey(ctx context.Context, id uint) (*dto.APIKeyResponse, error)
+	ListKeys(ctx context.Context, filter *dto.APIKeyFilter) (*dto.ListAPIKeysResponse, error)
+	RevokeKey(ctx context.Context, id uint) error
+}
+
+var (
+	// ErrAPIKeyNotFound is returned when the key does not exist.
+	ErrAPIKeyNotFound = apperror.NotFound("api key not found")
+	// ErrInvalidScope is returned for scopes that are not permissions.
+	ErrInvalidScope = apperror.Validation("unknown scope", apperror.FieldError{Field: "scopes", Message: "has an unknown scope"})
+	// ErrScopeNotHeld is returned when the caller asks for a scope their own
+	// role does not grant.
+	ErrScopeNotHeld = apperror.PermissionDenied("cannot grant a scope you do not hold")
+	// ErrInvalidCIDR is returned for source ranges that do not parse.
+	ErrInvalidCIDR = apperror.Validation("invalid cidr", apperror.FieldError{Field: "allowed_cidrs", Message: "has an invalid cidr"})
+	// ErrInvalidExpiry is returned for expiry times in the past.
+	ErrInvalidExpiry = apperror.InvalidField("expires_at", "must be in the future")
+)
+
+const (
+	keyPrefix = "frs_"
+	// prefixBytes and secretBytes are the entropy of the two key parts.
+	prefixBytes = 6
+	secretBytes = 32
+	// touchInterval bounds how often a key's last use is written, so that
+	// busy clients do not cause a write per request.
+	touchInterval = time.Minute
+)
+
+type apiKeyService struct {
+	repo      repository.APIKeyRepository
+	txManager database.TransactionManagerI
+	auditor   audit.Recorder
+	policy    *rbac.Policy
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+func NewAPIKeyService(
+	repo repository.APIKeyRepository,
+	txManager database.TransactionManagerI,
+	auditor audit.Recorder,
+	policy *rbac.Policy,
+	logger *zap.Logger,
+) APIKeyService {
+	return &apiKeyService{
+		repo:      repo,
+		txManager: txManager,
+		auditor:   auditor,
+		policy:    policy,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+func (s *apiKeyService) CreateKey(ctx context.Context, principal *identity.Principal, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	logger.For(ctx, s.logger).Info("Creating API key", zap.String("name", req.Name), zap.Strings("scopes", req.Scopes))
+
+	for _, scope := range req.Scopes {
+		if !rbac.ValidScope(scope) {
+			return nil, fmt.Errorf("%w %q", ErrInvalidScope, scope)
+		}
+		// API keys hold no role, so they cannot create other keys
+		if principal.APIKey != "" || !s.policy.Covers(principal.Role, scope) {
+			return nil, fmt.Errorf("%w: %s", ErrScopeNotHeld, scope)
+		}
+	}
+	cidrs, err := normalizeCIDRs(req.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, ErrInvalidExpiry
+	}
+
+	prefix, secret, err := generateKey()
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to generate API key", zap.Error(err))
+		return nil, err
+	}
+	key := &entity.APIKey{
+		Name:         req.Name,
+		Prefix:       prefix,
+		SecretHash:   hashSecret(secret),
+		Scopes:       strings.Join(slices.Compact(slices.Sorted(slices.Values(req.Scopes))), ","),
+		AllowedCIDRs: strings.Join(cidrs, ","),
+		CreatedBy:    principal.UserID,
+		ExpiresAt:    req.ExpiresAt,
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, key); err != nil {
+			return err
+		}
+		return s.auditor.Record(txCtx, apiKeyChange(audit.ActionCreate, nil, key))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create API key", zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *keyToResponse(key, now),
+		Key:            keyPrefix + prefix + "_" + secret,
+	}, nil
+}
+
+func (s *apiKeyService) GetKey(ctx context.Context, id uint) (*dto.APIKeyResponse, error) {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return keyToResponse(key, s.now()), nil
+}
+
+func (s *apiKeyService) ListKeys(ctx context.Context, filter *dto.APIKeyFilter) (*dto.ListAPIKeysResponse, error) {
+	now := s.now()
+	keys, page, err := s.repo.List(ctx, filter, now)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, *keyToResponse(&keys[i], now))
+	}
+
+	return &dto.ListAPIKeysResponse{
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
+	}, nil
+}
+
+// RevokeKey stops the key from authenticating. Revoking a revoked key is a
+// no-op.
+func (s *apiKeyService) RevokeKey(ctx context.Context, id uint) error {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	before := *key
+	now := s.now()
+	key.RevokedAt = &now
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, key); err != nil {
+			return err
+		}
+		return s.auditor.Record(txCtx, apiKeyChange(audit.ActionUpdate, &before, key))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to revoke API key", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	logger.For(ctx, s.logger).Info("API key revoked", zap.Uint("id", id), zap.String("prefix", key.Prefix))
+	return nil
+}
+
+// AuthenticateKey resolves a key presented from clientIP. Unknown, revoked
+// and expired keys and keys used outside their source ranges are all
+// rejected with identity.ErrUnauthenticated.
+func (s *apiKeyService) AuthenticateKey(ctx context.Context, rawKey, clientIP string) (*identity.Principal, error) {
+	prefix, secret, ok := parseKey(rawKey)
+	if !ok {
+		return nil, identity.ErrUnauthenticated
+	}
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, identity.ErrUnauthenticated
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, identity.ErrUnauthenticated
+	}
+
+	now := s.now()
+	if key.Status(now) != entity.StatusActive {
+		return nil, identity.ErrUnauthenticated
+	}
+	if !key.AllowsIP(clientIP) {
+		logger.For(ctx, s.logger).Warn("API key used outside its allowed source ranges",
+			zap.String("prefix", key.Prefix),
+			zap.String("client_ip", clientIP))
+		return nil, identity.ErrUnauthenticated
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval || key.LastUsedIP != clientIP {
+		if err := s.repo.Touch(ctx, key.ID, now, clientIP); err != nil {
+			// Losing a last-used update is no reason to reject the call
+			logger.For(ctx, s.logger).Error("Failed to record API key use", zap.String("prefix", key.Prefix), zap.Error(err))
+		}
+	}
+
+	return &identity.Principal{APIKey: key.Prefix, Scopes: key.ScopeList(), TenantID: key.TenantID}, nil
+}
+
+// generateKey returns a random key prefix and secret.
+func generateKey() (string, string, error) {
+	prefix := make([]byte, prefixBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(prefix), base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// parseKey splits "frs_<prefix>_<secret>". The hex prefix contains no "_",
+// the base64url secret may.
+func parseKey(key string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(key, keyPrefix)
+	if !ok {
+		return "", "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 2*prefixBytes || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeCIDRs validates the source ranges, turning single addresses into
+// one-address ranges.
+func normalizeCIDRs(values []string) ([]string, error) {
+	cidrs := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if addr, err := netip.ParseAddr(value); err == nil {
+			cidrs = append(cidrs, netip.PrefixFrom(addr, addr.BitLen()).String())
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q", ErrInvalidCIDR, value)
+		}
+		cidrs = append(cidrs, prefix.Masked().String())
+	}
+	return cidrs, nil
+}
+
+func apiKeyChange(action string, before, after *entity.APIKey) audit.Change {
+	change := audit.Change{EntityType: audit.EntityAPIKey, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+	}
+	return change
+}
+
+func keyToResponse(key *entity.APIKey, now time.Time) *dto.APIKeyResponse {
+	cidrs := key.CIDRList()
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+	return &dto.APIKeyResponse{
+		ID:           key.ID,
+		Name:         key.Name,
+		Prefix:       key.Prefix,
+		Scopes:       key.ScopeList(),
+		AllowedCIDRs: cidrs,
+		Status:       key.Status(now),
+		CreatedBy:    key.CreatedBy,
+		TenantID:     key.TenantID,
+		ExpiresAt:    formatTimestamp(key.ExpiresAt),
+		LastUsedAt:   formatTimestamp(key.LastUsedAt),
+		LastUsedIP:   key.LastUsedIP,
+		RevokedAt:    formatTimestamp(key.RevokedAt),
+		CreatedAt:    key.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apikeyDto "github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	apikeyEntity "github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+var operator = &identity.Principal{UserID: 2, Email: "ops@example.com", Role: "operator"}
+
+func setupAPIKeyService(t *testing.T) (*apiKeyService, *testutil.MockAPIKeyRepository, *testutil.MockAuditRecorder) {
+	policy, err := rbac.NewPolicy(map[string][]string{
+		"operator": {"nas:read", "radcheck:*", "radreply:*", "apikeys:*"},
+	})
+	require.NoError(t, err)
+
+	mockRepo := &testutil.MockAPIKeyRepository{}
+	mockAuditor := &testutil.MockAuditRecorder{}
+	service := NewAPIKeyService(
+		mockRepo,
+		&testutil.MockTransactionManager{},
+		mockAuditor,
+		policy,
+		testutil.NewSilentLogger(),
+	).(*apiKeyService)
+	service.now = func() time.Time { return testNow }
+	return service, mockRepo, mockAuditor
+}
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	t.Run("should create a key that authenticates", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockAuditor := setupAPIKeyService(t)
+		req := &apikeyDto.CreateAPIKeyRequest{
+			Name:         "Provisioning",
+			Scopes:       []string{"radreply:write", "radcheck:write", "radcheck:write"},
+			AllowedCIDRs: []string{"10.0.0.0/8", "192.0.2.10"},
+		}
+
+		var created *apikeyEntity.APIKey
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*apikeyEntity.APIKey)
+			created.ID = 1
+		})
+
+		// When
+		response, err := service.CreateKey(context.Background(), operator, req)
+
+		// Then
+		require.NoError(t, err)
+		assert.Regexp(t, `^frs_[0-9a-f]{12}_[A-Za-z0-9_-]{43}$`, response.Key)
+		assert.Equal(t, []string{"radcheck:write", "radreply:write"}, response.Scopes)
+		assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.10/32"}, response.AllowedCIDRs)
+		assert.Equal(t, apikeyEntity.StatusActive, response.Status)
+		assert.Equal(t, uint(2), response.CreatedBy)
+		assert.Len(t, created.SecretHash, 64)
+		require.Len(t, mockAuditor.Changes, 1)
+		assert.Equal(t, audit.EntityAPIKey, mockAuditor.Changes[0].EntityType)
+		assert.Equal(t, audit.ActionCreate, mockAuditor.Changes[0].Action)
+
+		// Mock expectations
+		mockRepo.On("GetByPrefix", mock.Anything, response.Prefix).Return(created, nil)
+		mockRepo.On("Touch", mock.Anything, uint(1), testNow, "10.1.2.3").Return(nil)
+
+		principal, err := service.AuthenticateKey(context.Background(), response.Key, "10.1.2.3")
+		assert.NoError(t, err)
+		assert.Equal(t, response.Prefix, principal.APIKey)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not grant scopes the caller does not hold", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+
+		for _, scope := range []string{"nas:write", "nas:*", "*"} {
+			req := &apikeyDto.CreateAPIKeyRequest{Name: "Too much", Scopes: []string{"nas:read", scope}}
+
+			// When
+			response, err := service.CreateKey(context.Background(), operator, req)
+
+			// Then
+			assert.ErrorIs(t, err, ErrScopeNotHeld, scope)
+			assert.Nil(t, response)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not let API keys create keys", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+		principal := &identity.Principal{APIKey: "a1b2c3d4e5f6", Scopes: []string{"*"}}
+		req := &apikeyDto.CreateAPIKeyRequest{Name: "Child", Scopes: []string{"nas:read"}}
+
+		// When
+		_, err := service.CreateKey(context.Background(), principal, req)
+
+		// Then
+		assert.ErrorIs(t, err, ErrScopeNotHeld)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject invalid requests", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+		past := testNow.Add(-time.Minute)
+
+		tests := []struct {
+			req  *apikeyDto.CreateAPIKeyRequest
+			want error
+		}{
+			{&apikeyDto.CreateAPIKeyRequest{Name: "x", Scopes: []string{"nas:reboot"}}, ErrInvalidScope},
+			{&apikeyDto.CreateAPIKeyRequest{Name: "x", Scopes: []string{"nas:read"}, AllowedCIDRs: []string{"10.0.0.0/33"}}, ErrInvalidCIDR},
+			{&apikeyDto.CreateAPIKeyRequest{Name: "x", Scopes: []string{"nas:read"}, ExpiresAt: &past}, ErrInvalidExpiry},
+		}
+		for _, tt := range tests {
+			// When
+			_, err := service.CreateKey(context.Background(), operator, tt.req)
+
+			// Then
+			assert.ErrorIs(t, err, tt.want)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyService_AuthenticateKey(t *testing.T) {
+	t.Run("should return the scopes of the key and record its use", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+
+		// Mock expectations
+		mockRepo.On("GetByPrefix", mock.Anything, "a1b2c3d4e5f6").Return(testutil.CreateAPIKeyFixture(), nil)
+		mockRepo.On("Touch", mock.Anything, uint(1), testNow, "10.1.2.3").Return(nil)
+
+		// When
+		principal, err := service.AuthenticateKey(context.Background(), testutil.APIKeyFixtureKey, "10.1.2.3")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, &identity.Principal{APIKey: "a1b2c3d4e5f6", Scopes: []string{"radcheck:write", "radreply:write"}}, principal)
+		assert.Equal(t, "api-key:a1b2c3d4e5f6", principal.Actor())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not record every use", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+		key := testutil.CreateAPIKeyFixture()
+		lastUsedAt := testNow.Add(-10 * time.Second)
+		key.LastUsedAt = &lastUsedAt
+		key.LastUsedIP = "10.1.2.3"
+
+		// Mock expectations
+		mockRepo.On("GetByPrefix", mock.Anything, "a1b2c3d4e5f6").Return(key, nil)
+
+		// When
+		_, err := service.AuthenticateKey(context.Background(), testutil.APIKeyFixtureKey, "10.1.2.3")
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject keys that may not be used", func(t *testing.T) {
+		expiresAt := testNow
+		tests := []struct {
+			name     string
+			key      string
+			clientIP string
+			modify   func(key *apikeyEntity.APIKey)
+		}{
+			{"wrong secret", "frs_a1b2c3d4e5f6_d3Jvbmc", "10.1.2.3", nil},
+			{"malformed", "a1b2c3d4e5f6", "10.1.2.3", nil},
+			{"outside the allowed ranges", testutil.APIKeyFixtureKey, "192.0.2.10", nil},
+			{"revoked", testutil.APIKeyFixtureKey, "10.1.2.3", func(key *apikeyEntity.APIKey) { key.RevokedAt = &expiresAt }},
+			{"expired", testutil.APIKeyFixtureKey, "10.1.2.3", func(key *apikeyEntity.APIKey) { key.ExpiresAt = &expiresAt }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Setup
+				service, mockRepo, _ := setupAPIKeyService(t)
+				key := testutil.CreateAPIKeyFixture()
+				if tt.modify != nil {
+					tt.modify(key)
+				}
+
+				// Mock expectations
+				mockRepo.On("GetByPrefix", mock.Anything, "a1b2c3d4e5f6").Return(key, nil).Maybe()
+
+				// When
+				principal, err := service.AuthenticateKey(context.Background(), tt.key, tt.clientIP)
+
+				// Then
+				assert.ErrorIs(t, err, identity.ErrUnauthenticated)
+				assert.Nil(t, principal)
+				mockRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("should reject unknown keys", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+
+		// Mock expectations
+		mockRepo.On("GetByPrefix", mock.Anything, "a1b2c3d4e5f6").Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		_, err := service.AuthenticateKey(context.Background(), testutil.APIKeyFixtureKey, "10.1.2.3")
+
+		// Then
+		assert.ErrorIs(t, err, identity.ErrUnauthenticated)
+	})
+}
+
+func TestAPIKeyService_RevokeKey(t *testing.T) {
+	t.Run("should revoke the key", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockAuditor := setupAPIKeyService(t)
+		key := testutil.CreateAPIKeyFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(key, nil)
+		mockRepo.On("Update", mock.Anything, key).Return(nil)
+
+		// When
+		err := service.RevokeKey(context.Background(), 1)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, key.RevokedAt)
+		assert.Equal(t, apikeyEntity.StatusRevoked, key.Status(testNow))
+		require.Len(t, mockAuditor.Changes, 1)
+		assert.Equal(t, audit.ActionUpdate, mockAuditor.Changes[0].Action)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return not found error", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupAPIKeyService(t)
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		err := service.RevokeKey(context.Background(), 999)
+
+		// Then
+		assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+	})
+}
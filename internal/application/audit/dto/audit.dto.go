@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+)
+
+type AuditEntryResponse struct {
+	ID         uint64          `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Actor      string          `json:"actor"`
+	Source     string          `json:"source"`
+	RequestID  string          `json:"request_id,omitempty"`
+	TenantID   *uint           `json:"tenant_id,omitempty"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Changes    json.RawMessage `json:"changes" swaggertype:"object"`
+}
+
+type ListAuditEntriesResponse struct {
+	Data           []AuditEntryResponse `json:"data"`
+	Total          *int64               `json:"total,omitempty"`
+	TotalEstimated bool                 `json:"total_estimated,omitempty"`
+	Page           int                  `json:"page"`
+	PageSize       int                  `json:"page_size"`
+	TotalPage      *int                 `json:"total_page,omitempty"`
+	NextCursor     string               `json:"next_cursor,omitempty"`
+}
+
+// AuditFilter selects entries by entity, actor and time range. From is
+// inclusive and To is exclusive; both are RFC 3339 timestamps.
+type AuditFilter struct {
+	EntityType string    `json:"entity_type" form:"entity_type" binding:"omitempty,oneof=user payment nas radcheck radreply api_key tenant"`
+	EntityID   string    `json:"entity_id" form:"entity_id"`
+	Actor      string    `json:"actor" form:"actor"`
+	Source     string    `json:"source" form:"source" binding:"omitempty,oneof=rest grpc worker cli unknown"`
+	Action     string    `json:"action" form:"action" binding:"omitempty,oneof=create update delete"`
+	From       time.Time `json:"from" form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `json:"to" form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int       `json:"page" form:"page" binding:"min=1"`
+	PageSize   int       `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+	logger       *zap.Logger
+}
+
+func NewAuditHandler(auditService service.AuditService, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+func (h *AuditHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/audit", h.ListEntries)
+}
+
+// ListEntries godoc
+// @Summary List audit entries
+// @Description Get the recorded administrative changes, newest first. Each entry holds the changed fields with their before and after values; passwords and secrets are redacted.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param entity_type query string false "Filter by entity type" Enums(user, payment, nas, radcheck, radreply, api_key, tenant)
+// @Param entity_id query string false "Filter by entity ID"
+// @Param actor query string false "Filter by actor"
+// @Param source query string false "Filter by source" Enums(rest, grpc, worker, cli, unknown)
+// @Param action query string false "Filter by action" Enums(create, update, delete)
+// @Param from query string false "Only entries at or after this RFC 3339 time"
+// @Param to query string false "Only entries before this RFC 3339 time"
+// @Success 200 {object} dto.ListAuditEntriesResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /audit [get]
+func (h *AuditHandler) ListEntries(c *gin.Context) {
+	var filter dto.AuditFilter
+	filter.Page = 1
+	filter.PageSize = 10
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.auditService.ListEntries(c.Request.Context(), &filter)
+	if err != nil {
+		h.logger.Error("Failed to list audit entries", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
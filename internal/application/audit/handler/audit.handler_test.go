@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	auditDto "github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuditHandler() (*gin.Engine, *testutil.MockAuditService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockAuditService{}
+	handler := NewAuditHandler(mockService, testutil.NewSilentLogger())
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+	return router, mockService
+}
+
+func TestAuditHandler_ListEntries(t *testing.T) {
+	t.Run("should bind the filter from the query", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuditHandler()
+		response := &auditDto.ListAuditEntriesResponse{
+			Data:  []auditDto.AuditEntryResponse{{ID: 1, Actor: "alice", Changes: json.RawMessage(`{}`)}},
+			Total: pagination.Exact(1).Total, Page: 1, PageSize: 10,
+		}
+
+		// Mock expectations
+		mockService.On("ListEntries", mock.Anything, mock.MatchedBy(func(f *auditDto.AuditFilter) bool {
+			return f.EntityType == "nas" && f.EntityID == "3" && f.Actor == "alice" &&
+				f.From.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) &&
+				f.To.Equal(time.Date(2024, 5, 2, 7, 0, 0, 0, time.FixedZone("", 7*60*60))) &&
+				f.Page == 1 && f.PageSize == 10
+		})).Return(response, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/api/v1/audit?entity_type=nas&entity_id=3&actor=alice&from=2024-05-01T00:00:00Z&to=2024-05-02T07:00:00%2B07:00", nil))
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		var result auditDto.ListAuditEntriesResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, int64(1), *result.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return bad request for an unknown entity type", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuditHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/audit?entity_type=session", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListEntries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return bad request for an invalid time range", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuditHandler()
+
+		// Mock expectations
+		mockService.On("ListEntries", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidTimeRange)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/api/v1/audit?from=2024-05-02T00:00:00Z&to=2024-05-01T00:00:00Z", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/service"
+
+	"go.uber.org/fx"
+)
+
+// Module provides all audit domain dependencies. The entries themselves are
+// written by the audit.Recorder provided by each command.
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewAuditRepository,
+		service.NewAuditService,
+		handler.NewAuditHandler,
+	),
+)
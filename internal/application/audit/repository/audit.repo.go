@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AuditRepository reads the entries written by audit.Recorder.
+type AuditRepository interface {
+	List(ctx context.Context, filter *dto.AuditFilter) ([]audit.Entry, pagination.Page, error)
+}
+
+type auditRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewAuditRepository(db *gorm.DB, logger *zap.Logger) AuditRepository {
+	return &auditRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// List returns the entries matching filter, newest first.
+func (r *auditRepository) List(ctx context.Context, filter *dto.AuditFilter) ([]audit.Entry, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+
+	query := db.Model(&audit.Entry{}).Scopes(tenant.Filter(ctx))
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	// Entries are stored in UTC
+	if !filter.From.IsZero() {
+		query = query.Where("occurred_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("occurred_at < ?", filter.To.UTC())
+	}
+
+	order := pagination.Order{{Column: "occurred_at", Desc: true}}
+	entries, page, err := pagination.Find[audit.Entry](query, filter.Params, filter.Page, filter.PageSize, order)
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list audit entries", zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+
+	return entries, page, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auditDto "github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository_List(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewAuditRepository(db, logger)
+
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for i, actor := range []string{"alice", "bob", "alice"} {
+		entry := testutil.CreateAuditEntryFixture()
+		entry.ID = 0
+		entry.Actor = actor
+		entry.OccurredAt = base.Add(time.Duration(i) * time.Hour)
+		entry.EntityID = audit.EntityID(uint(i%2 + 1))
+		require.NoError(t, db.Create(entry).Error)
+	}
+
+	t.Run("should list entries newest first", func(t *testing.T) {
+		// When
+		entries, page, err := repo.List(context.Background(), &auditDto.AuditFilter{Page: 1, PageSize: 10})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *page.Total)
+		require.Len(t, entries, 3)
+		assert.Equal(t, base.Add(2*time.Hour), entries[0].OccurredAt.UTC())
+		assert.Equal(t, base, entries[2].OccurredAt.UTC())
+	})
+
+	t.Run("should filter by entity and actor", func(t *testing.T) {
+		// When
+		entries, page, err := repo.List(context.Background(), &auditDto.AuditFilter{
+			EntityType: audit.EntityNAS,
+			EntityID:   "1",
+			Actor:      "alice",
+			Page:       1,
+			PageSize:   10,
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *page.Total)
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, "alice", entry.Actor)
+			assert.Equal(t, "1", entry.EntityID)
+		}
+	})
+
+	t.Run("should filter by time range with an exclusive end", func(t *testing.T) {
+		// Given
+		jakarta := time.FixedZone("WIB", 7*60*60)
+
+		// When
+		entries, page, err := repo.List(context.Background(), &auditDto.AuditFilter{
+			From:     base.Add(time.Hour).In(jakarta),
+			To:       base.Add(2 * time.Hour).In(jakarta),
+			Page:     1,
+			PageSize: 10,
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *page.Total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "bob", entries[0].Actor)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidTimeRange is returned when the end of the time range is not
+// after its start.
+var ErrInvalidTimeRange = apperror.InvalidField("to", "must be after from")
+
+type AuditService interface {
+	ListEntries(ctx context.Context, filter *dto.AuditFilter) (*dto.ListAuditEntriesResponse, error)
+}
+
+type auditService struct {
+	repo   repository.AuditRepository
+	logger *zap.Logger
+}
+
+func NewAuditService(repo repository.AuditRepository, logger *zap.Logger) AuditService {
+	return &auditService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *auditService) ListEntries(ctx context.Context, filter *dto.AuditFilter) (*dto.ListAuditEntriesResponse, error) {
+	logger.For(ctx, s.logger).Info("Listing audit entries", zap.Int("page", filter.Page), zap.Int("page_size", filter.PageSize))
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	// Set defaults
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 10
+	}
+
+	entries, page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list audit entries", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.AuditEntryResponse, 0, len(entries))
+	for i := range entries {
+		responses = append(responses, *entryToResponse(&entries[i]))
+	}
+
+	return &dto.ListAuditEntriesResponse{
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
+	}, nil
+}
+
+func entryToResponse(entry *audit.Entry) *dto.AuditEntryResponse {
+	return &dto.AuditEntryResponse{
+		ID:         entry.ID,
+		OccurredAt: entry.OccurredAt,
+		Actor:      entry.Actor,
+		Source:     entry.Source,
+		RequestID:  entry.RequestID,
+		TenantID:   entry.TenantID,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Action:     entry.Action,
+		Changes:    json.RawMessage(entry.Changes),
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	auditDto "github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditService_ListEntries(t *testing.T) {
+	t.Run("should list entries with their changes", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockAuditRepository{}
+		service := NewAuditService(mockRepo, testutil.NewSilentLogger())
+		entry := testutil.CreateAuditEntryFixture()
+		filter := &auditDto.AuditFilter{EntityType: audit.EntityNAS, PageSize: 500}
+
+		// Mock expectations
+		mockRepo.On("List", mock.Anything, filter).Return([]audit.Entry{*entry}, pagination.Exact(11), nil)
+
+		// When
+		result, err := service.ListEntries(context.Background(), filter)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Page)
+		assert.Equal(t, 10, result.PageSize)
+		assert.Equal(t, 2, *result.TotalPage)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, entry.Actor, result.Data[0].Actor)
+		assert.JSONEq(t, entry.Changes, string(result.Data[0].Changes))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a time range that ends before it starts", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockAuditRepository{}
+		service := NewAuditService(mockRepo, testutil.NewSilentLogger())
+		now := time.Now()
+
+		// When
+		result, err := service.ListEntries(context.Background(), &auditDto.AuditFilter{From: now, To: now.Add(-time.Hour)})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidTimeRange)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockAuditRepository{}
+		service := NewAuditService(mockRepo, testutil.NewSilentLogger())
+
+		// Mock expectations
+		mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, pagination.Page{}, errors.New("database error"))
+
+		// When
+		result, err := service.ListEntries(context.Background(), &auditDto.AuditFilter{})
+
+		// Then
+		assert.EqualError(t, err, "database error")
+		assert.Nil(t, result)
+	})
+}
@@ -6,10 +6,9 @@ import (
 	"github.com/novriyantoAli/freeradius-service/api/proto/auth"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type AuthGrpcHandler struct {
@@ -32,11 +31,11 @@ func (h *AuthGrpcHandler) CreateAuth(
 	// Validate username and password
 	if req.Username == "" {
 		h.logger.Warn("CreateAuth gRPC request missing username")
-		return nil, status.Errorf(codes.InvalidArgument, "username is required")
+		return nil, apperror.GRPCStatus(apperror.InvalidField("username", "is required"))
 	}
 	if req.Password == "" {
 		h.logger.Warn("CreateAuth gRPC request missing password")
-		return nil, status.Errorf(codes.InvalidArgument, "password is required")
+		return nil, apperror.GRPCStatus(apperror.InvalidField("password", "is required"))
 	}
 
 	// Convert proto attributes to DTO
@@ -71,7 +70,7 @@ func (h *AuthGrpcHandler) CreateAuth(
 	authResponse, err := h.authService.CreateAuth(ctx, createReq)
 	if err != nil {
 		h.logger.Error("Failed to create auth via gRPC", zap.String("username", req.Username), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to create auth: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	// Convert response to proto
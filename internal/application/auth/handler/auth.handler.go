@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 )
 
 type AuthHandler struct {
@@ -31,20 +32,22 @@ func (h *AuthHandler) RegisterRoutes(r *gin.RouterGroup) {
 // @Produce json
 // @Param request body dto.CreateAuthRequest true "Create Auth Request"
 // @Success 201 {object} dto.CreateAuthResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/auth [post]
 func (h *AuthHandler) CreateAuth(ctx *gin.Context) {
 	var req dto.CreateAuthRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	result, err := h.service.CreateAuth(ctx.Request.Context(), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -36,7 +36,7 @@ func TestAuthHandler_CreateAuth_Success(t *testing.T) {
 		return fn(ctx)
 	}
 
-	authService := service.NewAuthService(mockRadcheckRepo, mockRadreplyRepo, mockTxManager)
+	authService := service.NewAuthService(mockRadcheckRepo, mockRadreplyRepo, mockTxManager, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{})
 	authHandler := handler.NewAuthHandler(authService)
 
 	gin.SetMode(gin.TestMode)
@@ -75,7 +75,7 @@ func TestAuthHandler_CreateAuth_Success(t *testing.T) {
 
 func TestAuthHandler_CreateAuth_MissingUsername(t *testing.T) {
 	mockTxManager := &testutil.MockTransactionManager{}
-	authService := service.NewAuthService(nil, nil, mockTxManager)
+	authService := service.NewAuthService(nil, nil, mockTxManager, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{})
 	authHandler := handler.NewAuthHandler(authService)
 
 	gin.SetMode(gin.TestMode)
@@ -5,7 +5,9 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/service"
 	radcheckrepo "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/repository"
 	radreplyrepo "github.com/novriyantoAli/freeradius-service/internal/application/radreply/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -23,8 +25,10 @@ func provideAuthService(
 	radcheckRepo radcheckrepo.RadcheckRepository,
 	radreplyRepo radreplyrepo.RadreplyRepository,
 	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
 ) service.AuthService {
-	return service.NewAuthService(radcheckRepo, radreplyRepo, txManager)
+	return service.NewAuthService(radcheckRepo, radreplyRepo, txManager, outbox, auditor)
 }
 
 func provideAuthHandler(authService service.AuthService) *handler.AuthHandler {
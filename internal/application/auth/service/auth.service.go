@@ -2,14 +2,17 @@ package service
 
 import (
 	"context"
-	"errors"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/dto"
 	radcheckentity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	radcheckrepo "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/repository"
 	radreplyentity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
 	radreplyrepo "github.com/novriyantoAli/freeradius-service/internal/application/radreply/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 )
 
 // AuthService defines authentication business logic
@@ -21,6 +24,8 @@ type authService struct {
 	radcheckRepo radcheckrepo.RadcheckRepository
 	radreplyRepo radreplyrepo.RadreplyRepository
 	txManager    database.TransactionManagerI
+	outbox       outbox.Outbox
+	auditor      audit.Recorder
 }
 
 // NewAuthService creates a new authentication service
@@ -28,29 +33,34 @@ func NewAuthService(
 	radcheckRepo radcheckrepo.RadcheckRepository,
 	radreplyRepo radreplyrepo.RadreplyRepository,
 	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
 ) AuthService {
 	return &authService{
 		radcheckRepo: radcheckRepo,
 		radreplyRepo: radreplyRepo,
 		txManager:    txManager,
+		outbox:       outbox,
+		auditor:      auditor,
 	}
 }
 
 // CreateAuth creates authentication credentials with radcheck and radreply entries in a transaction
 func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest) (*dto.CreateAuthResponse, error) {
 	if req.Username == "" {
-		return nil, errors.New("username is required")
+		return nil, apperror.InvalidField("username", "is required")
 	}
 	if req.Password == "" {
-		return nil, errors.New("password is required")
+		return nil, apperror.InvalidField("password", "is required")
 	}
 
 	var response dto.CreateAuthResponse
-	response.Username = req.Username
 	response.Password = req.Password
 
 	// Execute in transaction
 	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var changes []audit.Change
+
 		// Create User-Password radcheck entry
 		passwordRadcheck := &radcheckentity.Radcheck{
 			Username:  req.Username,
@@ -62,6 +72,8 @@ func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest
 		if err := s.radcheckRepo.Create(txCtx, passwordRadcheck); err != nil {
 			return err
 		}
+		changes = append(changes, radcheckCreated(passwordRadcheck))
+		response.Username = passwordRadcheck.Username
 
 		response.Attributes = append(response.Attributes, dto.AuthCreateAttrResponse{
 			ID:        passwordRadcheck.ID,
@@ -91,6 +103,7 @@ func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest
 			if err := s.radcheckRepo.Create(txCtx, radcheck); err != nil {
 				return err
 			}
+			changes = append(changes, radcheckCreated(radcheck))
 
 			response.Attributes = append(response.Attributes, dto.AuthCreateAttrResponse{
 				ID:        radcheck.ID,
@@ -117,6 +130,12 @@ func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest
 			if err := s.radreplyRepo.Create(txCtx, radreply); err != nil {
 				return err
 			}
+			changes = append(changes, audit.Change{
+				EntityType: audit.EntityRadreply,
+				EntityID:   audit.EntityID(radreply.ID),
+				Action:     audit.ActionCreate,
+				After:      radreply,
+			})
 
 			response.ReplyAttrs = append(response.ReplyAttrs, dto.AuthCreateAttrResponse{
 				ID:        radreply.ID,
@@ -126,7 +145,10 @@ func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest
 			})
 		}
 
-		return nil
+		if err := s.auditor.Record(txCtx, changes...); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, subscriberCreated(&response))
 	})
 
 	if err != nil {
@@ -135,3 +157,33 @@ func (s *authService) CreateAuth(ctx context.Context, req *dto.CreateAuthRequest
 
 	return &response, nil
 }
+
+// subscriberCreated lists the attribute names of a new subscriber; values
+// stay out of the event since they include the password.
+func subscriberCreated(response *dto.CreateAuthResponse) events.SubscriberCreated {
+	event := events.SubscriberCreated{
+		Username:        response.Username,
+		CheckAttributes: make([]string, 0, len(response.Attributes)),
+		ReplyAttributes: make([]string, 0, len(response.ReplyAttrs)),
+	}
+	for _, attr := range response.Attributes {
+		event.CheckAttributes = append(event.CheckAttributes, attr.Attribute)
+	}
+	for _, attr := range response.ReplyAttrs {
+		event.ReplyAttributes = append(event.ReplyAttributes, attr.Attribute)
+	}
+	return event
+}
+
+func radcheckCreated(radcheck *radcheckentity.Radcheck) audit.Change {
+	change := audit.Change{
+		EntityType: audit.EntityRadcheck,
+		EntityID:   audit.EntityID(radcheck.ID),
+		Action:     audit.ActionCreate,
+		After:      radcheck,
+	}
+	if radcheck.HoldsPassword() {
+		change.Redact = []string{"value"}
+	}
+	return change
+}
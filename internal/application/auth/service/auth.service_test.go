@@ -8,6 +8,7 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth/service"
 	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	radreplyEntity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -30,7 +31,8 @@ func TestAuthService_CreateAuth_Success(t *testing.T) {
 		return fn(ctx)
 	}
 
-	authService := service.NewAuthService(mockRadcheckRepo, mockRadreplyRepo, mockTxManager)
+	mockOutbox := &testutil.MockOutbox{}
+	authService := service.NewAuthService(mockRadcheckRepo, mockRadreplyRepo, mockTxManager, mockOutbox, &testutil.MockAuditRecorder{})
 
 	req := &dto.CreateAuthRequest{
 		Username: "newuser",
@@ -51,11 +53,16 @@ func TestAuthService_CreateAuth_Success(t *testing.T) {
 	require.Equal(t, "password123", result.Password)
 	require.Greater(t, len(result.Attributes), 0)
 	require.Greater(t, len(result.ReplyAttrs), 0)
+	require.Equal(t, []events.Event{events.SubscriberCreated{
+		Username:        "newuser",
+		CheckAttributes: []string{"User-Password", "Framed-IP-Address"},
+		ReplyAttributes: []string{"Reply-Message"},
+	}}, mockOutbox.Events)
 }
 
 func TestAuthService_CreateAuth_MissingUsername(t *testing.T) {
 	mockTxManager := &testutil.MockTransactionManager{}
-	authService := service.NewAuthService(nil, nil, mockTxManager)
+	authService := service.NewAuthService(nil, nil, mockTxManager, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{})
 
 	req := &dto.CreateAuthRequest{
 		Username: "",
@@ -71,7 +78,7 @@ func TestAuthService_CreateAuth_MissingUsername(t *testing.T) {
 
 func TestAuthService_CreateAuth_MissingPassword(t *testing.T) {
 	mockTxManager := &testutil.MockTransactionManager{}
-	authService := service.NewAuthService(nil, nil, mockTxManager)
+	authService := service.NewAuthService(nil, nil, mockTxManager, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{})
 
 	req := &dto.CreateAuthRequest{
 		Username: "newuser",
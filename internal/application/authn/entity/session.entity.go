@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Session is a login of a user to the admin API. The refresh token handed
+// out at login is rotated on every refresh; only the SHA-256 hashes of the
+// current one and of the one it replaced are stored, the latter to tell the
+// reuse of a rotated token from a wrong one.
+type Session struct {
+	ID                       string     `json:"id" gorm:"primaryKey;size:36"`
+	UserID                   uint       `json:"user_id" gorm:"not null;index"`
+	RefreshTokenHash         string     `json:"-" gorm:"not null;size:64"`
+	PreviousRefreshTokenHash string     `json:"-" gorm:"not null;default:'';size:64"`
+	UserAgent                string     `json:"user_agent" gorm:"size:255"`
+	ClientIP                 string     `json:"client_ip" gorm:"size:64"`
+	CreatedAt                time.Time  `json:"created_at"`
+	LastUsedAt               time.Time  `json:"last_used_at"`
+	ExpiresAt                time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt                *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (s Session) TableName() string {
+	return "admin_sessions"
+}
+
+// Active reports whether the session can still be used at now.
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
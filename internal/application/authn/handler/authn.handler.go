@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"go.uber.org/zap"
+)
+
+type AuthnHandler struct {
+	authnService service.AuthnService
+	logger       *zap.Logger
+}
+
+func NewAuthnHandler(authnService service.AuthnService, logger *zap.Logger) *AuthnHandler {
+	return &AuthnHandler{
+		authnService: authnService,
+		logger:       logger,
+	}
+}
+
+// RegisterPublicRoutes registers the routes that issue tokens and therefore
+// cannot require one.
+func (h *AuthnHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.POST("/login", h.Login)
+		authRoutes.POST("/refresh", h.Refresh)
+	}
+}
+
+// RegisterRoutes registers the routes that act on the caller's sessions.
+func (h *AuthnHandler) RegisterRoutes(r *gin.RouterGroup) {
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.POST("/logout", h.Logout)
+		authRoutes.GET("/sessions", h.ListSessions)
+		authRoutes.DELETE("/sessions/:id", h.RevokeSession)
+	}
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchange an email and password for a short-lived access token and a refresh token
+// @Tags authn
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "Login Request"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /auth/login [post]
+func (h *AuthnHandler) Login(c *gin.Context) {
+	var req dto.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.authnService.Login(c.Request.Context(), &req, clientInfo(c))
+	if err != nil {
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a new refresh token. The old refresh token stops working; presenting it again revokes the session.
+// @Tags authn
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Refresh Request"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /auth/refresh [post]
+func (h *AuthnHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.authnService.Refresh(c.Request.Context(), &req, clientInfo(c))
+	if err != nil {
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the session of the access token
+// @Tags authn
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /auth/logout [post]
+func (h *AuthnHandler) Logout(c *gin.Context) {
+	principal, ok := identity.PrincipalFrom(c.Request.Context())
+	if !ok {
+		apperror.Respond(c, identity.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.authnService.Logout(c.Request.Context(), principal); err != nil {
+		h.logger.Error("Failed to log out", zap.Uint("user_id", principal.UserID), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description List the active login sessions of the caller
+// @Tags authn
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.ListSessionsResponse
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /auth/sessions [get]
+func (h *AuthnHandler) ListSessions(c *gin.Context) {
+	principal, ok := identity.PrincipalFrom(c.Request.Context())
+	if !ok {
+		apperror.Respond(c, identity.ErrUnauthenticated)
+		return
+	}
+
+	resp, err := h.authnService.ListSessions(c.Request.Context(), principal)
+	if err != nil {
+		h.logger.Error("Failed to list sessions", zap.Uint("user_id", principal.UserID), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Revoke one of the caller's login sessions, e.g. on a lost device
+// @Tags authn
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthnHandler) RevokeSession(c *gin.Context) {
+	principal, ok := identity.PrincipalFrom(c.Request.Context())
+	if !ok {
+		apperror.Respond(c, identity.ErrUnauthenticated)
+		return
+	}
+
+	sessionID := c.Param("id")
+	if err := h.authnService.RevokeSession(c.Request.Context(), principal, sessionID); err != nil {
+		h.logger.Error("Failed to revoke session", zap.String("session_id", sessionID), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func clientInfo(c *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		ClientIP:  c.ClientIP(),
+	}
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testPrincipal = &identity.Principal{UserID: 1, Email: "john@example.com", SessionID: "3f6c1a52-9d7e-4b8a-a1f0-2c5d8e7b9a14"}
+
+func setupAuthnHandler() (*gin.Engine, *testutil.MockAuthnService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockAuthnService{}
+	handler := NewAuthnHandler(mockService, testutil.NewSilentLogger())
+
+	router := gin.New()
+	api := router.Group("/api/v1")
+	handler.RegisterPublicRoutes(api)
+	handler.RegisterRoutes(api.Group("", func(c *gin.Context) {
+		// Stands in for middleware.Authenticate
+		c.Request = c.Request.WithContext(identity.WithPrincipal(c.Request.Context(), testPrincipal))
+	}))
+	return router, mockService
+}
+
+func TestAuthnHandler_Login(t *testing.T) {
+	t.Run("should return the issued tokens", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+		req := dto.LoginRequest{Email: "john@example.com", Password: "secret"}
+		body, _ := json.Marshal(req)
+
+		// Mock expectations
+		mockService.On("Login", mock.Anything, &req, dto.ClientInfo{UserAgent: "curl/8.5.0", ClientIP: "192.0.2.10"}).
+			Return(&dto.TokenResponse{AccessToken: "access", TokenType: service.TokenType, ExpiresIn: 900, RefreshToken: "refresh"}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("User-Agent", "curl/8.5.0")
+		httpReq.RemoteAddr = "192.0.2.10:51234"
+		router.ServeHTTP(w, httpReq)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		var result dto.TokenResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "access", result.AccessToken)
+		assert.Equal(t, "refresh", result.RefreshToken)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return unauthorized for invalid credentials", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+		body, _ := json.Marshal(dto.LoginRequest{Email: "john@example.com", Password: "wrong"})
+
+		// Mock expectations
+		mockService.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrInvalidCredentials)
+
+		// When
+		w := httptest.NewRecorder()
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		// Then
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return bad request for a missing password", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBufferString(`{"email":"john@example.com"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthnHandler_Refresh(t *testing.T) {
+	t.Run("should return unauthorized for a reused refresh token", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+
+		// Mock expectations
+		mockService.On("Refresh", mock.Anything, &dto.RefreshRequest{RefreshToken: "id.secret"}, mock.Anything).
+			Return(nil, service.ErrInvalidRefreshToken)
+
+		// When
+		w := httptest.NewRecorder()
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBufferString(`{"refresh_token":"id.secret"}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+
+		// Then
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestAuthnHandler_Logout(t *testing.T) {
+	t.Run("should revoke the current session", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+
+		// Mock expectations
+		mockService.On("Logout", mock.Anything, testPrincipal).Return(nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestAuthnHandler_ListSessions(t *testing.T) {
+	t.Run("should list the sessions of the caller", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+
+		// Mock expectations
+		mockService.On("ListSessions", mock.Anything, testPrincipal).Return(&dto.ListSessionsResponse{
+			Data: []dto.SessionResponse{{ID: testPrincipal.SessionID, Current: true}},
+		}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil))
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		var result dto.ListSessionsResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		if assert.Len(t, result.Data, 1) {
+			assert.True(t, result.Data[0].Current)
+		}
+	})
+}
+
+func TestAuthnHandler_RevokeSession(t *testing.T) {
+	t.Run("should return not found for a session of another user", func(t *testing.T) {
+		// Setup
+		router, mockService := setupAuthnHandler()
+
+		// Mock expectations
+		mockService.On("RevokeSession", mock.Anything, testPrincipal, "other").Return(service.ErrSessionNotFound)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/other", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
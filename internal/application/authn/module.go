@@ -0,0 +1,71 @@
+package authn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/service"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/jwt"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides login to the admin API, the Authenticator enforcing it and
+// the rbac.Policy deciding what each role may do. It relies on the user
+// repository provided by user.Module.
+var Module = fx.Options(
+	fx.Provide(
+		provideSigner,
+		providePolicy,
+		repository.NewSessionRepository,
+		service.NewAuthnService,
+		handler.NewAuthnHandler,
+		func(s service.AuthnService) identity.Authenticator {
+			return s
+		},
+		func(s service.AuthnService) identity.CredentialVerifier {
+			return s
+		},
+	),
+)
+
+func provideSigner(cfg *config.Config) (*jwt.Signer, error) {
+	signer, err := jwt.NewSigner([]byte(cfg.Auth.JWTSecret), cfg.Auth.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("auth.jwt_secret: %w", err)
+	}
+	return signer, nil
+}
+
+// providePolicy builds the policy from rbac.roles and replaces it whenever
+// the config file changes. An invalid edit is logged and the previous policy
+// stays in force.
+func providePolicy(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) (*rbac.Policy, error) {
+	policy, err := rbac.NewPolicy(cfg.RBAC.Roles)
+	if err != nil {
+		return nil, fmt.Errorf("rbac.roles: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			config.Watch(func(updated *config.Config, err error) {
+				if err == nil {
+					err = policy.Replace(updated.RBAC.Roles)
+				}
+				if err != nil {
+					logger.Error("Failed to reload rbac.roles, keeping the previous policy", zap.Error(err))
+					return
+				}
+				logger.Info("Reloaded rbac.roles")
+			})
+			return nil
+		},
+	})
+	return policy, nil
+}
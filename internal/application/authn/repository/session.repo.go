@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByID(ctx context.Context, id string) (*entity.Session, error)
+	ListActiveByUser(ctx context.Context, userID uint, now time.Time) ([]entity.Session, error)
+	Update(ctx context.Context, session *entity.Session) error
+	// Rotate replaces the refresh token hash of a session only while it
+	// still holds currentHash, reporting whether it did, and keeps
+	// currentHash as the previous one. Two refreshes with the same token
+	// therefore cannot both succeed.
+	Rotate(ctx context.Context, session *entity.Session, currentHash string) (bool, error)
+}
+
+type sessionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewSessionRepository(db *gorm.DB, logger *zap.Logger) SessionRepository {
+	return &sessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating login session", zap.Uint("user_id", session.UserID))
+	return db.Create(session).Error
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id string) (*entity.Session, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var session entity.Session
+	if err := db.First(&session, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uint, now time.Time) ([]entity.Session, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var sessions []entity.Session
+	err := db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list login sessions", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *entity.Session) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	return db.Save(session).Error
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, session *entity.Session, currentHash string) (bool, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	result := db.Model(&entity.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, currentHash).
+		Updates(map[string]any{
+			"refresh_token_hash":          session.RefreshTokenHash,
+			"previous_refresh_token_hash": currentHash,
+			"user_agent":                  session.UserAgent,
+			"client_ip":                   session.ClientIP,
+			"last_used_at":                session.LastUsedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
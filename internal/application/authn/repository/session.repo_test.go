@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRepository_ListActiveByUser(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+	now := time.Now()
+
+	active := testutil.CreateAdminSessionFixture()
+	require.NoError(t, repo.Create(context.Background(), active))
+
+	revoked := testutil.CreateAdminSessionFixture()
+	revoked.ID = "8b1e4f0a-2c3d-4e5f-9a6b-7c8d9e0f1a2b"
+	revoked.RevokedAt = &now
+	require.NoError(t, repo.Create(context.Background(), revoked))
+
+	expired := testutil.CreateAdminSessionFixture()
+	expired.ID = "c4d5e6f7-0a1b-4c2d-8e3f-4a5b6c7d8e9f"
+	expired.ExpiresAt = now.Add(-time.Minute)
+	require.NoError(t, repo.Create(context.Background(), expired))
+
+	other := testutil.CreateAdminSessionFixture()
+	other.ID = "0f9e8d7c-6b5a-4f3e-a2d1-c0b9a8f7e6d5"
+	other.UserID = 2
+	require.NoError(t, repo.Create(context.Background(), other))
+
+	// When
+	sessions, err := repo.ListActiveByUser(context.Background(), 1, now)
+
+	// Then
+	assert.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, active.ID, sessions[0].ID)
+}
+
+func TestSessionRepository_Rotate(t *testing.T) {
+	t.Run("should replace the hash it was given", func(t *testing.T) {
+		// Setup
+		db, err := testutil.SetupTestDB()
+		require.NoError(t, err)
+		repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = "old"
+		require.NoError(t, repo.Create(context.Background(), session))
+
+		// When
+		session.RefreshTokenHash = "new"
+		rotated, err := repo.Rotate(context.Background(), session, "old")
+
+		// Then
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+		stored, err := repo.GetByID(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new", stored.RefreshTokenHash)
+		assert.Equal(t, "old", stored.PreviousRefreshTokenHash)
+	})
+
+	t.Run("should not replace a hash that already changed", func(t *testing.T) {
+		// Setup
+		db, err := testutil.SetupTestDB()
+		require.NoError(t, err)
+		repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = "winner"
+		require.NoError(t, repo.Create(context.Background(), session))
+
+		// When
+		session.RefreshTokenHash = "loser"
+		rotated, err := repo.Rotate(context.Background(), session, "old")
+
+		// Then
+		assert.NoError(t, err)
+		assert.False(t, rotated)
+		stored, err := repo.GetByID(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "winner", stored.RefreshTokenHash)
+	})
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/repository"
+	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	userRepo "github.com/novriyantoAli/freeradius-service/internal/application/user/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/jwt"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// TokenType is the type of the issued access tokens.
+const TokenType = "Bearer"
+
+var (
+	// ErrInvalidCredentials is returned for an unknown email or a wrong
+	// password, without telling which.
+	ErrInvalidCredentials = apperror.Unauthenticated("invalid email or password")
+	// ErrInvalidRefreshToken is returned for unknown, expired, revoked or
+	// already rotated refresh tokens.
+	ErrInvalidRefreshToken = apperror.Unauthenticated("invalid refresh token")
+	// ErrSessionNotFound is returned when the session does not exist, is no
+	// longer active or belongs to another user.
+	ErrSessionNotFound = apperror.NotFound("session not found")
+)
+
+type AuthnService interface {
+	identity.Authenticator
+	identity.CredentialVerifier
+	Login(ctx context.Context, req *dto.LoginRequest, client dto.ClientInfo) (*dto.TokenResponse, error)
+	Refresh(ctx context.Context, req *dto.RefreshRequest, client dto.ClientInfo) (*dto.TokenResponse, error)
+	Logout(ctx context.Context, principal *identity.Principal) error
+	ListSessions(ctx context.Context, principal *identity.Principal) (*dto.ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, principal *identity.Principal, sessionID string) error
+}
+
+// authnService issues an access token and a refresh token per login
+// session. A refresh token is "<session ID>.<secret>"; refreshing rotates the
+// secret, and presenting a rotated secret again revokes the session since
+// the token must have leaked. A session ends RefreshTokenTTL after login.
+type authnService struct {
+	sessions repository.SessionRepository
+	users    userRepo.UserRepository
+	signer   *jwt.Signer
+	cfg      *config.Config
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+func NewAuthnService(
+	sessions repository.SessionRepository,
+	users userRepo.UserRepository,
+	signer *jwt.Signer,
+	cfg *config.Config,
+	logger *zap.Logger,
+) AuthnService {
+	return &authnService{
+		sessions: sessions,
+		users:    users,
+		signer:   signer,
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func (s *authnService) Login(ctx context.Context, req *dto.LoginRequest, client dto.ClientInfo) (*dto.TokenResponse, error) {
+	user, err := s.verify(ctx, req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			logger.For(ctx, s.logger).Warn("Failed login", zap.String("email", req.Email), zap.String("client_ip", client.ClientIP))
+		}
+		return nil, err
+	}
+
+	secret, hash, err := newRefreshSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	session := &entity.Session{
+		ID:               uuid.NewString(),
+		UserID:           user.ID,
+		RefreshTokenHash: hash,
+		UserAgent:        truncate(client.UserAgent, 255),
+		ClientIP:         client.ClientIP,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(s.cfg.Auth.RefreshTokenTTL),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create login session", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("User logged in", zap.Uint("user_id", user.ID), zap.String("session_id", session.ID))
+	return s.issue(user, session, secret)
+}
+
+func (s *authnService) Refresh(ctx context.Context, req *dto.RefreshRequest, client dto.ClientInfo) (*dto.TokenResponse, error) {
+	sessionID, secret, ok := strings.Cut(req.RefreshToken, ".")
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	if !session.Active(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	currentHash, hash := session.RefreshTokenHash, hashSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(currentHash)) != 1 {
+		// Only the token this one replaced proves a stolen token; the
+		// session ID alone, which access tokens carry, does not
+		if session.PreviousRefreshTokenHash == "" || subtle.ConstantTimeCompare([]byte(hash), []byte(session.PreviousRefreshTokenHash)) != 1 {
+			logger.For(ctx, s.logger).Warn("Invalid refresh token",
+				zap.String("session_id", session.ID),
+				zap.String("client_ip", client.ClientIP))
+			return nil, ErrInvalidRefreshToken
+		}
+		logger.For(ctx, s.logger).Warn("Rotated refresh token reused, revoking session",
+			zap.String("session_id", session.ID),
+			zap.String("client_ip", client.ClientIP))
+		return nil, s.revokeReused(ctx, session, now)
+	}
+
+	user, err := s.users.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	newSecret, newHash, err := newRefreshSecret()
+	if err != nil {
+		return nil, err
+	}
+	session.RefreshTokenHash = newHash
+	session.PreviousRefreshTokenHash = currentHash
+	session.LastUsedAt = now
+	session.UserAgent = truncate(client.UserAgent, 255)
+	session.ClientIP = client.ClientIP
+
+	rotated, err := s.sessions.Rotate(ctx, session, currentHash)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to rotate refresh token", zap.String("session_id", session.ID), zap.Error(err))
+		return nil, err
+	}
+	if !rotated {
+		// A concurrent refresh with the same token won
+		logger.For(ctx, s.logger).Warn("Refresh token used concurrently, revoking session", zap.String("session_id", session.ID))
+		return nil, s.revokeReused(ctx, session, now)
+	}
+
+	return s.issue(user, session, newSecret)
+}
+
+func (s *authnService) Logout(ctx context.Context, principal *identity.Principal) error {
+	return s.RevokeSession(ctx, principal, principal.SessionID)
+}
+
+func (s *authnService) ListSessions(ctx context.Context, principal *identity.Principal) (*dto.ListSessionsResponse, error) {
+	sessions, err := s.sessions.ListActiveByUser(ctx, principal.UserID, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			ClientIP:   session.ClientIP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == principal.SessionID,
+		})
+	}
+	return &dto.ListSessionsResponse{Data: responses}, nil
+}
+
+func (s *authnService) RevokeSession(ctx context.Context, principal *identity.Principal, sessionID string) error {
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	now := s.now()
+	if session.UserID != principal.UserID || !session.Active(now) {
+		return ErrSessionNotFound
+	}
+
+	session.RevokedAt = &now
+	if err := s.sessions.Update(ctx, session); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to revoke login session", zap.String("session_id", session.ID), zap.Error(err))
+		return err
+	}
+	logger.For(ctx, s.logger).Info("Login session revoked", zap.String("session_id", session.ID), zap.Uint("user_id", session.UserID))
+	return nil
+}
+
+// Authenticate resolves an access token to its user. The session is looked
+// up on every call so that revocation takes effect immediately rather than
+// when the access token expires.
+func (s *authnService) Authenticate(ctx context.Context, accessToken string) (*identity.Principal, error) {
+	claims, err := s.signer.Parse(accessToken)
+	if err != nil {
+		return nil, identity.ErrUnauthenticated
+	}
+	session, err := s.sessions.GetByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, identity.ErrUnauthenticated
+		}
+		return nil, err
+	}
+	if !session.Active(s.now()) || claims.Subject != strconv.FormatUint(uint64(session.UserID), 10) {
+		return nil, identity.ErrUnauthenticated
+	}
+	user, err := s.users.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, identity.ErrUnauthenticated
+		}
+		return nil, err
+	}
+
+	return &identity.Principal{UserID: user.ID, Email: user.Email, Role: user.Role, TenantID: user.TenantID, SessionID: session.ID}, nil
+}
+
+func (s *authnService) VerifyCredentials(ctx context.Context, email, password string) (*identity.Principal, error) {
+	user, err := s.verify(ctx, email, password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return nil, identity.ErrUnauthenticated
+		}
+		return nil, err
+	}
+	return &identity.Principal{UserID: user.ID, Email: user.Email, Role: user.Role, TenantID: user.TenantID}, nil
+}
+
+// verify checks the password of the user with email. Unknown emails are
+// compared against a dummy hash so that they take as long as wrong passwords.
+func (s *authnService) verify(ctx context.Context, email, password string) (*userEntity.User, error) {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+func (s *authnService) issue(user *userEntity.User, session *entity.Session, secret string) (*dto.TokenResponse, error) {
+	ttl := s.cfg.Auth.AccessTokenTTL
+	accessToken, err := s.signer.Sign(jwt.Claims{
+		Subject:   strconv.FormatUint(uint64(user.ID), 10),
+		SessionID: session.ID,
+		ExpiresAt: s.now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        TokenType,
+		ExpiresIn:        int(ttl.Seconds()),
+		RefreshToken:     session.ID + "." + secret,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (s *authnService) revokeReused(ctx context.Context, session *entity.Session, now time.Time) error {
+	session.RevokedAt = &now
+	if err := s.sessions.Update(ctx, session); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to revoke login session", zap.String("session_id", session.ID), zap.Error(err))
+		return err
+	}
+	return ErrInvalidRefreshToken
+}
+
+// newRefreshSecret returns a random refresh token secret and its hash.
+func newRefreshSecret() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	return secret, hashSecret(secret), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	dummyHashOnce  sync.Once
+	dummyHashValue []byte
+)
+
+func dummyHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHashValue, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	})
+	return dummyHashValue
+}
+
+func truncate(value string, size int) string {
+	if len(value) > size {
+		return value[:size]
+	}
+	return value
+}
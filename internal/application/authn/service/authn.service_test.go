@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
+	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/jwt"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const testPassword = "correct horse battery staple"
+
+func setupAuthnService(t *testing.T) (*authnService, *testutil.MockAdminSessionRepository, *testutil.MockUserRepository) {
+	signer, err := jwt.NewSigner([]byte(strings.Repeat("k", jwt.MinKeySize)), "freeradius-service")
+	require.NoError(t, err)
+	cfg := &config.Config{Auth: config.AuthConfig{
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 720 * time.Hour,
+	}}
+	sessions := &testutil.MockAdminSessionRepository{}
+	users := &testutil.MockUserRepository{}
+	service := NewAuthnService(sessions, users, signer, cfg, testutil.NewSilentLogger()).(*authnService)
+	return service, sessions, users
+}
+
+func userWithPassword(t *testing.T) *userEntity.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := testutil.CreateUserFixture()
+	user.Password = string(hash)
+	return user
+}
+
+func TestAuthnService_Login(t *testing.T) {
+	t.Run("should issue tokens for valid credentials", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		user := userWithPassword(t)
+		client := dto.ClientInfo{UserAgent: "curl/8.5.0", ClientIP: "192.0.2.10"}
+
+		// Mock expectations
+		users.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		sessions.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+			return s.UserID == user.ID && s.ClientIP == client.ClientIP && s.RefreshTokenHash != ""
+		})).Return(nil)
+
+		// When
+		resp, err := service.Login(context.Background(), &dto.LoginRequest{Email: user.Email, Password: testPassword}, client)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, TokenType, resp.TokenType)
+		assert.Equal(t, 900, resp.ExpiresIn)
+		claims, err := service.signer.Parse(resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "1", claims.Subject)
+		stored := sessions.Calls[0].Arguments.Get(1).(*entity.Session)
+		assert.Equal(t, stored.ID, claims.SessionID)
+		assert.True(t, strings.HasPrefix(resp.RefreshToken, stored.ID+"."))
+		assert.NotContains(t, resp.RefreshToken, stored.RefreshTokenHash)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("should reject a wrong password", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		user := userWithPassword(t)
+
+		// Mock expectations
+		users.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+
+		// When
+		resp, err := service.Login(context.Background(), &dto.LoginRequest{Email: user.Email, Password: "wrong"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		assert.Nil(t, resp)
+		sessions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an unknown email with the same error", func(t *testing.T) {
+		// Setup
+		service, _, users := setupAuthnService(t)
+
+		// Mock expectations
+		users.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		_, err := service.Login(context.Background(), &dto.LoginRequest{Email: "nobody@example.com", Password: testPassword}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestAuthnService_Refresh(t *testing.T) {
+	t.Run("should rotate the refresh token", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = hashSecret("old-secret")
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+		users.On("GetByID", mock.Anything, uint(1)).Return(testutil.CreateUserFixture(), nil)
+		sessions.On("Rotate", mock.Anything, mock.AnythingOfType("*entity.Session"), hashSecret("old-secret")).Return(true, nil)
+
+		// When
+		resp, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: session.ID + ".old-secret"}, dto.ClientInfo{})
+
+		// Then
+		require.NoError(t, err)
+		_, secret, _ := strings.Cut(resp.RefreshToken, ".")
+		assert.NotEqual(t, "old-secret", secret)
+		assert.Equal(t, hashSecret(secret), session.RefreshTokenHash)
+		assert.Equal(t, hashSecret("old-secret"), session.PreviousRefreshTokenHash)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("should revoke the session when a rotated token is reused", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = hashSecret("current-secret")
+		session.PreviousRefreshTokenHash = hashSecret("rotated-secret")
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+		sessions.On("Update", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+			return s.RevokedAt != nil
+		})).Return(nil)
+
+		// When
+		resp, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: session.ID + ".rotated-secret"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Nil(t, resp)
+		sessions.AssertExpectations(t)
+		sessions.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a wrong token without revoking the session", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = hashSecret("current-secret")
+		session.PreviousRefreshTokenHash = hashSecret("rotated-secret")
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+
+		// When
+		resp, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: session.ID + ".guessed-secret"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Nil(t, resp)
+		assert.Nil(t, session.RevokedAt)
+		sessions.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		sessions.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should revoke the session when a concurrent refresh won", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = hashSecret("old-secret")
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+		users.On("GetByID", mock.Anything, uint(1)).Return(testutil.CreateUserFixture(), nil)
+		sessions.On("Rotate", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+		sessions.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		// When
+		_, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: session.ID + ".old-secret"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.NotNil(t, session.RevokedAt)
+	})
+
+	t.Run("should reject a revoked session", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		session.RefreshTokenHash = hashSecret("old-secret")
+		revokedAt := time.Now().Add(-time.Minute)
+		session.RevokedAt = &revokedAt
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+
+		// When
+		_, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: session.ID + ".old-secret"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("should reject a malformed token", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+
+		// When
+		_, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: "garbage"}, dto.ClientInfo{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		sessions.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthnService_Authenticate(t *testing.T) {
+	t.Run("should resolve the user of an active session", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		user := testutil.CreateUserFixture()
+		resp, err := service.issue(user, session, "secret")
+		require.NoError(t, err)
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+		users.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+		// When
+		principal, err := service.Authenticate(context.Background(), resp.AccessToken)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, &identity.Principal{UserID: user.ID, Email: user.Email, Role: user.Role, SessionID: session.ID}, principal)
+	})
+
+	t.Run("should reject the token of a revoked session", func(t *testing.T) {
+		// Setup
+		service, sessions, users := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+		resp, err := service.issue(testutil.CreateUserFixture(), session, "secret")
+		require.NoError(t, err)
+		revokedAt := time.Now()
+		session.RevokedAt = &revokedAt
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+
+		// When
+		principal, err := service.Authenticate(context.Background(), resp.AccessToken)
+
+		// Then
+		assert.ErrorIs(t, err, identity.ErrUnauthenticated)
+		assert.Nil(t, principal)
+		users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an expired token", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		service.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		resp, err := service.issue(testutil.CreateUserFixture(), testutil.CreateAdminSessionFixture(), "secret")
+		require.NoError(t, err)
+
+		// When
+		_, err = service.Authenticate(context.Background(), resp.AccessToken)
+
+		// Then
+		assert.ErrorIs(t, err, identity.ErrUnauthenticated)
+		sessions.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthnService_RevokeSession(t *testing.T) {
+	t.Run("should revoke a session of the caller", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+		sessions.On("Update", mock.Anything, session).Return(nil)
+
+		// When
+		err := service.RevokeSession(context.Background(), &identity.Principal{UserID: 1}, session.ID)
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotNil(t, session.RevokedAt)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("should not revoke a session of another user", func(t *testing.T) {
+		// Setup
+		service, sessions, _ := setupAuthnService(t)
+		session := testutil.CreateAdminSessionFixture()
+
+		// Mock expectations
+		sessions.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+
+		// When
+		err := service.RevokeSession(context.Background(), &identity.Principal{UserID: 2}, session.ID)
+
+		// Then
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, session.RevokedAt)
+		sessions.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
 type CreateNASRequest struct {
 	NASName         string `json:"nasname" binding:"required,max=128"`
 	ShortName       string `json:"shortname" binding:"omitempty,max=32"`
@@ -11,6 +13,8 @@ type CreateNASRequest struct {
 	Description     string `json:"description" binding:"omitempty,max=200"`
 	RequireMa       string `json:"require_ma" binding:"omitempty,max=4"`
 	LimitProxyState string `json:"limit_proxy_state" binding:"omitempty,max=4"`
+	HealthCheck     bool   `json:"health_check"`
+	HealthCheckPort *int   `json:"health_check_port" binding:"omitempty,min=0,max=65535"`
 }
 
 type UpdateNASRequest struct {
@@ -24,37 +28,112 @@ type UpdateNASRequest struct {
 	Description     string `json:"description" binding:"omitempty,max=200"`
 	RequireMa       string `json:"require_ma" binding:"omitempty,max=4"`
 	LimitProxyState string `json:"limit_proxy_state" binding:"omitempty,max=4"`
+	HealthCheck     *bool  `json:"health_check"`
+	HealthCheckPort *int   `json:"health_check_port" binding:"omitempty,min=0,max=65535"`
 }
 
 type NASResponse struct {
-	ID              uint   `json:"id"`
-	NASName         string `json:"nasname"`
-	ShortName       string `json:"shortname"`
-	Type            string `json:"type"`
-	Ports           *int   `json:"ports"`
-	Secret          string `json:"secret"`
-	Server          string `json:"server"`
-	Community       string `json:"community"`
-	Description     string `json:"description"`
-	RequireMa       string `json:"require_ma"`
-	LimitProxyState string `json:"limit_proxy_state"`
-	CreatedAt       string `json:"created_at"`
-	UpdatedAt       string `json:"updated_at"`
+	ID              uint    `json:"id"`
+	NASName         string  `json:"nasname"`
+	ShortName       string  `json:"shortname"`
+	Type            string  `json:"type"`
+	Ports           *int    `json:"ports"`
+	Secret          string  `json:"secret"`
+	Server          string  `json:"server"`
+	Community       string  `json:"community"`
+	Description     string  `json:"description"`
+	RequireMa       string  `json:"require_ma"`
+	LimitProxyState string  `json:"limit_proxy_state"`
+	SecretRotatedAt *string `json:"secret_rotated_at,omitempty"`
+	HealthCheck     bool    `json:"health_check"`
+	HealthCheckPort int     `json:"health_check_port"`
+	HealthStatus    string  `json:"health_status"`
+	LastSeenAt      *string `json:"last_seen_at,omitempty"`
+	LastCheckedAt   *string `json:"last_checked_at,omitempty"`
+	TenantID        *uint   `json:"tenant_id,omitempty"`
+	LastRTTMs       int64   `json:"last_rtt_ms"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+// NASSecretResponse carries a plaintext secret and is only returned by the
+// reveal and rotate endpoints.
+type NASSecretResponse struct {
+	ID              uint    `json:"id"`
+	NASName         string  `json:"nasname"`
+	Secret          string  `json:"secret"`
+	SecretRotatedAt *string `json:"secret_rotated_at,omitempty"`
+}
+
+type ReencryptNASSecretsResponse struct {
+	Reencrypted int `json:"reencrypted"`
+}
+
+// NASMatchResponse tells which NAS FreeRADIUS would pick for a source IP.
+type NASMatchResponse struct {
+	IP            string      `json:"ip"`
+	MatchedPrefix string      `json:"matched_prefix"`
+	NAS           NASResponse `json:"nas"`
+}
+
+// NASHealthTarget is a NAS the health check worker should probe.
+type NASHealthTarget struct {
+	ID           uint
+	NASName      string
+	Secret       string
+	Port         int
+	HealthStatus string
 }
 
 type ListNASResponse struct {
-	Data      []NASResponse `json:"data"`
-	Total     int64         `json:"total"`
-	Page      int           `json:"page"`
-	PageSize  int           `json:"page_size"`
-	TotalPage int           `json:"total_page"`
+	Data           []NASResponse `json:"data"`
+	Total          *int64        `json:"total,omitempty"`
+	TotalEstimated bool          `json:"total_estimated,omitempty"`
+	Page           int           `json:"page"`
+	PageSize       int           `json:"page_size"`
+	TotalPage      *int          `json:"total_page,omitempty"`
+	NextCursor     string        `json:"next_cursor,omitempty"`
 }
 
+// NASFilter takes the expressions of package listquery, such as
+// health_status=in:up,unknown or sort=-created_at.
 type NASFilter struct {
-	NASName     string `json:"nasname" form:"nasname"`
-	ShortName   string `json:"shortname" form:"shortname"`
-	Type        string `json:"type" form:"type"`
-	Description string `json:"description" form:"description"`
-	Page        int    `json:"page" form:"page" binding:"min=1"`
-	PageSize    int    `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	NASName      string `json:"nasname" form:"nasname"`
+	ShortName    string `json:"shortname" form:"shortname"`
+	Type         string `json:"type" form:"type"`
+	Description  string `json:"description" form:"description"`
+	HealthStatus string `json:"health_status" form:"health_status"`
+	CreatedAt    string `json:"created_at" form:"created_at"`
+	LastSeenAt   string `json:"last_seen_at" form:"last_seen_at"`
+	Sort         string `json:"sort" form:"sort"`
+	Page         int    `json:"page" form:"page" binding:"min=1"`
+	PageSize     int    `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
+
+// Import actions reported for every client found in an imported clients.conf.
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+)
+
+type NASFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type NASImportChange struct {
+	NASName string           `json:"nasname"`
+	Action  string           `json:"action"`
+	Fields  []NASFieldChange `json:"fields,omitempty"`
+}
+
+type ImportClientsConfResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Changes   []NASImportChange `json:"changes"`
 }
@@ -8,8 +8,8 @@ import (
 
 type NAS struct {
 	ID              uint           `json:"id" gorm:"primaryKey"`
-	NASName         string         `json:"nasname" gorm:"index;uniqueIndex;not null;size:128"`
-	ShortName       string         `json:"shortname" gorm:"size:32"`
+	NASName         string         `json:"nasname" gorm:"column:nasname;uniqueIndex:idx_nas_nas_name;not null;size:128"`
+	ShortName       string         `json:"shortname" gorm:"column:shortname;size:32"`
 	Type            string         `json:"type" gorm:"size:30;default:'other'"`
 	Ports           int            `json:"ports"`
 	Secret          string         `json:"secret" gorm:"not null;default:'secret'"`
@@ -18,11 +18,26 @@ type NAS struct {
 	Description     string         `json:"description" gorm:"size:200;default:'RADIUS Client'"`
 	RequireMa       string         `json:"require_ma" gorm:"size:4;default:'auto'"`
 	LimitProxyState string         `json:"limit_proxy_state" gorm:"size:4;default:'auto'"`
+	SecretRotatedAt *time.Time     `json:"secret_rotated_at"`
+	HealthCheck     bool           `json:"health_check" gorm:"default:false"`
+	HealthCheckPort int            `json:"health_check_port" gorm:"default:0"`
+	HealthStatus    string         `json:"health_status" gorm:"size:8;default:'unknown';index"`
+	LastSeenAt      *time.Time     `json:"last_seen_at"`
+	LastCheckedAt   *time.Time     `json:"last_checked_at"`
+	LastRTTMs       int64          `json:"last_rtt_ms" gorm:"column:last_rtt_ms"`
+	TenantID        *uint          `json:"tenant_id" gorm:"index"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// Health states recorded by the Status-Server health check.
+const (
+	HealthStatusUnknown = "unknown"
+	HealthStatusUp      = "up"
+	HealthStatusDown    = "down"
+)
+
 func (n NAS) TableName() string {
 	return "nas"
 }
@@ -7,10 +7,10 @@ import (
 	"github.com/novriyantoAli/freeradius-service/api/proto/nas"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -32,6 +32,7 @@ func (h *NASGrpcHandler) CreateNAS(
 	req *nas.CreateNASRequest,
 ) (*nas.CreateNASResponse, error) {
 	ports := int(req.Ports)
+	healthCheckPort := int(req.HealthCheckPort)
 	createReq := &dto.CreateNASRequest{
 		NASName:         req.Nasname,
 		ShortName:       req.Shortname,
@@ -43,12 +44,14 @@ func (h *NASGrpcHandler) CreateNAS(
 		Description:     req.Description,
 		RequireMa:       req.RequireMa,
 		LimitProxyState: req.LimitProxyState,
+		HealthCheck:     req.HealthCheck,
+		HealthCheckPort: &healthCheckPort,
 	}
 
-	nasResponse, err := h.nasService.CreateNAS(createReq)
+	nasResponse, err := h.nasService.CreateNAS(ctx, createReq)
 	if err != nil {
 		h.logger.Error("Failed to create NAS via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to create NAS: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &nas.CreateNASResponse{
@@ -57,10 +60,10 @@ func (h *NASGrpcHandler) CreateNAS(
 }
 
 func (h *NASGrpcHandler) GetNAS(ctx context.Context, req *nas.GetNASRequest) (*nas.GetNASResponse, error) {
-	nasResponse, err := h.nasService.GetNASByID(uint(req.Id))
+	nasResponse, err := h.nasService.GetNASByID(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to get NAS via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.NotFound, "NAS not found: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &nas.GetNASResponse{
@@ -80,17 +83,23 @@ func (h *NASGrpcHandler) ListNAS(ctx context.Context, req *nas.ListNASRequest) (
 	}
 
 	filter := &dto.NASFilter{
-		NASName:   req.Filter.Nasname,
-		ShortName: req.Filter.Shortname,
-		Type:      req.Filter.Type,
-		Page:      page,
-		PageSize:  pageSize,
+		NASName:      req.GetFilter().GetNasname(),
+		ShortName:    req.GetFilter().GetShortname(),
+		Type:         req.GetFilter().GetType(),
+		Description:  req.GetFilter().GetDescription(),
+		HealthStatus: req.GetFilter().GetHealthStatus(),
+		CreatedAt:    req.GetFilter().GetCreatedAt(),
+		LastSeenAt:   req.GetFilter().GetLastSeenAt(),
+		Sort:         req.GetFilter().GetSort(),
+		Page:         page,
+		PageSize:     pageSize,
+		Params:       pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)},
 	}
 
-	listResponse, err := h.nasService.ListNAS(filter)
+	listResponse, err := h.nasService.ListNAS(ctx, filter)
 	if err != nil {
 		h.logger.Error("Failed to list NAS via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to list NAS: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	protoNAS := make([]*nas.NAS, len(listResponse.Data))
@@ -99,10 +108,12 @@ func (h *NASGrpcHandler) ListNAS(ctx context.Context, req *nas.ListNASRequest) (
 	}
 
 	return &nas.ListNASResponse{
-		Nas:      protoNAS,
-		Total:    listResponse.Total,
-		Page:     int32(listResponse.Page),
-		PageSize: int32(listResponse.PageSize),
+		Nas:            protoNAS,
+		Total:          pagination.TotalOrZero(listResponse.Total),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
 	}, nil
 }
 
@@ -122,12 +133,17 @@ func (h *NASGrpcHandler) UpdateNAS(
 		Description:     req.Description,
 		RequireMa:       req.RequireMa,
 		LimitProxyState: req.LimitProxyState,
+		HealthCheck:     req.HealthCheck,
+	}
+	if req.HealthCheckPort != nil {
+		healthCheckPort := int(*req.HealthCheckPort)
+		updateReq.HealthCheckPort = &healthCheckPort
 	}
 
-	nasResponse, err := h.nasService.UpdateNAS(uint(req.Id), updateReq)
+	nasResponse, err := h.nasService.UpdateNAS(ctx, uint(req.Id), updateReq)
 	if err != nil {
 		h.logger.Error("Failed to update NAS via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to update NAS: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &nas.UpdateNASResponse{
@@ -139,10 +155,10 @@ func (h *NASGrpcHandler) DeleteNAS(
 	ctx context.Context,
 	req *nas.DeleteNASRequest,
 ) (*nas.DeleteNASResponse, error) {
-	err := h.nasService.DeleteNAS(uint(req.Id))
+	err := h.nasService.DeleteNAS(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to delete NAS via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to delete NAS: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &nas.DeleteNASResponse{
@@ -150,6 +166,124 @@ func (h *NASGrpcHandler) DeleteNAS(
 	}, nil
 }
 
+func (h *NASGrpcHandler) RevealNASSecret(
+	ctx context.Context,
+	req *nas.RevealNASSecretRequest,
+) (*nas.NASSecretResponse, error) {
+	secretResponse, err := h.nasService.RevealNASSecret(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to reveal NAS secret via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return h.toProtoSecret(secretResponse), nil
+}
+
+func (h *NASGrpcHandler) RotateNASSecret(
+	ctx context.Context,
+	req *nas.RotateNASSecretRequest,
+) (*nas.NASSecretResponse, error) {
+	secretResponse, err := h.nasService.RotateNASSecret(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to rotate NAS secret via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return h.toProtoSecret(secretResponse), nil
+}
+
+func (h *NASGrpcHandler) MatchNAS(ctx context.Context, req *nas.MatchNASRequest) (*nas.MatchNASResponse, error) {
+	matchResponse, err := h.nasService.MatchNAS(ctx, req.Ip)
+	if err != nil {
+		h.logger.Error("Failed to match NAS via gRPC", zap.String("ip", req.Ip), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &nas.MatchNASResponse{
+		Ip:            matchResponse.IP,
+		MatchedPrefix: matchResponse.MatchedPrefix,
+		Nas:           h.toProtoNAS(&matchResponse.NAS),
+	}, nil
+}
+
+func (h *NASGrpcHandler) GetClientsConf(
+	ctx context.Context,
+	req *nas.GetClientsConfRequest,
+) (*nas.GetClientsConfResponse, error) {
+	data, err := h.nasService.GenerateClientsConf(ctx)
+	if err != nil {
+		h.logger.Error("Failed to generate clients.conf via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &nas.GetClientsConfResponse{
+		Content: string(data),
+	}, nil
+}
+
+func (h *NASGrpcHandler) ImportClientsConf(
+	ctx context.Context,
+	req *nas.ImportClientsConfRequest,
+) (*nas.ImportClientsConfResponse, error) {
+	importResponse, err := h.nasService.ImportClientsConf(ctx, []byte(req.Content), req.DryRun)
+	if err != nil {
+		h.logger.Error("Failed to import clients.conf via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	changes := make([]*nas.NASImportChange, len(importResponse.Changes))
+	for i, change := range importResponse.Changes {
+		fields := make([]*nas.NASFieldChange, len(change.Fields))
+		for j, field := range change.Fields {
+			fields[j] = &nas.NASFieldChange{Field: field.Field, Old: field.Old, New: field.New}
+		}
+		changes[i] = &nas.NASImportChange{Nasname: change.NASName, Action: change.Action, Fields: fields}
+	}
+
+	return &nas.ImportClientsConfResponse{
+		DryRun:    importResponse.DryRun,
+		Created:   int32(importResponse.Created),
+		Updated:   int32(importResponse.Updated),
+		Unchanged: int32(importResponse.Unchanged),
+		Changes:   changes,
+	}, nil
+}
+
+func (h *NASGrpcHandler) ReencryptNASSecrets(
+	ctx context.Context,
+	req *nas.ReencryptNASSecretsRequest,
+) (*nas.ReencryptNASSecretsResponse, error) {
+	reencryptResponse, err := h.nasService.ReencryptNASSecrets(ctx)
+	if err != nil {
+		h.logger.Error("Failed to re-encrypt NAS secrets via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &nas.ReencryptNASSecretsResponse{
+		Reencrypted: int32(reencryptResponse.Reencrypted),
+	}, nil
+}
+
+func (h *NASGrpcHandler) toProtoSecret(n *dto.NASSecretResponse) *nas.NASSecretResponse {
+	return &nas.NASSecretResponse{
+		Id:              uint32(n.ID),
+		Nasname:         n.NASName,
+		Secret:          n.Secret,
+		SecretRotatedAt: toProtoTimestamp(n.SecretRotatedAt),
+	}
+}
+
+func toProtoTimestamp(timestamp *string) *timestamppb.Timestamp {
+	if timestamp == nil {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(parsed)
+}
+
 func (h *NASGrpcHandler) toProtoNAS(n *dto.NASResponse) *nas.NAS {
 	var ports int32
 	if n.Ports != nil {
@@ -160,6 +294,12 @@ func (h *NASGrpcHandler) toProtoNAS(n *dto.NASResponse) *nas.NAS {
 	createdAt, _ := time.Parse("2006-01-02 15:04:05", n.CreatedAt)
 	updatedAt, _ := time.Parse("2006-01-02 15:04:05", n.UpdatedAt)
 
+	var tenantID *uint32
+	if n.TenantID != nil {
+		id := uint32(*n.TenantID)
+		tenantID = &id
+	}
+
 	return &nas.NAS{
 		Id:              uint32(n.ID),
 		Nasname:         n.NASName,
@@ -174,5 +314,13 @@ func (h *NASGrpcHandler) toProtoNAS(n *dto.NASResponse) *nas.NAS {
 		LimitProxyState: n.LimitProxyState,
 		CreatedAt:       timestamppb.New(createdAt),
 		UpdatedAt:       timestamppb.New(updatedAt),
+		SecretRotatedAt: toProtoTimestamp(n.SecretRotatedAt),
+		HealthCheck:     n.HealthCheck,
+		HealthCheckPort: int32(n.HealthCheckPort),
+		HealthStatus:    n.HealthStatus,
+		LastSeenAt:      toProtoTimestamp(n.LastSeenAt),
+		LastCheckedAt:   toProtoTimestamp(n.LastCheckedAt),
+		LastRttMs:       n.LastRTTMs,
+		TenantId:        tenantID,
 	}
 }
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/nas"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupNASGrpcHandler() (*NASGrpcHandler, *testutil.MockNASService) {
+	mockService := &testutil.MockNASService{}
+	logger := testutil.NewSilentLogger()
+	handler := NewNASGrpcHandler(mockService, logger)
+	return handler, mockService
+}
+
+func TestNASGrpcHandler_GetNAS(t *testing.T) {
+	t.Run("should return the tenant of the NAS", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASGrpcHandler()
+		tenantID := uint(7)
+		mockService.On("GetNASByID", mock.Anything, uint(1)).
+			Return(&dto.NASResponse{ID: 1, NASName: "10.0.0.1", TenantID: &tenantID}, nil)
+
+		// When
+		result, err := handler.GetNAS(context.Background(), &nas.GetNASRequest{Id: 1})
+
+		// Then
+		require.NoError(t, err)
+		require.NotNil(t, result.Nas.TenantId)
+		assert.Equal(t, uint32(7), *result.Nas.TenantId)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASGrpcHandler_GetClientsConf(t *testing.T) {
+	t.Run("should return the clients.conf document", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASGrpcHandler()
+		mockService.On("GenerateClientsConf", mock.Anything).Return([]byte("client router1 {\n}\n"), nil)
+
+		// When
+		result, err := handler.GetClientsConf(context.Background(), &nas.GetClientsConfRequest{})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "client router1 {\n}\n", result.Content)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASGrpcHandler_ImportClientsConf(t *testing.T) {
+	t.Run("should report the changes of a dry run", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASGrpcHandler()
+		mockService.On("ImportClientsConf", mock.Anything, []byte("client r1 {}"), true).
+			Return(&dto.ImportClientsConfResponse{
+				DryRun:  true,
+				Updated: 1,
+				Changes: []dto.NASImportChange{{
+					NASName: "10.0.0.1",
+					Action:  "update",
+					Fields:  []dto.NASFieldChange{{Field: "shortname", Old: "r0", New: "r1"}},
+				}},
+			}, nil)
+
+		// When
+		result, err := handler.ImportClientsConf(context.Background(), &nas.ImportClientsConfRequest{
+			Content: "client r1 {}",
+			DryRun:  true,
+		})
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, int32(1), result.Updated)
+		require.Len(t, result.Changes, 1)
+		assert.Equal(t, "update", result.Changes[0].Action)
+		assert.Equal(t, "r1", result.Changes[0].Fields[0].New)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return internal error when service fails", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASGrpcHandler()
+		mockService.On("ImportClientsConf", mock.Anything, mock.Anything, false).Return(nil, errors.New("database error"))
+
+		// When
+		result, err := handler.ImportClientsConf(context.Background(), &nas.ImportClientsConfRequest{})
+
+		// Then
+		assert.Nil(t, result)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASGrpcHandler_ReencryptNASSecrets(t *testing.T) {
+	t.Run("should return the number of secrets re-encrypted", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASGrpcHandler()
+		mockService.On("ReencryptNASSecrets", mock.Anything).Return(&dto.ReencryptNASSecretsResponse{Reencrypted: 3}, nil)
+
+		// When
+		result, err := handler.ReencryptNASSecrets(context.Background(), &nas.ReencryptNASSecretsRequest{})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), result.Reencrypted)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 	"go.uber.org/zap"
 )
 
@@ -22,14 +24,20 @@ func NewNASHandler(nasService service.NASService, logger *zap.Logger) *NASHandle
 	}
 }
 
-func (h *NASHandler) RegisterRoutes(r *gin.Engine) {
-	nasGroup := r.Group("/api/v1/nas")
+func (h *NASHandler) RegisterRoutes(r *gin.RouterGroup) {
+	nasGroup := r.Group("/nas")
 	{
 		nasGroup.POST("", h.CreateNAS)
 		nasGroup.GET("", h.ListNAS)
+		nasGroup.GET("/match", h.MatchNAS)
+		nasGroup.GET("/clients.conf", h.GetClientsConf)
+		nasGroup.POST("/clients.conf", h.ImportClientsConf)
+		nasGroup.POST("/secrets/reencrypt", h.ReencryptNASSecrets)
 		nasGroup.GET("/:id", h.GetNAS)
 		nasGroup.PUT("/:id", h.UpdateNAS)
 		nasGroup.DELETE("/:id", h.DeleteNAS)
+		nasGroup.GET("/:id/secret", h.RevealNASSecret)
+		nasGroup.POST("/:id/secret/rotate", h.RotateNASSecret)
 	}
 }
 
@@ -41,27 +49,61 @@ func (h *NASHandler) RegisterRoutes(r *gin.Engine) {
 // @Produce json
 // @Param request body dto.CreateNASRequest true "Create NAS Request"
 // @Success 201 {object} dto.NASResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/nas [post]
+// @Failure 400 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas [post]
 func (h *NASHandler) CreateNAS(c *gin.Context) {
 	var req dto.CreateNASRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(c, apperror.InvalidRequest(err))
 		return
 	}
 
-	resp, err := h.nasService.CreateNAS(&req)
+	resp, err := h.nasService.CreateNAS(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create NAS", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Respond(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, resp)
 }
 
+// MatchNAS godoc
+// @Summary Match NAS by source IP
+// @Description Return the NAS entry FreeRADIUS would use for a request from the given source IP, i.e. the longest matching prefix. Hostnames are resolved for the lookup.
+// @Tags NAS
+// @Accept json
+// @Produce json
+// @Param ip query string true "Source IP address"
+// @Success 200 {object} dto.NASMatchResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/match [get]
+func (h *NASHandler) MatchNAS(c *gin.Context) {
+	ip := c.Query("ip")
+	if ip == "" {
+		apperror.Respond(c, apperror.InvalidField("ip", "is required"))
+		return
+	}
+
+	resp, err := h.nasService.MatchNAS(c.Request.Context(), ip)
+	if err != nil {
+		h.logger.Error("Failed to match NAS", zap.String("ip", ip), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetNAS godoc
 // @Summary Get NAS by ID
 // @Description Get a Network Access Server by ID
@@ -70,21 +112,23 @@ func (h *NASHandler) CreateNAS(c *gin.Context) {
 // @Produce json
 // @Param id path int true "NAS ID"
 // @Success 200 {object} dto.NASResponse
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/nas/{id} [get]
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/{id} [get]
 func (h *NASHandler) GetNAS(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		h.logger.Error("Invalid ID", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	resp, err := h.nasService.GetNASByID(uint(id))
+	resp, err := h.nasService.GetNASByID(c.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to get NAS", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		apperror.Respond(c, err)
 		return
 	}
 
@@ -99,14 +143,22 @@ func (h *NASHandler) GetNAS(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
-// @Param nasname query string false "Filter by NAS name"
-// @Param shortname query string false "Filter by short name"
-// @Param type query string false "Filter by type"
-// @Param description query string false "Filter by description"
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param nasname query string false "Filter by NAS name: exact, prefix:, contains: or in:"
+// @Param shortname query string false "Filter by short name: exact, prefix:, contains: or in:"
+// @Param type query string false "Filter by type: exact, prefix:, contains: or in:"
+// @Param description query string false "Filter by description: exact, prefix:, contains: or in:"
+// @Param health_status query string false "Filter by health status (unknown, up, down): exact or in:"
+// @Param created_at query string false "Filter by creation: a date, an RFC 3339 time or a from..to range"
+// @Param last_seen_at query string false "Filter by last reply: a date, an RFC 3339 time or a from..to range"
+// @Param sort query string false "Comma separated fields, descending with a leading -: id, nasname, shortname, type, health_status, created_at"
 // @Success 200 {object} dto.ListNASResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/nas [get]
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas [get]
 func (h *NASHandler) ListNAS(c *gin.Context) {
 	var filter dto.NASFilter
 	filter.Page = 1
@@ -114,14 +166,74 @@ func (h *NASHandler) ListNAS(c *gin.Context) {
 
 	if err := c.ShouldBindQuery(&filter); err != nil {
 		h.logger.Error("Invalid query parameters", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(c, apperror.InvalidRequest(err))
 		return
 	}
 
-	resp, err := h.nasService.ListNAS(&filter)
+	resp, err := h.nasService.ListNAS(c.Request.Context(), &filter)
 	if err != nil {
 		h.logger.Error("Failed to list NAS", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetClientsConf godoc
+// @Summary Generate clients.conf
+// @Description Render every NAS as a FreeRADIUS clients.conf document
+// @Tags NAS
+// @Produce plain
+// @Success 200 {string} string "clients.conf content"
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/clients.conf [get]
+func (h *NASHandler) GetClientsConf(c *gin.Context) {
+	data, err := h.nasService.GenerateClientsConf(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to generate clients.conf", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+}
+
+// ImportClientsConf godoc
+// @Summary Import clients.conf
+// @Description Upsert the client blocks of a FreeRADIUS clients.conf into the nas table. With dry_run=true nothing is written and the response only describes what would be created, updated or left unchanged.
+// @Tags NAS
+// @Accept plain
+// @Produce json
+// @Param dry_run query bool false "Only report the changes"
+// @Param request body string true "clients.conf content"
+// @Success 200 {object} dto.ImportClientsConfResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/clients.conf [post]
+func (h *NASHandler) ImportClientsConf(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		h.logger.Error("Invalid dry_run parameter", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField("dry_run", "must be a boolean"))
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.logger.Error("Failed to read request body", zap.Error(err))
+		apperror.Respond(c, apperror.Wrap(apperror.KindValidation, err))
+		return
+	}
+
+	resp, err := h.nasService.ImportClientsConf(c.Request.Context(), data, dryRun)
+	if err != nil {
+		h.logger.Error("Failed to import clients.conf", zap.Error(err))
+		apperror.Respond(c, err)
 		return
 	}
 
@@ -137,29 +249,32 @@ func (h *NASHandler) ListNAS(c *gin.Context) {
 // @Param id path int true "NAS ID"
 // @Param request body dto.UpdateNASRequest true "Update NAS Request"
 // @Success 200 {object} dto.NASResponse
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/nas/{id} [put]
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/{id} [put]
 func (h *NASHandler) UpdateNAS(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		h.logger.Error("Invalid ID", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	var req dto.UpdateNASRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(c, apperror.InvalidRequest(err))
 		return
 	}
 
-	resp, err := h.nasService.UpdateNAS(uint(id), &req)
+	resp, err := h.nasService.UpdateNAS(c.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update NAS", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		apperror.Respond(c, err)
 		return
 	}
 
@@ -174,23 +289,111 @@ func (h *NASHandler) UpdateNAS(c *gin.Context) {
 // @Produce json
 // @Param id path int true "NAS ID"
 // @Success 204
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/v1/nas/{id} [delete]
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/{id} [delete]
 func (h *NASHandler) DeleteNAS(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		h.logger.Error("Invalid ID", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	err = h.nasService.DeleteNAS(uint(id))
+	err = h.nasService.DeleteNAS(c.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to delete NAS", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		apperror.Respond(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// RevealNASSecret godoc
+// @Summary Reveal NAS secret
+// @Description Return the plaintext shared secret of a Network Access Server. Every other endpoint masks the secret; this privileged call is logged.
+// @Tags NAS
+// @Accept json
+// @Produce json
+// @Param id path int true "NAS ID"
+// @Success 200 {object} dto.NASSecretResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/{id}/secret [get]
+func (h *NASHandler) RevealNASSecret(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid ID", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
+		return
+	}
+
+	resp, err := h.nasService.RevealNASSecret(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to reveal NAS secret", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RotateNASSecret godoc
+// @Summary Rotate NAS secret
+// @Description Replace the shared secret of a Network Access Server with a newly generated strong secret. The new secret is returned once.
+// @Tags NAS
+// @Accept json
+// @Produce json
+// @Param id path int true "NAS ID"
+// @Success 200 {object} dto.NASSecretResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/{id}/secret/rotate [post]
+func (h *NASHandler) RotateNASSecret(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid ID", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
+		return
+	}
+
+	resp, err := h.nasService.RotateNASSecret(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to rotate NAS secret", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReencryptNASSecrets godoc
+// @Summary Re-encrypt NAS secrets
+// @Description Rewrite every NAS secret that is not encrypted under the active key and bound to its row. Run after upgrading, and after changing secrets.active_key before removing the old key.
+// @Tags NAS
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.ReencryptNASSecretsResponse
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /nas/secrets/reencrypt [post]
+func (h *NASHandler) ReencryptNASSecrets(c *gin.Context) {
+	resp, err := h.nasService.ReencryptNASSecrets(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to re-encrypt NAS secrets", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
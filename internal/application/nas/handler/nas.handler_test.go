@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/clientsconf"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/nasaddr"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/gin-gonic/gin"
@@ -47,7 +53,7 @@ func TestNASHandler_CreateNAS(t *testing.T) {
 			UpdatedAt:       time.Now().String(),
 		}
 
-		mockService.On("CreateNAS", mock.AnythingOfType("*dto.CreateNASRequest")).Return(response, nil)
+		mockService.On("CreateNAS", mock.Anything, mock.AnythingOfType("*dto.CreateNASRequest")).Return(response, nil)
 
 		// Prepare request
 		reqBody, _ := json.Marshal(req)
@@ -92,7 +98,7 @@ func TestNASHandler_CreateNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		req := testutil.CreateNASRequestFixture()
-		mockService.On("CreateNAS", mock.AnythingOfType("*dto.CreateNASRequest")).Return(nil, errors.New("nasname already exists"))
+		mockService.On("CreateNAS", mock.Anything, mock.AnythingOfType("*dto.CreateNASRequest")).Return(nil, errors.New("database error"))
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -107,6 +113,42 @@ func TestNASHandler_CreateNAS(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("should map nasname validation errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "invalid address", err: fmt.Errorf("%w \"10.0.0.256\"", nasaddr.ErrInvalidAddress), status: http.StatusBadRequest},
+			{name: "unresolvable", err: fmt.Errorf("%w: nas.example.com", nasaddr.ErrUnresolvable), status: http.StatusBadRequest},
+			{name: "overlap", err: fmt.Errorf("%w: 10.0.0.0/8 overlaps 10.1.0.0/16", service.ErrOverlappingNAS), status: http.StatusConflict},
+			{name: "taken", err: service.ErrNASNameTaken, status: http.StatusConflict},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Setup
+				handler, mockService := setupNASHandler()
+
+				req := testutil.CreateNASRequestFixture()
+				mockService.On("CreateNAS", mock.Anything, mock.AnythingOfType("*dto.CreateNASRequest")).Return(nil, tt.err)
+
+				reqBody, _ := json.Marshal(req)
+				w := httptest.NewRecorder()
+				ctx, _ := gin.CreateTestContext(w)
+				ctx.Request = httptest.NewRequest("POST", "/api/v1/nas", bytes.NewBuffer(reqBody))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+
+				// When
+				handler.CreateNAS(ctx)
+
+				// Then
+				assert.Equal(t, tt.status, w.Code)
+				mockService.AssertExpectations(t)
+			})
+		}
+	})
 }
 
 func TestNASHandler_GetNAS(t *testing.T) {
@@ -132,7 +174,7 @@ func TestNASHandler_GetNAS(t *testing.T) {
 			UpdatedAt:       time.Now().String(),
 		}
 
-		mockService.On("GetNASByID", nasID).Return(response, nil)
+		mockService.On("GetNASByID", mock.Anything, nasID).Return(response, nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -178,7 +220,7 @@ func TestNASHandler_GetNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		nasID := uint(999)
-		mockService.On("GetNASByID", nasID).Return(nil, errors.New("nas not found"))
+		mockService.On("GetNASByID", mock.Anything, nasID).Return(nil, service.ErrNASNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -206,13 +248,12 @@ func TestNASHandler_ListNAS(t *testing.T) {
 				{ID: 1, NASName: "nas-01", ShortName: "n1", Type: "other"},
 				{ID: 2, NASName: "nas-02", ShortName: "n2", Type: "other"},
 			},
-			Total:     2,
-			Page:      1,
-			PageSize:  10,
-			TotalPage: 1,
+			Total:    pagination.Exact(2).Total,
+			Page:     1,
+			PageSize: 10,
 		}
 
-		mockService.On("ListNAS", mock.AnythingOfType("*dto.NASFilter")).Return(response, nil)
+		mockService.On("ListNAS", mock.Anything, mock.AnythingOfType("*dto.NASFilter")).Return(response, nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -228,7 +269,7 @@ func TestNASHandler_ListNAS(t *testing.T) {
 		var result nasDto.ListNASResponse
 		json.Unmarshal(w.Body.Bytes(), &result)
 		assert.Len(t, result.Data, 2)
-		assert.Equal(t, int64(2), result.Total)
+		assert.Equal(t, int64(2), *result.Total)
 		assert.Equal(t, 1, result.Page)
 		assert.Equal(t, 10, result.PageSize)
 	})
@@ -238,14 +279,13 @@ func TestNASHandler_ListNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		response := &nasDto.ListNASResponse{
-			Data:      []nasDto.NASResponse{},
-			Total:     0,
-			Page:      2,
-			PageSize:  5,
-			TotalPage: 0,
+			Data:     []nasDto.NASResponse{},
+			Total:    pagination.Exact(0).Total,
+			Page:     2,
+			PageSize: 5,
 		}
 
-		mockService.On("ListNAS", mock.AnythingOfType("*dto.NASFilter")).Return(response, nil)
+		mockService.On("ListNAS", mock.Anything, mock.AnythingOfType("*dto.NASFilter")).Return(response, nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -267,13 +307,12 @@ func TestNASHandler_ListNAS(t *testing.T) {
 			Data: []nasDto.NASResponse{
 				{ID: 1, NASName: "cisco-nas", ShortName: "cisco", Type: "cisco"},
 			},
-			Total:     1,
-			Page:      1,
-			PageSize:  10,
-			TotalPage: 1,
+			Total:    pagination.Exact(1).Total,
+			Page:     1,
+			PageSize: 10,
 		}
 
-		mockService.On("ListNAS", mock.MatchedBy(func(filter *nasDto.NASFilter) bool {
+		mockService.On("ListNAS", mock.Anything, mock.MatchedBy(func(filter *nasDto.NASFilter) bool {
 			return filter.Type == "cisco"
 		})).Return(response, nil)
 
@@ -309,7 +348,7 @@ func TestNASHandler_ListNAS(t *testing.T) {
 		// Setup
 		handler, mockService := setupNASHandler()
 
-		mockService.On("ListNAS", mock.AnythingOfType("*dto.NASFilter")).Return(nil, errors.New("database error"))
+		mockService.On("ListNAS", mock.Anything, mock.AnythingOfType("*dto.NASFilter")).Return(nil, errors.New("database error"))
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -341,7 +380,7 @@ func TestNASHandler_UpdateNAS(t *testing.T) {
 			UpdatedAt:   time.Now().String(),
 		}
 
-		mockService.On("UpdateNAS", nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(response, nil)
+		mockService.On("UpdateNAS", mock.Anything, nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(response, nil)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -413,7 +452,7 @@ func TestNASHandler_UpdateNAS(t *testing.T) {
 
 		nasID := uint(999)
 		req := testutil.CreateUpdateNASRequestFixture()
-		mockService.On("UpdateNAS", nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(nil, errors.New("nas not found"))
+		mockService.On("UpdateNAS", mock.Anything, nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(nil, service.ErrNASNotFound)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -438,7 +477,7 @@ func TestNASHandler_UpdateNAS(t *testing.T) {
 
 		nasID := uint(1)
 		req := testutil.CreateUpdateNASRequestFixture()
-		mockService.On("UpdateNAS", nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(nil, errors.New("database error"))
+		mockService.On("UpdateNAS", mock.Anything, nasID, mock.AnythingOfType("*dto.UpdateNASRequest")).Return(nil, errors.New("database error"))
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -453,7 +492,7 @@ func TestNASHandler_UpdateNAS(t *testing.T) {
 		handler.UpdateNAS(ctx)
 
 		// Then
-		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -464,7 +503,7 @@ func TestNASHandler_DeleteNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		nasID := uint(1)
-		mockService.On("DeleteNAS", nasID).Return(nil)
+		mockService.On("DeleteNAS", mock.Anything, nasID).Return(nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -506,7 +545,7 @@ func TestNASHandler_DeleteNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		nasID := uint(999)
-		mockService.On("DeleteNAS", nasID).Return(errors.New("nas not found"))
+		mockService.On("DeleteNAS", mock.Anything, nasID).Return(service.ErrNASNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -528,7 +567,7 @@ func TestNASHandler_DeleteNAS(t *testing.T) {
 		handler, mockService := setupNASHandler()
 
 		nasID := uint(1)
-		mockService.On("DeleteNAS", nasID).Return(errors.New("database error"))
+		mockService.On("DeleteNAS", mock.Anything, nasID).Return(errors.New("database error"))
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -540,8 +579,352 @@ func TestNASHandler_DeleteNAS(t *testing.T) {
 		// When
 		handler.DeleteNAS(ctx)
 
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_GetClientsConf(t *testing.T) {
+	t.Run("should return clients.conf as plain text", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		conf := []byte("client 192.0.2.10 {\n\tipaddr = 192.0.2.10\n}\n")
+		mockService.On("GenerateClientsConf", mock.Anything, mock.Anything).Return(conf, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/clients.conf", nil)
+
+		// When
+		handler.GetClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, string(conf), w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return internal server error when generation fails", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("GenerateClientsConf", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/clients.conf", nil)
+
+		// When
+		handler.GetClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_ImportClientsConf(t *testing.T) {
+	conf := "client a {\n\tipaddr = 10.0.0.1\n\tsecret = x\n}\n"
+
+	t.Run("should import clients.conf as dry run", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		response := &nasDto.ImportClientsConfResponse{
+			DryRun:  true,
+			Created: 1,
+			Changes: []nasDto.NASImportChange{{NASName: "10.0.0.1", Action: nasDto.ImportActionCreate}},
+		}
+		mockService.On("ImportClientsConf", mock.Anything, []byte(conf), true).Return(response, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/clients.conf?dry_run=true", bytes.NewBufferString(conf))
+		ctx.Request.Header.Set("Content-Type", "text/plain")
+
+		// When
+		handler.ImportClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+
+		var result nasDto.ImportClientsConfResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.Created)
+	})
+
+	t.Run("should return bad request for invalid dry_run", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/clients.conf?dry_run=maybe", bytes.NewBufferString(conf))
+
+		// When
+		handler.ImportClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return bad request for parse errors", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("ImportClientsConf", mock.Anything, []byte(conf), false).
+			Return(nil, apperror.Wrap(apperror.KindValidation, &clientsconf.ParseError{Line: 1, Msg: "client a has no secret"}))
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/clients.conf", bytes.NewBufferString(conf))
+
+		// When
+		handler.ImportClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return internal server error when import fails", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("ImportClientsConf", mock.Anything, []byte(conf), false).Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/clients.conf", bytes.NewBufferString(conf))
+
+		// When
+		handler.ImportClientsConf(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_RevealNASSecret(t *testing.T) {
+	t.Run("should reveal NAS secret", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		response := &nasDto.NASSecretResponse{ID: 1, NASName: "test-nas-01", Secret: "testing123"}
+		mockService.On("RevealNASSecret", mock.Anything, uint(1)).Return(response, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/1/secret", nil)
+		ctx.Params = gin.Params{
+			{Key: "id", Value: "1"},
+		}
+
+		// When
+		handler.RevealNASSecret(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+
+		var result nasDto.NASSecretResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "testing123", result.Secret)
+	})
+
+	t.Run("should return not found when NAS not found", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("RevealNASSecret", mock.Anything, uint(999)).Return(nil, service.ErrNASNotFound)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/999/secret", nil)
+		ctx.Params = gin.Params{
+			{Key: "id", Value: "999"},
+		}
+
+		// When
+		handler.RevealNASSecret(ctx)
+
 		// Then
 		assert.Equal(t, http.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("should return bad request for invalid ID", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/abc/secret", nil)
+		ctx.Params = gin.Params{
+			{Key: "id", Value: "abc"},
+		}
+
+		// When
+		handler.RevealNASSecret(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_RotateNASSecret(t *testing.T) {
+	t.Run("should rotate NAS secret", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		rotatedAt := "2026-01-01T00:00:00Z"
+		response := &nasDto.NASSecretResponse{ID: 1, NASName: "test-nas-01", Secret: "generated", SecretRotatedAt: &rotatedAt}
+		mockService.On("RotateNASSecret", mock.Anything, uint(1)).Return(response, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/1/secret/rotate", nil)
+		ctx.Params = gin.Params{
+			{Key: "id", Value: "1"},
+		}
+
+		// When
+		handler.RotateNASSecret(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+
+		var result nasDto.NASSecretResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "generated", result.Secret)
+		assert.Equal(t, rotatedAt, *result.SecretRotatedAt)
+	})
+
+	t.Run("should return internal server error when rotation fails", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("RotateNASSecret", mock.Anything, uint(1)).Return(nil, errors.New("database error"))
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/1/secret/rotate", nil)
+		ctx.Params = gin.Params{
+			{Key: "id", Value: "1"},
+		}
+
+		// When
+		handler.RotateNASSecret(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_ReencryptNASSecrets(t *testing.T) {
+	t.Run("should re-encrypt NAS secrets", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		mockService.On("ReencryptNASSecrets", mock.Anything, mock.Anything).Return(&nasDto.ReencryptNASSecretsResponse{Reencrypted: 2}, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("POST", "/api/v1/nas/secrets/reencrypt", nil)
+
+		// When
+		handler.ReencryptNASSecrets(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"reencrypted":2}`, w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestNASHandler_MatchNAS(t *testing.T) {
+	t.Run("should return the matching NAS", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		response := &nasDto.NASMatchResponse{
+			IP:            "10.1.2.3",
+			MatchedPrefix: "10.0.0.0/8",
+			NAS:           nasDto.NASResponse{ID: 1, NASName: "10.0.0.0/8", Secret: "********"},
+		}
+		mockService.On("MatchNAS", mock.Anything, "10.1.2.3").Return(response, nil)
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/match?ip=10.1.2.3", nil)
+
+		// When
+		handler.MatchNAS(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+
+		var result nasDto.NASMatchResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "10.0.0.0/8", result.MatchedPrefix)
+		assert.Equal(t, uint(1), result.NAS.ID)
+	})
+
+	t.Run("should return bad request without ip", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupNASHandler()
+
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/match", nil)
+
+		// When
+		handler.MatchNAS(ctx)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MatchNAS", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should map service errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "invalid ip", err: fmt.Errorf("%w \"10.0.0\"", nasaddr.ErrInvalidAddress), status: http.StatusBadRequest},
+			{name: "no match", err: service.ErrNoMatchingNAS, status: http.StatusNotFound},
+			{name: "database", err: errors.New("database error"), status: http.StatusInternalServerError},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Setup
+				handler, mockService := setupNASHandler()
+				mockService.On("MatchNAS", mock.Anything, "10.0.0").Return(nil, tt.err)
+
+				w := httptest.NewRecorder()
+				ctx, _ := gin.CreateTestContext(w)
+				ctx.Request = httptest.NewRequest("GET", "/api/v1/nas/match?ip=10.0.0", nil)
+
+				// When
+				handler.MatchNAS(ctx)
+
+				// Then
+				assert.Equal(t, tt.status, w.Code)
+				mockService.AssertExpectations(t)
+			})
+		}
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/handler"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/repository"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/worker"
 
 	"go.uber.org/fx"
 )
@@ -22,5 +23,8 @@ var WorkerModule = fx.Options(
 	fx.Provide(
 		repository.NewNASRepository,
 		service.NewNASService,
+		worker.NewStatusServerProber,
+		worker.NewOutboxStatusNotifier,
+		worker.NewHealthWorker,
 	),
 )
@@ -1,100 +1,324 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// nasFields are the fields the NAS list is filtered and sorted by.
+var nasFields = listquery.Fields{
+	"id":            {Column: "id", Kind: listquery.Number, Sortable: true},
+	"nasname":       {Column: "nasname", Kind: listquery.Text, Sortable: true},
+	"shortname":     {Column: "shortname", Kind: listquery.Text, Sortable: true},
+	"type":          {Column: "type", Kind: listquery.Text, Sortable: true},
+	"description":   {Column: "description", Kind: listquery.Text},
+	"health_status": {Column: "health_status", Kind: listquery.Text, Sortable: true},
+	"created_at":    {Column: "created_at", Kind: listquery.Time, Sortable: true},
+	"last_seen_at":  {Column: "last_seen_at", Kind: listquery.Time},
+}
+
 type NASRepository interface {
-	Create(nas *entity.NAS) error
-	GetByID(id uint) (*entity.NAS, error)
-	GetByNASName(nasname string) (*entity.NAS, error)
-	GetAll(filter *dto.NASFilter) ([]entity.NAS, int64, error)
-	Update(nas *entity.NAS) error
-	Delete(id uint) error
+	Create(ctx context.Context, nas *entity.NAS) error
+	GetByID(ctx context.Context, id uint) (*entity.NAS, error)
+	GetByNASName(ctx context.Context, nasname string) (*entity.NAS, error)
+	GetAll(ctx context.Context, filter *dto.NASFilter) ([]entity.NAS, pagination.Page, error)
+	ListAll(ctx context.Context) ([]entity.NAS, error)
+	ListAddresses(ctx context.Context) ([]entity.NAS, error)
+	Update(ctx context.Context, nas *entity.NAS) error
+	Delete(ctx context.Context, id uint) error
+	ReencryptSecrets(ctx context.Context) (int, error)
+	ListHealthCheckTargets(ctx context.Context) ([]entity.NAS, error)
+	UpdateHealth(ctx context.Context, id uint, status string, lastSeenAt *time.Time, checkedAt time.Time, rttMs int64) error
 }
 
+// nasRepository encrypts the secret column on write and decrypts it on read,
+// so callers only ever see plaintext secrets. Lookups are limited to the
+// tenant of the context, except for the deployment-wide address checks, key
+// rotation and health checks.
 type nasRepository struct {
-	db     *gorm.DB
-	logger *zap.Logger
+	db      *gorm.DB
+	logger  *zap.Logger
+	keyring *secretbox.Keyring
 }
 
-func NewNASRepository(db *gorm.DB, logger *zap.Logger) NASRepository {
+func NewNASRepository(db *gorm.DB, logger *zap.Logger, keyring *secretbox.Keyring) NASRepository {
 	return &nasRepository{
-		db:     db,
-		logger: logger,
+		db:      db,
+		logger:  logger,
+		keyring: keyring,
 	}
 }
 
-func (r *nasRepository) Create(nas *entity.NAS) error {
-	r.logger.Info("Creating NAS", zap.String("nasname", nas.NASName))
-	return r.db.Create(nas).Error
+func (r *nasRepository) Create(ctx context.Context, nas *entity.NAS) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating NAS", zap.String("nasname", nas.NASName))
+	nas.TenantID = tenant.ID(ctx)
+	return r.withEncryptedSecret(db, nas, func(tx *gorm.DB) error {
+		if err := purgeDeleted(tx, nas); err != nil {
+			return err
+		}
+		return tx.Create(nas).Error
+	})
 }
 
-func (r *nasRepository) GetByID(id uint) (*entity.NAS, error) {
+func (r *nasRepository) GetByID(ctx context.Context, id uint) (*entity.NAS, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var nas entity.NAS
-	err := r.db.First(&nas, id).Error
+	err := db.Scopes(tenant.Filter(ctx)).First(&nas, id).Error
 	if err != nil {
-		r.logger.Error("Failed to get NAS by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get NAS by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	if err := r.decryptSecret(&nas); err != nil {
 		return nil, err
 	}
 	return &nas, nil
 }
 
-func (r *nasRepository) GetByNASName(nasname string) (*entity.NAS, error) {
+func (r *nasRepository) GetByNASName(ctx context.Context, nasname string) (*entity.NAS, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var nas entity.NAS
-	err := r.db.Where("nas_name = ?", nasname).First(&nas).Error
+	err := db.Scopes(tenant.Filter(ctx)).Where("nasname = ?", nasname).First(&nas).Error
 	if err != nil {
-		r.logger.Error("Failed to get NAS by name", zap.String("nasname", nasname), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get NAS by name", zap.String("nasname", nasname), zap.Error(err))
+		return nil, err
+	}
+	if err := r.decryptSecret(&nas); err != nil {
 		return nil, err
 	}
 	return &nas, nil
 }
 
-func (r *nasRepository) GetAll(filter *dto.NASFilter) ([]entity.NAS, int64, error) {
-	var nasList []entity.NAS
-	var totalCount int64
+func (r *nasRepository) GetAll(ctx context.Context, filter *dto.NASFilter) ([]entity.NAS, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 
-	query := r.db.Model(&entity.NAS{})
+	query, err := nasFields.Where(ctx, db.Model(&entity.NAS{}).Scopes(tenant.Filter(ctx)), map[string]string{
+		"nasname":       filter.NASName,
+		"shortname":     filter.ShortName,
+		"type":          filter.Type,
+		"description":   filter.Description,
+		"health_status": filter.HealthStatus,
+		"created_at":    filter.CreatedAt,
+		"last_seen_at":  filter.LastSeenAt,
+	})
+	if err != nil {
+		return nil, pagination.Page{}, err
+	}
+	order, err := nasFields.Order(filter.Sort, pagination.Order{})
+	if err != nil {
+		return nil, pagination.Page{}, err
+	}
 
-	if filter.NASName != "" {
-		query = query.Where("nas_name LIKE ?", "%"+filter.NASName+"%")
+	nasList, page, err := pagination.Find[entity.NAS](query, filter.Params, filter.Page, filter.PageSize, order)
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get NAS list", zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+	for i := range nasList {
+		if err := r.decryptSecret(&nasList[i]); err != nil {
+			return nil, pagination.Page{}, err
+		}
 	}
-	if filter.ShortName != "" {
-		query = query.Where("short_name LIKE ?", "%"+filter.ShortName+"%")
+
+	return nasList, page, nil
+}
+
+func (r *nasRepository) ListAll(ctx context.Context) ([]entity.NAS, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var nasList []entity.NAS
+	err := db.Scopes(tenant.Filter(ctx)).Order("nasname ASC").Order("id ASC").Find(&nasList).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list all NAS", zap.Error(err))
+		return nil, err
 	}
-	if filter.Type != "" {
-		query = query.Where("type = ?", filter.Type)
+	for i := range nasList {
+		if err := r.decryptSecret(&nasList[i]); err != nil {
+			return nil, err
+		}
 	}
-	if filter.Description != "" {
-		query = query.Where("description LIKE ?", "%"+filter.Description+"%")
+	return nasList, nil
+}
+
+// ListAddresses returns only the ID, nasname and tenant of every NAS, for
+// address conflict checks that do not need the secrets. It spans all
+// tenants, as their clients share one FreeRADIUS server.
+func (r *nasRepository) ListAddresses(ctx context.Context) ([]entity.NAS, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var nasList []entity.NAS
+	err := db.Select("id", "nasname", "tenant_id").Order("id ASC").Find(&nasList).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list NAS addresses", zap.Error(err))
+		return nil, err
 	}
+	return nasList, nil
+}
 
-	query.Count(&totalCount)
+func (r *nasRepository) Update(ctx context.Context, nas *entity.NAS) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Updating NAS", zap.Uint("id", nas.ID))
+	return r.withEncryptedSecret(db, nas, func(tx *gorm.DB) error {
+		if err := purgeDeleted(tx, nas); err != nil {
+			return err
+		}
+		return tx.Save(nas).Error
+	})
+}
 
-	if filter.Page > 0 && filter.PageSize > 0 {
-		offset := (filter.Page - 1) * filter.PageSize
-		query = query.Offset(offset).Limit(filter.PageSize)
+func (r *nasRepository) Delete(ctx context.Context, id uint) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Deleting NAS", zap.Uint("id", id))
+	return db.Scopes(tenant.Filter(ctx)).Delete(&entity.NAS{}, id).Error
+}
+
+// ListHealthCheckTargets returns every NAS with the health check enabled.
+func (r *nasRepository) ListHealthCheckTargets(ctx context.Context) ([]entity.NAS, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var nasList []entity.NAS
+	err := db.Where("health_check = ?", true).Order("id ASC").Find(&nasList).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list NAS health check targets", zap.Error(err))
+		return nil, err
+	}
+	for i := range nasList {
+		if err := r.decryptSecret(&nasList[i]); err != nil {
+			return nil, err
+		}
+	}
+	return nasList, nil
+}
+
+// UpdateHealth stores the result of a health check without touching the
+// other columns, so it cannot race with API updates of the same row. A nil
+// lastSeenAt keeps the previous value.
+func (r *nasRepository) UpdateHealth(
+	ctx context.Context,
+	id uint,
+	status string,
+	lastSeenAt *time.Time,
+	checkedAt time.Time,
+	rttMs int64,
+) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	columns := map[string]interface{}{
+		"health_status":   status,
+		"last_checked_at": checkedAt,
+		"last_rtt_ms":     rttMs,
+	}
+	if lastSeenAt != nil {
+		columns["last_seen_at"] = *lastSeenAt
 	}
 
-	err := query.Find(&nasList).Error
+	err := db.Model(&entity.NAS{}).Where("id = ?", id).UpdateColumns(columns).Error
 	if err != nil {
-		r.logger.Error("Failed to get NAS list", zap.Error(err))
-		return nil, 0, err
+		logger.For(ctx, r.logger).Error("Failed to update NAS health", zap.Uint("id", id), zap.Error(err))
+		return err
 	}
+	return nil
+}
+
+// ReencryptSecrets rewrites every secret that is not stored under the active
+// key, including soft-deleted rows, so that retired keys can be removed from
+// the configuration. It returns the number of rows rewritten.
+func (r *nasRepository) ReencryptSecrets(ctx context.Context) (int, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var nasList []entity.NAS
+	if err := db.Unscoped().Select("id", "secret").Find(&nasList).Error; err != nil {
+		logger.For(ctx, r.logger).Error("Failed to load NAS secrets", zap.Error(err))
+		return 0, err
+	}
+
+	count := 0
+	for _, nas := range nasList {
+		if r.keyring.IsCurrent(nas.Secret) {
+			continue
+		}
 
-	return nasList, totalCount, nil
+		aad := secretbox.AAD(entity.NAS{}.TableName(), nas.ID)
+		plaintext, err := r.keyring.Decrypt(nas.Secret, aad)
+		if err != nil {
+			logger.For(ctx, r.logger).Error("Failed to decrypt NAS secret", zap.Uint("id", nas.ID), zap.Error(err))
+			return count, err
+		}
+		encrypted, err := r.keyring.Encrypt(plaintext, aad)
+		if err != nil {
+			logger.For(ctx, r.logger).Error("Failed to encrypt NAS secret", zap.Uint("id", nas.ID), zap.Error(err))
+			return count, err
+		}
+
+		err = db.Unscoped().Model(&entity.NAS{}).Where("id = ?", nas.ID).UpdateColumn("secret", encrypted).Error
+		if err != nil {
+			logger.For(ctx, r.logger).Error("Failed to store re-encrypted NAS secret", zap.Uint("id", nas.ID), zap.Error(err))
+			return count, err
+		}
+		count++
+	}
+
+	logger.For(ctx, r.logger).Info("NAS secrets re-encrypted", zap.Int("count", count))
+	return count, nil
 }
 
-func (r *nasRepository) Update(nas *entity.NAS) error {
-	r.logger.Info("Updating NAS", zap.Uint("id", nas.ID))
-	return r.db.Save(nas).Error
+// purgeDeleted removes the soft-deleted rows that hold the nasname of nas,
+// which the unique index of nasname still counts.
+func purgeDeleted(tx *gorm.DB, nas *entity.NAS) error {
+	return tx.Unscoped().
+		Where("nasname = ? AND deleted_at IS NOT NULL AND id <> ?", nas.NASName, nas.ID).
+		Delete(&entity.NAS{}).Error
+}
+
+// withEncryptedSecret runs fn in a transaction of db with nas.Secret
+// encrypted, bound to the row, and restores the plaintext afterwards. A new
+// row has no ID to bind to before fn inserts it, so its secret is written by
+// a second statement.
+func (r *nasRepository) withEncryptedSecret(db *gorm.DB, nas *entity.NAS, fn func(tx *gorm.DB) error) error {
+	plaintext := nas.Secret
+	defer func() { nas.Secret = plaintext }()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		inserted := nas.ID == 0 && r.keyring.Enabled()
+		if inserted {
+			nas.Secret = ""
+		} else if err := r.encryptSecret(nas, plaintext); err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil || !inserted {
+			return err
+		}
+
+		if err := r.encryptSecret(nas, plaintext); err != nil {
+			return err
+		}
+		return tx.Model(&entity.NAS{}).Where("id = ?", nas.ID).UpdateColumn("secret", nas.Secret).Error
+	})
 }
 
-func (r *nasRepository) Delete(id uint) error {
-	r.logger.Info("Deleting NAS", zap.Uint("id", id))
-	return r.db.Delete(&entity.NAS{}, id).Error
+func (r *nasRepository) encryptSecret(nas *entity.NAS, plaintext string) error {
+	encrypted, err := r.keyring.Encrypt(plaintext, secretbox.AAD(nas.TableName(), nas.ID))
+	if err != nil {
+		r.logger.Error("Failed to encrypt NAS secret", zap.String("nasname", nas.NASName), zap.Error(err))
+		return err
+	}
+	nas.Secret = encrypted
+	return nil
+}
+
+func (r *nasRepository) decryptSecret(nas *entity.NAS) error {
+	plaintext, err := r.keyring.Decrypt(nas.Secret, secretbox.AAD(nas.TableName(), nas.ID))
+	if err != nil {
+		r.logger.Error("Failed to decrypt NAS secret", zap.Uint("id", nas.ID), zap.Error(err))
+		return err
+	}
+	nas.Secret = plaintext
+	return nil
 }
@@ -1,15 +1,21 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"gorm.io/gorm"
 )
 
@@ -18,7 +24,7 @@ func TestNASRepository_Create(t *testing.T) {
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should create NAS successfully", func(t *testing.T) {
 		// Given
@@ -26,7 +32,7 @@ func TestNASRepository_Create(t *testing.T) {
 		nas.ID = 0 // Reset ID for creation
 
 		// When
-		err := repo.Create(nas)
+		err := repo.Create(context.Background(), nas)
 
 		// Then
 		assert.NoError(t, err)
@@ -52,14 +58,36 @@ func TestNASRepository_Create(t *testing.T) {
 		nas2.NASName = "duplicate-nas"
 
 		// When
-		err1 := repo.Create(nas1)
-		err2 := repo.Create(nas2)
+		err1 := repo.Create(context.Background(), nas1)
+		err2 := repo.Create(context.Background(), nas2)
 
 		// Then
 		assert.NoError(t, err1)
 		assert.Error(t, err2) // Should fail due to unique constraint
 	})
 
+	t.Run("should replace a deleted NAS with the same nasname", func(t *testing.T) {
+		// Given
+		deleted := testutil.CreateNASFixture()
+		deleted.ID = 0
+		deleted.NASName = "reused-nas"
+		require.NoError(t, repo.Create(context.Background(), deleted))
+		require.NoError(t, repo.Delete(context.Background(), deleted.ID))
+
+		nas := testutil.CreateNASFixture()
+		nas.ID = 0
+		nas.NASName = "reused-nas"
+
+		// When
+		err := repo.Create(context.Background(), nas)
+
+		// Then
+		require.NoError(t, err)
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&nasEntity.NAS{}).Where("nasname = ?", "reused-nas").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
 	// Cleanup
 	testutil.CleanDB(db)
 }
@@ -69,17 +97,17 @@ func TestNASRepository_GetByID(t *testing.T) {
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should get NAS by ID successfully", func(t *testing.T) {
 		// Given
 		nas := testutil.CreateNASFixture()
 		nas.ID = 0
-		err := repo.Create(nas)
+		err := repo.Create(context.Background(), nas)
 		require.NoError(t, err)
 
 		// When
-		foundNAS, err := repo.GetByID(nas.ID)
+		foundNAS, err := repo.GetByID(context.Background(), nas.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -91,7 +119,7 @@ func TestNASRepository_GetByID(t *testing.T) {
 
 	t.Run("should return error when NAS not found", func(t *testing.T) {
 		// When
-		_, err := repo.GetByID(999)
+		_, err := repo.GetByID(context.Background(), 999)
 
 		// Then
 		assert.Error(t, err)
@@ -102,23 +130,49 @@ func TestNASRepository_GetByID(t *testing.T) {
 	testutil.CleanDB(db)
 }
 
+func TestNASRepository_Tracing(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	nas := testutil.CreateNASFixture()
+	nas.ID = 0
+	require.NoError(t, db.Create(nas).Error)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	require.NoError(t, tracing.InstrumentGorm(db, tp))
+	repo := NewNASRepository(db, testutil.NewTestLogger(t), testutil.NewTestKeyring())
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "GET /api/v1/nas/:id")
+
+	// When
+	_, err = repo.GetByID(ctx, nas.ID)
+	parent.End()
+
+	// Then
+	require.NoError(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "SELECT nas", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
 func TestNASRepository_GetByNASName(t *testing.T) {
 	// Setup
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should get NAS by NASName successfully", func(t *testing.T) {
 		// Given
 		nas := testutil.CreateNASFixture()
 		nas.ID = 0
 		nas.NASName = "unique-nas-name"
-		err := repo.Create(nas)
+		err := repo.Create(context.Background(), nas)
 		require.NoError(t, err)
 
 		// When
-		foundNAS, err := repo.GetByNASName("unique-nas-name")
+		foundNAS, err := repo.GetByNASName(context.Background(), "unique-nas-name")
 
 		// Then
 		assert.NoError(t, err)
@@ -129,7 +183,7 @@ func TestNASRepository_GetByNASName(t *testing.T) {
 
 	t.Run("should return error when NAS NASName not found", func(t *testing.T) {
 		// When
-		_, err := repo.GetByNASName("nonexistent-nas")
+		_, err := repo.GetByNASName(context.Background(), "nonexistent-nas")
 
 		// Then
 		assert.Error(t, err)
@@ -145,7 +199,7 @@ func TestNASRepository_GetAll(t *testing.T) {
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should get all NAS with pagination", func(t *testing.T) {
 		// Given - Create multiple NAS
@@ -154,7 +208,7 @@ func TestNASRepository_GetAll(t *testing.T) {
 			nas.ID = 0
 			nas.NASName = fmt.Sprintf("nas-%d", i)
 			nas.ShortName = fmt.Sprintf("n%d", i)
-			err := repo.Create(nas)
+			err := repo.Create(context.Background(), nas)
 			require.NoError(t, err)
 		}
 
@@ -164,12 +218,12 @@ func TestNASRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		nasServers, totalCount, err := repo.GetAll(filter)
+		nasServers, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
-		assert.Len(t, nasServers, 3)          // Should return 3 NAS due to page size
-		assert.Equal(t, int64(5), totalCount) // Total count should be 5
+		assert.Len(t, nasServers, 3)           // Should return 3 NAS due to page size
+		assert.Equal(t, int64(5), *page.Total) // Total count should be 5
 	})
 
 	t.Run("should filter NAS by NASName", func(t *testing.T) {
@@ -178,14 +232,14 @@ func TestNASRepository_GetAll(t *testing.T) {
 		nas1.ID = 0
 		nas1.NASName = "radius-server-1"
 		nas1.ShortName = "rs1"
-		err := repo.Create(nas1)
+		err := repo.Create(context.Background(), nas1)
 		require.NoError(t, err)
 
 		nas2 := testutil.CreateNASFixture()
 		nas2.ID = 0
 		nas2.NASName = "radius-server-2"
 		nas2.ShortName = "rs2"
-		err = repo.Create(nas2)
+		err = repo.Create(context.Background(), nas2)
 		require.NoError(t, err)
 
 		filter := &nasDto.NASFilter{
@@ -193,12 +247,12 @@ func TestNASRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		nasServers, totalCount, err := repo.GetAll(filter)
+		nasServers, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, nasServers, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "radius-server-1", nasServers[0].NASName)
 	})
 
@@ -208,14 +262,14 @@ func TestNASRepository_GetAll(t *testing.T) {
 		nas1.ID = 0
 		nas1.NASName = "cisco-nas"
 		nas1.Type = "cisco"
-		err := repo.Create(nas1)
+		err := repo.Create(context.Background(), nas1)
 		require.NoError(t, err)
 
 		nas2 := testutil.CreateNASFixture()
 		nas2.ID = 0
 		nas2.NASName = "juniper-nas"
 		nas2.Type = "juniper"
-		err = repo.Create(nas2)
+		err = repo.Create(context.Background(), nas2)
 		require.NoError(t, err)
 
 		filter := &nasDto.NASFilter{
@@ -223,12 +277,12 @@ func TestNASRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		nasServers, totalCount, err := repo.GetAll(filter)
+		nasServers, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, nasServers, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "cisco", nasServers[0].Type)
 	})
 
@@ -238,27 +292,27 @@ func TestNASRepository_GetAll(t *testing.T) {
 		nas1.ID = 0
 		nas1.NASName = "prod-nas"
 		nas1.Description = "Production RADIUS Server"
-		err := repo.Create(nas1)
+		err := repo.Create(context.Background(), nas1)
 		require.NoError(t, err)
 
 		nas2 := testutil.CreateNASFixture()
 		nas2.ID = 0
 		nas2.NASName = "test-nas"
 		nas2.Description = "Testing RADIUS Server"
-		err = repo.Create(nas2)
+		err = repo.Create(context.Background(), nas2)
 		require.NoError(t, err)
 
 		filter := &nasDto.NASFilter{
-			Description: "Production",
+			Description: "contains:Production",
 		}
 
 		// When
-		nasServers, totalCount, err := repo.GetAll(filter)
+		nasServers, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, nasServers, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "Production RADIUS Server", nasServers[0].Description)
 	})
 
@@ -271,13 +325,13 @@ func TestNASRepository_Update(t *testing.T) {
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should update NAS successfully", func(t *testing.T) {
 		// Given
 		nas := testutil.CreateNASFixture()
 		nas.ID = 0
-		err := repo.Create(nas)
+		err := repo.Create(context.Background(), nas)
 		require.NoError(t, err)
 
 		// When
@@ -285,7 +339,7 @@ func TestNASRepository_Update(t *testing.T) {
 		nas.Description = "Updated Description"
 		ports := 1813
 		nas.Ports = ports
-		err = repo.Update(nas)
+		err = repo.Update(context.Background(), nas)
 
 		// Then
 		assert.NoError(t, err)
@@ -308,17 +362,17 @@ func TestNASRepository_Delete(t *testing.T) {
 	db, err := testutil.SetupTestDB()
 	require.NoError(t, err)
 	logger := testutil.NewTestLogger(t)
-	repo := NewNASRepository(db, logger)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
 
 	t.Run("should delete NAS successfully", func(t *testing.T) {
 		// Given
 		nas := testutil.CreateNASFixture()
 		nas.ID = 0
-		err := repo.Create(nas)
+		err := repo.Create(context.Background(), nas)
 		require.NoError(t, err)
 
 		// When
-		err = repo.Delete(nas.ID)
+		err = repo.Delete(context.Background(), nas.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -333,3 +387,180 @@ func TestNASRepository_Delete(t *testing.T) {
 	// Cleanup
 	testutil.CleanDB(db)
 }
+
+func TestNASRepository_ListAll(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
+
+	t.Run("should list every NAS ordered by nasname", func(t *testing.T) {
+		// Given
+		for _, name := range []string{"10.0.0.3", "10.0.0.1", "10.0.0.2"} {
+			nas := testutil.CreateNASFixture()
+			nas.ID = 0
+			nas.NASName = name
+			require.NoError(t, repo.Create(context.Background(), nas))
+		}
+
+		// When
+		nasList, err := repo.ListAll(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, nasList, 3)
+		assert.Equal(t, "10.0.0.1", nasList[0].NASName)
+		assert.Equal(t, "10.0.0.2", nasList[1].NASName)
+		assert.Equal(t, "10.0.0.3", nasList[2].NASName)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
+
+func TestNASRepository_SecretEncryption(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
+
+	t.Run("should store secrets encrypted and read them back in plaintext", func(t *testing.T) {
+		// Given
+		nas := testutil.CreateNASFixture()
+		nas.ID = 0
+
+		// When
+		err := repo.Create(context.Background(), nas)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "testing123", nas.Secret)
+
+		var stored string
+		require.NoError(t, db.Table("nas").Select("secret").Where("id = ?", nas.ID).Scan(&stored).Error)
+		assert.True(t, strings.HasPrefix(stored, "enc:v2:test:"))
+		assert.NotContains(t, stored, "testing123")
+
+		found, err := repo.GetByID(context.Background(), nas.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "testing123", found.Secret)
+	})
+
+	t.Run("should not read a secret copied from another row", func(t *testing.T) {
+		// Given
+		testutil.CleanDB(db)
+		first := testutil.CreateNASFixture()
+		first.ID = 0
+		first.NASName = "first-nas"
+		require.NoError(t, repo.Create(context.Background(), first))
+		second := testutil.CreateNASFixture()
+		second.ID = 0
+		second.NASName = "second-nas"
+		second.Secret = "other"
+		require.NoError(t, repo.Create(context.Background(), second))
+
+		var stored string
+		require.NoError(t, db.Table("nas").Select("secret").Where("id = ?", first.ID).Scan(&stored).Error)
+		require.NoError(t, db.Table("nas").Where("id = ?", second.ID).Update("secret", stored).Error)
+
+		// When
+		_, err := repo.GetByID(context.Background(), second.ID)
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should re-encrypt plaintext and retired-key secrets", func(t *testing.T) {
+		// Given
+		testutil.CleanDB(db)
+		legacy := testutil.CreateNASFixture()
+		legacy.ID = 0
+		legacy.NASName = "legacy-nas"
+		legacy.Secret = "plaintext"
+		require.NoError(t, db.Create(legacy).Error)
+
+		current := testutil.CreateNASFixture()
+		current.ID = 0
+		current.NASName = "current-nas"
+		require.NoError(t, repo.Create(context.Background(), current))
+
+		// When
+		count, err := repo.ReencryptSecrets(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		var stored string
+		require.NoError(t, db.Table("nas").Select("secret").Where("id = ?", legacy.ID).Scan(&stored).Error)
+		assert.True(t, strings.HasPrefix(stored, "enc:v2:test:"))
+
+		found, err := repo.GetByNASName(context.Background(), "legacy-nas")
+		require.NoError(t, err)
+		assert.Equal(t, "plaintext", found.Secret)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
+
+func TestNASRepository_Health(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewNASRepository(db, logger, testutil.NewTestKeyring())
+
+	monitored := testutil.CreateNASFixture()
+	monitored.ID = 0
+	monitored.NASName = "10.0.0.1"
+	monitored.HealthCheck = true
+	require.NoError(t, repo.Create(context.Background(), monitored))
+
+	unmonitored := testutil.CreateNASFixture()
+	unmonitored.ID = 0
+	unmonitored.NASName = "10.0.0.2"
+	require.NoError(t, repo.Create(context.Background(), unmonitored))
+
+	t.Run("should list only NAS with health check enabled", func(t *testing.T) {
+		// When
+		targets, err := repo.ListHealthCheckTargets(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, targets, 1)
+		assert.Equal(t, monitored.ID, targets[0].ID)
+		assert.Equal(t, monitored.Secret, targets[0].Secret)
+	})
+
+	t.Run("should update health columns and filter by status", func(t *testing.T) {
+		// Given
+		checkedAt := time.Now().UTC().Truncate(time.Second)
+
+		// When
+		err := repo.UpdateHealth(context.Background(), monitored.ID, nasEntity.HealthStatusUp, &checkedAt, checkedAt, 12)
+		require.NoError(t, err)
+		err = repo.UpdateHealth(context.Background(), monitored.ID, nasEntity.HealthStatusDown, nil, checkedAt.Add(time.Minute), 0)
+		require.NoError(t, err)
+
+		// Then
+		found, err := repo.GetByID(context.Background(), monitored.ID)
+		require.NoError(t, err)
+		assert.Equal(t, nasEntity.HealthStatusDown, found.HealthStatus)
+		require.NotNil(t, found.LastSeenAt)
+		assert.True(t, checkedAt.Equal(*found.LastSeenAt))
+		require.NotNil(t, found.LastCheckedAt)
+		assert.True(t, checkedAt.Add(time.Minute).Equal(*found.LastCheckedAt))
+		assert.Equal(t, monitored.Secret, found.Secret)
+
+		down, page, err := repo.GetAll(context.Background(), &nasDto.NASFilter{HealthStatus: nasEntity.HealthStatusDown})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *page.Total)
+		assert.Equal(t, monitored.ID, down[0].ID)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
@@ -1,46 +1,94 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/clientsconf"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/nasaddr"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type NASService interface {
-	CreateNAS(req *dto.CreateNASRequest) (*dto.NASResponse, error)
-	GetNASByID(id uint) (*dto.NASResponse, error)
-	ListNAS(filter *dto.NASFilter) (*dto.ListNASResponse, error)
-	UpdateNAS(id uint, req *dto.UpdateNASRequest) (*dto.NASResponse, error)
-	DeleteNAS(id uint) error
+	CreateNAS(ctx context.Context, req *dto.CreateNASRequest) (*dto.NASResponse, error)
+	GetNASByID(ctx context.Context, id uint) (*dto.NASResponse, error)
+	ListNAS(ctx context.Context, filter *dto.NASFilter) (*dto.ListNASResponse, error)
+	UpdateNAS(ctx context.Context, id uint, req *dto.UpdateNASRequest) (*dto.NASResponse, error)
+	DeleteNAS(ctx context.Context, id uint) error
+	GenerateClientsConf(ctx context.Context) ([]byte, error)
+	WriteClientsConf(ctx context.Context, path string) error
+	ImportClientsConf(ctx context.Context, data []byte, dryRun bool) (*dto.ImportClientsConfResponse, error)
+	RevealNASSecret(ctx context.Context, id uint) (*dto.NASSecretResponse, error)
+	RotateNASSecret(ctx context.Context, id uint) (*dto.NASSecretResponse, error)
+	ReencryptNASSecrets(ctx context.Context) (*dto.ReencryptNASSecretsResponse, error)
+	GetHealthCheckTargets(ctx context.Context) ([]dto.NASHealthTarget, error)
+	RecordNASHealth(ctx context.Context, id uint, up bool, rtt time.Duration, checkedAt time.Time) error
+	MatchNAS(ctx context.Context, ip string) (*dto.NASMatchResponse, error)
 }
 
+// resolveTimeout bounds the DNS lookup of a hostname nasname.
+const resolveTimeout = 5 * time.Second
+
+var (
+	// ErrNASNotFound is returned for NAS IDs that do not exist.
+	ErrNASNotFound = apperror.NotFound("nas not found")
+	// ErrNASNameTaken is returned when another NAS already has the nasname.
+	ErrNASNameTaken = apperror.Conflict("nasname already exists")
+	// ErrOverlappingNAS is returned when a nasname covers addresses that
+	// another NAS already defines.
+	ErrOverlappingNAS = apperror.Conflict("nas address overlaps an existing nas")
+	// ErrNoMatchingNAS is returned by MatchNAS when no NAS covers the IP.
+	ErrNoMatchingNAS = apperror.NotFound("no nas matches ip")
+)
+
 type nasService struct {
-	nasRepo repository.NASRepository
-	logger  *zap.Logger
+	nasRepo   repository.NASRepository
+	txManager database.TransactionManagerI
+	outbox    outbox.Outbox
+	auditor   audit.Recorder
+	resolver  nasaddr.Resolver
+	logger    *zap.Logger
 }
 
-func NewNASService(nasRepo repository.NASRepository, logger *zap.Logger) NASService {
+func NewNASService(
+	nasRepo repository.NASRepository,
+	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
+	logger *zap.Logger,
+) NASService {
 	return &nasService{
-		nasRepo: nasRepo,
-		logger:  logger,
+		nasRepo:   nasRepo,
+		txManager: txManager,
+		outbox:    outbox,
+		auditor:   auditor,
+		resolver:  net.DefaultResolver,
+		logger:    logger,
 	}
 }
 
-func (s *nasService) CreateNAS(req *dto.CreateNASRequest) (*dto.NASResponse, error) {
-	s.logger.Info("Creating NAS", zap.String("nasname", req.NASName))
+func (s *nasService) CreateNAS(ctx context.Context, req *dto.CreateNASRequest) (*dto.NASResponse, error) {
+	logger.For(ctx, s.logger).Info("Creating NAS", zap.String("nasname", req.NASName))
 
-	// Check if NASName already exists
-	existing, err := s.nasRepo.GetByNASName(req.NASName)
-	if err == nil && existing != nil {
-		s.logger.Warn("NASName already exists", zap.String("nasname", req.NASName))
-		return nil, errors.New("nasname already exists")
-	}
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		s.logger.Error("Failed to check NASName existence", zap.Error(err))
+	if err := s.checkNASName(ctx, req.NASName, 0); err != nil {
+		logger.For(ctx, s.logger).Warn("Invalid nasname", zap.String("nasname", req.NASName), zap.Error(err))
 		return nil, err
 	}
 
@@ -55,39 +103,53 @@ func (s *nasService) CreateNAS(req *dto.CreateNASRequest) (*dto.NASResponse, err
 		Description:     req.Description,
 		RequireMa:       req.RequireMa,
 		LimitProxyState: req.LimitProxyState,
+		HealthCheck:     req.HealthCheck,
+		HealthStatus:    entity.HealthStatusUnknown,
 	}
 
 	if req.Ports != nil {
 		nas.Ports = *req.Ports
 	}
+	if req.HealthCheckPort != nil {
+		nas.HealthCheckPort = *req.HealthCheckPort
+	}
 
-	if err := s.nasRepo.Create(nas); err != nil {
-		s.logger.Error("Failed to create NAS", zap.Error(err))
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.nasRepo.Create(txCtx, nas); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, nasChange(audit.ActionCreate, nil, nas)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, nasCreated(nas))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create NAS", zap.Error(err))
 		return nil, err
 	}
 
-	s.logger.Info("NAS created successfully", zap.Uint("id", nas.ID))
+	logger.For(ctx, s.logger).Info("NAS created successfully", zap.Uint("id", nas.ID))
 	return entityToResponse(nas), nil
 }
 
-func (s *nasService) GetNASByID(id uint) (*dto.NASResponse, error) {
-	s.logger.Info("Getting NAS by ID", zap.Uint("id", id))
+func (s *nasService) GetNASByID(ctx context.Context, id uint) (*dto.NASResponse, error) {
+	logger.For(ctx, s.logger).Info("Getting NAS by ID", zap.Uint("id", id))
 
-	nas, err := s.nasRepo.GetByID(id)
+	nas, err := s.nasRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("NAS not found", zap.Uint("id", id))
-			return nil, errors.New("nas not found")
+			logger.For(ctx, s.logger).Warn("NAS not found", zap.Uint("id", id))
+			return nil, ErrNASNotFound
 		}
-		s.logger.Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
 	return entityToResponse(nas), nil
 }
 
-func (s *nasService) ListNAS(filter *dto.NASFilter) (*dto.ListNASResponse, error) {
-	s.logger.Info("Listing NAS", zap.Int("page", filter.Page), zap.Int("page_size", filter.PageSize))
+func (s *nasService) ListNAS(ctx context.Context, filter *dto.NASFilter) (*dto.ListNASResponse, error) {
+	logger.For(ctx, s.logger).Info("Listing NAS", zap.Int("page", filter.Page), zap.Int("page_size", filter.PageSize))
 
 	// Set defaults
 	if filter.Page < 1 {
@@ -97,9 +159,9 @@ func (s *nasService) ListNAS(filter *dto.NASFilter) (*dto.ListNASResponse, error
 		filter.PageSize = 10
 	}
 
-	nasList, total, err := s.nasRepo.GetAll(filter)
+	nasList, page, err := s.nasRepo.GetAll(ctx, filter)
 	if err != nil {
-		s.logger.Error("Failed to list NAS", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to list NAS", zap.Error(err))
 		return nil, err
 	}
 
@@ -108,36 +170,39 @@ func (s *nasService) ListNAS(filter *dto.NASFilter) (*dto.ListNASResponse, error
 		responses = append(responses, *entityToResponse(&nas))
 	}
 
-	totalPages := int(total) / filter.PageSize
-	if int(total)%filter.PageSize > 0 {
-		totalPages++
-	}
-
-	s.logger.Info("NAS list retrieved", zap.Int64("total", total), zap.Int("page", filter.Page))
+	logger.For(ctx, s.logger).Info("NAS list retrieved", zap.Int("count", len(responses)), zap.Int("page", filter.Page))
 	return &dto.ListNASResponse{
-		Data:      responses,
-		Total:     total,
-		Page:      filter.Page,
-		PageSize:  filter.PageSize,
-		TotalPage: totalPages,
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
 	}, nil
 }
 
-func (s *nasService) UpdateNAS(id uint, req *dto.UpdateNASRequest) (*dto.NASResponse, error) {
-	s.logger.Info("Updating NAS", zap.Uint("id", id))
+func (s *nasService) UpdateNAS(ctx context.Context, id uint, req *dto.UpdateNASRequest) (*dto.NASResponse, error) {
+	logger.For(ctx, s.logger).Info("Updating NAS", zap.Uint("id", id))
 
-	nas, err := s.nasRepo.GetByID(id)
+	nas, err := s.nasRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("NAS not found", zap.Uint("id", id))
-			return nil, errors.New("nas not found")
+			logger.For(ctx, s.logger).Warn("NAS not found", zap.Uint("id", id))
+			return nil, ErrNASNotFound
 		}
-		s.logger.Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
+	before := *nas
+
 	// Update fields if provided
-	if req.NASName != "" {
+	if req.NASName != "" && req.NASName != nas.NASName {
+		if err := s.checkNASName(ctx, req.NASName, nas.ID); err != nil {
+			logger.For(ctx, s.logger).Warn("Invalid nasname", zap.String("nasname", req.NASName), zap.Error(err))
+			return nil, err
+		}
 		nas.NASName = req.NASName
 	}
 	if req.ShortName != "" {
@@ -149,8 +214,10 @@ func (s *nasService) UpdateNAS(id uint, req *dto.UpdateNASRequest) (*dto.NASResp
 	if req.Ports != nil {
 		nas.Ports = *req.Ports
 	}
-	if req.Secret != "" {
+	if req.Secret != "" && req.Secret != nas.Secret {
 		nas.Secret = req.Secret
+		rotatedAt := time.Now()
+		nas.SecretRotatedAt = &rotatedAt
 	}
 	if req.Server != "" {
 		nas.Server = req.Server
@@ -167,38 +234,590 @@ func (s *nasService) UpdateNAS(id uint, req *dto.UpdateNASRequest) (*dto.NASResp
 	if req.LimitProxyState != "" {
 		nas.LimitProxyState = req.LimitProxyState
 	}
+	if req.HealthCheck != nil {
+		nas.HealthCheck = *req.HealthCheck
+		if !nas.HealthCheck {
+			nas.HealthStatus = entity.HealthStatusUnknown
+		}
+	}
+	if req.HealthCheckPort != nil {
+		nas.HealthCheckPort = *req.HealthCheckPort
+	}
 
-	if err := s.nasRepo.Update(nas); err != nil {
-		s.logger.Error("Failed to update NAS", zap.Uint("id", id), zap.Error(err))
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.nasRepo.Update(txCtx, nas); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, nasChange(audit.ActionUpdate, &before, nas)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, nasUpdated(nas))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to update NAS", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
-	s.logger.Info("NAS updated successfully", zap.Uint("id", id))
+	logger.For(ctx, s.logger).Info("NAS updated successfully", zap.Uint("id", id))
 	return entityToResponse(nas), nil
 }
 
-func (s *nasService) DeleteNAS(id uint) error {
-	s.logger.Info("Deleting NAS", zap.Uint("id", id))
+func (s *nasService) DeleteNAS(ctx context.Context, id uint) error {
+	logger.For(ctx, s.logger).Info("Deleting NAS", zap.Uint("id", id))
+
+	nas, err := s.nasRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Warn("NAS not found", zap.Uint("id", id))
+			return ErrNASNotFound
+		}
+		logger.For(ctx, s.logger).Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.nasRepo.Delete(txCtx, nas.ID); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, nasChange(audit.ActionDelete, nas, nil)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.NASDeleted{NASID: nas.ID, NASName: nas.NASName})
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to delete NAS", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	logger.For(ctx, s.logger).Info("NAS deleted successfully", zap.Uint("id", id))
+	return nil
+}
+
+// clientsConfFileMode keeps the generated file readable by the radiusd group only,
+// since it contains shared secrets.
+const clientsConfFileMode = 0o640
+
+func (s *nasService) GenerateClientsConf(ctx context.Context) ([]byte, error) {
+	logger.For(ctx, s.logger).Info("Generating clients.conf")
+
+	nasList, err := s.nasRepo.ListAll(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list NAS for clients.conf", zap.Error(err))
+		return nil, err
+	}
+
+	clients := make([]clientsconf.Client, 0, len(nasList))
+	for i := range nasList {
+		clients = append(clients, entityToClient(&nasList[i]))
+	}
+
+	data, err := clientsconf.Render(clients)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to render clients.conf", zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("clients.conf generated", zap.Int("clients", len(clients)))
+	return data, nil
+}
+
+func (s *nasService) WriteClientsConf(ctx context.Context, path string) error {
+	data, err := s.GenerateClientsConf(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := clientsconf.WriteFileAtomic(path, data, clientsConfFileMode); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to write clients.conf", zap.String("path", path), zap.Error(err))
+		return err
+	}
+
+	logger.For(ctx, s.logger).Info("clients.conf written", zap.String("path", path))
+	return nil
+}
+
+// maskedSecret replaces secret values in every response except the explicit
+// reveal and rotate calls, and in import diffs.
+const maskedSecret = "********"
+
+const (
+	// generatedSecretLength yields roughly 190 bits of entropy from secretAlphabet.
+	generatedSecretLength = 32
+	// secretAlphabet avoids look-alike characters and symbols that some NAS
+	// configuration shells mangle.
+	secretAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+)
+
+// ErrInvalidClient is returned when an imported client does not fit the nas table.
+var ErrInvalidClient = apperror.Validation("invalid client")
+
+func (s *nasService) ImportClientsConf(ctx context.Context, data []byte, dryRun bool) (*dto.ImportClientsConfResponse, error) {
+	logger.For(ctx, s.logger).Info("Importing clients.conf", zap.Bool("dry_run", dryRun))
+
+	clients, err := clientsconf.Parse(data)
+	if err != nil {
+		logger.For(ctx, s.logger).Warn("Failed to parse clients.conf", zap.Error(err))
+		return nil, apperror.Wrap(apperror.KindValidation, err)
+	}
+
+	// Plan and apply in one transaction, so that the import is checked
+	// against the rows it writes over and an invalid client or a failed write
+	// leaves nothing half applied.
+	var resp *dto.ImportClientsConfResponse
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		resp, err = s.importClients(txCtx, clients, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		logger.For(ctx, s.logger).Info("clients.conf dry run completed",
+			zap.Int("created", resp.Created), zap.Int("updated", resp.Updated), zap.Int("unchanged", resp.Unchanged))
+		return resp, nil
+	}
+
+	logger.For(ctx, s.logger).Info("clients.conf imported",
+		zap.Int("created", resp.Created), zap.Int("updated", resp.Updated), zap.Int("unchanged", resp.Unchanged))
+	return resp, nil
+}
+
+// importClients upserts clients, or only plans it on a dry run.
+func (s *nasService) importClients(
+	ctx context.Context,
+	clients []clientsconf.Client,
+	dryRun bool,
+) (*dto.ImportClientsConfResponse, error) {
+	resp := &dto.ImportClientsConfResponse{
+		DryRun:  dryRun,
+		Changes: make([]dto.NASImportChange, 0, len(clients)),
+	}
+
+	// Plan every change before writing anything.
+	existing, err := s.nasRepo.ListAddresses(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list NAS addresses", zap.Error(err))
+		return nil, err
+	}
+
+	var creates, updates []*entity.NAS
+	var previous []entity.NAS
+	for _, client := range clients {
+		if err := validateClient(client); err != nil {
+			logger.For(ctx, s.logger).Warn("Invalid client in clients.conf", zap.String("nasname", client.Address), zap.Error(err))
+			return nil, err
+		}
+
+		nas, err := s.nasRepo.GetByNASName(ctx, client.Address)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Error("Failed to look up NAS", zap.String("nasname", client.Address), zap.Error(err))
+			return nil, err
+		}
+
+		action := dto.ImportActionUpdate
+		if nas == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+			action = dto.ImportActionCreate
+			nas = &entity.NAS{NASName: client.Address}
+		}
+
+		if action == dto.ImportActionCreate {
+			// New clients must not overlap existing ones or each other.
+			if err := s.validateNASName(ctx, client.Address, 0, existing); err != nil {
+				logger.For(ctx, s.logger).Warn("Invalid client in clients.conf", zap.String("nasname", client.Address), zap.Error(err))
+				return nil, fmt.Errorf("%w: %w", ErrInvalidClient, err)
+			}
+			existing = append(existing, entity.NAS{NASName: client.Address})
+		}
+
+		before := *nas
+		fields := applyClient(nas, client)
+		switch {
+		case action == dto.ImportActionCreate:
+			creates = append(creates, nas)
+			resp.Created++
+		case len(fields) > 0:
+			updates = append(updates, nas)
+			previous = append(previous, before)
+			resp.Updated++
+		default:
+			action = dto.ImportActionUnchanged
+			resp.Unchanged++
+		}
+
+		resp.Changes = append(resp.Changes, dto.NASImportChange{
+			NASName: client.Address,
+			Action:  action,
+			Fields:  fields,
+		})
+	}
+
+	if dryRun {
+		return resp, nil
+	}
+
+	for _, nas := range creates {
+		if err := s.nasRepo.Create(ctx, nas); err != nil {
+			logger.For(ctx, s.logger).Error("Failed to create NAS from clients.conf", zap.String("nasname", nas.NASName), zap.Error(err))
+			return nil, err
+		}
+		if err := s.auditor.Record(ctx, nasChange(audit.ActionCreate, nil, nas)); err != nil {
+			return nil, err
+		}
+		if err := s.outbox.Add(ctx, nasCreated(nas)); err != nil {
+			return nil, err
+		}
+	}
+	for i, nas := range updates {
+		if err := s.nasRepo.Update(ctx, nas); err != nil {
+			logger.For(ctx, s.logger).Error("Failed to update NAS from clients.conf", zap.String("nasname", nas.NASName), zap.Error(err))
+			return nil, err
+		}
+		if err := s.auditor.Record(ctx, nasChange(audit.ActionUpdate, &previous[i], nas)); err != nil {
+			return nil, err
+		}
+		if err := s.outbox.Add(ctx, nasUpdated(nas)); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// validateClient enforces the same column limits as CreateNASRequest.
+func validateClient(client clientsconf.Client) error {
+	limits := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"nasname", client.Address, 128},
+		{"shortname", clientShortName(client), 32},
+		{"type", client.NASType, 30},
+		{"secret", client.Secret, 60},
+		{"server", client.VirtualServer, 64},
+	}
+	for _, limit := range limits {
+		if len(limit.value) > limit.max {
+			return fmt.Errorf("%w %s: %s exceeds %d characters", ErrInvalidClient, client.Address, limit.field, limit.max)
+		}
+	}
+	return nil
+}
+
+// clientShortName falls back to the block name, as FreeRADIUS does, unless
+// the block is simply named after its address.
+func clientShortName(client clientsconf.Client) string {
+	if client.ShortName != "" {
+		return client.ShortName
+	}
+	if address, err := clientsconf.NormalizeAddress(client.Name); err == nil && address == client.Address {
+		return ""
+	}
+	return client.Name
+}
+
+// applyClient copies the attributes set in a clients.conf block onto nas and
+// reports which fields changed. Attributes absent from the block leave the
+// stored value untouched.
+func applyClient(nas *entity.NAS, client clientsconf.Client) []dto.NASFieldChange {
+	var changes []dto.NASFieldChange
+
+	set := func(field string, current *string, value string, secret bool) {
+		if value == "" || *current == value {
+			return
+		}
+		change := dto.NASFieldChange{Field: field, Old: *current, New: value}
+		if secret {
+			change.Old, change.New = maskedSecret, maskedSecret
+			if nas.ID == 0 {
+				change.Old = ""
+			} else {
+				rotatedAt := time.Now()
+				nas.SecretRotatedAt = &rotatedAt
+			}
+		}
+		changes = append(changes, change)
+		*current = value
+	}
+
+	set("shortname", &nas.ShortName, clientShortName(client), false)
+	set("type", &nas.Type, client.NASType, false)
+	set("secret", &nas.Secret, client.Secret, true)
+	set("server", &nas.Server, client.VirtualServer, false)
+	set("require_ma", &nas.RequireMa, client.RequireMessageAuthenticator, false)
+	set("limit_proxy_state", &nas.LimitProxyState, client.LimitProxyState, false)
+
+	return changes
+}
+
+func (s *nasService) RevealNASSecret(ctx context.Context, id uint) (*dto.NASSecretResponse, error) {
+	nas, err := s.nasRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Warn("NAS not found", zap.Uint("id", id))
+			return nil, ErrNASNotFound
+		}
+		logger.For(ctx, s.logger).Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
 
-	nas, err := s.nasRepo.GetByID(id)
+	logger.For(ctx, s.logger).Warn("NAS secret revealed", zap.Uint("id", id), zap.String("nasname", nas.NASName))
+	return entityToSecretResponse(nas), nil
+}
+
+func (s *nasService) RotateNASSecret(ctx context.Context, id uint) (*dto.NASSecretResponse, error) {
+	logger.For(ctx, s.logger).Info("Rotating NAS secret", zap.Uint("id", id))
+
+	nas, err := s.nasRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("NAS not found", zap.Uint("id", id))
-			return errors.New("nas not found")
+			logger.For(ctx, s.logger).Warn("NAS not found", zap.Uint("id", id))
+			return nil, ErrNASNotFound
+		}
+		logger.For(ctx, s.logger).Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to generate NAS secret", zap.Error(err))
+		return nil, err
+	}
+
+	before := *nas
+	rotatedAt := time.Now()
+	nas.Secret = secret
+	nas.SecretRotatedAt = &rotatedAt
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.nasRepo.Update(txCtx, nas); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, nasChange(audit.ActionUpdate, &before, nas)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.NASSecretRotated{NASID: nas.ID})
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to store rotated NAS secret", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("NAS secret rotated", zap.Uint("id", id))
+	return entityToSecretResponse(nas), nil
+}
+
+func (s *nasService) ReencryptNASSecrets(ctx context.Context) (*dto.ReencryptNASSecretsResponse, error) {
+	logger.For(ctx, s.logger).Info("Re-encrypting NAS secrets")
+
+	count, err := s.nasRepo.ReencryptSecrets(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to re-encrypt NAS secrets", zap.Int("reencrypted", count), zap.Error(err))
+		return nil, err
+	}
+
+	return &dto.ReencryptNASSecretsResponse{Reencrypted: count}, nil
+}
+
+func (s *nasService) MatchNAS(ctx context.Context, ip string) (*dto.NASMatchResponse, error) {
+	logger.For(ctx, s.logger).Info("Matching NAS for IP", zap.String("ip", ip))
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || addr.Zone() != "" {
+		return nil, fmt.Errorf("%w %q: not an IP address", nasaddr.ErrInvalidAddress, ip)
+	}
+
+	nasList, err := s.nasRepo.ListAll(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list NAS for matching", zap.Error(err))
+		return nil, err
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	// Candidate IDs index into nasList.
+	candidates := make([]nasaddr.Candidate, 0, len(nasList))
+	for i, nas := range nasList {
+		address, err := nasaddr.Parse(nas.NASName)
+		if err != nil {
+			logger.For(ctx, s.logger).Warn("Skipping NAS with invalid nasname", zap.Uint("id", nas.ID), zap.Error(err))
+			continue
+		}
+		prefixes, err := nasaddr.Resolve(resolveCtx, s.resolver, address)
+		if err != nil {
+			logger.For(ctx, s.logger).Warn("Skipping NAS with unresolvable nasname", zap.Uint("id", nas.ID), zap.Error(err))
+			continue
+		}
+		for _, prefix := range prefixes {
+			candidates = append(candidates, nasaddr.Candidate{ID: uint(i), Prefix: prefix})
+		}
+	}
+
+	match, ok := nasaddr.Match(addr, candidates)
+	if !ok {
+		logger.For(ctx, s.logger).Info("No NAS matches IP", zap.String("ip", ip))
+		return nil, ErrNoMatchingNAS
+	}
+
+	nas := &nasList[match.ID]
+	return &dto.NASMatchResponse{
+		IP:            addr.Unmap().String(),
+		MatchedPrefix: match.Prefix.String(),
+		NAS:           *entityToResponse(nas),
+	}, nil
+}
+
+// checkNASName validates nasname against every stored NAS except excludeID.
+func (s *nasService) checkNASName(ctx context.Context, nasname string, excludeID uint) error {
+	others, err := s.nasRepo.ListAddresses(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list NAS addresses", zap.Error(err))
+		return err
+	}
+	return s.validateNASName(ctx, nasname, excludeID, others)
+}
+
+// validateNASName checks that nasname is an IP address, a CIDR prefix or a
+// resolvable hostname, and that it does not conflict with others, of any
+// tenant as nasname is unique across them. Stored hostnames are not
+// resolved, and a zero excludeID excludes nothing.
+func (s *nasService) validateNASName(ctx context.Context, nasname string, excludeID uint, others []entity.NAS) error {
+	for _, other := range others {
+		if other.NASName == nasname && (excludeID == 0 || other.ID != excludeID) {
+			return ErrNASNameTaken
 		}
-		s.logger.Error("Failed to get NAS", zap.Uint("id", id), zap.Error(err))
+	}
+
+	address, err := nasaddr.Parse(nasname)
+	if err != nil {
 		return err
 	}
 
-	if err := s.nasRepo.Delete(nas.ID); err != nil {
-		s.logger.Error("Failed to delete NAS", zap.Uint("id", id), zap.Error(err))
+	resolveCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	prefixes, err := nasaddr.Resolve(resolveCtx, s.resolver, address)
+	if err != nil {
 		return err
 	}
 
-	s.logger.Info("NAS deleted successfully", zap.Uint("id", id))
+	for _, other := range others {
+		if excludeID != 0 && other.ID == excludeID {
+			continue
+		}
+		otherAddress, err := nasaddr.Parse(other.NASName)
+		if err != nil || otherAddress.Kind == nasaddr.KindHostname {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if !nasaddr.Conflicts(prefix, otherAddress.Prefix) {
+				continue
+			}
+			// The clients of another tenant are not its to see
+			if !visible(ctx, other) {
+				return ErrOverlappingNAS
+			}
+			return fmt.Errorf("%w: %s overlaps %s", ErrOverlappingNAS, nasname, other.NASName)
+		}
+	}
+
+	return nil
+}
+
+// visible reports whether the tenant of ctx may see nas.
+func visible(ctx context.Context, nas entity.NAS) bool {
+	scope, ok := tenant.From(ctx)
+	return !ok || nas.TenantID != nil && *nas.TenantID == scope.ID
+}
+
+func (s *nasService) GetHealthCheckTargets(ctx context.Context) ([]dto.NASHealthTarget, error) {
+	nasList, err := s.nasRepo.ListHealthCheckTargets(ctx)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list NAS health check targets", zap.Error(err))
+		return nil, err
+	}
+
+	targets := make([]dto.NASHealthTarget, 0, len(nasList))
+	for _, nas := range nasList {
+		targets = append(targets, dto.NASHealthTarget{
+			ID:           nas.ID,
+			NASName:      nas.NASName,
+			Secret:       nas.Secret,
+			Port:         nas.HealthCheckPort,
+			HealthStatus: healthStatus(nas.HealthStatus),
+		})
+	}
+	return targets, nil
+}
+
+// RecordNASHealth stores the outcome of a health check. rtt is ignored when
+// the NAS is down.
+func (s *nasService) RecordNASHealth(ctx context.Context, id uint, up bool, rtt time.Duration, checkedAt time.Time) error {
+	status := entity.HealthStatusDown
+	var lastSeenAt *time.Time
+	var rttMs int64
+	if up {
+		status = entity.HealthStatusUp
+		lastSeenAt = &checkedAt
+		rttMs = rtt.Milliseconds()
+	}
+
+	if err := s.nasRepo.UpdateHealth(ctx, id, status, lastSeenAt, checkedAt, rttMs); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to record NAS health", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
 	return nil
 }
 
+func generateSecret() (string, error) {
+	alphabetSize := big.NewInt(int64(len(secretAlphabet)))
+	secret := make([]byte, generatedSecretLength)
+	for i := range secret {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		secret[i] = secretAlphabet[n.Int64()]
+	}
+	return string(secret), nil
+}
+
+// Helper function to convert entity to a clients.conf client
+func entityToClient(nas *entity.NAS) clientsconf.Client {
+	return clientsconf.Client{
+		Address:                     nas.NASName,
+		Secret:                      nas.Secret,
+		ShortName:                   nas.ShortName,
+		NASType:                     nas.Type,
+		VirtualServer:               nas.Server,
+		Description:                 nas.Description,
+		RequireMessageAuthenticator: nas.RequireMa,
+		LimitProxyState:             nas.LimitProxyState,
+	}
+}
+
+func nasChange(action string, before, after *entity.NAS) audit.Change {
+	change := audit.Change{EntityType: audit.EntityNAS, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+	}
+	return change
+}
+
+func nasCreated(nas *entity.NAS) events.NASCreated {
+	return events.NASCreated{NASID: nas.ID, NASName: nas.NASName, ShortName: nas.ShortName, Type: nas.Type}
+}
+
+func nasUpdated(nas *entity.NAS) events.NASUpdated {
+	return events.NASUpdated{NASID: nas.ID, NASName: nas.NASName, ShortName: nas.ShortName, Type: nas.Type}
+}
+
 // Helper function to convert entity to response
 func entityToResponse(nas *entity.NAS) *dto.NASResponse {
 	ports := nas.Ports
@@ -208,13 +827,46 @@ func entityToResponse(nas *entity.NAS) *dto.NASResponse {
 		ShortName:       nas.ShortName,
 		Type:            nas.Type,
 		Ports:           &ports,
-		Secret:          nas.Secret,
+		Secret:          maskedSecret,
 		Server:          nas.Server,
 		Community:       nas.Community,
 		Description:     nas.Description,
 		RequireMa:       nas.RequireMa,
 		LimitProxyState: nas.LimitProxyState,
+		SecretRotatedAt: formatTimestamp(nas.SecretRotatedAt),
+		HealthCheck:     nas.HealthCheck,
+		HealthCheckPort: nas.HealthCheckPort,
+		HealthStatus:    healthStatus(nas.HealthStatus),
+		LastSeenAt:      formatTimestamp(nas.LastSeenAt),
+		LastCheckedAt:   formatTimestamp(nas.LastCheckedAt),
+		TenantID:        nas.TenantID,
+		LastRTTMs:       nas.LastRTTMs,
 		CreatedAt:       nas.CreatedAt.String(),
 		UpdatedAt:       nas.UpdatedAt.String(),
 	}
 }
+
+// Helper function to convert entity to a plaintext secret response
+func entityToSecretResponse(nas *entity.NAS) *dto.NASSecretResponse {
+	return &dto.NASSecretResponse{
+		ID:              nas.ID,
+		NASName:         nas.NASName,
+		Secret:          nas.Secret,
+		SecretRotatedAt: formatTimestamp(nas.SecretRotatedAt),
+	}
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+func healthStatus(status string) string {
+	if status == "" {
+		return entity.HealthStatusUnknown
+	}
+	return status
+}
@@ -1,15 +1,26 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/nasaddr"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -18,19 +29,20 @@ func TestNASService_CreateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByNASName", req.NASName).Return(nil, gorm.ErrRecordNotFound)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.NAS")).Return(nil).Run(func(args mock.Arguments) {
-			nas := args.Get(0).(*nasEntity.NAS)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil).Run(func(args mock.Arguments) {
+			nas := args.Get(1).(*nasEntity.NAS)
 			nas.ID = 1
 		})
 
 		// When
-		response, err := service.CreateNAS(req)
+		response, err := service.CreateNAS(context.Background(), req)
 
 		// Then
 		assert.NoError(t, err)
@@ -39,6 +51,7 @@ func TestNASService_CreateNAS(t *testing.T) {
 		assert.Equal(t, req.NASName, response.NASName)
 		assert.Equal(t, req.ShortName, response.ShortName)
 		assert.Equal(t, req.Type, response.Type)
+		assert.Equal(t, []events.Event{events.NASCreated{NASID: 1, NASName: req.NASName, ShortName: req.ShortName, Type: req.Type}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -46,16 +59,18 @@ func TestNASService_CreateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateNASRequestFixture()
 		existingNAS := testutil.CreateNASFixture()
 
+		existingNAS.NASName = req.NASName
+
 		// Mock expectations
-		mockRepo.On("GetByNASName", req.NASName).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS}, nil)
 
 		// When
-		response, err := service.CreateNAS(req)
+		response, err := service.CreateNAS(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -68,15 +83,15 @@ func TestNASService_CreateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByNASName", req.NASName).Return(nil, errors.New("database error"))
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.CreateNAS(req)
+		response, err := service.CreateNAS(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -89,16 +104,16 @@ func TestNASService_CreateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByNASName", req.NASName).Return(nil, gorm.ErrRecordNotFound)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.NAS")).Return(errors.New("create failed"))
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(errors.New("create failed"))
 
 		// When
-		response, err := service.CreateNAS(req)
+		response, err := service.CreateNAS(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -111,21 +126,21 @@ func TestNASService_CreateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateNASRequestFixture()
 		req.Ports = nil // Explicitly set to nil
 
 		// Mock expectations
-		mockRepo.On("GetByNASName", req.NASName).Return(nil, gorm.ErrRecordNotFound)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.NAS")).Return(nil).Run(func(args mock.Arguments) {
-			nas := args.Get(0).(*nasEntity.NAS)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil).Run(func(args mock.Arguments) {
+			nas := args.Get(1).(*nasEntity.NAS)
 			nas.ID = 1
 			assert.Equal(t, 0, nas.Ports) // Should be 0 when nil pointer
 		})
 
 		// When
-		response, err := service.CreateNAS(req)
+		response, err := service.CreateNAS(context.Background(), req)
 
 		// Then
 		assert.NoError(t, err)
@@ -139,17 +154,17 @@ func TestNASService_GetNASByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		nas := testutil.CreateNASFixture()
 		nas.ID = nasID
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nas, nil)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nas, nil)
 
 		// When
-		response, err := service.GetNASByID(nasID)
+		response, err := service.GetNASByID(context.Background(), nasID)
 
 		// Then
 		assert.NoError(t, err)
@@ -157,6 +172,7 @@ func TestNASService_GetNASByID(t *testing.T) {
 		assert.Equal(t, nasID, response.ID)
 		assert.Equal(t, nas.NASName, response.NASName)
 		assert.Equal(t, nas.ShortName, response.ShortName)
+		assert.Equal(t, "********", response.Secret)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -164,15 +180,15 @@ func TestNASService_GetNASByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.GetNASByID(nasID)
+		response, err := service.GetNASByID(context.Background(), nasID)
 
 		// Then
 		assert.Error(t, err)
@@ -185,15 +201,15 @@ func TestNASService_GetNASByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.GetNASByID(nasID)
+		response, err := service.GetNASByID(context.Background(), nasID)
 
 		// Then
 		assert.Error(t, err)
@@ -208,7 +224,7 @@ func TestNASService_ListNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &nasDto.NASFilter{
 			Page:     1,
@@ -224,16 +240,16 @@ func TestNASService_ListNAS(t *testing.T) {
 		nasList[1].NASName = "nas-02"
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(nasList, int64(2), nil)
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nasList, pagination.Exact(2), nil)
 
 		// When
-		response, err := service.ListNAS(filter)
+		response, err := service.ListNAS(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Len(t, response.Data, 2)
-		assert.Equal(t, int64(2), response.Total)
+		assert.Equal(t, int64(2), *response.Total)
 		assert.Equal(t, 1, response.Page)
 		assert.Equal(t, 10, response.PageSize)
 		mockRepo.AssertExpectations(t)
@@ -243,7 +259,7 @@ func TestNASService_ListNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &nasDto.NASFilter{
 			Page:     0,
@@ -256,10 +272,10 @@ func TestNASService_ListNAS(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", expectedFilter).Return([]nasEntity.NAS{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]nasEntity.NAS{}, pagination.Exact(0), nil)
 
 		// When
-		response, err := service.ListNAS(filter)
+		response, err := service.ListNAS(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
@@ -273,7 +289,7 @@ func TestNASService_ListNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &nasDto.NASFilter{
 			Page:     1,
@@ -286,10 +302,10 @@ func TestNASService_ListNAS(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", expectedFilter).Return([]nasEntity.NAS{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]nasEntity.NAS{}, pagination.Exact(0), nil)
 
 		// When
-		response, err := service.ListNAS(filter)
+		response, err := service.ListNAS(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
@@ -302,7 +318,7 @@ func TestNASService_ListNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &nasDto.NASFilter{
 			Page:     1,
@@ -316,15 +332,15 @@ func TestNASService_ListNAS(t *testing.T) {
 		}
 
 		// Mock expectations - total 10 items, page size 3 = 4 total pages (3+3+3+1)
-		mockRepo.On("GetAll", filter).Return(nasList, int64(10), nil)
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nasList, pagination.Exact(10), nil)
 
 		// When
-		response, err := service.ListNAS(filter)
+		response, err := service.ListNAS(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
-		assert.Equal(t, 4, response.TotalPage)
+		assert.Equal(t, 4, *response.TotalPage)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -332,7 +348,7 @@ func TestNASService_ListNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &nasDto.NASFilter{
 			Page:     1,
@@ -340,10 +356,10 @@ func TestNASService_ListNAS(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(nil, int64(0), errors.New("database error"))
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nil, pagination.Page{}, errors.New("database error"))
 
 		// When
-		response, err := service.ListNAS(filter)
+		response, err := service.ListNAS(context.Background(), filter)
 
 		// Then
 		assert.Error(t, err)
@@ -358,7 +374,9 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		mockAuditor := &testutil.MockAuditRecorder{}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, mockAuditor, logger)
 
 		nasID := uint(1)
 		existingNAS := testutil.CreateNASFixture()
@@ -371,11 +389,12 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		req.Description = "New description"
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(existingNAS, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.NAS")).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS}, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil)
 
 		// When
-		response, err := service.UpdateNAS(nasID, req)
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
 
 		// Then
 		assert.NoError(t, err)
@@ -383,23 +402,65 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		assert.Equal(t, nasID, response.ID)
 		assert.Equal(t, "new-short", response.ShortName)
 		assert.Equal(t, "New description", response.Description)
+		assert.Equal(t, []events.Event{events.NASUpdated{NASID: nasID, NASName: existingNAS.NASName, ShortName: "new-short", Type: existingNAS.Type}}, mockOutbox.Events)
+
+		require.Len(t, mockAuditor.Changes, 1)
+		change := mockAuditor.Changes[0]
+		assert.Equal(t, audit.EntityNAS, change.EntityType)
+		assert.Equal(t, "1", change.EntityID)
+		assert.Equal(t, audit.ActionUpdate, change.Action)
+		fields, err := audit.Diff(change.Before, change.After)
+		require.NoError(t, err)
+		assert.Equal(t, audit.FieldChange{Before: "old-short", After: "new-short"}, fields["shortname"])
+		assert.Equal(t, audit.FieldChange{Before: "Old description", After: "New description"}, fields["description"])
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("should not publish the update when the audit entry fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		mockOutbox := &testutil.MockOutbox{}
+		mockAuditor := &testutil.MockAuditRecorder{
+			RecordFn: func(ctx context.Context, changes ...audit.Change) error {
+				return errors.New("audit unavailable")
+			},
+		}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, mockAuditor, logger)
+
+		nasID := uint(1)
+		existingNAS := testutil.CreateNASFixture()
+		existingNAS.ID = nasID
+		req := testutil.CreateUpdateNASRequestFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS}, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil)
+
+		// When
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
+
+		// Then
+		assert.EqualError(t, err, "audit unavailable")
+		assert.Nil(t, response)
+		assert.Empty(t, mockOutbox.Events)
+	})
+
 	t.Run("should return error when NAS not found", func(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(999)
 		req := testutil.CreateUpdateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.UpdateNAS(nasID, req)
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -412,7 +473,7 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		existingNAS := testutil.CreateNASFixture()
@@ -425,13 +486,13 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(existingNAS, nil)
-		mockRepo.On("Update", mock.MatchedBy(func(nas *nasEntity.NAS) bool {
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(existingNAS, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(nas *nasEntity.NAS) bool {
 			return nas.ShortName == "updated-short" && nas.Type == "original-type"
 		})).Return(nil)
 
 		// When
-		response, err := service.UpdateNAS(nasID, req)
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
 
 		// Then
 		assert.NoError(t, err)
@@ -444,16 +505,16 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		req := testutil.CreateUpdateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.UpdateNAS(nasID, req)
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -466,7 +527,7 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		existingNAS := testutil.CreateNASFixture()
@@ -475,11 +536,12 @@ func TestNASService_UpdateNAS(t *testing.T) {
 		req := testutil.CreateUpdateNASRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(existingNAS, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.NAS")).Return(errors.New("update failed"))
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS}, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(errors.New("update failed"))
 
 		// When
-		response, err := service.UpdateNAS(nasID, req)
+		response, err := service.UpdateNAS(context.Background(), nasID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -494,21 +556,23 @@ func TestNASService_DeleteNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		nas := testutil.CreateNASFixture()
 		nas.ID = nasID
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nas, nil)
-		mockRepo.On("Delete", nasID).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nas, nil)
+		mockRepo.On("Delete", mock.Anything, nasID).Return(nil)
 
 		// When
-		err := service.DeleteNAS(nasID)
+		err := service.DeleteNAS(context.Background(), nasID)
 
 		// Then
 		assert.NoError(t, err)
+		assert.Equal(t, []events.Event{events.NASDeleted{NASID: nasID, NASName: nas.NASName}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -516,15 +580,15 @@ func TestNASService_DeleteNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		err := service.DeleteNAS(nasID)
+		err := service.DeleteNAS(context.Background(), nasID)
 
 		// Then
 		assert.Error(t, err)
@@ -536,15 +600,15 @@ func TestNASService_DeleteNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nil, errors.New("database error"))
 
 		// When
-		err := service.DeleteNAS(nasID)
+		err := service.DeleteNAS(context.Background(), nasID)
 
 		// Then
 		assert.Error(t, err)
@@ -556,18 +620,18 @@ func TestNASService_DeleteNAS(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockNASRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewNASService(mockRepo, logger)
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		nasID := uint(1)
 		nas := testutil.CreateNASFixture()
 		nas.ID = nasID
 
 		// Mock expectations
-		mockRepo.On("GetByID", nasID).Return(nas, nil)
-		mockRepo.On("Delete", nasID).Return(errors.New("delete failed"))
+		mockRepo.On("GetByID", mock.Anything, nasID).Return(nas, nil)
+		mockRepo.On("Delete", mock.Anything, nasID).Return(errors.New("delete failed"))
 
 		// When
-		err := service.DeleteNAS(nasID)
+		err := service.DeleteNAS(context.Background(), nasID)
 
 		// Then
 		assert.Error(t, err)
@@ -575,3 +639,807 @@ func TestNASService_DeleteNAS(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestNASService_GenerateClientsConf(t *testing.T) {
+	t.Run("should render every NAS as a client block", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+		nas.NASName = "192.0.2.10"
+		nas.RequireMa = "yes"
+		nas.LimitProxyState = "no"
+
+		// Mock expectations
+		mockRepo.On("ListAll", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*nas}, nil)
+
+		// When
+		data, err := service.GenerateClientsConf(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		conf := string(data)
+		assert.Contains(t, conf, "client 192.0.2.10 {")
+		assert.Contains(t, conf, "\tipaddr = 192.0.2.10\n")
+		assert.Contains(t, conf, "\tsecret = \"testing123\"\n")
+		assert.Contains(t, conf, "\tshortname = \"test-nas\"\n")
+		assert.Contains(t, conf, "\tnas_type = \"other\"\n")
+		assert.Contains(t, conf, "\trequire_message_authenticator = yes\n")
+		assert.Contains(t, conf, "\tlimit_proxy_state = no\n")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("ListAll", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		// When
+		data, err := service.GenerateClientsConf(context.Background())
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error for invalid require_ma value", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+		nas.RequireMa = "maybe"
+
+		// Mock expectations
+		mockRepo.On("ListAll", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*nas}, nil)
+
+		// When
+		data, err := service.GenerateClientsConf(context.Background())
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+		assert.Contains(t, err.Error(), "require_message_authenticator")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_WriteClientsConf(t *testing.T) {
+	t.Run("should write clients.conf to the given path", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		path := filepath.Join(t.TempDir(), "clients.conf")
+
+		// Mock expectations
+		mockRepo.On("ListAll", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*testutil.CreateNASFixture()}, nil)
+
+		// When
+		err := service.WriteClientsConf(context.Background(), path)
+
+		// Then
+		assert.NoError(t, err)
+		data, readErr := os.ReadFile(path)
+		assert.NoError(t, readErr)
+		assert.Contains(t, string(data), "client 192.168.1.10 {")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_ImportClientsConf(t *testing.T) {
+	conf := []byte(`
+client new-bras {
+	ipaddr = 192.0.2.20
+	secret = newsecret
+	nas_type = cisco
+}
+
+client 192.0.2.21 {
+	secret = changed
+	require_message_authenticator = yes
+}
+
+client 192.0.2.22 {
+	secret = same
+}
+`)
+
+	t.Run("should report create, update and unchanged on dry run without writing", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		changed := testutil.CreateNASFixture()
+		changed.NASName = "192.0.2.21"
+		changed.Secret = "original"
+		changed.RequireMa = "auto"
+		same := testutil.CreateNASFixture()
+		same.ID = 2
+		same.NASName = "192.0.2.22"
+		same.Secret = "same"
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*changed, *same}, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.20").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.21").Return(changed, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.22").Return(same, nil)
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), conf, true)
+
+		// Then
+		assert.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 1, resp.Updated)
+		assert.Equal(t, 1, resp.Unchanged)
+		assert.Len(t, resp.Changes, 3)
+
+		assert.Equal(t, nasDto.ImportActionCreate, resp.Changes[0].Action)
+		assert.Contains(t, resp.Changes[0].Fields, nasDto.NASFieldChange{Field: "shortname", New: "new-bras"})
+		assert.Contains(t, resp.Changes[0].Fields, nasDto.NASFieldChange{Field: "type", New: "cisco"})
+
+		assert.Equal(t, nasDto.ImportActionUpdate, resp.Changes[1].Action)
+		assert.Equal(t, []nasDto.NASFieldChange{
+			{Field: "secret", Old: "********", New: "********"},
+			{Field: "require_ma", Old: "auto", New: "yes"},
+		}, resp.Changes[1].Fields)
+
+		assert.Equal(t, nasDto.ImportActionUnchanged, resp.Changes[2].Action)
+		assert.Empty(t, resp.Changes[2].Fields)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should upsert clients when not a dry run", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
+
+		changed := testutil.CreateNASFixture()
+		changed.NASName = "192.0.2.21"
+		same := testutil.CreateNASFixture()
+		same.NASName = "192.0.2.22"
+		same.Secret = "same"
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*changed, *same}, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.20").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.21").Return(changed, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.22").Return(same, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(nas *nasEntity.NAS) bool {
+			return nas.NASName == "192.0.2.20" && nas.Secret == "newsecret" && nas.Type == "cisco"
+		})).Return(nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(nas *nasEntity.NAS) bool {
+			return nas.NASName == "192.0.2.21" && nas.Secret == "changed" && nas.RequireMa == "yes"
+		})).Return(nil)
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), conf, false)
+
+		// Then
+		assert.NoError(t, err)
+		assert.False(t, resp.DryRun)
+		if assert.Len(t, mockOutbox.Events, 2) {
+			assert.Equal(t, events.TypeNASCreated, mockOutbox.Events[0].EventType())
+			assert.Equal(t, events.TypeNASUpdated, mockOutbox.Events[1].EventType())
+		}
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNumberOfCalls(t, "Create", 1)
+		mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("should look up and write every client in one transaction", func(t *testing.T) {
+		// Setup
+		type txKey struct{}
+		inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		txManager := &testutil.MockTransactionManager{
+			WithinTransactionFn: func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(context.WithValue(ctx, txKey{}, true))
+			},
+		}
+		service := NewNASService(mockRepo, txManager, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		changed := testutil.CreateNASFixture()
+		changed.NASName = "192.0.2.21"
+		same := testutil.CreateNASFixture()
+		same.NASName = "192.0.2.22"
+		same.Secret = "same"
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", inTx).Return([]nasEntity.NAS{*changed, *same}, nil)
+		mockRepo.On("GetByNASName", inTx, "192.0.2.20").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByNASName", inTx, "192.0.2.21").Return(changed, nil)
+		mockRepo.On("GetByNASName", inTx, "192.0.2.22").Return(same, nil)
+		mockRepo.On("Create", inTx, mock.Anything).Return(nil)
+		mockRepo.On("Update", inTx, mock.Anything).Return(nil)
+
+		// When
+		_, err := service.ImportClientsConf(context.Background(), conf, false)
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return parse error for malformed file", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), []byte("client broken {\n\tipaddr = 10.0.0.1\n"), false)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject clients that exceed column limits before writing", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		data := []byte("client a {\n\tipaddr = 10.0.0.1\n\tsecret = x\n\tshortname = " +
+			"this-short-name-is-far-too-long-for-the-column\n}\n")
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), data, false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidClient)
+		assert.Nil(t, resp)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when lookup fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "192.0.2.20").Return(nil, errors.New("database error"))
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), conf, true)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_RevealNASSecret(t *testing.T) {
+	t.Run("should return plaintext secret", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, nas.ID).Return(nas, nil)
+
+		// When
+		response, err := service.RevealNASSecret(context.Background(), nas.ID)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, "testing123", response.Secret)
+		assert.Equal(t, nas.NASName, response.NASName)
+		assert.Nil(t, response.SecretRotatedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when NAS not found", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		response, err := service.RevealNASSecret(context.Background(), 999)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Equal(t, "nas not found", err.Error())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_RotateNASSecret(t *testing.T) {
+	t.Run("should generate a strong secret and record rotation time", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, nas.ID).Return(nas, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *nasEntity.NAS) bool {
+			return updated.Secret != "testing123" && updated.SecretRotatedAt != nil
+		})).Return(nil)
+
+		// When
+		response, err := service.RotateNASSecret(context.Background(), nas.ID)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, response.Secret, 32)
+		assert.NotEqual(t, "testing123", response.Secret)
+		assert.NotNil(t, response.SecretRotatedAt)
+		assert.Equal(t, []events.Event{events.NASSecretRotated{NASID: nas.ID}}, mockOutbox.Events)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when update fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, nas.ID).Return(nas, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(errors.New("update failed"))
+
+		// When
+		response, err := service.RotateNASSecret(context.Background(), nas.ID)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_UpdateNAS_SecretRotation(t *testing.T) {
+	t.Run("should record rotation time when secret changes", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		nas := testutil.CreateNASFixture()
+		req := &nasDto.UpdateNASRequest{Secret: "new-secret"}
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, nas.ID).Return(nas, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil)
+
+		// When
+		response, err := service.UpdateNAS(context.Background(), nas.ID, req)
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotNil(t, response.SecretRotatedAt)
+		assert.Equal(t, "********", response.Secret)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_ReencryptNASSecrets(t *testing.T) {
+	t.Run("should report number of re-encrypted secrets", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("ReencryptSecrets", mock.Anything, mock.Anything).Return(3, nil)
+
+		// When
+		response, err := service.ReencryptNASSecrets(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, 3, response.Reencrypted)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("ReencryptSecrets", mock.Anything, mock.Anything).Return(1, errors.New("unknown key"))
+
+		// When
+		response, err := service.ReencryptNASSecrets(context.Background())
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_GetHealthCheckTargets(t *testing.T) {
+	t.Run("should map NAS entries to health check targets", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Given
+		nas := testutil.CreateNASFixture()
+		nas.HealthCheck = true
+		nas.HealthCheckPort = 1813
+		nas.HealthStatus = nasEntity.HealthStatusUp
+		fresh := testutil.CreateNASFixture()
+		fresh.ID = 2
+		fresh.HealthCheck = true
+
+		// Mock expectations
+		mockRepo.On("ListHealthCheckTargets", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*nas, *fresh}, nil)
+
+		// When
+		targets, err := service.GetHealthCheckTargets(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, targets, 2)
+		assert.Equal(t, nas.ID, targets[0].ID)
+		assert.Equal(t, nas.Secret, targets[0].Secret)
+		assert.Equal(t, 1813, targets[0].Port)
+		assert.Equal(t, nasEntity.HealthStatusUp, targets[0].HealthStatus)
+		assert.Equal(t, nasEntity.HealthStatusUnknown, targets[1].HealthStatus)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("ListHealthCheckTargets", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		// When
+		targets, err := service.GetHealthCheckTargets(context.Background())
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, targets)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNASService_RecordNASHealth(t *testing.T) {
+	checkedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should record up state with last seen and rtt", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("UpdateHealth", mock.Anything, uint(1), nasEntity.HealthStatusUp, &checkedAt, checkedAt, int64(15)).Return(nil)
+
+		// When
+		err := service.RecordNASHealth(context.Background(), 1, true, 15*time.Millisecond, checkedAt)
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should record down state without touching last seen", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewNASService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
+
+		// Mock expectations
+		mockRepo.On("UpdateHealth", mock.Anything, uint(1), nasEntity.HealthStatusDown, (*time.Time)(nil), checkedAt, int64(0)).Return(nil)
+
+		// When
+		err := service.RecordNASHealth(context.Background(), 1, false, 15*time.Millisecond, checkedAt)
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func newServiceWithResolver(repo *testutil.MockNASRepository, resolver nasaddr.Resolver) NASService {
+	service := NewNASService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger()).(*nasService)
+	service.resolver = resolver
+	return service
+}
+
+func TestNASService_CreateNAS_AddressValidation(t *testing.T) {
+	existing := []nasEntity.NAS{
+		{ID: 1, NASName: "10.0.0.0/16"},
+		{ID: 2, NASName: "192.0.2.1"},
+		{ID: 3, NASName: "legacy.example.com"},
+	}
+	resolver := fakeResolver{
+		"bras.example.com": {netip.MustParseAddr("192.0.2.50")},
+		"dup.example.com":  {netip.MustParseAddr("192.0.2.1")},
+	}
+
+	tests := []struct {
+		name    string
+		nasname string
+		wantErr error
+	}{
+		{name: "accepts a host inside an existing network", nasname: "10.0.5.1"},
+		{name: "accepts a non-overlapping network", nasname: "10.1.0.0/16"},
+		{name: "accepts a resolvable hostname", nasname: "bras.example.com"},
+		{name: "rejects an invalid IP", nasname: "10.0.0.256", wantErr: nasaddr.ErrInvalidAddress},
+		{name: "rejects host bits in a prefix", nasname: "10.2.0.1/16", wantErr: nasaddr.ErrInvalidAddress},
+		{name: "rejects an unresolvable hostname", nasname: "missing.example.com", wantErr: nasaddr.ErrUnresolvable},
+		{name: "rejects an overlapping network", nasname: "10.0.128.0/17", wantErr: ErrOverlappingNAS},
+		{name: "rejects the same host in another notation", nasname: "192.0.2.1/32", wantErr: ErrOverlappingNAS},
+		{name: "rejects a hostname resolving to an existing host", nasname: "dup.example.com", wantErr: ErrOverlappingNAS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRepo := &testutil.MockNASRepository{}
+			service := newServiceWithResolver(mockRepo, resolver)
+
+			req := testutil.CreateNASRequestFixture()
+			req.NASName = tt.nasname
+
+			// Mock expectations
+			mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return(existing, nil)
+			if tt.wantErr == nil {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil)
+			}
+
+			// When
+			response, err := service.CreateNAS(context.Background(), req)
+
+			// Then
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, response)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.nasname, response.NASName)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNASService_CreateNAS_OtherTenants(t *testing.T) {
+	otherTenant := uint(2)
+	existing := []nasEntity.NAS{{ID: 7, NASName: "10.0.0.0/16", TenantID: &otherTenant}}
+	ctx := tenant.WithScope(context.Background(), tenant.Scope{ID: 1, Realm: "isp-a.example"})
+
+	t.Run("should reject a nasname another tenant uses", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, fakeResolver{})
+		req := testutil.CreateNASRequestFixture()
+		req.NASName = "10.0.0.0/16"
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return(existing, nil)
+
+		// When
+		response, err := service.CreateNAS(ctx, req)
+
+		// Then
+		assert.ErrorIs(t, err, ErrNASNameTaken)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not name the NAS of another tenant it overlaps", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, fakeResolver{})
+		req := testutil.CreateNASRequestFixture()
+		req.NASName = "10.0.0.0/8"
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return(existing, nil)
+
+		// When
+		response, err := service.CreateNAS(ctx, req)
+
+		// Then
+		assert.ErrorIs(t, err, ErrOverlappingNAS)
+		assert.NotContains(t, err.Error(), "10.0.0.0/16")
+		assert.Nil(t, response)
+	})
+}
+
+func TestNASService_UpdateNAS_AddressValidation(t *testing.T) {
+	t.Run("should not treat the NAS itself as an overlap", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, fakeResolver{})
+
+		existingNAS := testutil.CreateNASFixture()
+		existingNAS.NASName = "10.0.0.0/16"
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, existingNAS.ID).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS}, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.NAS")).Return(nil)
+
+		// When
+		response, err := service.UpdateNAS(context.Background(), existingNAS.ID, &nasDto.UpdateNASRequest{NASName: "10.0.0.0/15"})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.0/15", response.NASName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a nasname overlapping another NAS", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, fakeResolver{})
+
+		existingNAS := testutil.CreateNASFixture()
+		other := nasEntity.NAS{ID: 2, NASName: "10.0.0.0/16"}
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, existingNAS.ID).Return(existingNAS, nil)
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{*existingNAS, other}, nil)
+
+		// When
+		response, err := service.UpdateNAS(context.Background(), existingNAS.ID, &nasDto.UpdateNASRequest{NASName: "10.0.0.0/8"})
+
+		// Then
+		assert.ErrorIs(t, err, ErrOverlappingNAS)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNASService_ImportClientsConf_Overlap(t *testing.T) {
+	t.Run("should reject overlapping networks within the file", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, fakeResolver{})
+
+		data := []byte("client a {\n\tipaddr = 10.0.0.0/16\n\tsecret = a\n}\n" +
+			"client b {\n\tipaddr = 10.0.0.0/8\n\tsecret = b\n}\n")
+
+		// Mock expectations
+		mockRepo.On("ListAddresses", mock.Anything, mock.Anything).Return([]nasEntity.NAS{}, nil)
+		mockRepo.On("GetByNASName", mock.Anything, "10.0.0.0/16").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByNASName", mock.Anything, "10.0.0.0/8").Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		resp, err := service.ImportClientsConf(context.Background(), data, false)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidClient)
+		assert.ErrorIs(t, err, ErrOverlappingNAS)
+		assert.Nil(t, resp)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNASService_MatchNAS(t *testing.T) {
+	nasList := []nasEntity.NAS{
+		{ID: 1, NASName: "10.0.0.0/8", Secret: "network"},
+		{ID: 2, NASName: "10.1.2.3", Secret: "host"},
+		{ID: 3, NASName: "radius.example.com", Secret: "named"},
+		{ID: 4, NASName: "not a nasname", Secret: "broken"},
+	}
+	resolver := fakeResolver{
+		"radius.example.com": {netip.MustParseAddr("2001:db8::10")},
+	}
+
+	tests := []struct {
+		name   string
+		ip     string
+		id     uint
+		prefix string
+	}{
+		{name: "network", ip: "10.200.0.1", id: 1, prefix: "10.0.0.0/8"},
+		{name: "longest prefix", ip: "10.1.2.3", id: 2, prefix: "10.1.2.3/32"},
+		{name: "resolved hostname", ip: "2001:db8::10", id: 3, prefix: "2001:db8::10/128"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should match "+tt.name, func(t *testing.T) {
+			// Setup
+			mockRepo := &testutil.MockNASRepository{}
+			service := newServiceWithResolver(mockRepo, resolver)
+
+			// Mock expectations
+			mockRepo.On("ListAll", mock.Anything, mock.Anything).Return(nasList, nil)
+
+			// When
+			response, err := service.MatchNAS(context.Background(), tt.ip)
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.id, response.NAS.ID)
+			assert.Equal(t, tt.prefix, response.MatchedPrefix)
+			assert.Equal(t, "********", response.NAS.Secret)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("should return ErrNoMatchingNAS when nothing matches", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, resolver)
+
+		// Mock expectations
+		mockRepo.On("ListAll", mock.Anything, mock.Anything).Return(nasList, nil)
+
+		// When
+		response, err := service.MatchNAS(context.Background(), "192.0.2.1")
+
+		// Then
+		assert.ErrorIs(t, err, ErrNoMatchingNAS)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should reject an invalid IP", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockNASRepository{}
+		service := newServiceWithResolver(mockRepo, resolver)
+
+		// When
+		response, err := service.MatchNAS(context.Background(), "10.0.0")
+
+		// Then
+		assert.ErrorIs(t, err, nasaddr.ErrInvalidAddress)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "ListAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/service"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// maxConcurrentProbes bounds the number of Status-Server probes in flight.
+const maxConcurrentProbes = 16
+
+// Scheduler registers periodic tasks.
+type Scheduler interface {
+	Register(cronspec string, task *asynq.Task, opts ...asynq.Option) error
+}
+
+type HealthWorker struct {
+	nasService service.NASService
+	prober     Prober
+	notifier   StatusNotifier
+	txManager  database.TransactionManagerI
+	logger     *zap.Logger
+	cfg        *config.Config
+}
+
+func NewHealthWorker(
+	nasService service.NASService,
+	prober Prober,
+	notifier StatusNotifier,
+	txManager database.TransactionManagerI,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *HealthWorker {
+	return &HealthWorker{
+		nasService: nasService,
+		prober:     prober,
+		notifier:   notifier,
+		txManager:  txManager,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+// RegisterPeriodicTasks schedules the health check. The task is unique for one
+// interval so that running several workers does not multiply the probes.
+func (w *HealthWorker) RegisterPeriodicTasks(scheduler Scheduler) error {
+	interval := w.cfg.Worker.NASHealthInterval
+	if interval <= 0 {
+		w.logger.Info("NAS health check disabled")
+		return nil
+	}
+
+	task := asynq.NewTask(TypeCheckNASHealth, nil)
+	return scheduler.Register(
+		fmt.Sprintf("@every %s", interval),
+		task,
+		asynq.Queue("default"),
+		asynq.MaxRetry(0),
+		asynq.Timeout(interval),
+		asynq.Unique(interval),
+	)
+}
+
+func (w *HealthWorker) HandleCheckNASHealth(ctx context.Context, task *asynq.Task) error {
+	targets, err := w.nasService.GetHealthCheckTargets(ctx)
+	if err != nil {
+		logger.For(ctx, w.logger).Error("Failed to get NAS health check targets", zap.Error(err))
+		return fmt.Errorf("failed to get health check targets: %w", err)
+	}
+
+	logger.For(ctx, w.logger).Info("Checking NAS health", zap.Int("targets", len(targets)))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentProbes)
+	for _, target := range targets {
+		// A network entry has no single address to probe.
+		if strings.Contains(target.NASName, "/") {
+			logger.For(ctx, w.logger).Debug("Skipping health check for NAS network",
+				zap.Uint("nas_id", target.ID),
+				zap.String("nasname", target.NASName))
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(target dto.NASHealthTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			w.checkNAS(ctx, target)
+		}(target)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (w *HealthWorker) checkNAS(ctx context.Context, target dto.NASHealthTarget) {
+	port := target.Port
+	if port == 0 {
+		port = w.cfg.Worker.NASHealthPort
+	}
+	addr := net.JoinHostPort(target.NASName, strconv.Itoa(port))
+
+	rtt, probeErr := w.prober.Probe(ctx, addr, target.Secret)
+	checkedAt := time.Now()
+	up := probeErr == nil
+
+	status := entity.HealthStatusDown
+	if up {
+		status = entity.HealthStatusUp
+	}
+
+	// The state change is notified in the transaction that records it, so
+	// that it is neither lost nor reported for a check that was not stored.
+	err := w.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := w.nasService.RecordNASHealth(txCtx, target.ID, up, rtt, checkedAt); err != nil {
+			return err
+		}
+		if status == target.HealthStatus {
+			return nil
+		}
+
+		change := StatusChange{
+			NASID:     target.ID,
+			NASName:   target.NASName,
+			From:      target.HealthStatus,
+			To:        status,
+			CheckedAt: checkedAt,
+		}
+		if up {
+			change.RTT = rtt
+		} else {
+			change.Error = probeErr.Error()
+		}
+		return w.notifier.NotifyStatusChange(txCtx, change)
+	})
+	if err != nil {
+		logger.For(ctx, w.logger).Error("Failed to record NAS health",
+			zap.Uint("nas_id", target.ID),
+			zap.Error(err))
+	}
+}
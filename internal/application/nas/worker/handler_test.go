@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeProber struct {
+	mu      sync.Mutex
+	results map[string]error
+	probed  []string
+}
+
+func (p *fakeProber) Probe(ctx context.Context, addr, secret string) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.probed = append(p.probed, addr)
+	if err := p.results[addr]; err != nil {
+		return 0, err
+	}
+	return 5 * time.Millisecond, nil
+}
+
+type fakeNotifier struct {
+	mu      sync.Mutex
+	changes []StatusChange
+}
+
+func (n *fakeNotifier) NotifyStatusChange(ctx context.Context, change StatusChange) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.changes = append(n.changes, change)
+	return nil
+}
+
+type MockScheduler struct {
+	mock.Mock
+}
+
+func (m *MockScheduler) Register(cronspec string, task *asynq.Task, opts ...asynq.Option) error {
+	args := m.Called(cronspec, task, opts)
+	return args.Error(0)
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Worker: config.WorkerConfig{
+			NASHealthInterval: time.Minute,
+			NASHealthTimeout:  time.Second,
+			NASHealthRetries:  3,
+			NASHealthPort:     1812,
+		},
+	}
+}
+
+func TestHealthWorker_HandleCheckNASHealth(t *testing.T) {
+	t.Run("should probe targets, record health and notify on state change", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockNASService{}
+		prober := &fakeProber{results: map[string]error{
+			"10.0.0.2:1812": errors.New("radstatus: no response"),
+		}}
+		notifier := &fakeNotifier{}
+		worker := NewHealthWorker(mockService, prober, notifier, &testutil.MockTransactionManager{}, testutil.NewSilentLogger(), testConfig())
+
+		// Given
+		targets := []dto.NASHealthTarget{
+			{ID: 1, NASName: "10.0.0.1", Secret: "s1", HealthStatus: entity.HealthStatusUp},
+			{ID: 2, NASName: "10.0.0.2", Secret: "s2", HealthStatus: entity.HealthStatusUp},
+			{ID: 3, NASName: "radius.example.com", Secret: "s3", Port: 18120, HealthStatus: entity.HealthStatusUnknown},
+			{ID: 4, NASName: "10.1.0.0/16", Secret: "s4", HealthStatus: entity.HealthStatusUnknown},
+		}
+
+		// Mock expectations
+		mockService.On("GetHealthCheckTargets", mock.Anything, mock.Anything).Return(targets, nil)
+		mockService.On("RecordNASHealth", mock.Anything, uint(1), true, 5*time.Millisecond, mock.AnythingOfType("time.Time")).Return(nil)
+		mockService.On("RecordNASHealth", mock.Anything, uint(2), false, time.Duration(0), mock.AnythingOfType("time.Time")).Return(nil)
+		mockService.On("RecordNASHealth", mock.Anything, uint(3), true, 5*time.Millisecond, mock.AnythingOfType("time.Time")).Return(nil)
+
+		// When
+		err := worker.HandleCheckNASHealth(context.Background(), asynq.NewTask(TypeCheckNASHealth, nil))
+
+		// Then
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"10.0.0.1:1812", "10.0.0.2:1812", "radius.example.com:18120"}, prober.probed)
+
+		assert.Len(t, notifier.changes, 2)
+		byID := map[uint]StatusChange{}
+		for _, change := range notifier.changes {
+			byID[change.NASID] = change
+		}
+		assert.Equal(t, entity.HealthStatusUp, byID[2].From)
+		assert.Equal(t, entity.HealthStatusDown, byID[2].To)
+		assert.Equal(t, "radstatus: no response", byID[2].Error)
+		assert.Equal(t, entity.HealthStatusUnknown, byID[3].From)
+		assert.Equal(t, entity.HealthStatusUp, byID[3].To)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should not notify when recording fails", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockNASService{}
+		prober := &fakeProber{results: map[string]error{}}
+		notifier := &fakeNotifier{}
+		worker := NewHealthWorker(mockService, prober, notifier, &testutil.MockTransactionManager{}, testutil.NewSilentLogger(), testConfig())
+
+		// Mock expectations
+		mockService.On("GetHealthCheckTargets", mock.Anything, mock.Anything).Return([]dto.NASHealthTarget{
+			{ID: 1, NASName: "10.0.0.1", Secret: "s1", HealthStatus: entity.HealthStatusDown},
+		}, nil)
+		mockService.On("RecordNASHealth", mock.Anything, uint(1), true, 5*time.Millisecond, mock.AnythingOfType("time.Time")).
+			Return(errors.New("database error"))
+
+		// When
+		err := worker.HandleCheckNASHealth(context.Background(), asynq.NewTask(TypeCheckNASHealth, nil))
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, notifier.changes)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return error when targets cannot be loaded", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockNASService{}
+		worker := NewHealthWorker(mockService, &fakeProber{}, &fakeNotifier{}, &testutil.MockTransactionManager{}, testutil.NewSilentLogger(), testConfig())
+
+		// Mock expectations
+		mockService.On("GetHealthCheckTargets", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		// When
+		err := worker.HandleCheckNASHealth(context.Background(), asynq.NewTask(TypeCheckNASHealth, nil))
+
+		// Then
+		assert.Error(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHealthWorker_RegisterPeriodicTasks(t *testing.T) {
+	t.Run("should register the health check at the configured interval", func(t *testing.T) {
+		// Setup
+		mockScheduler := &MockScheduler{}
+		worker := NewHealthWorker(&testutil.MockNASService{}, &fakeProber{}, &fakeNotifier{}, &testutil.MockTransactionManager{}, testutil.NewSilentLogger(), testConfig())
+
+		// Mock expectations
+		mockScheduler.On("Register", "@every 1m0s", mock.MatchedBy(func(task *asynq.Task) bool {
+			return task.Type() == TypeCheckNASHealth
+		}), mock.Anything).Return(nil)
+
+		// When
+		err := worker.RegisterPeriodicTasks(mockScheduler)
+
+		// Then
+		assert.NoError(t, err)
+		mockScheduler.AssertExpectations(t)
+	})
+
+	t.Run("should skip registration when the interval is zero", func(t *testing.T) {
+		// Setup
+		mockScheduler := &MockScheduler{}
+		cfg := testConfig()
+		cfg.Worker.NASHealthInterval = 0
+		worker := NewHealthWorker(&testutil.MockNASService{}, &fakeProber{}, &fakeNotifier{}, &testutil.MockTransactionManager{}, testutil.NewSilentLogger(), cfg)
+
+		// When
+		err := worker.RegisterPeriodicTasks(mockScheduler)
+
+		// Then
+		assert.NoError(t, err)
+		mockScheduler.AssertNotCalled(t, "Register")
+	})
+}
+
+func TestOutboxStatusNotifier_NotifyStatusChange(t *testing.T) {
+	t.Run("should record the change as a NASHealthChanged event", func(t *testing.T) {
+		// Setup
+		mockOutbox := &testutil.MockOutbox{}
+		notifier := NewOutboxStatusNotifier(mockOutbox, testutil.NewSilentLogger())
+
+		// When
+		err := notifier.NotifyStatusChange(context.Background(), StatusChange{
+			NASID:   2,
+			NASName: "10.0.0.2",
+			From:    entity.HealthStatusUp,
+			To:      entity.HealthStatusDown,
+			Error:   "radstatus: no response",
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, []events.Event{events.NASHealthChanged{
+			NASID:   2,
+			NASName: "10.0.0.2",
+			From:    entity.HealthStatusUp,
+			To:      entity.HealthStatusDown,
+			Error:   "radstatus: no response",
+		}}, mockOutbox.Events)
+	})
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+
+	"go.uber.org/zap"
+)
+
+// StatusChange describes a NAS moving between health states.
+type StatusChange struct {
+	NASID     uint
+	NASName   string
+	From      string
+	To        string
+	RTT       time.Duration
+	Error     string
+	CheckedAt time.Time
+}
+
+// StatusNotifier is told about every health state change, within the
+// transaction that records it.
+type StatusNotifier interface {
+	NotifyStatusChange(ctx context.Context, change StatusChange) error
+}
+
+type outboxStatusNotifier struct {
+	outbox outbox.Outbox
+	logger *zap.Logger
+}
+
+// NewOutboxStatusNotifier returns a StatusNotifier that logs state changes
+// and records them as NASHealthChanged events.
+func NewOutboxStatusNotifier(outbox outbox.Outbox, logger *zap.Logger) StatusNotifier {
+	return &outboxStatusNotifier{outbox: outbox, logger: logger}
+}
+
+func (n *outboxStatusNotifier) NotifyStatusChange(ctx context.Context, change StatusChange) error {
+	logger.For(ctx, n.logger).Warn("NAS health state changed",
+		zap.Uint("nas_id", change.NASID),
+		zap.String("nasname", change.NASName),
+		zap.String("from", change.From),
+		zap.String("to", change.To),
+		zap.Duration("rtt", change.RTT),
+		zap.String("error", change.Error),
+		zap.Time("checked_at", change.CheckedAt))
+
+	return n.outbox.Add(ctx, events.NASHealthChanged{
+		NASID:   change.NASID,
+		NASName: change.NASName,
+		From:    change.From,
+		To:      change.To,
+		Error:   change.Error,
+	})
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/radstatus"
+)
+
+// Prober checks that a RADIUS server is alive and returns its round-trip time.
+type Prober interface {
+	Probe(ctx context.Context, addr, secret string) (time.Duration, error)
+}
+
+type statusServerProber struct {
+	timeout  time.Duration
+	attempts int
+}
+
+// NewStatusServerProber returns a Prober that sends RFC 5997 Status-Server
+// requests.
+func NewStatusServerProber(cfg *config.Config) Prober {
+	return &statusServerProber{
+		timeout:  cfg.Worker.NASHealthTimeout,
+		attempts: cfg.Worker.NASHealthRetries,
+	}
+}
+
+func (p *statusServerProber) Probe(ctx context.Context, addr, secret string) (time.Duration, error) {
+	return radstatus.Probe(ctx, addr, secret, p.timeout, p.attempts)
+}
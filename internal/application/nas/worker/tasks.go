@@ -0,0 +1,5 @@
+package worker
+
+const (
+	TypeCheckNASHealth = "nas:check_health"
+)
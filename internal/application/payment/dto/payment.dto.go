@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 )
 
 type CreatePaymentRequest struct {
@@ -23,21 +25,30 @@ type PaymentResponse struct {
 	Status      string    `json:"status"`
 	Description string    `json:"description"`
 	UserID      uint      `json:"user_id"`
+	TenantID    *uint     `json:"tenant_id,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type PaymentListResponse struct {
-	Data       []PaymentResponse `json:"data"`
-	TotalCount int64             `json:"total_count"`
-	Page       int               `json:"page"`
-	PageSize   int               `json:"page_size"`
+	Data           []PaymentResponse `json:"data"`
+	TotalCount     *int64            `json:"total_count,omitempty"`
+	TotalEstimated bool              `json:"total_estimated,omitempty"`
+	Page           int               `json:"page"`
+	PageSize       int               `json:"page_size"`
+	NextCursor     string            `json:"next_cursor,omitempty"`
 }
 
+// PaymentFilter takes the expressions of package listquery, such as
+// amount=10..100 or sort=-created_at.
 type PaymentFilter struct {
-	Status   string `form:"status"`
-	Currency string `form:"currency"`
-	UserID   uint   `form:"user_id"`
-	Page     int    `form:"page"`
-	PageSize int    `form:"page_size"`
+	Status    string `form:"status"`
+	Currency  string `form:"currency"`
+	Amount    string `form:"amount"`
+	CreatedAt string `form:"created_at"`
+	Sort      string `form:"sort"`
+	UserID    uint   `form:"user_id"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+	pagination.Params
 }
@@ -7,12 +7,13 @@ import (
 )
 
 type Payment struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
+	ID          uint           `json:"id" gorm:"primaryKey;index:idx_payments_user_id_id,priority:2;index:idx_payments_tenant_id_id,priority:2"`
 	Amount      float64        `json:"amount" gorm:"not null"`
 	Currency    string         `json:"currency" gorm:"size:3;not null"`
 	Status      PaymentStatus  `json:"status" gorm:"default:pending"`
 	Description string         `json:"description" gorm:"size:500"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
+	UserID      uint           `json:"user_id" gorm:"not null;index:idx_payments_user_id_id,priority:1"`
+	TenantID    *uint          `json:"tenant_id" gorm:"index:idx_payments_tenant_id_id,priority:1"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
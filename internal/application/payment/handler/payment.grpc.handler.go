@@ -7,10 +7,10 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -38,10 +38,10 @@ func (h *PaymentGrpcHandler) CreatePayment(
 		UserID:      uint(req.UserId),
 	}
 
-	paymentResponse, err := h.paymentService.CreatePayment(createReq)
+	paymentResponse, err := h.paymentService.CreatePayment(ctx, createReq)
 	if err != nil {
 		h.logger.Error("Failed to create payment via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to create payment: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &payment.CreatePaymentResponse{
@@ -53,10 +53,10 @@ func (h *PaymentGrpcHandler) GetPayment(
 	ctx context.Context,
 	req *payment.GetPaymentRequest,
 ) (*payment.GetPaymentResponse, error) {
-	paymentResponse, err := h.paymentService.GetPaymentByID(uint(req.Id))
+	paymentResponse, err := h.paymentService.GetPaymentByID(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to get payment via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.NotFound, "payment not found: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &payment.GetPaymentResponse{
@@ -78,13 +78,13 @@ func (h *PaymentGrpcHandler) ListPayments(
 		pageSize = 10
 	}
 
-	filter := &dto.PaymentFilter{
-		Page:     page,
-		PageSize: pageSize,
-	}
+	filter := toPaymentFilter(req.GetFilter())
+	filter.Page = page
+	filter.PageSize = pageSize
+	filter.Params = pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)}
 
 	// Add status filter if provided
-	if req.Status != payment.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED {
+	if filter.Status == "" && req.Status != payment.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED {
 		filter.Status = h.protoStatusToString(req.Status)
 	}
 
@@ -93,10 +93,10 @@ func (h *PaymentGrpcHandler) ListPayments(
 		filter.UserID = uint(req.UserId)
 	}
 
-	listResponse, err := h.paymentService.GetPayments(filter)
+	listResponse, err := h.paymentService.GetPayments(ctx, filter)
 	if err != nil {
 		h.logger.Error("Failed to list payments via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to list payments: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	protoPayments := make([]*payment.Payment, len(listResponse.Data))
@@ -105,10 +105,12 @@ func (h *PaymentGrpcHandler) ListPayments(
 	}
 
 	return &payment.ListPaymentsResponse{
-		Payments: protoPayments,
-		Total:    listResponse.TotalCount,
-		Page:     int32(listResponse.Page),
-		PageSize: int32(listResponse.PageSize),
+		Payments:       protoPayments,
+		Total:          pagination.TotalOrZero(listResponse.TotalCount),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
 	}, nil
 }
 
@@ -125,10 +127,10 @@ func (h *PaymentGrpcHandler) UpdatePayment(
 		updateReq.Status = h.protoStatusToString(req.Status)
 	}
 
-	paymentResponse, err := h.paymentService.UpdatePayment(uint(req.Id), updateReq)
+	paymentResponse, err := h.paymentService.UpdatePayment(ctx, uint(req.Id), updateReq)
 	if err != nil {
 		h.logger.Error("Failed to update payment via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to update payment: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &payment.UpdatePaymentResponse{
@@ -140,10 +142,10 @@ func (h *PaymentGrpcHandler) DeletePayment(
 	ctx context.Context,
 	req *payment.DeletePaymentRequest,
 ) (*payment.DeletePaymentResponse, error) {
-	err := h.paymentService.DeletePayment(uint(req.Id))
+	err := h.paymentService.DeletePayment(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to delete payment via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to delete payment: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &payment.DeletePaymentResponse{
@@ -165,16 +167,16 @@ func (h *PaymentGrpcHandler) GetUserPayments(
 		pageSize = 10
 	}
 
-	filter := &dto.PaymentFilter{
-		Page:     page,
-		PageSize: pageSize,
-		UserID:   uint(req.UserId),
-	}
+	filter := toPaymentFilter(req.GetFilter())
+	filter.Page = page
+	filter.PageSize = pageSize
+	filter.UserID = uint(req.UserId)
+	filter.Params = pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)}
 
-	listResponse, err := h.paymentService.GetPayments(filter)
+	listResponse, err := h.paymentService.GetPayments(ctx, filter)
 	if err != nil {
 		h.logger.Error("Failed to get user payments via gRPC", zap.Uint32("user_id", req.UserId), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to get user payments: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	protoPayments := make([]*payment.Payment, len(listResponse.Data))
@@ -183,10 +185,12 @@ func (h *PaymentGrpcHandler) GetUserPayments(
 	}
 
 	return &payment.GetUserPaymentsResponse{
-		Payments: protoPayments,
-		Total:    listResponse.TotalCount,
-		Page:     int32(listResponse.Page),
-		PageSize: int32(listResponse.PageSize),
+		Payments:       protoPayments,
+		Total:          pagination.TotalOrZero(listResponse.TotalCount),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
 	}, nil
 }
 
@@ -232,3 +236,13 @@ func (h *PaymentGrpcHandler) protoStatusToString(status payment.PaymentStatus) s
 		return entity.PaymentStatusPending.String()
 	}
 }
+
+func toPaymentFilter(f *payment.PaymentFilter) *dto.PaymentFilter {
+	return &dto.PaymentFilter{
+		Status:    f.GetStatus(),
+		Currency:  f.GetCurrency(),
+		Amount:    f.GetAmount(),
+		CreatedAt: f.GetCreatedAt(),
+		Sort:      f.GetSort(),
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -31,21 +32,23 @@ func NewPaymentHandler(service service.PaymentService, logger *zap.Logger) *Paym
 // @Produce json
 // @Param payment body dto.CreatePaymentRequest true "Payment creation request"
 // @Success 201 {object} map[string]interface{} "Created payment"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request body"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /payments [post]
 func (h *PaymentHandler) CreatePayment(ctx *gin.Context) {
 	var req dto.CreatePaymentRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	payment, err := h.service.CreatePayment(&req)
+	payment, err := h.service.CreatePayment(ctx.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create payment", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create payment"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -60,21 +63,23 @@ func (h *PaymentHandler) CreatePayment(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Payment ID"
 // @Success 200 {object} map[string]interface{} "Payment details"
-// @Failure 400 {object} map[string]interface{} "Invalid payment ID"
-// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Failure 400 {object} apperror.Problem "Invalid payment ID"
+// @Failure 404 {object} apperror.Problem "Payment not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /payments/{id} [get]
 func (h *PaymentHandler) GetPayment(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	payment, err := h.service.GetPaymentByID(uint(id))
+	payment, err := h.service.GetPaymentByID(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to get payment", zap.Error(err))
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -87,27 +92,34 @@ func (h *PaymentHandler) GetPayment(ctx *gin.Context) {
 // @Tags payments
 // @Accept json
 // @Produce json
-// @Param status query string false "Filter by status" Enums(pending, completed, failed, canceled)
-// @Param currency query string false "Filter by currency (3-letter code)"
+// @Param status query string false "Filter by status (pending, completed, failed, canceled): exact or in:"
+// @Param currency query string false "Filter by currency (3-letter code): exact or in:"
+// @Param amount query string false "Filter by amount: exact, in: or a from..to range"
+// @Param created_at query string false "Filter by creation: a date, an RFC 3339 time or a from..to range"
 // @Param user_id query int false "Filter by user ID"
+// @Param sort query string false "Comma separated fields, descending with a leading -: id, status, currency, amount, created_at"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
 // @Success 200 {object} dto.PaymentListResponse "List of payments"
-// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid query parameters"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /payments [get]
 func (h *PaymentHandler) GetPayments(ctx *gin.Context) {
 	var filter dto.PaymentFilter
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
 		h.logger.Error("Invalid query parameters", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	payments, err := h.service.GetPayments(&filter)
+	payments, err := h.service.GetPayments(ctx.Request.Context(), &filter)
 	if err != nil {
 		h.logger.Error("Failed to get payments", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get payments"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -123,28 +135,30 @@ func (h *PaymentHandler) GetPayments(ctx *gin.Context) {
 // @Param id path int true "Payment ID"
 // @Param payment body dto.UpdatePaymentRequest true "Payment update request"
 // @Success 200 {object} map[string]interface{} "Updated payment"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /payments/{id} [put]
 func (h *PaymentHandler) UpdatePayment(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	var req dto.UpdatePaymentRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	payment, err := h.service.UpdatePayment(uint(id), &req)
+	payment, err := h.service.UpdatePayment(ctx.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update payment", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update payment"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -159,21 +173,23 @@ func (h *PaymentHandler) UpdatePayment(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Payment ID"
 // @Success 200 {object} map[string]interface{} "Payment deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid payment ID"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid payment ID"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /payments/{id} [delete]
 func (h *PaymentHandler) DeletePayment(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	err = h.service.DeletePayment(uint(id))
+	err = h.service.DeletePayment(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to delete payment", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete payment"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -204,21 +220,23 @@ func (h *PaymentHandler) RegisterRoutes(api *gin.RouterGroup) {
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} map[string]interface{} "List of payments for the user"
-// @Failure 400 {object} map[string]interface{} "Invalid user ID"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid user ID"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users/{id}/payments [get]
 func (h *PaymentHandler) GetPaymentsByUser(ctx *gin.Context) {
 	userIDStr := ctx.Param("id")
 	userID, err := strconv.ParseUint(userIDStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	payments, err := h.service.GetPaymentsByUser(uint(userID))
+	payments, err := h.service.GetPaymentsByUser(ctx.Request.Context(), uint(userID))
 	if err != nil {
 		h.logger.Error("Failed to get payments by user", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get payments"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
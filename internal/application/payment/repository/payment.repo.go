@@ -1,20 +1,36 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// paymentFields are the fields payments are filtered and sorted by.
+var paymentFields = listquery.Fields{
+	"id":         {Column: "id", Kind: listquery.Number, Sortable: true},
+	"status":     {Column: "status", Kind: listquery.Text, Sortable: true},
+	"currency":   {Column: "currency", Kind: listquery.Text, Sortable: true},
+	"amount":     {Column: "amount", Kind: listquery.Number, Sortable: true},
+	"created_at": {Column: "created_at", Kind: listquery.Time, Sortable: true},
+}
+
 type PaymentRepository interface {
-	Create(payment *entity.Payment) error
-	GetByID(id uint) (*entity.Payment, error)
-	GetAll(filter *dto.PaymentFilter) ([]entity.Payment, int64, error)
-	Update(payment *entity.Payment) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.Payment, error)
+	Create(ctx context.Context, payment *entity.Payment) error
+	GetByID(ctx context.Context, id uint) (*entity.Payment, error)
+	GetAll(ctx context.Context, filter *dto.PaymentFilter) ([]entity.Payment, pagination.Page, error)
+	Update(ctx context.Context, payment *entity.Payment) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint) ([]entity.Payment, error)
 }
 
 type paymentRepository struct {
@@ -29,68 +45,71 @@ func NewPaymentRepository(db *gorm.DB, logger *zap.Logger) PaymentRepository {
 	}
 }
 
-func (r *paymentRepository) Create(payment *entity.Payment) error {
-	r.logger.Info("Creating payment", zap.Uint("user_id", payment.UserID))
-	return r.db.Create(payment).Error
+func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating payment", zap.Uint("user_id", payment.UserID))
+	payment.TenantID = tenant.ID(ctx)
+	return db.Create(payment).Error
 }
 
-func (r *paymentRepository) GetByID(id uint) (*entity.Payment, error) {
+func (r *paymentRepository) GetByID(ctx context.Context, id uint) (*entity.Payment, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var payment entity.Payment
-	err := r.db.First(&payment, id).Error
+	err := db.Scopes(tenant.Filter(ctx)).First(&payment, id).Error
 	if err != nil {
-		r.logger.Error("Failed to get payment by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get payment by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 	return &payment, nil
 }
 
-func (r *paymentRepository) GetAll(filter *dto.PaymentFilter) ([]entity.Payment, int64, error) {
-	var payments []entity.Payment
-	var totalCount int64
-
-	query := r.db.Model(&entity.Payment{})
+func (r *paymentRepository) GetAll(ctx context.Context, filter *dto.PaymentFilter) ([]entity.Payment, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 
-	if filter.Status != "" {
-		query = query.Where("status = ?", filter.Status)
-	}
-	if filter.Currency != "" {
-		query = query.Where("currency = ?", filter.Currency)
+	query, err := paymentFields.Where(ctx, db.Model(&entity.Payment{}).Scopes(tenant.Filter(ctx)), map[string]string{
+		"status":     filter.Status,
+		"currency":   filter.Currency,
+		"amount":     filter.Amount,
+		"created_at": filter.CreatedAt,
+	})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
 	if filter.UserID != 0 {
 		query = query.Where("user_id = ?", filter.UserID)
 	}
-
-	query.Count(&totalCount)
-
-	if filter.Page > 0 && filter.PageSize > 0 {
-		offset := (filter.Page - 1) * filter.PageSize
-		query = query.Offset(offset).Limit(filter.PageSize)
+	order, err := paymentFields.Order(filter.Sort, pagination.Order{})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
 
-	err := query.Find(&payments).Error
+	payments, page, err := pagination.Find[entity.Payment](query, filter.Params, filter.Page, filter.PageSize, order)
 	if err != nil {
-		r.logger.Error("Failed to get payments", zap.Error(err))
-		return nil, 0, err
+		logger.For(ctx, r.logger).Error("Failed to get payments", zap.Error(err))
+		return nil, pagination.Page{}, err
 	}
 
-	return payments, totalCount, nil
+	return payments, page, nil
 }
 
-func (r *paymentRepository) Update(payment *entity.Payment) error {
-	r.logger.Info("Updating payment", zap.Uint("id", payment.ID))
-	return r.db.Save(payment).Error
+func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Updating payment", zap.Uint("id", payment.ID))
+	return db.Save(payment).Error
 }
 
-func (r *paymentRepository) Delete(id uint) error {
-	r.logger.Info("Deleting payment", zap.Uint("id", id))
-	return r.db.Delete(&entity.Payment{}, id).Error
+func (r *paymentRepository) Delete(ctx context.Context, id uint) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Deleting payment", zap.Uint("id", id))
+	return db.Scopes(tenant.Filter(ctx)).Delete(&entity.Payment{}, id).Error
 }
 
-func (r *paymentRepository) GetByUserID(userID uint) ([]entity.Payment, error) {
+func (r *paymentRepository) GetByUserID(ctx context.Context, userID uint) ([]entity.Payment, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var payments []entity.Payment
-	err := r.db.Where("user_id = ?", userID).Find(&payments).Error
+	err := db.Scopes(tenant.Filter(ctx)).Where("user_id = ?", userID).Find(&payments).Error
 	if err != nil {
-		r.logger.Error("Failed to get payments by user ID", zap.Uint("user_id", userID), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get payments by user ID", zap.Uint("user_id", userID), zap.Error(err))
 		return nil, err
 	}
 	return payments, nil
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
@@ -25,7 +26,7 @@ func TestPaymentRepository_Create(t *testing.T) {
 		payment.ID = 0 // Reset ID for creation
 
 		// When
-		err := repo.Create(payment)
+		err := repo.Create(context.Background(), payment)
 
 		// Then
 		assert.NoError(t, err)
@@ -55,11 +56,11 @@ func TestPaymentRepository_GetByID(t *testing.T) {
 		// Given
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = 0
-		err := repo.Create(payment)
+		err := repo.Create(context.Background(), payment)
 		require.NoError(t, err)
 
 		// When
-		foundPayment, err := repo.GetByID(payment.ID)
+		foundPayment, err := repo.GetByID(context.Background(), payment.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -71,7 +72,7 @@ func TestPaymentRepository_GetByID(t *testing.T) {
 
 	t.Run("should return error when payment not found", func(t *testing.T) {
 		// When
-		_, err := repo.GetByID(999)
+		_, err := repo.GetByID(context.Background(), 999)
 
 		// Then
 		assert.Error(t, err)
@@ -102,7 +103,7 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 			payment.ID = 0
 			payment.Amount = float64(100 + i)
 			payment.UserID = uint(i + 1)
-			err := repo.Create(payment)
+			err := repo.Create(context.Background(), payment)
 			require.NoError(t, err)
 		}
 
@@ -112,12 +113,12 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		payments, totalCount, err := repo.GetAll(filter)
+		payments, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
-		assert.Len(t, payments, 3)            // Should return 3 payments due to page size
-		assert.Equal(t, int64(5), totalCount) // Total count should be 5
+		assert.Len(t, payments, 3)             // Should return 3 payments due to page size
+		assert.Equal(t, int64(5), *page.Total) // Total count should be 5
 	})
 
 	t.Run("should filter payments by status", func(t *testing.T) {
@@ -127,14 +128,14 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		payment1.ID = 0
 		payment1.Status = entity.PaymentStatusPending
 		payment1.UserID = 1
-		err := repo.Create(payment1)
+		err := repo.Create(context.Background(), payment1)
 		require.NoError(t, err)
 
 		payment2 := testutil.CreatePaymentFixture()
 		payment2.ID = 0
 		payment2.Status = entity.PaymentStatusCompleted
 		payment2.UserID = 2
-		err = repo.Create(payment2)
+		err = repo.Create(context.Background(), payment2)
 		require.NoError(t, err)
 
 		filter := &dto.PaymentFilter{
@@ -142,12 +143,12 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		payments, totalCount, err := repo.GetAll(filter)
+		payments, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, payments, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, entity.PaymentStatusPending, payments[0].Status)
 	})
 
@@ -158,14 +159,14 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		payment1.ID = 0
 		payment1.Currency = "USD"
 		payment1.UserID = 1
-		err := repo.Create(payment1)
+		err := repo.Create(context.Background(), payment1)
 		require.NoError(t, err)
 
 		payment2 := testutil.CreatePaymentFixture()
 		payment2.ID = 0
 		payment2.Currency = "EUR"
 		payment2.UserID = 2
-		err = repo.Create(payment2)
+		err = repo.Create(context.Background(), payment2)
 		require.NoError(t, err)
 
 		filter := &dto.PaymentFilter{
@@ -173,12 +174,12 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		payments, totalCount, err := repo.GetAll(filter)
+		payments, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, payments, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "USD", payments[0].Currency)
 	})
 
@@ -188,13 +189,13 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		payment1 := testutil.CreatePaymentFixture()
 		payment1.ID = 0
 		payment1.UserID = 1
-		err := repo.Create(payment1)
+		err := repo.Create(context.Background(), payment1)
 		require.NoError(t, err)
 
 		payment2 := testutil.CreatePaymentFixture()
 		payment2.ID = 0
 		payment2.UserID = 2
-		err = repo.Create(payment2)
+		err = repo.Create(context.Background(), payment2)
 		require.NoError(t, err)
 
 		filter := &dto.PaymentFilter{
@@ -202,12 +203,12 @@ func TestPaymentRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		payments, totalCount, err := repo.GetAll(filter)
+		payments, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, payments, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, uint(1), payments[0].UserID)
 	})
 
@@ -226,13 +227,13 @@ func TestPaymentRepository_Update(t *testing.T) {
 		// Given
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = 0
-		err := repo.Create(payment)
+		err := repo.Create(context.Background(), payment)
 		require.NoError(t, err)
 
 		// When
 		payment.Status = entity.PaymentStatusCompleted
 		payment.Description = "Updated description"
-		err = repo.Update(payment)
+		err = repo.Update(context.Background(), payment)
 
 		// Then
 		assert.NoError(t, err)
@@ -260,11 +261,11 @@ func TestPaymentRepository_Delete(t *testing.T) {
 		// Given
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = 0
-		err := repo.Create(payment)
+		err := repo.Create(context.Background(), payment)
 		require.NoError(t, err)
 
 		// When
-		err = repo.Delete(payment.ID)
+		err = repo.Delete(context.Background(), payment.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -295,7 +296,7 @@ func TestPaymentRepository_GetByUserID(t *testing.T) {
 			payment.ID = 0
 			payment.UserID = userID
 			payment.Amount = float64(100 + i)
-			err := repo.Create(payment)
+			err := repo.Create(context.Background(), payment)
 			require.NoError(t, err)
 		}
 
@@ -303,11 +304,11 @@ func TestPaymentRepository_GetByUserID(t *testing.T) {
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = 0
 		payment.UserID = 2
-		err = repo.Create(payment)
+		err = repo.Create(context.Background(), payment)
 		require.NoError(t, err)
 
 		// When
-		payments, err := repo.GetByUserID(userID)
+		payments, err := repo.GetByUserID(context.Background(), userID)
 
 		// Then
 		assert.NoError(t, err)
@@ -319,7 +320,7 @@ func TestPaymentRepository_GetByUserID(t *testing.T) {
 
 	t.Run("should return empty slice for user with no payments", func(t *testing.T) {
 		// When
-		payments, err := repo.GetByUserID(999)
+		payments, err := repo.GetByUserID(context.Background(), 999)
 
 		// Then
 		assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -8,44 +9,69 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/repository"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrPaymentNotFound is returned for payment IDs that do not exist.
+	ErrPaymentNotFound = apperror.NotFound("payment not found")
+	// ErrUserNotFound is returned when a payment is created for a user that
+	// does not exist.
+	ErrUserNotFound = apperror.Validation("user not found", apperror.FieldError{Field: "user_id", Message: "does not exist"})
+	// ErrInvalidStatus is returned for statuses a payment cannot have.
+	ErrInvalidStatus = apperror.Validation("invalid payment status", apperror.FieldError{Field: "status", Message: "is not a valid payment status"})
+)
+
 type PaymentService interface {
-	CreatePayment(req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error)
-	GetPaymentByID(id uint) (*dto.PaymentResponse, error)
-	GetPayments(filter *dto.PaymentFilter) (*dto.PaymentListResponse, error)
-	UpdatePayment(id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error)
-	DeletePayment(id uint) error
-	GetPaymentsByUser(userID uint) ([]dto.PaymentResponse, error)
+	CreatePayment(ctx context.Context, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error)
+	GetPaymentByID(ctx context.Context, id uint) (*dto.PaymentResponse, error)
+	GetPayments(ctx context.Context, filter *dto.PaymentFilter) (*dto.PaymentListResponse, error)
+	UpdatePayment(ctx context.Context, id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error)
+	DeletePayment(ctx context.Context, id uint) error
+	GetPaymentsByUser(ctx context.Context, userID uint) ([]dto.PaymentResponse, error)
 }
 
 type paymentService struct {
 	repo        repository.PaymentRepository
 	userService service.UserService
+	txManager   database.TransactionManagerI
+	outbox      outbox.Outbox
+	auditor     audit.Recorder
 	logger      *zap.Logger
 }
 
 func NewPaymentService(
 	repo repository.PaymentRepository,
 	userService service.UserService,
+	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
 	logger *zap.Logger,
 ) PaymentService {
 	return &paymentService{
 		repo:        repo,
 		userService: userService,
+		txManager:   txManager,
+		outbox:      outbox,
+		auditor:     auditor,
 		logger:      logger,
 	}
 }
 
-func (s *paymentService) CreatePayment(req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+func (s *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
 	// Validate that user exists before creating payment
-	_, err := s.userService.GetUserByID(req.UserID)
+	_, err := s.userService.GetUserByID(ctx, req.UserID)
 	if err != nil {
-		s.logger.Error("User not found for payment creation", zap.Uint("user_id", req.UserID), zap.Error(err))
-		return nil, errors.New("user not found")
+		logger.For(ctx, s.logger).Error("User not found for payment creation", zap.Uint("user_id", req.UserID), zap.Error(err))
+		return nil, ErrUserNotFound
 	}
 
 	payment := &entity.Payment{
@@ -58,20 +84,34 @@ func (s *paymentService) CreatePayment(req *dto.CreatePaymentRequest) (*dto.Paym
 		UpdatedAt:   time.Now(),
 	}
 
-	err = s.repo.Create(payment)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, payment); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, paymentChange(audit.ActionCreate, nil, payment)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.PaymentCreated{
+			PaymentID: payment.ID,
+			UserID:    payment.UserID,
+			Amount:    payment.Amount,
+			Currency:  payment.Currency,
+			Status:    payment.Status.String(),
+		})
+	})
 	if err != nil {
-		s.logger.Error("Failed to create payment", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to create payment", zap.Error(err))
 		return nil, err
 	}
 
 	return s.entityToResponse(payment), nil
 }
 
-func (s *paymentService) GetPaymentByID(id uint) (*dto.PaymentResponse, error) {
-	payment, err := s.repo.GetByID(id)
+func (s *paymentService) GetPaymentByID(ctx context.Context, id uint) (*dto.PaymentResponse, error) {
+	payment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("payment not found")
+			return nil, ErrPaymentNotFound
 		}
 		return nil, err
 	}
@@ -79,7 +119,7 @@ func (s *paymentService) GetPaymentByID(id uint) (*dto.PaymentResponse, error) {
 	return s.entityToResponse(payment), nil
 }
 
-func (s *paymentService) GetPayments(filter *dto.PaymentFilter) (*dto.PaymentListResponse, error) {
+func (s *paymentService) GetPayments(ctx context.Context, filter *dto.PaymentFilter) (*dto.PaymentListResponse, error) {
 	if filter.Page <= 0 {
 		filter.Page = 1
 	}
@@ -87,7 +127,7 @@ func (s *paymentService) GetPayments(filter *dto.PaymentFilter) (*dto.PaymentLis
 		filter.PageSize = 10
 	}
 
-	payments, totalCount, err := s.repo.GetAll(filter)
+	payments, page, err := s.repo.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -98,56 +138,76 @@ func (s *paymentService) GetPayments(filter *dto.PaymentFilter) (*dto.PaymentLis
 	}
 
 	return &dto.PaymentListResponse{
-		Data:       responses,
-		TotalCount: totalCount,
-		Page:       filter.Page,
-		PageSize:   filter.PageSize,
+		Data:           responses,
+		TotalCount:     page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		NextCursor:     page.NextCursor,
 	}, nil
 }
 
-func (s *paymentService) UpdatePayment(id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error) {
-	payment, err := s.repo.GetByID(id)
+func (s *paymentService) UpdatePayment(ctx context.Context, id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error) {
+	payment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("payment not found")
+			return nil, ErrPaymentNotFound
 		}
 		return nil, err
 	}
 
 	status := entity.PaymentStatus(req.Status)
 	if !status.IsValid() {
-		return nil, errors.New("invalid payment status")
+		return nil, ErrInvalidStatus
 	}
 
+	before := *payment
+	previous := payment.Status
 	payment.Status = status
 	if req.Description != "" {
 		payment.Description = req.Description
 	}
 	payment.UpdatedAt = time.Now()
 
-	err = s.repo.Update(payment)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, payment); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, paymentChange(audit.ActionUpdate, &before, payment)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, statusEvents(payment, previous)...)
+	})
 	if err != nil {
-		s.logger.Error("Failed to update payment", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to update payment", zap.Error(err))
 		return nil, err
 	}
 
 	return s.entityToResponse(payment), nil
 }
 
-func (s *paymentService) DeletePayment(id uint) error {
-	_, err := s.repo.GetByID(id)
+func (s *paymentService) DeletePayment(ctx context.Context, id uint) error {
+	payment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("payment not found")
+			return ErrPaymentNotFound
 		}
 		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, paymentChange(audit.ActionDelete, payment, nil)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.PaymentDeleted{PaymentID: id, UserID: payment.UserID})
+	})
 }
 
-func (s *paymentService) GetPaymentsByUser(userID uint) ([]dto.PaymentResponse, error) {
-	payments, err := s.repo.GetByUserID(userID)
+func (s *paymentService) GetPaymentsByUser(ctx context.Context, userID uint) ([]dto.PaymentResponse, error) {
+	payments, err := s.repo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +220,43 @@ func (s *paymentService) GetPaymentsByUser(userID uint) ([]dto.PaymentResponse,
 	return responses, nil
 }
 
+// statusEvents returns the events for a payment whose status was previous
+// before the update; none when the status did not change.
+func paymentChange(action string, before, after *entity.Payment) audit.Change {
+	change := audit.Change{EntityType: audit.EntityPayment, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+	}
+	return change
+}
+
+func statusEvents(payment *entity.Payment, previous entity.PaymentStatus) []events.Event {
+	if payment.Status == previous {
+		return nil
+	}
+
+	evts := []events.Event{events.PaymentStatusChanged{
+		PaymentID: payment.ID,
+		UserID:    payment.UserID,
+		From:      previous.String(),
+		To:        payment.Status.String(),
+	}}
+	if payment.Status == entity.PaymentStatusCompleted {
+		evts = append(evts, events.PaymentCompleted{
+			PaymentID: payment.ID,
+			UserID:    payment.UserID,
+			Amount:    payment.Amount,
+			Currency:  payment.Currency,
+		})
+	}
+	return evts
+}
+
 func (s *paymentService) entityToResponse(payment *entity.Payment) *dto.PaymentResponse {
 	return &dto.PaymentResponse{
 		ID:          payment.ID,
@@ -168,6 +265,7 @@ func (s *paymentService) entityToResponse(payment *entity.Payment) *dto.PaymentR
 		Status:      payment.Status.String(),
 		Description: payment.Description,
 		UserID:      payment.UserID,
+		TenantID:    payment.TenantID,
 		CreatedAt:   payment.CreatedAt,
 		UpdatedAt:   payment.UpdatedAt,
 	}
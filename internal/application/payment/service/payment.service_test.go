@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -8,6 +9,8 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	userDto "github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -21,7 +24,8 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreatePaymentRequestFixture()
 		userResponse := &userDto.UserResponse{
@@ -31,14 +35,14 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockUserService.On("GetUserByID", req.UserID).Return(userResponse, nil)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.Payment")).Return(nil).Run(func(args mock.Arguments) {
-			payment := args.Get(0).(*entity.Payment)
+		mockUserService.On("GetUserByID", mock.Anything, req.UserID).Return(userResponse, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil).Run(func(args mock.Arguments) {
+			payment := args.Get(1).(*entity.Payment)
 			payment.ID = 1
 		})
 
 		// When
-		response, err := service.CreatePayment(req)
+		response, err := service.CreatePayment(context.Background(), req)
 
 		// Then
 		assert.NoError(t, err)
@@ -50,6 +54,13 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		assert.Equal(t, req.UserID, response.UserID)
 		assert.Equal(t, entity.PaymentStatusPending.String(), response.Status)
 		mockRepo.AssertExpectations(t)
+		assert.Equal(t, []events.Event{events.PaymentCreated{
+			PaymentID: 1,
+			UserID:    req.UserID,
+			Amount:    req.Amount,
+			Currency:  req.Currency,
+			Status:    entity.PaymentStatusPending.String(),
+		}}, mockOutbox.Events)
 		mockUserService.AssertExpectations(t)
 	})
 
@@ -58,22 +69,22 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreatePaymentRequestFixture()
 
 		// Mock expectations
-		mockUserService.On("GetUserByID", req.UserID).Return(nil, errors.New("user not found"))
+		mockUserService.On("GetUserByID", mock.Anything, req.UserID).Return(nil, errors.New("user not found"))
 
 		// When
-		response, err := service.CreatePayment(req)
+		response, err := service.CreatePayment(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
 		assert.Nil(t, response)
 		assert.Contains(t, err.Error(), "user not found")
 		mockUserService.AssertExpectations(t)
-		mockRepo.AssertNotCalled(t, "Create")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when payment creation fails", func(t *testing.T) {
@@ -81,7 +92,7 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreatePaymentRequestFixture()
 		userResponse := &userDto.UserResponse{
@@ -91,11 +102,11 @@ func TestPaymentService_CreatePayment(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockUserService.On("GetUserByID", req.UserID).Return(userResponse, nil)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.Payment")).Return(errors.New("create failed"))
+		mockUserService.On("GetUserByID", mock.Anything, req.UserID).Return(userResponse, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(errors.New("create failed"))
 
 		// When
-		response, err := service.CreatePayment(req)
+		response, err := service.CreatePayment(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -112,17 +123,17 @@ func TestPaymentService_GetPaymentByID(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = paymentID
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(payment, nil)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(payment, nil)
 
 		// When
-		response, err := service.GetPaymentByID(paymentID)
+		response, err := service.GetPaymentByID(context.Background(), paymentID)
 
 		// Then
 		assert.NoError(t, err)
@@ -139,15 +150,15 @@ func TestPaymentService_GetPaymentByID(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.GetPaymentByID(paymentID)
+		response, err := service.GetPaymentByID(context.Background(), paymentID)
 
 		// Then
 		assert.Error(t, err)
@@ -161,15 +172,15 @@ func TestPaymentService_GetPaymentByID(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.GetPaymentByID(paymentID)
+		response, err := service.GetPaymentByID(context.Background(), paymentID)
 
 		// Then
 		assert.Error(t, err)
@@ -185,7 +196,7 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.PaymentFilter{
 			Page:     1,
@@ -201,16 +212,16 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		payments[1].Amount = 200.00
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(payments, int64(2), nil)
+		mockRepo.On("GetAll", mock.Anything, filter).Return(payments, pagination.Exact(2), nil)
 
 		// When
-		response, err := service.GetPayments(filter)
+		response, err := service.GetPayments(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Len(t, response.Data, 2)
-		assert.Equal(t, int64(2), response.TotalCount)
+		assert.Equal(t, int64(2), *response.TotalCount)
 		assert.Equal(t, 1, response.Page)
 		assert.Equal(t, 10, response.PageSize)
 		mockRepo.AssertExpectations(t)
@@ -221,7 +232,7 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.PaymentFilter{
 			Page:     0,
@@ -234,10 +245,10 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", expectedFilter).Return([]entity.Payment{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.Payment{}, pagination.Exact(0), nil)
 
 		// When
-		response, err := service.GetPayments(filter)
+		response, err := service.GetPayments(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
@@ -252,7 +263,7 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.PaymentFilter{
 			Page:     1,
@@ -260,10 +271,10 @@ func TestPaymentService_GetPayments(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(nil, int64(0), errors.New("database error"))
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nil, pagination.Page{}, errors.New("database error"))
 
 		// When
-		response, err := service.GetPayments(filter)
+		response, err := service.GetPayments(context.Background(), filter)
 
 		// Then
 		assert.Error(t, err)
@@ -279,7 +290,8 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		existingPayment := testutil.CreatePaymentFixture()
@@ -291,11 +303,11 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		req.Description = "Updated description"
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(existingPayment, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.Payment")).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(existingPayment, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
 
 		// When
-		response, err := service.UpdatePayment(paymentID, req)
+		response, err := service.UpdatePayment(context.Background(), paymentID, req)
 
 		// Then
 		assert.NoError(t, err)
@@ -303,6 +315,50 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		assert.Equal(t, paymentID, response.ID)
 		assert.Equal(t, entity.PaymentStatusCompleted.String(), response.Status)
 		assert.Equal(t, req.Description, response.Description)
+		assert.Equal(t, []events.Event{
+			events.PaymentStatusChanged{
+				PaymentID: paymentID,
+				UserID:    existingPayment.UserID,
+				From:      entity.PaymentStatusPending.String(),
+				To:        entity.PaymentStatusCompleted.String(),
+			},
+			events.PaymentCompleted{
+				PaymentID: paymentID,
+				UserID:    existingPayment.UserID,
+				Amount:    existingPayment.Amount,
+				Currency:  existingPayment.Currency,
+			},
+		}, mockOutbox.Events)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not record events when the status is unchanged", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockPaymentRepository{}
+		mockUserService := &testutil.MockUserService{}
+		logger := testutil.NewSilentLogger()
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
+
+		paymentID := uint(1)
+		existingPayment := testutil.CreatePaymentFixture()
+		existingPayment.ID = paymentID
+		existingPayment.Status = entity.PaymentStatusPending
+
+		req := testutil.CreateUpdatePaymentRequestFixture()
+		req.Status = entity.PaymentStatusPending.String()
+		req.Description = "Updated description"
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(existingPayment, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(nil)
+
+		// When
+		_, err := service.UpdatePayment(context.Background(), paymentID, req)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -311,16 +367,16 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(999)
 		req := testutil.CreateUpdatePaymentRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.UpdatePayment(paymentID, req)
+		response, err := service.UpdatePayment(context.Background(), paymentID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -334,7 +390,7 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		existingPayment := testutil.CreatePaymentFixture()
@@ -344,10 +400,10 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		req.Status = "invalid_status"
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(existingPayment, nil)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(existingPayment, nil)
 
 		// When
-		response, err := service.UpdatePayment(paymentID, req)
+		response, err := service.UpdatePayment(context.Background(), paymentID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -361,7 +417,7 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		existingPayment := testutil.CreatePaymentFixture()
@@ -371,11 +427,11 @@ func TestPaymentService_UpdatePayment(t *testing.T) {
 		req.Status = entity.PaymentStatusCompleted.String()
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(existingPayment, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.Payment")).Return(errors.New("update failed"))
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(existingPayment, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Payment")).Return(errors.New("update failed"))
 
 		// When
-		response, err := service.UpdatePayment(paymentID, req)
+		response, err := service.UpdatePayment(context.Background(), paymentID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -391,21 +447,23 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = paymentID
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(payment, nil)
-		mockRepo.On("Delete", paymentID).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(payment, nil)
+		mockRepo.On("Delete", mock.Anything, paymentID).Return(nil)
 
 		// When
-		err := service.DeletePayment(paymentID)
+		err := service.DeletePayment(context.Background(), paymentID)
 
 		// Then
 		assert.NoError(t, err)
+		assert.Equal(t, []events.Event{events.PaymentDeleted{PaymentID: paymentID, UserID: payment.UserID}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -414,15 +472,15 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		err := service.DeletePayment(paymentID)
+		err := service.DeletePayment(context.Background(), paymentID)
 
 		// Then
 		assert.Error(t, err)
@@ -435,18 +493,18 @@ func TestPaymentService_DeletePayment(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		paymentID := uint(1)
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = paymentID
 
 		// Mock expectations
-		mockRepo.On("GetByID", paymentID).Return(payment, nil)
-		mockRepo.On("Delete", paymentID).Return(errors.New("delete failed"))
+		mockRepo.On("GetByID", mock.Anything, paymentID).Return(payment, nil)
+		mockRepo.On("Delete", mock.Anything, paymentID).Return(errors.New("delete failed"))
 
 		// When
-		err := service.DeletePayment(paymentID)
+		err := service.DeletePayment(context.Background(), paymentID)
 
 		// Then
 		assert.Error(t, err)
@@ -461,7 +519,7 @@ func TestPaymentService_GetPaymentsByUser(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		payments := []entity.Payment{
@@ -475,10 +533,10 @@ func TestPaymentService_GetPaymentsByUser(t *testing.T) {
 		payments[1].Amount = 200.00
 
 		// Mock expectations
-		mockRepo.On("GetByUserID", userID).Return(payments, nil)
+		mockRepo.On("GetByUserID", mock.Anything, userID).Return(payments, nil)
 
 		// When
-		response, err := service.GetPaymentsByUser(userID)
+		response, err := service.GetPaymentsByUser(context.Background(), userID)
 
 		// Then
 		assert.NoError(t, err)
@@ -496,15 +554,15 @@ func TestPaymentService_GetPaymentsByUser(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByUserID", userID).Return([]entity.Payment{}, nil)
+		mockRepo.On("GetByUserID", mock.Anything, userID).Return([]entity.Payment{}, nil)
 
 		// When
-		response, err := service.GetPaymentsByUser(userID)
+		response, err := service.GetPaymentsByUser(context.Background(), userID)
 
 		// Then
 		assert.NoError(t, err)
@@ -518,15 +576,15 @@ func TestPaymentService_GetPaymentsByUser(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByUserID", userID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByUserID", mock.Anything, userID).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.GetPaymentsByUser(userID)
+		response, err := service.GetPaymentsByUser(context.Background(), userID)
 
 		// Then
 		assert.Error(t, err)
@@ -542,7 +600,7 @@ func TestPaymentService_entityToResponse(t *testing.T) {
 		mockRepo := &testutil.MockPaymentRepository{}
 		mockUserService := &testutil.MockUserService{}
 		logger := testutil.NewSilentLogger()
-		service := NewPaymentService(mockRepo, mockUserService, logger).(*paymentService)
+		service := NewPaymentService(mockRepo, mockUserService, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger).(*paymentService)
 
 		payment := testutil.CreatePaymentFixture()
 		payment.ID = 1
@@ -10,6 +10,8 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/service"
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
 
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
@@ -28,10 +30,12 @@ type PaymentWorker struct {
 
 type CheckPaymentStatusPayload struct {
 	PaymentID uint `json:"payment_id"`
+	queue.Correlation
 }
 
 type ProcessPaymentPayload struct {
 	PaymentID uint `json:"payment_id"`
+	queue.Correlation
 }
 
 func NewPaymentWorker(
@@ -51,19 +55,19 @@ func NewPaymentWorker(
 func (w *PaymentWorker) HandleCheckPaymentStatus(ctx context.Context, task *asynq.Task) error {
 	var payload CheckPaymentStatusPayload
 	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
-		w.logger.Error("Failed to unmarshal payment status check payload",
+		logger.For(ctx, w.logger).Error("Failed to unmarshal payment status check payload",
 			zap.Error(err),
 			zap.ByteString("payload", task.Payload()))
 		return fmt.Errorf("json.Unmarshal failed: %w", err)
 	}
 
-	w.logger.Info("Processing payment status check",
+	logger.For(ctx, w.logger).Info("Processing payment status check",
 		zap.Uint("payment_id", payload.PaymentID))
 
 	// Get payment from database
-	payment, err := w.paymentService.GetPaymentByID(payload.PaymentID)
+	payment, err := w.paymentService.GetPaymentByID(ctx, payload.PaymentID)
 	if err != nil {
-		w.logger.Error("Failed to get payment",
+		logger.For(ctx, w.logger).Error("Failed to get payment",
 			zap.Uint("payment_id", payload.PaymentID),
 			zap.Error(err))
 		return fmt.Errorf("failed to get payment: %w", err)
@@ -73,7 +77,7 @@ func (w *PaymentWorker) HandleCheckPaymentStatus(ctx context.Context, task *asyn
 	if payment.Status == entity.PaymentStatusCompleted.String() ||
 		payment.Status == entity.PaymentStatusFailed.String() ||
 		payment.Status == entity.PaymentStatusCanceled.String() {
-		w.logger.Info("Payment already in final state, skipping check",
+		logger.For(ctx, w.logger).Info("Payment already in final state, skipping check",
 			zap.Uint("payment_id", payload.PaymentID),
 			zap.String("status", payment.Status))
 		return nil
@@ -90,16 +94,16 @@ func (w *PaymentWorker) HandleCheckPaymentStatus(ctx context.Context, task *asyn
 			Description: fmt.Sprintf("Status updated by worker at %s", time.Now().Format(time.RFC3339)),
 		}
 
-		_, err := w.paymentService.UpdatePayment(payload.PaymentID, updateReq)
+		_, err := w.paymentService.UpdatePayment(ctx, payload.PaymentID, updateReq)
 		if err != nil {
-			w.logger.Error("Failed to update payment status",
+			logger.For(ctx, w.logger).Error("Failed to update payment status",
 				zap.Uint("payment_id", payload.PaymentID),
 				zap.String("new_status", newStatus),
 				zap.Error(err))
 			return fmt.Errorf("failed to update payment status: %w", err)
 		}
 
-		w.logger.Info("Payment status updated",
+		logger.For(ctx, w.logger).Info("Payment status updated",
 			zap.Uint("payment_id", payload.PaymentID),
 			zap.String("old_status", payment.Status),
 			zap.String("new_status", newStatus))
@@ -107,8 +111,8 @@ func (w *PaymentWorker) HandleCheckPaymentStatus(ctx context.Context, task *asyn
 
 	// Schedule next check if payment is still pending
 	if newStatus == entity.PaymentStatusPending.String() {
-		if err := w.SchedulePaymentStatusCheck(payload.PaymentID, w.cfg.Worker.PaymentCheckInterval); err != nil {
-			w.logger.Error("Failed to schedule next payment check",
+		if err := w.SchedulePaymentStatusCheck(ctx, payload.PaymentID, w.cfg.Worker.PaymentCheckInterval); err != nil {
+			logger.For(ctx, w.logger).Error("Failed to schedule next payment check",
 				zap.Uint("payment_id", payload.PaymentID),
 				zap.Error(err))
 			// Don't return error as the current task was successful
@@ -121,19 +125,19 @@ func (w *PaymentWorker) HandleCheckPaymentStatus(ctx context.Context, task *asyn
 func (w *PaymentWorker) HandleProcessPayment(ctx context.Context, task *asynq.Task) error {
 	var payload ProcessPaymentPayload
 	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
-		w.logger.Error("Failed to unmarshal process payment payload",
+		logger.For(ctx, w.logger).Error("Failed to unmarshal process payment payload",
 			zap.Error(err),
 			zap.ByteString("payload", task.Payload()))
 		return fmt.Errorf("json.Unmarshal failed: %w", err)
 	}
 
-	w.logger.Info("Processing payment",
+	logger.For(ctx, w.logger).Info("Processing payment",
 		zap.Uint("payment_id", payload.PaymentID))
 
 	// Get payment from database
-	payment, err := w.paymentService.GetPaymentByID(payload.PaymentID)
+	payment, err := w.paymentService.GetPaymentByID(ctx, payload.PaymentID)
 	if err != nil {
-		w.logger.Error("Failed to get payment for processing",
+		logger.For(ctx, w.logger).Error("Failed to get payment for processing",
 			zap.Uint("payment_id", payload.PaymentID),
 			zap.Error(err))
 		return fmt.Errorf("failed to get payment: %w", err)
@@ -155,16 +159,16 @@ func (w *PaymentWorker) HandleProcessPayment(ctx context.Context, task *asynq.Ta
 		Description: fmt.Sprintf("Payment processed by worker at %s", time.Now().Format(time.RFC3339)),
 	}
 
-	_, err = w.paymentService.UpdatePayment(payload.PaymentID, updateReq)
+	_, err = w.paymentService.UpdatePayment(ctx, payload.PaymentID, updateReq)
 	if err != nil {
-		w.logger.Error("Failed to update payment after processing",
+		logger.For(ctx, w.logger).Error("Failed to update payment after processing",
 			zap.Uint("payment_id", payload.PaymentID),
 			zap.String("new_status", newStatus),
 			zap.Error(err))
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
-	w.logger.Info("Payment processing completed",
+	logger.For(ctx, w.logger).Info("Payment processing completed",
 		zap.Uint("payment_id", payload.PaymentID),
 		zap.String("final_status", newStatus),
 		zap.Bool("success", success))
@@ -172,8 +176,13 @@ func (w *PaymentWorker) HandleProcessPayment(ctx context.Context, task *asynq.Ta
 	return nil
 }
 
-func (w *PaymentWorker) SchedulePaymentStatusCheck(paymentID uint, delay time.Duration) error {
-	payload := CheckPaymentStatusPayload{PaymentID: paymentID}
+// SchedulePaymentStatusCheck enqueues a status check of the payment after
+// delay, correlated with the request of ctx.
+func (w *PaymentWorker) SchedulePaymentStatusCheck(ctx context.Context, paymentID uint, delay time.Duration) error {
+	ctx, span := queue.StartEnqueue(ctx, TypeCheckPaymentStatus)
+	defer span.End()
+
+	payload := CheckPaymentStatusPayload{PaymentID: paymentID, Correlation: queue.NewCorrelation(ctx)}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
@@ -187,11 +196,12 @@ func (w *PaymentWorker) SchedulePaymentStatusCheck(paymentID uint, delay time.Du
 	}
 
 	info, err := w.client.Enqueue(task, opts...)
+	queue.RecordEnqueue(span, info, err)
 	if err != nil {
 		return fmt.Errorf("failed to enqueue task: %w", err)
 	}
 
-	w.logger.Info("Scheduled payment status check",
+	logger.For(ctx, w.logger).Info("Scheduled payment status check",
 		zap.Uint("payment_id", paymentID),
 		zap.Duration("delay", delay),
 		zap.String("task_id", info.ID))
@@ -199,8 +209,13 @@ func (w *PaymentWorker) SchedulePaymentStatusCheck(paymentID uint, delay time.Du
 	return nil
 }
 
-func (w *PaymentWorker) SchedulePaymentProcessing(paymentID uint) error {
-	payload := ProcessPaymentPayload{PaymentID: paymentID}
+// SchedulePaymentProcessing enqueues the processing of the payment,
+// correlated with the request of ctx.
+func (w *PaymentWorker) SchedulePaymentProcessing(ctx context.Context, paymentID uint) error {
+	ctx, span := queue.StartEnqueue(ctx, TypeProcessPayment)
+	defer span.End()
+
+	payload := ProcessPaymentPayload{PaymentID: paymentID, Correlation: queue.NewCorrelation(ctx)}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
@@ -213,11 +228,12 @@ func (w *PaymentWorker) SchedulePaymentProcessing(paymentID uint) error {
 	}
 
 	info, err := w.client.Enqueue(task, opts...)
+	queue.RecordEnqueue(span, info, err)
 	if err != nil {
 		return fmt.Errorf("failed to enqueue task: %w", err)
 	}
 
-	w.logger.Info("Scheduled payment processing",
+	logger.For(ctx, w.logger).Info("Scheduled payment processing",
 		zap.Uint("payment_id", paymentID),
 		zap.String("task_id", info.ID))
 
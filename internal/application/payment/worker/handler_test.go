@@ -10,56 +10,58 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/hibiken/asynq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockPaymentService struct {
 	mock.Mock
 }
 
-func (m *MockPaymentService) CreatePayment(req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
-	args := m.Called(req)
+func (m *MockPaymentService) CreatePayment(ctx context.Context, req *dto.CreatePaymentRequest) (*dto.PaymentResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.PaymentResponse), args.Error(1)
 }
 
-func (m *MockPaymentService) GetPaymentByID(id uint) (*dto.PaymentResponse, error) {
-	args := m.Called(id)
+func (m *MockPaymentService) GetPaymentByID(ctx context.Context, id uint) (*dto.PaymentResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.PaymentResponse), args.Error(1)
 }
 
-func (m *MockPaymentService) GetPayments(filter *dto.PaymentFilter) (*dto.PaymentListResponse, error) {
-	args := m.Called(filter)
+func (m *MockPaymentService) GetPayments(ctx context.Context, filter *dto.PaymentFilter) (*dto.PaymentListResponse, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.PaymentListResponse), args.Error(1)
 }
 
-func (m *MockPaymentService) UpdatePayment(id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error) {
-	args := m.Called(id, req)
+func (m *MockPaymentService) UpdatePayment(ctx context.Context, id uint, req *dto.UpdatePaymentRequest) (*dto.PaymentResponse, error) {
+	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.PaymentResponse), args.Error(1)
 }
 
-func (m *MockPaymentService) DeletePayment(id uint) error {
-	args := m.Called(id)
+func (m *MockPaymentService) DeletePayment(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockPaymentService) GetPaymentsByUser(userID uint) ([]dto.PaymentResponse, error) {
-	args := m.Called(userID)
+func (m *MockPaymentService) GetPaymentsByUser(ctx context.Context, userID uint) ([]dto.PaymentResponse, error) {
+	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -125,8 +127,8 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
-		mockService.On("UpdatePayment", paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(updatedPayment, nil)
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
+		mockService.On("UpdatePayment", mock.Anything, paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(updatedPayment, nil)
 
 		// When
 		err := worker.HandleCheckPaymentStatus(context.Background(), task)
@@ -137,7 +139,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 
 		// Verify the update request has the correct status
 		updateCall := mockService.Calls[1]
-		updateReq := updateCall.Arguments[1].(*dto.UpdatePaymentRequest)
+		updateReq := updateCall.Arguments[2].(*dto.UpdatePaymentRequest)
 		assert.Equal(t, entity.PaymentStatusCompleted.String(), updateReq.Status)
 		assert.Contains(t, updateReq.Description, "Status updated by worker")
 	})
@@ -158,7 +160,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 			UpdatedAt: time.Now().Add(-1 * time.Hour),
 		}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
 
 		// When
 		err := worker.HandleCheckPaymentStatus(context.Background(), task)
@@ -166,7 +168,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 		// Then
 		assert.NoError(t, err)
 		mockService.AssertExpectations(t)
-		mockService.AssertNotCalled(t, "UpdatePayment")
+		mockService.AssertNotCalled(t, "UpdatePayment", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should schedule next check when payment remains pending", func(t *testing.T) {
@@ -187,7 +189,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 
 		taskInfo := &asynq.TaskInfo{ID: "task-123"}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
 		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(taskInfo, nil)
 
 		// When
@@ -197,7 +199,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 		assert.NoError(t, err)
 		mockService.AssertExpectations(t)
 		mockClient.AssertExpectations(t)
-		mockService.AssertNotCalled(t, "UpdatePayment")
+		mockService.AssertNotCalled(t, "UpdatePayment", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when payload is invalid", func(t *testing.T) {
@@ -224,7 +226,7 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 		payloadBytes, _ := json.Marshal(payload)
 		task := asynq.NewTask(TypeCheckPaymentStatus, payloadBytes)
 
-		mockService.On("GetPaymentByID", paymentID).Return(nil, errors.New("payment not found"))
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(nil, errors.New("payment not found"))
 
 		// When
 		err := worker.HandleCheckPaymentStatus(context.Background(), task)
@@ -251,8 +253,8 @@ func TestPaymentWorker_HandleCheckPaymentStatus(t *testing.T) {
 			UpdatedAt: time.Now().Add(-3 * time.Minute),
 		}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
-		mockService.On("UpdatePayment", paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(nil, errors.New("update failed"))
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
+		mockService.On("UpdatePayment", mock.Anything, paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(nil, errors.New("update failed"))
 
 		// When
 		err := worker.HandleCheckPaymentStatus(context.Background(), task)
@@ -294,8 +296,8 @@ func TestPaymentWorker_HandleProcessPayment(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
-		mockService.On("UpdatePayment", paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(processedPayment, nil)
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
+		mockService.On("UpdatePayment", mock.Anything, paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(processedPayment, nil)
 
 		// When
 		err := worker.HandleProcessPayment(context.Background(), task)
@@ -306,7 +308,7 @@ func TestPaymentWorker_HandleProcessPayment(t *testing.T) {
 
 		// Verify the update request
 		updateCall := mockService.Calls[1]
-		updateReq := updateCall.Arguments[1].(*dto.UpdatePaymentRequest)
+		updateReq := updateCall.Arguments[2].(*dto.UpdatePaymentRequest)
 		// Status could be completed or failed based on simulation
 		assert.True(t, updateReq.Status == entity.PaymentStatusCompleted.String() || updateReq.Status == entity.PaymentStatusFailed.String())
 		assert.Contains(t, updateReq.Description, "Payment processed by worker")
@@ -336,7 +338,7 @@ func TestPaymentWorker_HandleProcessPayment(t *testing.T) {
 		payloadBytes, _ := json.Marshal(payload)
 		task := asynq.NewTask(TypeProcessPayment, payloadBytes)
 
-		mockService.On("GetPaymentByID", paymentID).Return(nil, errors.New("payment not found"))
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(nil, errors.New("payment not found"))
 
 		// When
 		err := worker.HandleProcessPayment(context.Background(), task)
@@ -363,8 +365,8 @@ func TestPaymentWorker_HandleProcessPayment(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("GetPaymentByID", paymentID).Return(payment, nil)
-		mockService.On("UpdatePayment", paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(nil, errors.New("update failed"))
+		mockService.On("GetPaymentByID", mock.Anything, paymentID).Return(payment, nil)
+		mockService.On("UpdatePayment", mock.Anything, paymentID, mock.AnythingOfType("*dto.UpdatePaymentRequest")).Return(nil, errors.New("update failed"))
 
 		// When
 		err := worker.HandleProcessPayment(context.Background(), task)
@@ -388,7 +390,7 @@ func TestPaymentWorker_SchedulePaymentStatusCheck(t *testing.T) {
 		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(taskInfo, nil)
 
 		// When
-		err := worker.SchedulePaymentStatusCheck(paymentID, delay)
+		err := worker.SchedulePaymentStatusCheck(context.Background(), paymentID, delay)
 
 		// Then
 		assert.NoError(t, err)
@@ -405,6 +407,23 @@ func TestPaymentWorker_SchedulePaymentStatusCheck(t *testing.T) {
 		assert.Equal(t, paymentID, payload.PaymentID)
 	})
 
+	t.Run("should carry the request ID of the caller", func(t *testing.T) {
+		// Setup
+		worker, _, mockClient := setupPaymentWorker()
+		ctx := requestid.WithID(context.Background(), "req-123")
+
+		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(&asynq.TaskInfo{ID: "task-123"}, nil)
+
+		// When
+		err := worker.SchedulePaymentStatusCheck(ctx, 1, 5*time.Minute)
+
+		// Then
+		require.NoError(t, err)
+		var payload CheckPaymentStatusPayload
+		require.NoError(t, json.Unmarshal(mockClient.Calls[0].Arguments[0].(*asynq.Task).Payload(), &payload))
+		assert.Equal(t, "req-123", payload.RequestID)
+	})
+
 	t.Run("should return error when enqueue fails", func(t *testing.T) {
 		// Setup
 		worker, _, mockClient := setupPaymentWorker()
@@ -415,7 +434,7 @@ func TestPaymentWorker_SchedulePaymentStatusCheck(t *testing.T) {
 		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(nil, errors.New("enqueue failed"))
 
 		// When
-		err := worker.SchedulePaymentStatusCheck(paymentID, delay)
+		err := worker.SchedulePaymentStatusCheck(context.Background(), paymentID, delay)
 
 		// Then
 		assert.Error(t, err)
@@ -435,7 +454,7 @@ func TestPaymentWorker_SchedulePaymentProcessing(t *testing.T) {
 		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(taskInfo, nil)
 
 		// When
-		err := worker.SchedulePaymentProcessing(paymentID)
+		err := worker.SchedulePaymentProcessing(context.Background(), paymentID)
 
 		// Then
 		assert.NoError(t, err)
@@ -461,7 +480,7 @@ func TestPaymentWorker_SchedulePaymentProcessing(t *testing.T) {
 		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.AnythingOfType("[]asynq.Option")).Return(nil, errors.New("enqueue failed"))
 
 		// When
-		err := worker.SchedulePaymentProcessing(paymentID)
+		err := worker.SchedulePaymentProcessing(context.Background(), paymentID)
 
 		// Then
 		assert.Error(t, err)
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
 type CreateRadcheckRequest struct {
 	Username  string `json:"username" binding:"required,max=64"`
 	Attribute string `json:"attribute" binding:"required,max=64"`
@@ -20,19 +22,26 @@ type RadcheckResponse struct {
 	Attribute string `json:"attribute"`
 	Op        string `json:"op"`
 	Value     string `json:"value"`
+	TenantID  *uint  `json:"tenant_id,omitempty"`
 }
 
 type ListRadcheckResponse struct {
-	Data      []RadcheckResponse `json:"data"`
-	Total     int64              `json:"total"`
-	Page      int                `json:"page"`
-	PageSize  int                `json:"page_size"`
-	TotalPage int                `json:"total_page"`
+	Data           []RadcheckResponse `json:"data"`
+	Total          *int64             `json:"total,omitempty"`
+	TotalEstimated bool               `json:"total_estimated,omitempty"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+	TotalPage      *int               `json:"total_page,omitempty"`
+	NextCursor     string             `json:"next_cursor,omitempty"`
 }
 
+// RadcheckFilter takes the expressions of package listquery, such as
+// username=prefix:ali or sort=-username.
 type RadcheckFilter struct {
 	Username  string `json:"username" form:"username"`
 	Attribute string `json:"attribute" form:"attribute"`
+	Sort      string `json:"sort" form:"sort"`
 	Page      int    `json:"page" form:"page" binding:"min=1"`
 	PageSize  int    `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
 }
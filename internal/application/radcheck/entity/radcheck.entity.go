@@ -1,13 +1,22 @@
 package entity
 
+import "strings"
+
 type Radcheck struct {
-	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement;index:idx_radcheck_tenant_id_id,priority:2"`
 	Username  string `json:"username" gorm:"index;not null;size:64"`
 	Attribute string `json:"attribute" gorm:"not null;size:64"`
 	Op        string `json:"op" gorm:"not null;size:2;default:':='"`
 	Value     string `json:"value" gorm:"not null;size:253"`
+	TenantID  *uint  `json:"tenant_id" gorm:"index:idx_radcheck_tenant_id_id,priority:1"`
 }
 
 func (r Radcheck) TableName() string {
 	return "radcheck"
 }
+
+// HoldsPassword reports whether the value is a password, such as the value
+// of Cleartext-Password or NT-Password.
+func (r Radcheck) HoldsPassword() bool {
+	return strings.Contains(r.Attribute, "Password")
+}
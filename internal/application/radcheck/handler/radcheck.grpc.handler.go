@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/radcheck"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type RadcheckGrpcHandler struct {
+	radcheck.UnimplementedRadcheckServiceServer
+	radcheckService service.RadcheckService
+	logger          *zap.Logger
+}
+
+func NewRadcheckGrpcHandler(radcheckService service.RadcheckService, logger *zap.Logger) *RadcheckGrpcHandler {
+	return &RadcheckGrpcHandler{
+		radcheckService: radcheckService,
+		logger:          logger,
+	}
+}
+
+func (h *RadcheckGrpcHandler) CreateRadcheck(
+	ctx context.Context,
+	req *radcheck.CreateRadcheckRequest,
+) (*radcheck.CreateRadcheckResponse, error) {
+	createReq := &dto.CreateRadcheckRequest{
+		Username:  req.Username,
+		Attribute: req.Attribute,
+		Op:        req.Op,
+		Value:     req.Value,
+	}
+
+	radcheckResponse, err := h.radcheckService.CreateRadcheck(ctx, createReq)
+	if err != nil {
+		h.logger.Error("Failed to create radcheck via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radcheck.CreateRadcheckResponse{
+		Radcheck: toProtoRadcheck(radcheckResponse),
+	}, nil
+}
+
+func (h *RadcheckGrpcHandler) GetRadcheck(
+	ctx context.Context,
+	req *radcheck.GetRadcheckRequest,
+) (*radcheck.GetRadcheckResponse, error) {
+	radcheckResponse, err := h.radcheckService.GetRadcheckByID(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to get radcheck via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radcheck.GetRadcheckResponse{
+		Radcheck: toProtoRadcheck(radcheckResponse),
+	}, nil
+}
+
+func (h *RadcheckGrpcHandler) ListRadcheck(
+	ctx context.Context,
+	req *radcheck.ListRadcheckRequest,
+) (*radcheck.ListRadcheckResponse, error) {
+	filter := &dto.RadcheckFilter{
+		Username:  req.GetFilter().GetUsername(),
+		Attribute: req.GetFilter().GetAttribute(),
+		Sort:      req.GetFilter().GetSort(),
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		Params:    pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)},
+	}
+
+	listResponse, err := h.radcheckService.ListRadcheck(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list radcheck via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	protoRadchecks := make([]*radcheck.Radcheck, len(listResponse.Data))
+	for i := range listResponse.Data {
+		protoRadchecks[i] = toProtoRadcheck(&listResponse.Data[i])
+	}
+
+	return &radcheck.ListRadcheckResponse{
+		Radchecks:      protoRadchecks,
+		Total:          pagination.TotalOrZero(listResponse.Total),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
+	}, nil
+}
+
+func (h *RadcheckGrpcHandler) UpdateRadcheck(
+	ctx context.Context,
+	req *radcheck.UpdateRadcheckRequest,
+) (*radcheck.UpdateRadcheckResponse, error) {
+	updateReq := &dto.UpdateRadcheckRequest{
+		Username:  req.Username,
+		Attribute: req.Attribute,
+		Op:        req.Op,
+		Value:     req.Value,
+	}
+
+	radcheckResponse, err := h.radcheckService.UpdateRadcheck(ctx, uint(req.Id), updateReq)
+	if err != nil {
+		h.logger.Error("Failed to update radcheck via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radcheck.UpdateRadcheckResponse{
+		Radcheck: toProtoRadcheck(radcheckResponse),
+	}, nil
+}
+
+func (h *RadcheckGrpcHandler) DeleteRadcheck(
+	ctx context.Context,
+	req *radcheck.DeleteRadcheckRequest,
+) (*radcheck.DeleteRadcheckResponse, error) {
+	err := h.radcheckService.DeleteRadcheck(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to delete radcheck via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radcheck.DeleteRadcheckResponse{
+		Success: true,
+	}, nil
+}
+
+func toProtoRadcheck(r *dto.RadcheckResponse) *radcheck.Radcheck {
+	return &radcheck.Radcheck{
+		Id:        uint32(r.ID),
+		Username:  r.Username,
+		Attribute: r.Attribute,
+		Op:        r.Op,
+		Value:     r.Value,
+	}
+}
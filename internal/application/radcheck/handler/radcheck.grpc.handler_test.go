@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/radcheck"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupRadcheckGrpcHandler() (*RadcheckGrpcHandler, *testutil.MockRadcheckService) {
+	mockService := &testutil.MockRadcheckService{}
+	logger := testutil.NewSilentLogger()
+	handler := NewRadcheckGrpcHandler(mockService, logger)
+	return handler, mockService
+}
+
+func TestRadcheckGrpcHandler_CreateRadcheck(t *testing.T) {
+	t.Run("should create radcheck successfully", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+
+		req := &radcheck.CreateRadcheckRequest{
+			Username:  "alice",
+			Attribute: "Cleartext-Password",
+			Op:        ":=",
+			Value:     "secret",
+		}
+		response := &dto.RadcheckResponse{ID: 1, Username: "alice", Attribute: "Cleartext-Password", Op: ":=", Value: "secret"}
+
+		mockService.On("CreateRadcheck", mock.Anything, &dto.CreateRadcheckRequest{
+			Username:  "alice",
+			Attribute: "Cleartext-Password",
+			Op:        ":=",
+			Value:     "secret",
+		}).Return(response, nil)
+
+		// When
+		result, err := handler.CreateRadcheck(context.Background(), req)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), result.Radcheck.Id)
+		assert.Equal(t, "alice", result.Radcheck.Username)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return internal error when service fails", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("CreateRadcheck", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		// When
+		result, err := handler.CreateRadcheck(context.Background(), &radcheck.CreateRadcheckRequest{})
+
+		// Then
+		assert.Nil(t, result)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestRadcheckGrpcHandler_GetRadcheck(t *testing.T) {
+	t.Run("should return not found", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("GetRadcheckByID", mock.Anything, uint(99)).Return(nil, service.ErrRadcheckNotFound)
+
+		// When
+		result, err := handler.GetRadcheck(context.Background(), &radcheck.GetRadcheckRequest{Id: 99})
+
+		// Then
+		assert.Nil(t, result)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestRadcheckGrpcHandler_ListRadcheck(t *testing.T) {
+	t.Run("should pass filter and map results", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+
+		listResponse := &dto.ListRadcheckResponse{
+			Data:     []dto.RadcheckResponse{{ID: 1, Username: "alice"}, {ID: 2, Username: "alice"}},
+			Total:    pagination.Exact(2).Total,
+			Page:     1,
+			PageSize: 10,
+		}
+		mockService.On("ListRadcheck", mock.Anything, &dto.RadcheckFilter{Username: "alice", Page: 1, PageSize: 10}).
+			Return(listResponse, nil)
+
+		// When
+		result, err := handler.ListRadcheck(context.Background(), &radcheck.ListRadcheckRequest{
+			Page:     1,
+			PageSize: 10,
+			Filter:   &radcheck.RadcheckFilter{Username: "alice"},
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, result.Radchecks, 2)
+		assert.Equal(t, int64(2), result.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should accept a request without filter", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("ListRadcheck", mock.Anything, &dto.RadcheckFilter{}).
+			Return(&dto.ListRadcheckResponse{Page: 1, PageSize: 10}, nil)
+
+		// When
+		result, err := handler.ListRadcheck(context.Background(), &radcheck.ListRadcheckRequest{})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, result.Radchecks)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestRadcheckGrpcHandler_UpdateRadcheck(t *testing.T) {
+	t.Run("should update radcheck successfully", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("UpdateRadcheck", mock.Anything, uint(1), &dto.UpdateRadcheckRequest{Value: "new"}).
+			Return(&dto.RadcheckResponse{ID: 1, Value: "new"}, nil)
+
+		// When
+		result, err := handler.UpdateRadcheck(context.Background(), &radcheck.UpdateRadcheckRequest{Id: 1, Value: "new"})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, "new", result.Radcheck.Value)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestRadcheckGrpcHandler_DeleteRadcheck(t *testing.T) {
+	t.Run("should delete radcheck successfully", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("DeleteRadcheck", mock.Anything, uint(1)).Return(nil)
+
+		// When
+		result, err := handler.DeleteRadcheck(context.Background(), &radcheck.DeleteRadcheckRequest{Id: 1})
+
+		// Then
+		assert.NoError(t, err)
+		assert.True(t, result.Success)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupRadcheckGrpcHandler()
+		mockService.On("DeleteRadcheck", mock.Anything, uint(99)).Return(service.ErrRadcheckNotFound)
+
+		// When
+		result, err := handler.DeleteRadcheck(context.Background(), &radcheck.DeleteRadcheckRequest{Id: 99})
+
+		// Then
+		assert.Nil(t, result)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 	"go.uber.org/zap"
 )
 
@@ -30,21 +31,23 @@ func NewRadcheckHandler(service service.RadcheckService, logger *zap.Logger) *Ra
 // @Produce json
 // @Param request body dto.CreateRadcheckRequest true "Radcheck creation request"
 // @Success 201 {object} map[string]interface{} "Created radcheck"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request body"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radcheck [post]
 func (h *RadcheckHandler) CreateRadcheck(ctx *gin.Context) {
 	var req dto.CreateRadcheckRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	radcheck, err := h.service.CreateRadcheck(ctx.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create radcheck", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create radcheck"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -59,21 +62,23 @@ func (h *RadcheckHandler) CreateRadcheck(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Radcheck ID"
 // @Success 200 {object} map[string]interface{} "Radcheck details"
-// @Failure 400 {object} map[string]interface{} "Invalid radcheck ID"
-// @Failure 404 {object} map[string]interface{} "Radcheck not found"
+// @Failure 400 {object} apperror.Problem "Invalid radcheck ID"
+// @Failure 404 {object} apperror.Problem "Radcheck not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radcheck/{id} [get]
 func (h *RadcheckHandler) GetRadcheck(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radcheck ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	radcheck, err := h.service.GetRadcheckByID(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to get radcheck", zap.Error(err))
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Radcheck not found"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -86,26 +91,31 @@ func (h *RadcheckHandler) GetRadcheck(ctx *gin.Context) {
 // @Tags radcheck
 // @Accept json
 // @Produce json
-// @Param username query string false "Filter by username"
-// @Param attribute query string false "Filter by attribute"
+// @Param username query string false "Filter by username: exact, prefix:, contains: or in:"
+// @Param attribute query string false "Filter by attribute: exact, prefix:, contains: or in:"
+// @Param sort query string false "Comma separated fields, descending with a leading -: id, username, attribute"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
 // @Success 200 {object} dto.ListRadcheckResponse "List of radchecks"
-// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid query parameters"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radcheck [get]
 func (h *RadcheckHandler) ListRadcheck(ctx *gin.Context) {
 	var filter dto.RadcheckFilter
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
 		h.logger.Error("Invalid query parameters", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	radchecks, err := h.service.ListRadcheck(ctx.Request.Context(), &filter)
 	if err != nil {
 		h.logger.Error("Failed to list radcheck", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list radcheck"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -121,33 +131,31 @@ func (h *RadcheckHandler) ListRadcheck(ctx *gin.Context) {
 // @Param id path int true "Radcheck ID"
 // @Param request body dto.UpdateRadcheckRequest true "Radcheck update request"
 // @Success 200 {object} map[string]interface{} "Updated radcheck"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
-// @Failure 404 {object} map[string]interface{} "Radcheck not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request"
+// @Failure 404 {object} apperror.Problem "Radcheck not found"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radcheck/{id} [put]
 func (h *RadcheckHandler) UpdateRadcheck(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radcheck ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	var req dto.UpdateRadcheckRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	radcheck, err := h.service.UpdateRadcheck(ctx.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update radcheck", zap.Error(err))
-		if err.Error() == "radcheck not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update radcheck"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -162,26 +170,24 @@ func (h *RadcheckHandler) UpdateRadcheck(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Radcheck ID"
 // @Success 200 {object} map[string]interface{} "Radcheck deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid radcheck ID"
-// @Failure 404 {object} map[string]interface{} "Radcheck not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid radcheck ID"
+// @Failure 404 {object} apperror.Problem "Radcheck not found"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radcheck/{id} [delete]
 func (h *RadcheckHandler) DeleteRadcheck(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radcheck ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	err = h.service.DeleteRadcheck(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to delete radcheck", zap.Error(err))
-		if err.Error() == "radcheck not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete radcheck"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
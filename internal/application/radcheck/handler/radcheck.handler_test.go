@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 )
 
@@ -163,7 +165,7 @@ func TestRadcheckHandler_GetRadcheck(t *testing.T) {
 		handler, mockService := setupRadcheckHandler()
 
 		radcheckID := uint(999)
-		mockService.On("GetRadcheckByID", mock.Anything, radcheckID).Return(nil, errors.New("radcheck not found"))
+		mockService.On("GetRadcheckByID", mock.Anything, radcheckID).Return(nil, service.ErrRadcheckNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -191,10 +193,9 @@ func TestRadcheckHandler_ListRadcheck(t *testing.T) {
 				{ID: 1, Username: "user1", Attribute: "User-Password", Op: ":=", Value: "pass1"},
 				{ID: 2, Username: "user2", Attribute: "User-Password", Op: ":=", Value: "pass2"},
 			},
-			Total:     2,
-			Page:      1,
-			PageSize:  10,
-			TotalPage: 1,
+			Total:    pagination.Exact(2).Total,
+			Page:     1,
+			PageSize: 10,
 		}
 
 		mockService.On("ListRadcheck", mock.Anything, mock.AnythingOfType("*dto.RadcheckFilter")).Return(response, nil)
@@ -213,7 +214,7 @@ func TestRadcheckHandler_ListRadcheck(t *testing.T) {
 		var result dto.ListRadcheckResponse
 		json.Unmarshal(w.Body.Bytes(), &result)
 		assert.Len(t, result.Data, 2)
-		assert.Equal(t, int64(2), result.Total)
+		assert.Equal(t, int64(2), *result.Total)
 	})
 
 	t.Run("should return bad request for invalid query parameters", func(t *testing.T) {
@@ -339,7 +340,7 @@ func TestRadcheckHandler_UpdateRadcheck(t *testing.T) {
 
 		radcheckID := uint(999)
 		req := testutil.CreateUpdateRadcheckRequestFixture()
-		mockService.On("UpdateRadcheck", mock.Anything, radcheckID, mock.AnythingOfType("*dto.UpdateRadcheckRequest")).Return(nil, errors.New("radcheck not found"))
+		mockService.On("UpdateRadcheck", mock.Anything, radcheckID, mock.AnythingOfType("*dto.UpdateRadcheckRequest")).Return(nil, service.ErrRadcheckNotFound)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -436,7 +437,7 @@ func TestRadcheckHandler_DeleteRadcheck(t *testing.T) {
 		handler, mockService := setupRadcheckHandler()
 
 		radcheckID := uint(999)
-		mockService.On("DeleteRadcheck", mock.Anything, radcheckID).Return(errors.New("radcheck not found"))
+		mockService.On("DeleteRadcheck", mock.Anything, radcheckID).Return(service.ErrRadcheckNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
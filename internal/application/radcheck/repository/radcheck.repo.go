@@ -6,19 +6,33 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// radcheckFields are the fields radchecks are filtered and sorted by. Exact
+// usernames are looked up in the realm of the tenant.
+var radcheckFields = listquery.Fields{
+	"id":        {Column: "id", Kind: listquery.Number, Sortable: true},
+	"username":  {Column: "username", Kind: listquery.Text, Sortable: true, Qualify: tenant.QualifyUsername},
+	"attribute": {Column: "attribute", Kind: listquery.Text, Sortable: true},
+}
+
 type RadcheckRepository interface {
 	Create(ctx context.Context, radcheck *entity.Radcheck) error
 	GetByID(ctx context.Context, id uint) (*entity.Radcheck, error)
 	GetByUsernameAndAttribute(ctx context.Context, username, attribute string) (*entity.Radcheck, error)
-	GetAll(ctx context.Context, filter *dto.RadcheckFilter) ([]entity.Radcheck, int64, error)
+	GetAll(ctx context.Context, filter *dto.RadcheckFilter) ([]entity.Radcheck, pagination.Page, error)
 	Update(ctx context.Context, radcheck *entity.Radcheck) error
 	Delete(ctx context.Context, id uint) error
 }
 
+// radcheckRepository keeps every row in the tenant of the context: usernames
+// are placed in its realm and lookups are limited to it.
 type radcheckRepository struct {
 	db     *gorm.DB
 	logger *zap.Logger
@@ -35,7 +49,13 @@ func (r *radcheckRepository) Create(ctx context.Context, radcheck *entity.Radche
 	if radcheck.Op == "" {
 		radcheck.Op = ":="
 	}
-	r.logger.Info("Creating radcheck", zap.String("username", radcheck.Username))
+	username, err := tenant.QualifyUsername(ctx, radcheck.Username)
+	if err != nil {
+		return err
+	}
+	radcheck.Username = username
+	radcheck.TenantID = tenant.ID(ctx)
+	logger.For(ctx, r.logger).Info("Creating radcheck", zap.String("username", radcheck.Username))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	return db.Create(radcheck).Error
 }
@@ -43,65 +63,64 @@ func (r *radcheckRepository) Create(ctx context.Context, radcheck *entity.Radche
 func (r *radcheckRepository) GetByID(ctx context.Context, id uint) (*entity.Radcheck, error) {
 	var radcheck entity.Radcheck
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	err := db.First(&radcheck, id).Error
+	err := db.Scopes(tenant.Filter(ctx)).First(&radcheck, id).Error
 	if err != nil {
-		r.logger.Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 	return &radcheck, nil
 }
 
 func (r *radcheckRepository) GetByUsernameAndAttribute(ctx context.Context, username, attribute string) (*entity.Radcheck, error) {
+	username, err := tenant.QualifyUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
 	var radcheck entity.Radcheck
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	err := db.Where("username = ? AND attribute = ?", username, attribute).First(&radcheck).Error
+	err = db.Scopes(tenant.Filter(ctx)).Where("username = ? AND attribute = ?", username, attribute).First(&radcheck).Error
 	if err != nil {
-		r.logger.Error("Failed to get radcheck", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get radcheck", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
 		return nil, err
 	}
 	return &radcheck, nil
 }
 
-func (r *radcheckRepository) GetAll(ctx context.Context, filter *dto.RadcheckFilter) ([]entity.Radcheck, int64, error) {
-	var radchecks []entity.Radcheck
-	var totalCount int64
-
-	query := database.GetDB(ctx, r.db).(*gorm.DB).Model(&entity.Radcheck{})
-
-	if filter.Username != "" {
-		query = query.Where("username LIKE ?", "%"+filter.Username+"%")
-	}
-	if filter.Attribute != "" {
-		query = query.Where("attribute LIKE ?", "%"+filter.Attribute+"%")
-	}
-
-	if err := query.Count(&totalCount).Error; err != nil {
-		r.logger.Error("Failed to count radchecks", zap.Error(err))
-		return nil, 0, err
+func (r *radcheckRepository) GetAll(ctx context.Context, filter *dto.RadcheckFilter) ([]entity.Radcheck, pagination.Page, error) {
+	query, err := radcheckFields.Where(ctx, database.GetDB(ctx, r.db).(*gorm.DB).Model(&entity.Radcheck{}).Scopes(tenant.Filter(ctx)), map[string]string{
+		"username":  filter.Username,
+		"attribute": filter.Attribute,
+	})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
-
-	if filter.Page > 0 && filter.PageSize > 0 {
-		offset := (filter.Page - 1) * filter.PageSize
-		query = query.Offset(offset).Limit(filter.PageSize)
+	order, err := radcheckFields.Order(filter.Sort, pagination.Order{})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
 
-	err := query.Find(&radchecks).Error
+	radchecks, page, err := pagination.Find[entity.Radcheck](query, filter.Params, filter.Page, filter.PageSize, order)
 	if err != nil {
-		r.logger.Error("Failed to get radchecks", zap.Error(err))
-		return nil, 0, err
+		logger.For(ctx, r.logger).Error("Failed to get radchecks", zap.Error(err))
+		return nil, pagination.Page{}, err
 	}
 
-	return radchecks, totalCount, nil
+	return radchecks, page, nil
 }
 
 func (r *radcheckRepository) Update(ctx context.Context, radcheck *entity.Radcheck) error {
-	r.logger.Info("Updating radcheck", zap.Uint("id", radcheck.ID))
+	username, err := tenant.QualifyUsername(ctx, radcheck.Username)
+	if err != nil {
+		return err
+	}
+	radcheck.Username = username
+	logger.For(ctx, r.logger).Info("Updating radcheck", zap.Uint("id", radcheck.ID))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	return db.Save(radcheck).Error
 }
 
 func (r *radcheckRepository) Delete(ctx context.Context, id uint) error {
-	r.logger.Info("Deleting radcheck", zap.Uint("id", id))
+	logger.For(ctx, r.logger).Info("Deleting radcheck", zap.Uint("id", id))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	return db.Delete(&entity.Radcheck{}, id).Error
+	return db.Scopes(tenant.Filter(ctx)).Delete(&entity.Radcheck{}, id).Error
 }
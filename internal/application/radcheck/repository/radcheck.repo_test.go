@@ -10,6 +10,7 @@ import (
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 )
 
@@ -165,13 +166,13 @@ func TestRadcheckRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		radchecks, total, err := repo.GetAll(context.Background(), filter)
+		radchecks, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		require.NoError(t, err)
 		assert.NotNil(t, radchecks)
 		assert.Equal(t, 10, len(radchecks))
-		assert.Equal(t, int64(15), total)
+		assert.Equal(t, int64(15), *page.Total)
 	})
 
 	t.Run("should filter radchecks by username", func(t *testing.T) {
@@ -200,12 +201,12 @@ func TestRadcheckRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		radchecks, total, err := repo.GetAll(context.Background(), filter)
+		radchecks, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		require.NoError(t, err)
 		assert.Equal(t, 1, len(radchecks))
-		assert.Equal(t, int64(1), total)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "alice", radchecks[0].Username)
 	})
 
@@ -235,12 +236,12 @@ func TestRadcheckRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		radchecks, total, err := repo.GetAll(context.Background(), filter)
+		radchecks, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		require.NoError(t, err)
 		assert.Equal(t, 1, len(radchecks))
-		assert.Equal(t, int64(1), total)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "User-Password", radchecks[0].Attribute)
 	})
 
@@ -254,12 +255,12 @@ func TestRadcheckRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		radchecks, total, err := repo.GetAll(context.Background(), filter)
+		radchecks, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(radchecks))
-		assert.Equal(t, int64(0), total)
+		assert.Equal(t, int64(0), *page.Total)
 	})
 }
 
@@ -351,3 +352,79 @@ func TestRadcheckRepository_Delete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRadcheckRepository_TenantScope(t *testing.T) {
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	defer testutil.CleanDB(db)
+
+	logger := testutil.NewTestLogger(t)
+	repo := NewRadcheckRepository(db, logger)
+	ispA := tenant.WithScope(context.Background(), tenant.Scope{ID: 1, Realm: "isp-a.example"})
+	ispB := tenant.WithScope(context.Background(), tenant.Scope{ID: 2, Realm: "isp-b.example"})
+
+	t.Run("should place usernames in the realm of the tenant", func(t *testing.T) {
+		// Given
+		radcheck := &entity.Radcheck{Username: "john", Attribute: "Cleartext-Password", Value: "secret"}
+
+		// When
+		err := repo.Create(ispA, radcheck)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "john@isp-a.example", radcheck.Username)
+		require.NotNil(t, radcheck.TenantID)
+		assert.Equal(t, uint(1), *radcheck.TenantID)
+
+		found, err := repo.GetByUsernameAndAttribute(ispA, "john", "Cleartext-Password")
+		require.NoError(t, err)
+		assert.Equal(t, radcheck.ID, found.ID)
+	})
+
+	t.Run("should let tenants use the same username", func(t *testing.T) {
+		// Given
+		radcheck := &entity.Radcheck{Username: "john", Attribute: "Cleartext-Password", Value: "other"}
+
+		// When
+		err := repo.Create(ispB, radcheck)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "john@isp-b.example", radcheck.Username)
+	})
+
+	t.Run("should reject usernames in the realm of another tenant", func(t *testing.T) {
+		// Given
+		radcheck := &entity.Radcheck{Username: "john@isp-b.example", Attribute: "Simultaneous-Use", Value: "1"}
+
+		// When
+		err := repo.Create(ispA, radcheck)
+
+		// Then
+		assert.ErrorIs(t, err, tenant.ErrForeignRealm)
+		assert.Zero(t, radcheck.ID)
+	})
+
+	t.Run("should hide the rows of other tenants", func(t *testing.T) {
+		// Given
+		_, page, err := repo.GetAll(context.Background(), &dto.RadcheckFilter{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), *page.Total)
+
+		// When
+		radchecks, page, err := repo.GetAll(ispB, &dto.RadcheckFilter{Page: 1, PageSize: 10})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), *page.Total)
+		require.Len(t, radchecks, 1)
+		assert.Equal(t, "john@isp-b.example", radchecks[0].Username)
+
+		_, err = repo.GetByID(ispB, radchecks[0].ID-1)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		require.NoError(t, repo.Delete(ispB, radchecks[0].ID-1))
+		_, err = repo.GetByID(ispA, radchecks[0].ID-1)
+		assert.NoError(t, err)
+	})
+}
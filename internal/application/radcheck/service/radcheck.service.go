@@ -7,11 +7,20 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// ErrRadcheckNotFound is returned for radcheck IDs that do not exist.
+var ErrRadcheckNotFound = apperror.NotFound("radcheck not found")
+
 type RadcheckService interface {
 	CreateRadcheck(ctx context.Context, req *dto.CreateRadcheckRequest) (*dto.RadcheckResponse, error)
 	GetRadcheckByID(ctx context.Context, id uint) (*dto.RadcheckResponse, error)
@@ -22,14 +31,26 @@ type RadcheckService interface {
 }
 
 type radcheckService struct {
-	repo   repository.RadcheckRepository
-	logger *zap.Logger
+	repo      repository.RadcheckRepository
+	txManager database.TransactionManagerI
+	outbox    outbox.Outbox
+	auditor   audit.Recorder
+	logger    *zap.Logger
 }
 
-func NewRadcheckService(repo repository.RadcheckRepository, logger *zap.Logger) RadcheckService {
+func NewRadcheckService(
+	repo repository.RadcheckRepository,
+	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
+	logger *zap.Logger,
+) RadcheckService {
 	return &radcheckService{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		txManager: txManager,
+		outbox:    outbox,
+		auditor:   auditor,
+		logger:    logger,
 	}
 }
 
@@ -45,9 +66,17 @@ func (s *radcheckService) CreateRadcheck(ctx context.Context, req *dto.CreateRad
 		Value:     req.Value,
 	}
 
-	err := s.repo.Create(ctx, radcheck)
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, radcheck); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radcheckChange(audit.ActionCreate, nil, radcheck)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radcheck, events.ActionCreated))
+	})
 	if err != nil {
-		s.logger.Error("Failed to create radcheck", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to create radcheck", zap.Error(err))
 		return nil, err
 	}
 
@@ -58,9 +87,9 @@ func (s *radcheckService) GetRadcheckByID(ctx context.Context, id uint) (*dto.Ra
 	radcheck, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("radcheck not found")
+			return nil, ErrRadcheckNotFound
 		}
-		s.logger.Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
@@ -71,9 +100,9 @@ func (s *radcheckService) GetRadcheckByUsernameAndAttribute(ctx context.Context,
 	radcheck, err := s.repo.GetByUsernameAndAttribute(ctx, username, attribute)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("radcheck not found")
+			return nil, ErrRadcheckNotFound
 		}
-		s.logger.Error("Failed to get radcheck", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get radcheck", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
 		return nil, err
 	}
 
@@ -91,9 +120,9 @@ func (s *radcheckService) ListRadcheck(ctx context.Context, filter *dto.Radcheck
 		filter.PageSize = 100
 	}
 
-	radchecks, totalCount, err := s.repo.GetAll(ctx, filter)
+	radchecks, page, err := s.repo.GetAll(ctx, filter)
 	if err != nil {
-		s.logger.Error("Failed to list radchecks", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to list radchecks", zap.Error(err))
 		return nil, err
 	}
 
@@ -103,11 +132,13 @@ func (s *radcheckService) ListRadcheck(ctx context.Context, filter *dto.Radcheck
 	}
 
 	return &dto.ListRadcheckResponse{
-		Data:      responses,
-		Total:     totalCount,
-		Page:      filter.Page,
-		PageSize:  filter.PageSize,
-		TotalPage: (int(totalCount) + filter.PageSize - 1) / filter.PageSize,
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
 	}, nil
 }
 
@@ -119,12 +150,13 @@ func (s *radcheckService) UpdateRadcheck(ctx context.Context, id uint, req *dto.
 	radcheck, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("radcheck not found")
+			return nil, ErrRadcheckNotFound
 		}
-		s.logger.Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
+	before := *radcheck
 	if req.Username != "" {
 		radcheck.Username = req.Username
 	}
@@ -138,9 +170,17 @@ func (s *radcheckService) UpdateRadcheck(ctx context.Context, id uint, req *dto.
 		radcheck.Value = req.Value
 	}
 
-	err = s.repo.Update(ctx, radcheck)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, radcheck); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radcheckChange(audit.ActionUpdate, &before, radcheck)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radcheck, events.ActionUpdated))
+	})
 	if err != nil {
-		s.logger.Error("Failed to update radcheck", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to update radcheck", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 
@@ -148,24 +188,62 @@ func (s *radcheckService) UpdateRadcheck(ctx context.Context, id uint, req *dto.
 }
 
 func (s *radcheckService) DeleteRadcheck(ctx context.Context, id uint) error {
-	_, err := s.repo.GetByID(ctx, id)
+	radcheck, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("radcheck not found")
+			return ErrRadcheckNotFound
 		}
-		s.logger.Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to get radcheck by ID", zap.Uint("id", id), zap.Error(err))
 		return err
 	}
 
-	err = s.repo.Delete(ctx, id)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radcheckChange(audit.ActionDelete, radcheck, nil)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radcheck, events.ActionDeleted))
+	})
 	if err != nil {
-		s.logger.Error("Failed to delete radcheck", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to delete radcheck", zap.Uint("id", id), zap.Error(err))
 		return err
 	}
 
 	return nil
 }
 
+func radcheckChange(action string, before, after *entity.Radcheck) audit.Change {
+	change := audit.Change{EntityType: audit.EntityRadcheck, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+		if before.HoldsPassword() {
+			change.Redact = []string{"value"}
+		}
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+		if after.HoldsPassword() {
+			change.Redact = []string{"value"}
+		}
+	}
+	return change
+}
+
+func attributesChanged(radcheck *entity.Radcheck, action string) events.AttributesChanged {
+	return events.AttributesChanged{
+		Username:    radcheck.Username,
+		Table:       events.TableRadcheck,
+		Action:      action,
+		AttributeID: radcheck.ID,
+		Attribute:   radcheck.Attribute,
+		Op:          radcheck.Op,
+	}
+}
+
 func (s *radcheckService) entityToResponse(radcheck *entity.Radcheck) *dto.RadcheckResponse {
 	return &dto.RadcheckResponse{
 		ID:        radcheck.ID,
@@ -173,29 +251,30 @@ func (s *radcheckService) entityToResponse(radcheck *entity.Radcheck) *dto.Radch
 		Attribute: radcheck.Attribute,
 		Op:        radcheck.Op,
 		Value:     radcheck.Value,
+		TenantID:  radcheck.TenantID,
 	}
 }
 
 func (s *radcheckService) validateCreateRequest(req *dto.CreateRadcheckRequest) error {
 	if req.Username == "" {
-		return errors.New("username is required")
+		return apperror.InvalidField("username", "is required")
 	}
 	if len(req.Username) < 1 || len(req.Username) > 64 {
-		return errors.New("username must be between 1 and 64 characters")
+		return apperror.InvalidField("username", "must be between 1 and 64 characters")
 	}
 
 	if req.Attribute == "" {
-		return errors.New("attribute is required")
+		return apperror.InvalidField("attribute", "is required")
 	}
 	if len(req.Attribute) < 1 || len(req.Attribute) > 64 {
-		return errors.New("attribute must be between 1 and 64 characters")
+		return apperror.InvalidField("attribute", "must be between 1 and 64 characters")
 	}
 
 	if req.Value == "" {
-		return errors.New("value is required")
+		return apperror.InvalidField("value", "is required")
 	}
 	if len(req.Value) < 1 || len(req.Value) > 253 {
-		return errors.New("value must be between 1 and 253 characters")
+		return apperror.InvalidField("value", "must be between 1 and 253 characters")
 	}
 
 	return nil
@@ -203,15 +282,15 @@ func (s *radcheckService) validateCreateRequest(req *dto.CreateRadcheckRequest)
 
 func (s *radcheckService) validateUpdateRequest(req *dto.UpdateRadcheckRequest) error {
 	if req.Username != "" && (len(req.Username) < 1 || len(req.Username) > 64) {
-		return errors.New("username must be between 1 and 64 characters")
+		return apperror.InvalidField("username", "must be between 1 and 64 characters")
 	}
 
 	if req.Attribute != "" && (len(req.Attribute) < 1 || len(req.Attribute) > 64) {
-		return errors.New("attribute must be between 1 and 64 characters")
+		return apperror.InvalidField("attribute", "must be between 1 and 64 characters")
 	}
 
 	if req.Value != "" && (len(req.Value) < 1 || len(req.Value) > 253) {
-		return errors.New("value must be between 1 and 253 characters")
+		return apperror.InvalidField("value", "must be between 1 and 253 characters")
 	}
 
 	return nil
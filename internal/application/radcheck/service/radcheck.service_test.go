@@ -7,10 +7,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 )
 
@@ -19,7 +23,9 @@ func TestRadcheckService_CreateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		mockAuditor := &testutil.MockAuditRecorder{}
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, mockAuditor, logger)
 
 		req := testutil.CreateRadcheckRequestFixture()
 
@@ -40,6 +46,21 @@ func TestRadcheckService_CreateRadcheck(t *testing.T) {
 		assert.Equal(t, req.Attribute, response.Attribute)
 		assert.Equal(t, req.Op, response.Op)
 		assert.Equal(t, req.Value, response.Value)
+		assert.Equal(t, []events.Event{events.AttributesChanged{
+			Username:    req.Username,
+			Table:       events.TableRadcheck,
+			Action:      events.ActionCreated,
+			AttributeID: 1,
+			Attribute:   req.Attribute,
+			Op:          req.Op,
+		}}, mockOutbox.Events)
+
+		// The password value never reaches the audit trail
+		require.Len(t, mockAuditor.Changes, 1)
+		fields, err := audit.Diff(mockAuditor.Changes[0].Before, mockAuditor.Changes[0].After, mockAuditor.Changes[0].Redact...)
+		require.NoError(t, err)
+		assert.Equal(t, audit.FieldChange{After: audit.Redacted}, fields["value"])
+		assert.Equal(t, audit.FieldChange{After: req.Attribute}, fields["attribute"])
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -47,7 +68,7 @@ func TestRadcheckService_CreateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateRadcheckRequestFixture()
 
@@ -70,7 +91,7 @@ func TestRadcheckService_GetRadcheckByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		radcheck := testutil.CreateRadcheckFixture()
@@ -97,7 +118,7 @@ func TestRadcheckService_GetRadcheckByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(999)
 
@@ -118,7 +139,7 @@ func TestRadcheckService_GetRadcheckByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 
@@ -141,7 +162,7 @@ func TestRadcheckService_GetRadcheckByUsernameAndAttribute(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		username := "testuser"
 		attribute := "User-Password"
@@ -169,7 +190,7 @@ func TestRadcheckService_GetRadcheckByUsernameAndAttribute(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		username := "nonexistent"
 		attribute := "User-Password"
@@ -193,7 +214,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.RadcheckFilter{
 			Page:     1,
@@ -209,7 +230,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		radchecks[1].Username = "user2"
 
 		// Mock expectations
-		mockRepo.On("GetAll", mock.Anything, filter).Return(radchecks, int64(2), nil)
+		mockRepo.On("GetAll", mock.Anything, filter).Return(radchecks, pagination.Exact(2), nil)
 
 		// When
 		response, err := service.ListRadcheck(context.Background(), filter)
@@ -218,7 +239,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Len(t, response.Data, 2)
-		assert.Equal(t, int64(2), response.Total)
+		assert.Equal(t, int64(2), *response.Total)
 		assert.Equal(t, 1, response.Page)
 		assert.Equal(t, 10, response.PageSize)
 		mockRepo.AssertExpectations(t)
@@ -228,7 +249,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.RadcheckFilter{
 			Page:     0,
@@ -241,7 +262,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.Radcheck{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.Radcheck{}, pagination.Exact(0), nil)
 
 		// When
 		response, err := service.ListRadcheck(context.Background(), filter)
@@ -258,7 +279,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.RadcheckFilter{
 			Page:     1,
@@ -271,7 +292,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.Radcheck{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.Radcheck{}, pagination.Exact(0), nil)
 
 		// When
 		response, err := service.ListRadcheck(context.Background(), filter)
@@ -287,7 +308,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.RadcheckFilter{
 			Page:     1,
@@ -295,7 +316,7 @@ func TestRadcheckService_ListRadcheck(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", mock.Anything, filter).Return(nil, int64(0), errors.New("database error"))
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nil, pagination.Page{}, errors.New("database error"))
 
 		// When
 		response, err := service.ListRadcheck(context.Background(), filter)
@@ -313,7 +334,7 @@ func TestRadcheckService_UpdateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		existingRadcheck := testutil.CreateRadcheckFixture()
@@ -342,7 +363,7 @@ func TestRadcheckService_UpdateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(999)
 		req := testutil.CreateUpdateRadcheckRequestFixture()
@@ -364,7 +385,7 @@ func TestRadcheckService_UpdateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		existingRadcheck := testutil.CreateRadcheckFixture()
@@ -396,7 +417,7 @@ func TestRadcheckService_UpdateRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		existingRadcheck := testutil.CreateRadcheckFixture()
@@ -424,7 +445,8 @@ func TestRadcheckService_DeleteRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		radcheck := testutil.CreateRadcheckFixture()
@@ -439,6 +461,9 @@ func TestRadcheckService_DeleteRadcheck(t *testing.T) {
 
 		// Then
 		assert.NoError(t, err)
+		if assert.Len(t, mockOutbox.Events, 1) {
+			assert.Equal(t, events.ActionDeleted, mockOutbox.Events[0].(events.AttributesChanged).Action)
+		}
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -446,7 +471,7 @@ func TestRadcheckService_DeleteRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(999)
 
@@ -466,7 +491,7 @@ func TestRadcheckService_DeleteRadcheck(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		radcheckID := uint(1)
 		radcheck := testutil.CreateRadcheckFixture()
@@ -491,7 +516,7 @@ func TestRadcheckService_entityToResponse(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockRadcheckRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewRadcheckService(mockRepo, logger).(*radcheckService)
+		service := NewRadcheckService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger).(*radcheckService)
 
 		radcheck := testutil.CreateRadcheckFixture()
 		radcheck.ID = 1
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
 type CreateRadreplyRequest struct {
 	Username  string `json:"username" binding:"required"`
 	Attribute string `json:"attribute" binding:"required"`
@@ -20,19 +22,26 @@ type RadreplyResponse struct {
 	Attribute string `json:"attribute"`
 	Op        string `json:"op"`
 	Value     string `json:"value"`
+	TenantID  *uint  `json:"tenant_id,omitempty"`
 }
 
 type ListRadreplyResponse struct {
-	Data      []RadreplyResponse `json:"data"`
-	Total     int64              `json:"total"`
-	Page      int                `json:"page"`
-	PageSize  int                `json:"page_size"`
-	TotalPage int                `json:"total_page"`
+	Data           []RadreplyResponse `json:"data"`
+	Total          *int64             `json:"total,omitempty"`
+	TotalEstimated bool               `json:"total_estimated,omitempty"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+	TotalPage      *int               `json:"total_page,omitempty"`
+	NextCursor     string             `json:"next_cursor,omitempty"`
 }
 
+// RadreplyFilter takes the expressions of package listquery, such as
+// username=prefix:ali or sort=-username.
 type RadreplyFilter struct {
 	Username  string `form:"username"`
 	Attribute string `form:"attribute"`
+	Sort      string `form:"sort"`
 	Page      int    `form:"page,default=1"`
 	PageSize  int    `form:"page_size,default=10"`
+	pagination.Params
 }
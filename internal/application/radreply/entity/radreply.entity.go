@@ -1,11 +1,12 @@
 package entity
 
 type Radreply struct {
-	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement;index:idx_radreply_tenant_id_id,priority:2"`
 	Username  string `json:"username" gorm:"index;not null;size:64"`
 	Attribute string `json:"attribute" gorm:"not null;size:64"`
 	Op        string `json:"op" gorm:"not null;size:2;default:'='"`
 	Value     string `json:"value" gorm:"not null;size:253"`
+	TenantID  *uint  `json:"tenant_id" gorm:"index:idx_radreply_tenant_id_id,priority:1"`
 }
 
 func (r Radreply) TableName() string {
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/radreply"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type RadreplyGrpcHandler struct {
+	radreply.UnimplementedRadreplyServiceServer
+	radreplyService service.RadreplyService
+	logger          *zap.Logger
+}
+
+func NewRadreplyGrpcHandler(radreplyService service.RadreplyService, logger *zap.Logger) *RadreplyGrpcHandler {
+	return &RadreplyGrpcHandler{
+		radreplyService: radreplyService,
+		logger:          logger,
+	}
+}
+
+func (h *RadreplyGrpcHandler) CreateRadreply(
+	ctx context.Context,
+	req *radreply.CreateRadreplyRequest,
+) (*radreply.CreateRadreplyResponse, error) {
+	createReq := &dto.CreateRadreplyRequest{
+		Username:  req.Username,
+		Attribute: req.Attribute,
+		Op:        req.Op,
+		Value:     req.Value,
+	}
+
+	radreplyResponse, err := h.radreplyService.CreateRadreply(ctx, createReq)
+	if err != nil {
+		h.logger.Error("Failed to create radreply via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radreply.CreateRadreplyResponse{
+		Radreply: toProtoRadreply(radreplyResponse),
+	}, nil
+}
+
+func (h *RadreplyGrpcHandler) GetRadreply(
+	ctx context.Context,
+	req *radreply.GetRadreplyRequest,
+) (*radreply.GetRadreplyResponse, error) {
+	radreplyResponse, err := h.radreplyService.GetRadreplyByID(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to get radreply via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radreply.GetRadreplyResponse{
+		Radreply: toProtoRadreply(radreplyResponse),
+	}, nil
+}
+
+func (h *RadreplyGrpcHandler) ListRadreply(
+	ctx context.Context,
+	req *radreply.ListRadreplyRequest,
+) (*radreply.ListRadreplyResponse, error) {
+	filter := &dto.RadreplyFilter{
+		Username:  req.GetFilter().GetUsername(),
+		Attribute: req.GetFilter().GetAttribute(),
+		Sort:      req.GetFilter().GetSort(),
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		Params:    pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)},
+	}
+
+	listResponse, err := h.radreplyService.ListRadreply(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list radreply via gRPC", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	protoRadreplies := make([]*radreply.Radreply, len(listResponse.Data))
+	for i := range listResponse.Data {
+		protoRadreplies[i] = toProtoRadreply(&listResponse.Data[i])
+	}
+
+	return &radreply.ListRadreplyResponse{
+		Radreplies:     protoRadreplies,
+		Total:          pagination.TotalOrZero(listResponse.Total),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
+	}, nil
+}
+
+func (h *RadreplyGrpcHandler) UpdateRadreply(
+	ctx context.Context,
+	req *radreply.UpdateRadreplyRequest,
+) (*radreply.UpdateRadreplyResponse, error) {
+	updateReq := &dto.UpdateRadreplyRequest{
+		Username:  req.Username,
+		Attribute: req.Attribute,
+		Op:        req.Op,
+		Value:     req.Value,
+	}
+
+	radreplyResponse, err := h.radreplyService.UpdateRadreply(ctx, uint(req.Id), updateReq)
+	if err != nil {
+		h.logger.Error("Failed to update radreply via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radreply.UpdateRadreplyResponse{
+		Radreply: toProtoRadreply(radreplyResponse),
+	}, nil
+}
+
+func (h *RadreplyGrpcHandler) DeleteRadreply(
+	ctx context.Context,
+	req *radreply.DeleteRadreplyRequest,
+) (*radreply.DeleteRadreplyResponse, error) {
+	err := h.radreplyService.DeleteRadreply(ctx, uint(req.Id))
+	if err != nil {
+		h.logger.Error("Failed to delete radreply via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &radreply.DeleteRadreplyResponse{
+		Success: true,
+	}, nil
+}
+
+func toProtoRadreply(r *dto.RadreplyResponse) *radreply.Radreply {
+	return &radreply.Radreply{
+		Id:        uint32(r.ID),
+		Username:  r.Username,
+		Attribute: r.Attribute,
+		Op:        r.Op,
+		Value:     r.Value,
+	}
+}
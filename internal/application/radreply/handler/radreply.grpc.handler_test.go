@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/radreply"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
+	radreplyservice "github.com/novriyantoAli/freeradius-service/internal/application/radreply/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRadreplyGrpcHandler_CreateRadreply(t *testing.T) {
+	t.Run("should create radreply successfully", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.CreateRadreply(context.Background(), &radreply.CreateRadreplyRequest{
+			Username:  "alice",
+			Attribute: "Session-Timeout",
+			Op:        "=",
+			Value:     "3600",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), result.Radreply.Id)
+		assert.Equal(t, "Session-Timeout", result.Radreply.Attribute)
+		assert.Equal(t, "3600", result.Radreply.Value)
+	})
+
+	t.Run("should return internal error when service fails", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		service.CreateRadreplyFn = func(ctx context.Context, req *dto.CreateRadreplyRequest) (*dto.RadreplyResponse, error) {
+			return nil, errors.New("database error")
+		}
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.CreateRadreply(context.Background(), &radreply.CreateRadreplyRequest{})
+
+		assert.Nil(t, result)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestRadreplyGrpcHandler_GetRadreply(t *testing.T) {
+	t.Run("should return not found", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		service.GetRadreplyByIDFn = func(ctx context.Context, id uint) (*dto.RadreplyResponse, error) {
+			return nil, radreplyservice.ErrRadreplyNotFound
+		}
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.GetRadreply(context.Background(), &radreply.GetRadreplyRequest{Id: 99})
+
+		assert.Nil(t, result)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestRadreplyGrpcHandler_ListRadreply(t *testing.T) {
+	t.Run("should pass filter and map results", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		var received *dto.RadreplyFilter
+		service.ListRadreplyFn = func(ctx context.Context, filter *dto.RadreplyFilter) (*dto.ListRadreplyResponse, error) {
+			received = filter
+			return &dto.ListRadreplyResponse{
+				Data:     []dto.RadreplyResponse{{ID: 1, Username: "alice"}, {ID: 2, Username: "alice"}},
+				Total:    pagination.Exact(2).Total,
+				Page:     filter.Page,
+				PageSize: filter.PageSize,
+			}, nil
+		}
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.ListRadreply(context.Background(), &radreply.ListRadreplyRequest{
+			Page:     1,
+			PageSize: 10,
+			Filter:   &radreply.RadreplyFilter{Username: "alice"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.RadreplyFilter{Username: "alice", Page: 1, PageSize: 10}, received)
+		assert.Len(t, result.Radreplies, 2)
+		assert.Equal(t, int64(2), result.Total)
+	})
+}
+
+func TestRadreplyGrpcHandler_DeleteRadreply(t *testing.T) {
+	t.Run("should delete radreply successfully", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.DeleteRadreply(context.Background(), &radreply.DeleteRadreplyRequest{Id: 1})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Success)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		service := testutil.NewMockRadreplyService()
+		service.DeleteRadreplyFn = func(ctx context.Context, id uint) error {
+			return radreplyservice.ErrRadreplyNotFound
+		}
+		handler := NewRadreplyGrpcHandler(service, testutil.NewSilentLogger())
+
+		result, err := handler.DeleteRadreply(context.Background(), &radreply.DeleteRadreplyRequest{Id: 99})
+
+		assert.Nil(t, result)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 	"go.uber.org/zap"
 )
 
@@ -41,22 +42,24 @@ func (h *RadreplyHandler) RegisterRoutes(r *gin.RouterGroup) {
 // @Produce json
 // @Param request body dto.CreateRadreplyRequest true "Create Radreply Request"
 // @Success 201 {object} dto.RadreplyResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radreply [post]
 func (h *RadreplyHandler) CreateRadreply(ctx *gin.Context) {
 	var req dto.CreateRadreplyRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	result, err := h.service.CreateRadreply(ctx.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create radreply", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -71,24 +74,22 @@ func (h *RadreplyHandler) CreateRadreply(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Radreply ID"
 // @Success 200 {object} dto.RadreplyResponse
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radreply/{id} [get]
 func (h *RadreplyHandler) GetRadreply(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid id"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	result, err := h.service.GetRadreplyByID(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to get radreply", zap.Error(err))
-		if err.Error() == "radreply not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"message": "radreply not found"})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -103,25 +104,30 @@ func (h *RadreplyHandler) GetRadreply(ctx *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
-// @Param username query string false "Filter by username"
-// @Param attribute query string false "Filter by attribute"
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param username query string false "Filter by username: exact, prefix:, contains: or in:"
+// @Param attribute query string false "Filter by attribute: exact, prefix:, contains: or in:"
+// @Param sort query string false "Comma separated fields, descending with a leading -: id, username, attribute"
 // @Success 200 {object} dto.ListRadreplyResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radreply [get]
 func (h *RadreplyHandler) ListRadreply(ctx *gin.Context) {
 	var filter dto.RadreplyFilter
 
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
 		h.logger.Error("Invalid query parameters", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	result, err := h.service.ListRadreply(ctx.Request.Context(), &filter)
 	if err != nil {
 		h.logger.Error("Failed to list radreply", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -137,14 +143,16 @@ func (h *RadreplyHandler) ListRadreply(ctx *gin.Context) {
 // @Param id path int true "Radreply ID"
 // @Param request body dto.UpdateRadreplyRequest true "Update Radreply Request"
 // @Success 200 {object} dto.RadreplyResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radreply/{id} [put]
 func (h *RadreplyHandler) UpdateRadreply(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid id"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
@@ -152,18 +160,14 @@ func (h *RadreplyHandler) UpdateRadreply(ctx *gin.Context) {
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
 	result, err := h.service.UpdateRadreply(ctx.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update radreply", zap.Error(err))
-		if err.Error() == "radreply not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"message": "radreply not found"})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -178,19 +182,21 @@ func (h *RadreplyHandler) UpdateRadreply(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "Radreply ID"
 // @Success 204
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/radreply/{id} [delete]
 func (h *RadreplyHandler) DeleteRadreply(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid id"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	if err := h.service.DeleteRadreply(ctx.Request.Context(), uint(id)); err != nil {
 		h.logger.Error("Failed to delete radreply", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		apperror.Respond(ctx, err)
 		return
 	}
 
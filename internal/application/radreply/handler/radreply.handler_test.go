@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
+	radreplyservice "github.com/novriyantoAli/freeradius-service/internal/application/radreply/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -103,7 +104,7 @@ func TestRadreplyHandler_GetRadreply(t *testing.T) {
 	t.Run("should return not found when radreply not found", func(t *testing.T) {
 		service := testutil.NewMockRadreplyService()
 		service.GetRadreplyByIDFn = func(ctx context.Context, id uint) (*dto.RadreplyResponse, error) {
-			return nil, errors.New("radreply not found")
+			return nil, radreplyservice.ErrRadreplyNotFound
 		}
 		handler := NewRadreplyHandler(service, testutil.NewSilentLogger())
 
@@ -148,10 +149,9 @@ func TestRadreplyHandler_ListRadreply(t *testing.T) {
 						Value:     "Welcome",
 					},
 				},
-				Total:     1,
-				Page:      1,
-				PageSize:  10,
-				TotalPage: 1,
+				Total:    pagination.Exact(1).Total,
+				Page:     1,
+				PageSize: 10,
 			}, nil
 		}
 		handler := NewRadreplyHandler(service, testutil.NewSilentLogger())
@@ -233,7 +233,7 @@ func TestRadreplyHandler_UpdateRadreply(t *testing.T) {
 	t.Run("should return not found when radreply not found", func(t *testing.T) {
 		service := testutil.NewMockRadreplyService()
 		service.UpdateRadreplyFn = func(ctx context.Context, id uint, req *dto.UpdateRadreplyRequest) (*dto.RadreplyResponse, error) {
-			return nil, errors.New("radreply not found")
+			return nil, radreplyservice.ErrRadreplyNotFound
 		}
 		handler := NewRadreplyHandler(service, testutil.NewSilentLogger())
 
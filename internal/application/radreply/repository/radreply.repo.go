@@ -6,19 +6,33 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// radreplyFields are the fields radreplies are filtered and sorted by. Exact
+// usernames are looked up in the realm of the tenant.
+var radreplyFields = listquery.Fields{
+	"id":        {Column: "id", Kind: listquery.Number, Sortable: true},
+	"username":  {Column: "username", Kind: listquery.Text, Sortable: true, Qualify: tenant.QualifyUsername},
+	"attribute": {Column: "attribute", Kind: listquery.Text, Sortable: true},
+}
+
 type RadreplyRepository interface {
 	Create(ctx context.Context, radreply *entity.Radreply) error
 	GetByID(ctx context.Context, id uint) (*entity.Radreply, error)
 	GetByUsernameAndAttribute(ctx context.Context, username, attribute string) (*entity.Radreply, error)
-	GetAll(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error)
+	GetAll(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error)
 	Update(ctx context.Context, radreply *entity.Radreply) error
 	Delete(ctx context.Context, id uint) error
 }
 
+// radreplyRepository keeps every row in the tenant of the context: usernames
+// are placed in its realm and lookups are limited to it.
 type radreplyRepository struct {
 	db     *gorm.DB
 	logger *zap.Logger
@@ -32,7 +46,13 @@ func NewRadreplyRepository(db *gorm.DB, logger *zap.Logger) RadreplyRepository {
 }
 
 func (r *radreplyRepository) Create(ctx context.Context, radreply *entity.Radreply) error {
-	r.logger.Info("Creating radreply", zap.String("username", radreply.Username))
+	username, err := tenant.QualifyUsername(ctx, radreply.Username)
+	if err != nil {
+		return err
+	}
+	radreply.Username = username
+	radreply.TenantID = tenant.ID(ctx)
+	logger.For(ctx, r.logger).Info("Creating radreply", zap.String("username", radreply.Username))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	return db.Create(radreply).Error
 }
@@ -40,63 +60,64 @@ func (r *radreplyRepository) Create(ctx context.Context, radreply *entity.Radrep
 func (r *radreplyRepository) GetByID(ctx context.Context, id uint) (*entity.Radreply, error) {
 	var radreply entity.Radreply
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	err := db.Where("id = ?", id).First(&radreply).Error
+	err := db.Scopes(tenant.Filter(ctx)).Where("id = ?", id).First(&radreply).Error
 	if err != nil {
-		r.logger.Error("Failed to get radreply by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get radreply by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 	return &radreply, nil
 }
 
 func (r *radreplyRepository) GetByUsernameAndAttribute(ctx context.Context, username, attribute string) (*entity.Radreply, error) {
+	username, err := tenant.QualifyUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
 	var radreply entity.Radreply
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	err := db.Where("username = ? AND attribute = ?", username, attribute).First(&radreply).Error
+	err = db.Scopes(tenant.Filter(ctx)).Where("username = ? AND attribute = ?", username, attribute).First(&radreply).Error
 	if err != nil {
-		r.logger.Error("Failed to get radreply", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get radreply", zap.String("username", username), zap.String("attribute", attribute), zap.Error(err))
 		return nil, err
 	}
 	return &radreply, nil
 }
 
-func (r *radreplyRepository) GetAll(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error) {
-	var radreply []entity.Radreply
-	var total int64
-
-	query := database.GetDB(ctx, r.db).(*gorm.DB)
-
-	if filter.Username != "" {
-		query = query.Where("username = ?", filter.Username)
-	}
-
-	if filter.Attribute != "" {
-		query = query.Where("attribute = ?", filter.Attribute)
+func (r *radreplyRepository) GetAll(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error) {
+	query, err := radreplyFields.Where(ctx, database.GetDB(ctx, r.db).(*gorm.DB).Model(&entity.Radreply{}).Scopes(tenant.Filter(ctx)), map[string]string{
+		"username":  filter.Username,
+		"attribute": filter.Attribute,
+	})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
-
-	// Get total count
-	if err := query.Model(&entity.Radreply{}).Count(&total).Error; err != nil {
-		r.logger.Error("Failed to get radreply count", zap.Error(err))
-		return nil, 0, err
+	order, err := radreplyFields.Order(filter.Sort, pagination.Order{})
+	if err != nil {
+		return nil, pagination.Page{}, err
 	}
 
-	// Get paginated results
-	offset := (filter.Page - 1) * filter.PageSize
-	if err := query.Offset(offset).Limit(filter.PageSize).Find(&radreply).Error; err != nil {
-		r.logger.Error("Failed to get radreply list", zap.Error(err))
-		return nil, 0, err
+	radreply, page, err := pagination.Find[entity.Radreply](query, filter.Params, filter.Page, filter.PageSize, order)
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get radreply list", zap.Error(err))
+		return nil, pagination.Page{}, err
 	}
 
-	return radreply, total, nil
+	return radreply, page, nil
 }
 
 func (r *radreplyRepository) Update(ctx context.Context, radreply *entity.Radreply) error {
-	r.logger.Info("Updating radreply", zap.Uint("id", radreply.ID))
+	username, err := tenant.QualifyUsername(ctx, radreply.Username)
+	if err != nil {
+		return err
+	}
+	radreply.Username = username
+	logger.For(ctx, r.logger).Info("Updating radreply", zap.Uint("id", radreply.ID))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	return db.Save(radreply).Error
 }
 
 func (r *radreplyRepository) Delete(ctx context.Context, id uint) error {
-	r.logger.Info("Deleting radreply", zap.Uint("id", id))
+	logger.For(ctx, r.logger).Info("Deleting radreply", zap.Uint("id", id))
 	db := database.GetDB(ctx, r.db).(*gorm.DB)
-	return db.Delete(&entity.Radreply{}, id).Error
+	return db.Scopes(tenant.Filter(ctx)).Delete(&entity.Radreply{}, id).Error
 }
@@ -122,10 +122,10 @@ func TestRadreplyRepository_GetAll(t *testing.T) {
 		PageSize: 10,
 	}
 
-	result, total, err := repo.GetAll(context.Background(), filter)
+	result, page, err := repo.GetAll(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, int64(2), total)
+	assert.Equal(t, int64(2), *page.Total)
 	assert.Len(t, result, 2)
 }
 
@@ -148,10 +148,10 @@ func TestRadreplyRepository_GetAll_WithFilter(t *testing.T) {
 		PageSize: 10,
 	}
 
-	result, total, err := repo.GetAll(context.Background(), filter)
+	result, page, err := repo.GetAll(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, int64(1), total)
+	assert.Equal(t, int64(1), *page.Total)
 	assert.Len(t, result, 1)
 }
 
@@ -177,10 +177,10 @@ func TestRadreplyRepository_GetAll_Pagination(t *testing.T) {
 		PageSize: 10,
 	}
 
-	result, total, err := repo.GetAll(context.Background(), filter)
+	result, page, err := repo.GetAll(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, int64(15), total)
+	assert.Equal(t, int64(15), *page.Total)
 	assert.Len(t, result, 5)
 }
 
@@ -196,10 +196,10 @@ func TestRadreplyRepository_GetAll_Empty(t *testing.T) {
 		PageSize: 10,
 	}
 
-	result, total, err := repo.GetAll(context.Background(), filter)
+	result, page, err := repo.GetAll(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, int64(0), total)
+	assert.Equal(t, int64(0), *page.Total)
 	assert.Len(t, result, 0)
 }
 
@@ -7,10 +7,19 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// ErrRadreplyNotFound is returned for radreply IDs that do not exist.
+var ErrRadreplyNotFound = apperror.NotFound("radreply not found")
+
 type RadreplyService interface {
 	CreateRadreply(ctx context.Context, req *dto.CreateRadreplyRequest) (*dto.RadreplyResponse, error)
 	GetRadreplyByID(ctx context.Context, id uint) (*dto.RadreplyResponse, error)
@@ -22,12 +31,24 @@ type RadreplyService interface {
 
 type radreplyService struct {
 	repository repository.RadreplyRepository
+	txManager  database.TransactionManagerI
+	outbox     outbox.Outbox
+	auditor    audit.Recorder
 	logger     *zap.Logger
 }
 
-func NewRadreplyService(repository repository.RadreplyRepository, logger *zap.Logger) RadreplyService {
+func NewRadreplyService(
+	repository repository.RadreplyRepository,
+	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
+	logger *zap.Logger,
+) RadreplyService {
 	return &radreplyService{
 		repository: repository,
+		txManager:  txManager,
+		outbox:     outbox,
+		auditor:    auditor,
 		logger:     logger,
 	}
 }
@@ -45,8 +66,17 @@ func (s *radreplyService) CreateRadreply(ctx context.Context, req *dto.CreateRad
 		Value:     req.Value,
 	}
 
-	if err := s.repository.Create(ctx, radreply); err != nil {
-		s.logger.Error("Failed to create radreply", zap.Error(err))
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.Create(txCtx, radreply); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radreplyChange(audit.ActionCreate, nil, radreply)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radreply, events.ActionCreated))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create radreply", zap.Error(err))
 		return nil, err
 	}
 
@@ -57,7 +87,7 @@ func (s *radreplyService) GetRadreplyByID(ctx context.Context, id uint) (*dto.Ra
 	radreply, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("radreply not found")
+			return nil, ErrRadreplyNotFound
 		}
 		return nil, err
 	}
@@ -69,7 +99,7 @@ func (s *radreplyService) GetRadreplyByUsernameAndAttribute(ctx context.Context,
 	radreply, err := s.repository.GetByUsernameAndAttribute(ctx, username, attribute)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("radreply not found")
+			return nil, ErrRadreplyNotFound
 		}
 		return nil, err
 	}
@@ -88,7 +118,7 @@ func (s *radreplyService) ListRadreply(ctx context.Context, filter *dto.Radreply
 		filter.PageSize = 100
 	}
 
-	radreply, total, err := s.repository.GetAll(ctx, filter)
+	radreply, page, err := s.repository.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -98,14 +128,14 @@ func (s *radreplyService) ListRadreply(ctx context.Context, filter *dto.Radreply
 		responses = append(responses, *s.entityToResponse(&r))
 	}
 
-	totalPage := int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize))
-
 	return &dto.ListRadreplyResponse{
-		Data:      responses,
-		Total:     total,
-		Page:      filter.Page,
-		PageSize:  filter.PageSize,
-		TotalPage: totalPage,
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
 	}, nil
 }
 
@@ -120,6 +150,7 @@ func (s *radreplyService) UpdateRadreply(ctx context.Context, id uint, req *dto.
 		return nil, err
 	}
 
+	before := *radreply
 	if req.Username != "" {
 		radreply.Username = req.Username
 	}
@@ -133,8 +164,17 @@ func (s *radreplyService) UpdateRadreply(ctx context.Context, id uint, req *dto.
 		radreply.Value = req.Value
 	}
 
-	if err := s.repository.Update(ctx, radreply); err != nil {
-		s.logger.Error("Failed to update radreply", zap.Error(err))
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repository.Update(txCtx, radreply); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radreplyChange(audit.ActionUpdate, &before, radreply)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radreply, events.ActionUpdated))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to update radreply", zap.Error(err))
 		return nil, err
 	}
 
@@ -142,13 +182,55 @@ func (s *radreplyService) UpdateRadreply(ctx context.Context, id uint, req *dto.
 }
 
 func (s *radreplyService) DeleteRadreply(ctx context.Context, id uint) error {
-	if err := s.repository.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete radreply", zap.Error(err))
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		radreply, err := s.repository.GetByID(txCtx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Deleting a missing radreply stays a no-op.
+				return nil
+			}
+			return err
+		}
+
+		if err := s.repository.Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, radreplyChange(audit.ActionDelete, radreply, nil)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, attributesChanged(radreply, events.ActionDeleted))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to delete radreply", zap.Error(err))
 		return err
 	}
 	return nil
 }
 
+func radreplyChange(action string, before, after *entity.Radreply) audit.Change {
+	change := audit.Change{EntityType: audit.EntityRadreply, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+	}
+	return change
+}
+
+func attributesChanged(radreply *entity.Radreply, action string) events.AttributesChanged {
+	return events.AttributesChanged{
+		Username:    radreply.Username,
+		Table:       events.TableRadreply,
+		Action:      action,
+		AttributeID: radreply.ID,
+		Attribute:   radreply.Attribute,
+		Op:          radreply.Op,
+	}
+}
+
 func (s *radreplyService) entityToResponse(radreply *entity.Radreply) *dto.RadreplyResponse {
 	return &dto.RadreplyResponse{
 		ID:        radreply.ID,
@@ -156,6 +238,7 @@ func (s *radreplyService) entityToResponse(radreply *entity.Radreply) *dto.Radre
 		Attribute: radreply.Attribute,
 		Op:        radreply.Op,
 		Value:     radreply.Value,
+		TenantID:  radreply.TenantID,
 	}
 }
 
@@ -164,34 +247,34 @@ func (s *radreplyService) entityToResponse(radreply *entity.Radreply) *dto.Radre
 func (s *radreplyService) validateCreateRequest(req *dto.CreateRadreplyRequest) error {
 	// Username constraint: NOT NULL, size 1-64
 	if req.Username == "" {
-		return errors.New("username is required")
+		return apperror.InvalidField("username", "is required")
 	}
 	if len(req.Username) > 64 {
-		return errors.New("username must not exceed 64 characters")
+		return apperror.InvalidField("username", "must not exceed 64 characters")
 	}
 
 	// Attribute constraint: NOT NULL, size 1-64
 	if req.Attribute == "" {
-		return errors.New("attribute is required")
+		return apperror.InvalidField("attribute", "is required")
 	}
 	if len(req.Attribute) > 64 {
-		return errors.New("attribute must not exceed 64 characters")
+		return apperror.InvalidField("attribute", "must not exceed 64 characters")
 	}
 
 	// Op constraint: NOT NULL, size 1-2, default '='
 	if req.Op == "" {
-		return errors.New("op is required")
+		return apperror.InvalidField("op", "is required")
 	}
 	if len(req.Op) > 2 {
-		return errors.New("op must not exceed 2 characters")
+		return apperror.InvalidField("op", "must not exceed 2 characters")
 	}
 
 	// Value constraint: NOT NULL, size 1-253
 	if req.Value == "" {
-		return errors.New("value is required")
+		return apperror.InvalidField("value", "is required")
 	}
 	if len(req.Value) > 253 {
-		return errors.New("value must not exceed 253 characters")
+		return apperror.InvalidField("value", "must not exceed 253 characters")
 	}
 
 	return nil
@@ -200,19 +283,19 @@ func (s *radreplyService) validateCreateRequest(req *dto.CreateRadreplyRequest)
 func (s *radreplyService) validateUpdateRequest(req *dto.UpdateRadreplyRequest) error {
 	// Only validate fields that are being updated
 	if req.Username != "" && len(req.Username) > 64 {
-		return errors.New("username must not exceed 64 characters")
+		return apperror.InvalidField("username", "must not exceed 64 characters")
 	}
 
 	if req.Attribute != "" && len(req.Attribute) > 64 {
-		return errors.New("attribute must not exceed 64 characters")
+		return apperror.InvalidField("attribute", "must not exceed 64 characters")
 	}
 
 	if req.Op != "" && len(req.Op) > 2 {
-		return errors.New("op must not exceed 2 characters")
+		return apperror.InvalidField("op", "must not exceed 2 characters")
 	}
 
 	if req.Value != "" && len(req.Value) > 253 {
-		return errors.New("value must not exceed 253 characters")
+		return apperror.InvalidField("value", "must not exceed 253 characters")
 	}
 
 	return nil
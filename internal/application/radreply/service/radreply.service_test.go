@@ -6,6 +6,8 @@ import (
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -14,7 +16,8 @@ import (
 func TestRadreplyService_CreateRadreply(t *testing.T) {
 	t.Run("should create radreply successfully", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := testutil.CreateRadreplyRequestFixture()
 
@@ -24,6 +27,12 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, req.Username, result.Username)
 		assert.Equal(t, req.Attribute, result.Attribute)
+		if assert.Len(t, mockOutbox.Events, 1) {
+			event := mockOutbox.Events[0].(events.AttributesChanged)
+			assert.Equal(t, events.TableRadreply, event.Table)
+			assert.Equal(t, events.ActionCreated, event.Action)
+			assert.Equal(t, req.Username, event.Username)
+		}
 	})
 
 	t.Run("should fail when repository fails", func(t *testing.T) {
@@ -31,7 +40,7 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 		repo.CreateFn = func(ctx context.Context, radreply *entity.Radreply) error {
 			return gorm.ErrInvalidDB
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := testutil.CreateRadreplyRequestFixture()
 
@@ -43,7 +52,7 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 
 	t.Run("should fail on validation error - empty username", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := &dto.CreateRadreplyRequest{
 			Username:  "",
@@ -61,7 +70,7 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 
 	t.Run("should fail on validation error - username too long", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := &dto.CreateRadreplyRequest{
 			Username:  "a" + string(make([]byte, 65)),
@@ -78,7 +87,7 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 
 	t.Run("should fail on validation error - empty value", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := &dto.CreateRadreplyRequest{
 			Username:  "john",
@@ -98,7 +107,7 @@ func TestRadreplyService_CreateRadreply(t *testing.T) {
 func TestRadreplyService_GetRadreplyByID(t *testing.T) {
 	t.Run("should get radreply by id successfully", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		fixture := testutil.CreateRadreplyFixture()
 		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
@@ -117,7 +126,7 @@ func TestRadreplyService_GetRadreplyByID(t *testing.T) {
 		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
 			return nil, gorm.ErrRecordNotFound
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		result, err := service.GetRadreplyByID(context.Background(), 9999)
 
@@ -129,7 +138,7 @@ func TestRadreplyService_GetRadreplyByID(t *testing.T) {
 func TestRadreplyService_GetRadreplyByUsernameAndAttribute(t *testing.T) {
 	t.Run("should get radreply by username and attribute successfully", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		fixture := testutil.CreateRadreplyFixture()
 		repo.GetByUsernameAndAttributeFn = func(ctx context.Context, username, attribute string) (*entity.Radreply, error) {
@@ -148,7 +157,7 @@ func TestRadreplyService_GetRadreplyByUsernameAndAttribute(t *testing.T) {
 		repo.GetByUsernameAndAttributeFn = func(ctx context.Context, username, attribute string) (*entity.Radreply, error) {
 			return nil, gorm.ErrRecordNotFound
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		result, err := service.GetRadreplyByUsernameAndAttribute(context.Background(), "nonexistent", "nonexistent")
 
@@ -160,14 +169,14 @@ func TestRadreplyService_GetRadreplyByUsernameAndAttribute(t *testing.T) {
 func TestRadreplyService_ListRadreply(t *testing.T) {
 	t.Run("should list radreply with pagination", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		fixtures := []entity.Radreply{
 			*testutil.CreateRadreplyFixture(),
 			*testutil.CreateRadreplyFixture(),
 		}
-		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error) {
-			return fixtures, int64(len(fixtures)), nil
+		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error) {
+			return fixtures, pagination.Exact(int64(len(fixtures))), nil
 		}
 
 		filter := &dto.RadreplyFilter{Page: 1, PageSize: 10}
@@ -175,18 +184,18 @@ func TestRadreplyService_ListRadreply(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
-		assert.Equal(t, int64(2), result.Total)
+		assert.Equal(t, int64(2), *result.Total)
 		assert.Len(t, result.Data, 2)
 	})
 
 	t.Run("should apply default pagination when not provided", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
-		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error) {
+		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error) {
 			assert.Equal(t, 1, filter.Page)
 			assert.Equal(t, 10, filter.PageSize)
-			return []entity.Radreply{}, 0, nil
+			return []entity.Radreply{}, pagination.Exact(0), nil
 		}
 
 		result, err := service.ListRadreply(context.Background(), &dto.RadreplyFilter{})
@@ -197,11 +206,11 @@ func TestRadreplyService_ListRadreply(t *testing.T) {
 
 	t.Run("should cap page size at 100", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
-		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error) {
+		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error) {
 			assert.Equal(t, 100, filter.PageSize)
-			return []entity.Radreply{}, 0, nil
+			return []entity.Radreply{}, pagination.Exact(0), nil
 		}
 
 		result, err := service.ListRadreply(context.Background(), &dto.RadreplyFilter{PageSize: 200})
@@ -212,10 +221,10 @@ func TestRadreplyService_ListRadreply(t *testing.T) {
 
 	t.Run("should fail when repository fails", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, int64, error) {
-			return nil, 0, gorm.ErrInvalidDB
+		repo.GetAllFn = func(ctx context.Context, filter *dto.RadreplyFilter) ([]entity.Radreply, pagination.Page, error) {
+			return nil, pagination.Page{}, gorm.ErrInvalidDB
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		result, err := service.ListRadreply(context.Background(), &dto.RadreplyFilter{})
 
@@ -227,7 +236,7 @@ func TestRadreplyService_ListRadreply(t *testing.T) {
 func TestRadreplyService_UpdateRadreply(t *testing.T) {
 	t.Run("should update radreply successfully", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		fixture := testutil.CreateRadreplyFixture()
 		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
@@ -249,7 +258,7 @@ func TestRadreplyService_UpdateRadreply(t *testing.T) {
 		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
 			return nil, gorm.ErrRecordNotFound
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		req := testutil.CreateUpdateRadreplyRequestFixture()
 		result, err := service.UpdateRadreply(context.Background(), 9999, req)
@@ -260,7 +269,7 @@ func TestRadreplyService_UpdateRadreply(t *testing.T) {
 
 	t.Run("should fail when repository update fails", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		fixture := testutil.CreateRadreplyFixture()
 		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
@@ -281,15 +290,39 @@ func TestRadreplyService_UpdateRadreply(t *testing.T) {
 func TestRadreplyService_DeleteRadreply(t *testing.T) {
 	t.Run("should delete radreply successfully", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
+
+		repo.DeleteFn = func(ctx context.Context, id uint) error {
+			return nil
+		}
+
+		err := service.DeleteRadreply(context.Background(), 1)
 
+		assert.NoError(t, err)
+		if assert.Len(t, mockOutbox.Events, 1) {
+			assert.Equal(t, events.ActionDeleted, mockOutbox.Events[0].(events.AttributesChanged).Action)
+		}
+	})
+
+	t.Run("should not delete or record an event when radreply does not exist", func(t *testing.T) {
+		repo := testutil.NewMockRadreplyRepository()
+		repo.GetByIDFn = func(ctx context.Context, id uint) (*entity.Radreply, error) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		deleted := false
 		repo.DeleteFn = func(ctx context.Context, id uint) error {
+			deleted = true
 			return nil
 		}
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		err := service.DeleteRadreply(context.Background(), 1)
 
 		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Empty(t, mockOutbox.Events)
 	})
 
 	t.Run("should fail when repository fails", func(t *testing.T) {
@@ -297,7 +330,7 @@ func TestRadreplyService_DeleteRadreply(t *testing.T) {
 		repo.DeleteFn = func(ctx context.Context, id uint) error {
 			return gorm.ErrInvalidDB
 		}
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		err := service.DeleteRadreply(context.Background(), 1)
 
@@ -308,7 +341,7 @@ func TestRadreplyService_DeleteRadreply(t *testing.T) {
 func TestRadreplyService_entityToResponse(t *testing.T) {
 	t.Run("should convert entity to response correctly", func(t *testing.T) {
 		repo := testutil.NewMockRadreplyRepository()
-		service := NewRadreplyService(repo, testutil.NewSilentLogger())
+		service := NewRadreplyService(repo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
 
 		entity := &entity.Radreply{
 			ID:        1,
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type RecordSessionEventRequest struct {
+	EventType       string     `json:"event_type" binding:"required,oneof=start interim stop"`
+	AcctSessionID   string     `json:"acct_session_id" binding:"required,max=64"`
+	AcctUniqueID    string     `json:"acct_unique_id" binding:"omitempty,max=32"`
+	Username        string     `json:"username" binding:"required,max=64"`
+	GroupName       string     `json:"group_name" binding:"omitempty,max=64"`
+	NASIPAddress    string     `json:"nas_ip_address" binding:"required,ip"`
+	NASPortID       string     `json:"nas_port_id" binding:"omitempty,max=32"`
+	FramedIPAddress string     `json:"framed_ip_address" binding:"omitempty,ip"`
+	SessionTime     int64      `json:"session_time" binding:"min=0"`
+	InputOctets     int64      `json:"input_octets" binding:"min=0"`
+	OutputOctets    int64      `json:"output_octets" binding:"min=0"`
+	TerminateCause  string     `json:"terminate_cause" binding:"omitempty,max=32"`
+	EventTime       *time.Time `json:"event_time"`
+}
+
+type SessionEventResponse struct {
+	ID              uint64    `json:"id"`
+	EventType       string    `json:"event_type"`
+	AcctSessionID   string    `json:"acct_session_id"`
+	AcctUniqueID    string    `json:"acct_unique_id"`
+	Username        string    `json:"username"`
+	GroupName       string    `json:"group_name"`
+	NASIPAddress    string    `json:"nas_ip_address"`
+	NASPortID       string    `json:"nas_port_id"`
+	FramedIPAddress string    `json:"framed_ip_address"`
+	SessionTime     int64     `json:"session_time"`
+	InputOctets     int64     `json:"input_octets"`
+	OutputOctets    int64     `json:"output_octets"`
+	TerminateCause  string    `json:"terminate_cause"`
+	EventTime       time.Time `json:"event_time"`
+}
+
+// SessionWatchFilter selects the events a watcher receives. Empty fields
+// match everything; set fields must match exactly.
+type SessionWatchFilter struct {
+	NASIPAddress string
+	Username     string
+	GroupName    string
+}
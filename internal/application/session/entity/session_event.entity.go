@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	EventTypeStart   = "start"
+	EventTypeInterim = "interim"
+	EventTypeStop    = "stop"
+)
+
+// SessionEvent is one accounting record (start, interim update or stop) in
+// the append-only session event log. The auto-increment ID orders events and
+// is handed to watchers as their resume cursor.
+type SessionEvent struct {
+	ID              uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventType       string    `json:"event_type" gorm:"not null;size:8"`
+	AcctSessionID   string    `json:"acct_session_id" gorm:"index;not null;size:64"`
+	AcctUniqueID    string    `json:"acct_unique_id" gorm:"size:32"`
+	Username        string    `json:"username" gorm:"index;not null;size:64"`
+	GroupName       string    `json:"group_name" gorm:"size:64"`
+	NASIPAddress    string    `json:"nas_ip_address" gorm:"index;not null;size:45"`
+	NASPortID       string    `json:"nas_port_id" gorm:"size:32"`
+	FramedIPAddress string    `json:"framed_ip_address" gorm:"size:45"`
+	SessionTime     int64     `json:"session_time"`
+	InputOctets     int64     `json:"input_octets"`
+	OutputOctets    int64     `json:"output_octets"`
+	TerminateCause  string    `json:"terminate_cause" gorm:"size:32"`
+	EventTime       time.Time `json:"event_time" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (e SessionEvent) TableName() string {
+	return "session_events"
+}
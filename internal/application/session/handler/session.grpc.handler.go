@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/session"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SessionGrpcHandler struct {
+	session.UnimplementedSessionServiceServer
+	sessionService service.SessionService
+	logger         *zap.Logger
+}
+
+func NewSessionGrpcHandler(sessionService service.SessionService, logger *zap.Logger) *SessionGrpcHandler {
+	return &SessionGrpcHandler{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+func (h *SessionGrpcHandler) WatchSessions(
+	req *session.WatchSessionsRequest,
+	stream session.SessionService_WatchSessionsServer,
+) error {
+	filter := &dto.SessionWatchFilter{
+		NASIPAddress: req.GetFilter().GetNasIpAddress(),
+		Username:     req.GetFilter().GetUsername(),
+		GroupName:    req.GetFilter().GetGroupName(),
+	}
+
+	h.logger.Info("Session watcher connected",
+		zap.String("nas_ip_address", filter.NASIPAddress),
+		zap.String("username", filter.Username),
+		zap.String("group_name", filter.GroupName),
+		zap.Uint64("cursor", req.Cursor))
+
+	err := h.sessionService.WatchSessions(stream.Context(), filter, req.Cursor, func(event *dto.SessionEventResponse) error {
+		return stream.Send(toProtoSessionEvent(event))
+	})
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		h.logger.Info("Session watcher disconnected")
+		return status.FromContextError(err).Err()
+	case errors.Is(err, service.ErrWatcherLagging):
+		h.logger.Warn("Session watcher fell behind", zap.Error(err))
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrBrokerClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		h.logger.Error("Failed to watch sessions via gRPC", zap.Error(err))
+		return apperror.GRPCStatus(err)
+	}
+}
+
+func toProtoSessionEvent(e *dto.SessionEventResponse) *session.SessionEvent {
+	return &session.SessionEvent{
+		Id:              e.ID,
+		EventType:       e.EventType,
+		AcctSessionId:   e.AcctSessionID,
+		AcctUniqueId:    e.AcctUniqueID,
+		Username:        e.Username,
+		GroupName:       e.GroupName,
+		NasIpAddress:    e.NASIPAddress,
+		NasPortId:       e.NASPortID,
+		FramedIpAddress: e.FramedIPAddress,
+		SessionTime:     e.SessionTime,
+		InputOctets:     e.InputOctets,
+		OutputOctets:    e.OutputOctets,
+		TerminateCause:  e.TerminateCause,
+		EventTime:       timestamppb.New(e.EventTime),
+	}
+}
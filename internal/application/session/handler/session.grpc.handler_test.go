@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/session"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*session.SessionEvent
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(event *session.SessionEvent) error {
+	s.sent = append(s.sent, event)
+	return nil
+}
+
+func TestSessionGrpcHandler_WatchSessions(t *testing.T) {
+	t.Run("should pass filter and cursor and forward events", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockSessionService{}
+		handler := NewSessionGrpcHandler(mockService, testutil.NewSilentLogger())
+		stream := &fakeWatchStream{ctx: context.Background()}
+
+		// Given
+		eventTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		filter := &dto.SessionWatchFilter{NASIPAddress: "10.0.0.1", Username: "alice", GroupName: "gold"}
+
+		// Mock expectations
+		mockService.On("WatchSessions", mock.Anything, filter, uint64(41), mock.Anything).
+			Run(func(args mock.Arguments) {
+				send := args.Get(3).(func(*dto.SessionEventResponse) error)
+				_ = send(&dto.SessionEventResponse{ID: 42, EventType: "stop", Username: "alice", EventTime: eventTime})
+			}).
+			Return(context.Canceled)
+
+		// When
+		err := handler.WatchSessions(&session.WatchSessionsRequest{
+			Filter: &session.SessionFilter{NasIpAddress: "10.0.0.1", Username: "alice", GroupName: "gold"},
+			Cursor: 41,
+		}, stream)
+
+		// Then
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Len(t, stream.sent, 1)
+		assert.Equal(t, uint64(42), stream.sent[0].Id)
+		assert.Equal(t, "stop", stream.sent[0].EventType)
+		assert.True(t, stream.sent[0].EventTime.AsTime().Equal(eventTime))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should map broker errors to status codes", func(t *testing.T) {
+		tests := []struct {
+			err  error
+			code codes.Code
+		}{
+			{err: service.ErrWatcherLagging, code: codes.Aborted},
+			{err: service.ErrBrokerClosed, code: codes.Unavailable},
+			{err: errors.New("database error"), code: codes.Internal},
+		}
+
+		for _, tt := range tests {
+			// Setup
+			mockService := &testutil.MockSessionService{}
+			handler := NewSessionGrpcHandler(mockService, testutil.NewSilentLogger())
+
+			// Mock expectations
+			mockService.On("WatchSessions", mock.Anything, &dto.SessionWatchFilter{}, uint64(0), mock.Anything).Return(tt.err)
+
+			// When
+			err := handler.WatchSessions(&session.WatchSessionsRequest{}, &fakeWatchStream{ctx: context.Background()})
+
+			// Then
+			assert.Equal(t, tt.code, status.Code(err), tt.err.Error())
+		}
+	})
+}
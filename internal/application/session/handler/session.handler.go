@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"go.uber.org/zap"
+)
+
+type SessionHandler struct {
+	service service.SessionService
+	logger  *zap.Logger
+}
+
+func NewSessionHandler(service service.SessionService, logger *zap.Logger) *SessionHandler {
+	return &SessionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RecordSessionEvent godoc
+// @Summary Record a session accounting event
+// @Description Append an accounting start, interim or stop event to the session event log; connected WatchSessions streams receive it
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param request body dto.RecordSessionEventRequest true "Session event"
+// @Success 201 {object} map[string]interface{} "Recorded event"
+// @Failure 400 {object} apperror.Problem "Invalid request body"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/sessions/events [post]
+func (h *SessionHandler) RecordSessionEvent(ctx *gin.Context) {
+	var req dto.RecordSessionEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
+		return
+	}
+
+	event, err := h.service.RecordEvent(ctx.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to record session event", zap.Error(err))
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": event})
+}
+
+func (h *SessionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	sessions := router.Group("/sessions")
+	{
+		sessions.POST("/events", h.RecordSessionEvent)
+	}
+}
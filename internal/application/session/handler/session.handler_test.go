@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupSessionHandler() (*SessionHandler, *testutil.MockSessionService, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockSessionService{}
+	handler := NewSessionHandler(mockService, testutil.NewSilentLogger())
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+	return handler, mockService, router
+}
+
+func TestSessionHandler_RecordSessionEvent(t *testing.T) {
+	validRequest := dto.RecordSessionEventRequest{
+		EventType:     "start",
+		AcctSessionID: "5f1a0001",
+		Username:      "alice",
+		NASIPAddress:  "10.0.0.1",
+	}
+
+	t.Run("should record session event successfully", func(t *testing.T) {
+		// Setup
+		_, mockService, router := setupSessionHandler()
+
+		// Mock expectations
+		mockService.On("RecordEvent", mock.Anything, &validRequest).
+			Return(&dto.SessionEventResponse{ID: 1, EventType: "start", Username: "alice"}, nil)
+
+		// When
+		body, _ := json.Marshal(validRequest)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/sessions/events", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"username":"alice"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown event type", func(t *testing.T) {
+		// Setup
+		_, mockService, router := setupSessionHandler()
+
+		// Given
+		invalid := validRequest
+		invalid.EventType = "alive"
+
+		// When
+		body, _ := json.Marshal(invalid)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/sessions/events", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RecordEvent")
+	})
+
+	t.Run("should return internal server error when service fails", func(t *testing.T) {
+		// Setup
+		_, mockService, router := setupSessionHandler()
+
+		// Mock expectations
+		mockService.On("RecordEvent", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		// When
+		body, _ := json.Marshal(validRequest)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/sessions/events", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
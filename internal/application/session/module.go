@@ -0,0 +1,19 @@
+package session
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/service"
+
+	"go.uber.org/fx"
+)
+
+// Module provides all session domain dependencies
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewSessionRepository,
+		service.NewEventBroker,
+		service.NewSessionService,
+		handler.NewSessionHandler,
+	),
+)
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, event *entity.SessionEvent) error
+	LatestID(ctx context.Context) (uint64, error)
+	ListAfter(ctx context.Context, cursor uint64, limit int) ([]entity.SessionEvent, error)
+	ListRange(ctx context.Context, after, upTo uint64, filter *dto.SessionWatchFilter, limit int) ([]entity.SessionEvent, error)
+}
+
+type sessionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewSessionRepository(db *gorm.DB, logger *zap.Logger) SessionRepository {
+	return &sessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, event *entity.SessionEvent) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	return db.Create(event).Error
+}
+
+// LatestID returns the ID of the newest event, or 0 when the log is empty.
+func (r *sessionRepository) LatestID(ctx context.Context) (uint64, error) {
+	var id uint64
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	err := db.Model(&entity.SessionEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get latest session event ID", zap.Error(err))
+		return 0, err
+	}
+	return id, nil
+}
+
+// ListAfter returns up to limit events with an ID greater than cursor, oldest
+// first, of every tenant.
+func (r *sessionRepository) ListAfter(ctx context.Context, cursor uint64, limit int) ([]entity.SessionEvent, error) {
+	var events []entity.SessionEvent
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	err := db.Where("id > ?", cursor).Order("id ASC").Limit(limit).Find(&events).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list session events", zap.Uint64("cursor", cursor), zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListRange returns up to limit events matching filter with after < ID <= upTo,
+// oldest first. Events have no tenant of their own, so a tenant sees those of
+// the usernames in its realm.
+func (r *sessionRepository) ListRange(
+	ctx context.Context,
+	after, upTo uint64,
+	filter *dto.SessionWatchFilter,
+	limit int,
+) ([]entity.SessionEvent, error) {
+	var events []entity.SessionEvent
+
+	query := database.GetDB(ctx, r.db).(*gorm.DB).Scopes(tenant.RealmFilter(ctx)).Where("id > ? AND id <= ?", after, upTo)
+	if filter.NASIPAddress != "" {
+		query = query.Where("nas_ip_address = ?", filter.NASIPAddress)
+	}
+	if filter.Username != "" {
+		query = query.Where("username = ?", filter.Username)
+	}
+	if filter.GroupName != "" {
+		query = query.Where("group_name = ?", filter.GroupName)
+	}
+
+	err := query.Order("id ASC").Limit(limit).Find(&events).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list session events", zap.Uint64("after", after), zap.Uint64("up_to", upTo), zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+)
+
+func seedEvents(t *testing.T, repo SessionRepository, events ...entity.SessionEvent) []entity.SessionEvent {
+	t.Helper()
+	for i := range events {
+		if events[i].EventTime.IsZero() {
+			events[i].EventTime = time.Now().UTC()
+		}
+		require.NoError(t, repo.Create(context.Background(), &events[i]))
+	}
+	return events
+}
+
+func TestSessionRepository_LatestID(t *testing.T) {
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	defer testutil.CleanDB(db)
+
+	repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+
+	t.Run("should return zero for an empty log", func(t *testing.T) {
+		// When
+		id, err := repo.LatestID(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Zero(t, id)
+	})
+
+	t.Run("should return the newest event ID", func(t *testing.T) {
+		// Given
+		events := seedEvents(t, repo,
+			entity.SessionEvent{EventType: entity.EventTypeStart, AcctSessionID: "s1", Username: "alice", NASIPAddress: "10.0.0.1"},
+			entity.SessionEvent{EventType: entity.EventTypeStop, AcctSessionID: "s1", Username: "alice", NASIPAddress: "10.0.0.1"},
+		)
+
+		// When
+		id, err := repo.LatestID(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, events[1].ID, id)
+	})
+}
+
+func TestSessionRepository_ListAfter(t *testing.T) {
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	defer testutil.CleanDB(db)
+
+	repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+
+	t.Run("should return events after the cursor in order", func(t *testing.T) {
+		// Given
+		events := seedEvents(t, repo,
+			entity.SessionEvent{EventType: entity.EventTypeStart, AcctSessionID: "s1", Username: "alice", NASIPAddress: "10.0.0.1"},
+			entity.SessionEvent{EventType: entity.EventTypeStart, AcctSessionID: "s2", Username: "bob", NASIPAddress: "10.0.0.2"},
+			entity.SessionEvent{EventType: entity.EventTypeInterim, AcctSessionID: "s1", Username: "alice", NASIPAddress: "10.0.0.1"},
+		)
+
+		// When
+		result, err := repo.ListAfter(context.Background(), events[0].ID, 1)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, events[1].ID, result[0].ID)
+	})
+}
+
+func TestSessionRepository_ListRange(t *testing.T) {
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	defer testutil.CleanDB(db)
+
+	repo := NewSessionRepository(db, testutil.NewTestLogger(t))
+
+	events := seedEvents(t, repo,
+		entity.SessionEvent{EventType: entity.EventTypeStart, AcctSessionID: "s1", Username: "alice", GroupName: "gold", NASIPAddress: "10.0.0.1"},
+		entity.SessionEvent{EventType: entity.EventTypeStart, AcctSessionID: "s2", Username: "bob", GroupName: "silver", NASIPAddress: "10.0.0.2"},
+		entity.SessionEvent{EventType: entity.EventTypeInterim, AcctSessionID: "s1", Username: "alice", GroupName: "gold", NASIPAddress: "10.0.0.1"},
+		entity.SessionEvent{EventType: entity.EventTypeStop, AcctSessionID: "s1", Username: "alice", GroupName: "gold", NASIPAddress: "10.0.0.1"},
+	)
+
+	t.Run("should bound the range on both ends", func(t *testing.T) {
+		// When
+		result, err := repo.ListRange(context.Background(), events[0].ID, events[2].ID, &dto.SessionWatchFilter{}, 10)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, events[1].ID, result[0].ID)
+		assert.Equal(t, events[2].ID, result[1].ID)
+	})
+
+	t.Run("should apply the filter", func(t *testing.T) {
+		// Given
+		filter := &dto.SessionWatchFilter{NASIPAddress: "10.0.0.1", GroupName: "gold"}
+
+		// When
+		result, err := repo.ListRange(context.Background(), 0, events[3].ID, filter, 10)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		for _, event := range result {
+			assert.Equal(t, "alice", event.Username)
+		}
+	})
+
+	t.Run("should filter by username", func(t *testing.T) {
+		// When
+		result, err := repo.ListRange(context.Background(), 0, events[3].ID, &dto.SessionWatchFilter{Username: "bob"}, 10)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, events[1].ID, result[0].ID)
+	})
+}
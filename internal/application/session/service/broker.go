@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWatchPollInterval = time.Second
+	defaultWatchBufferSize   = 256
+	brokerBatchSize          = 500
+)
+
+var (
+	ErrWatcherLagging = errors.New("session watcher fell behind, resume from the last cursor")
+	ErrBrokerClosed   = errors.New("session event broker is shutting down")
+)
+
+// EventBroker tails the session event log and fans new events out to the
+// connected watchers, so that a single query per poll interval serves every
+// dashboard. It polls only while somebody is watching.
+//
+// The log is tailed by ID, so an insert that commits after a higher ID has
+// already been polled is not delivered live; recording is kept to a single
+// short INSERT to make that window negligible.
+type EventBroker struct {
+	repo       repository.SessionRepository
+	logger     *zap.Logger
+	interval   time.Duration
+	bufferSize int
+
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	latest   uint64
+	running  bool
+	closed   bool
+}
+
+type watcher struct {
+	filter *dto.SessionWatchFilter
+	// realm limits the events of a watcher acting for a tenant to its
+	// subscribers.
+	realm  string
+	events chan *dto.SessionEventResponse
+	// start is the newest event ID the broker had seen when the watcher was
+	// added; every event delivered through events is newer.
+	start uint64
+	err   error
+}
+
+func NewEventBroker(repo repository.SessionRepository, logger *zap.Logger, cfg *config.Config) *EventBroker {
+	interval := cfg.Sessions.WatchPollInterval
+	if interval <= 0 {
+		interval = defaultWatchPollInterval
+	}
+	bufferSize := cfg.Sessions.WatchBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultWatchBufferSize
+	}
+
+	return &EventBroker{
+		repo:       repo,
+		logger:     logger,
+		interval:   interval,
+		bufferSize: bufferSize,
+		watchers:   make(map[*watcher]struct{}),
+	}
+}
+
+// Watch streams events matching filter to send. Events after cursor that
+// were recorded before the watcher joined are replayed from the database
+// first, then live events follow without gaps or duplicates.
+func (b *EventBroker) Watch(
+	ctx context.Context,
+	filter *dto.SessionWatchFilter,
+	cursor uint64,
+	send func(*dto.SessionEventResponse) error,
+) error {
+	w, err := b.subscribe(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer b.unsubscribe(w)
+
+	for cursor > 0 && cursor < w.start {
+		events, err := b.repo.ListRange(ctx, cursor, w.start, filter, brokerBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			if err := send(toResponse(&events[i])); err != nil {
+				return err
+			}
+			cursor = events[i].ID
+		}
+		if len(events) < brokerBatchSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-w.events:
+			if !ok {
+				return w.err
+			}
+			if event.ID <= cursor {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close disconnects every watcher with ErrBrokerClosed and rejects new ones.
+// Streams would otherwise keep a graceful server shutdown waiting forever.
+func (b *EventBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for w := range b.watchers {
+		b.drop(w, ErrBrokerClosed)
+	}
+}
+
+func (b *EventBroker) subscribe(ctx context.Context, filter *dto.SessionWatchFilter) (*watcher, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	if !b.running {
+		latest, err := b.repo.LatestID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.latest = latest
+		b.running = true
+		go b.run()
+	}
+
+	w := &watcher{
+		filter: filter,
+		events: make(chan *dto.SessionEventResponse, b.bufferSize),
+		start:  b.latest,
+	}
+	if scope, ok := tenant.From(ctx); ok {
+		w.realm = scope.Realm
+	}
+	b.watchers[w] = struct{}{}
+	return w, nil
+}
+
+func (b *EventBroker) unsubscribe(w *watcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.watchers, w)
+}
+
+// drop removes w and closes its channel; callers hold b.mu.
+func (b *EventBroker) drop(w *watcher, err error) {
+	delete(b.watchers, w)
+	w.err = err
+	close(w.events)
+}
+
+func (b *EventBroker) run() {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for {
+			b.mu.Lock()
+			if b.closed || len(b.watchers) == 0 {
+				b.running = false
+				b.mu.Unlock()
+				return
+			}
+			cursor := b.latest
+			b.mu.Unlock()
+
+			events, err := b.repo.ListAfter(context.Background(), cursor, brokerBatchSize)
+			if err != nil {
+				b.logger.Error("Failed to poll session events", zap.Uint64("cursor", cursor), zap.Error(err))
+				break
+			}
+
+			b.publish(events)
+			if len(events) < brokerBatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (b *EventBroker) publish(events []entity.SessionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range events {
+		event := &events[i]
+		b.latest = event.ID
+
+		response := toResponse(event)
+		for w := range b.watchers {
+			if !matches(w.filter, event) || (w.realm != "" && !tenant.InRealm(event.Username, w.realm)) {
+				continue
+			}
+			select {
+			case w.events <- response:
+			default:
+				b.logger.Warn("Disconnecting lagging session watcher", zap.Uint64("event_id", event.ID))
+				b.drop(w, ErrWatcherLagging)
+			}
+		}
+	}
+}
+
+func matches(filter *dto.SessionWatchFilter, event *entity.SessionEvent) bool {
+	if filter.NASIPAddress != "" && filter.NASIPAddress != event.NASIPAddress {
+		return false
+	}
+	if filter.Username != "" && filter.Username != event.Username {
+		return false
+	}
+	if filter.GroupName != "" && filter.GroupName != event.GroupName {
+		return false
+	}
+	return true
+}
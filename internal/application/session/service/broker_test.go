@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is an in-memory session event log safe for the broker's
+// polling goroutine.
+type memoryRepository struct {
+	mu     sync.Mutex
+	events []entity.SessionEvent
+}
+
+func (r *memoryRepository) Create(ctx context.Context, event *entity.SessionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.ID = uint64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *memoryRepository) LatestID(ctx context.Context) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return uint64(len(r.events)), nil
+}
+
+func (r *memoryRepository) ListAfter(ctx context.Context, cursor uint64, limit int) ([]entity.SessionEvent, error) {
+	return r.ListRange(ctx, cursor, ^uint64(0), &dto.SessionWatchFilter{}, limit)
+}
+
+func (r *memoryRepository) ListRange(
+	ctx context.Context,
+	after, upTo uint64,
+	filter *dto.SessionWatchFilter,
+	limit int,
+) ([]entity.SessionEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []entity.SessionEvent
+	for _, event := range r.events {
+		if event.ID > after && event.ID <= upTo && matches(filter, &event) && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) add(username, nas string) {
+	_ = r.Create(context.Background(), &entity.SessionEvent{
+		EventType:    entity.EventTypeStart,
+		Username:     username,
+		NASIPAddress: nas,
+		EventTime:    time.Now(),
+	})
+}
+
+func newTestBroker(repo *memoryRepository, bufferSize int) *EventBroker {
+	return NewEventBroker(repo, testutil.NewSilentLogger(), &config.Config{
+		Sessions: config.SessionsConfig{
+			WatchPollInterval: 5 * time.Millisecond,
+			WatchBufferSize:   bufferSize,
+		},
+	})
+}
+
+// watch runs broker.Watch in the background and collects the received IDs.
+type watchResult struct {
+	ids  chan uint64
+	done chan error
+}
+
+func startWatch(ctx context.Context, broker *EventBroker, filter *dto.SessionWatchFilter, cursor uint64) *watchResult {
+	result := &watchResult{ids: make(chan uint64, 100), done: make(chan error, 1)}
+	go func() {
+		result.done <- broker.Watch(ctx, filter, cursor, func(event *dto.SessionEventResponse) error {
+			result.ids <- event.ID
+			return nil
+		})
+	}()
+	return result
+}
+
+func (w *watchResult) next(t *testing.T) uint64 {
+	t.Helper()
+	select {
+	case id := <-w.ids:
+		return id
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for session event")
+		return 0
+	}
+}
+
+func waitForWatchers(t *testing.T, broker *EventBroker, count int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		return len(broker.watchers) == count
+	}, 2*time.Second, time.Millisecond)
+}
+
+func TestEventBroker_Watch(t *testing.T) {
+	t.Run("should stream only new events matching the filter", func(t *testing.T) {
+		// Setup
+		repo := &memoryRepository{}
+		broker := newTestBroker(repo, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Given
+		repo.add("alice", "10.0.0.1")
+		watch := startWatch(ctx, broker, &dto.SessionWatchFilter{NASIPAddress: "10.0.0.1"}, 0)
+		waitForWatchers(t, broker, 1)
+
+		// When
+		repo.add("bob", "10.0.0.2")
+		repo.add("alice", "10.0.0.1")
+
+		// Then
+		assert.Equal(t, uint64(3), watch.next(t))
+		cancel()
+		assert.ErrorIs(t, <-watch.done, context.Canceled)
+	})
+
+	t.Run("should replay from the cursor before switching to live events", func(t *testing.T) {
+		// Setup
+		repo := &memoryRepository{}
+		broker := newTestBroker(repo, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Given
+		repo.add("alice", "10.0.0.1")
+		repo.add("bob", "10.0.0.1")
+		repo.add("alice", "10.0.0.1")
+		watch := startWatch(ctx, broker, &dto.SessionWatchFilter{Username: "alice"}, 1)
+
+		// Then
+		assert.Equal(t, uint64(3), watch.next(t))
+
+		// When
+		waitForWatchers(t, broker, 1)
+		repo.add("alice", "10.0.0.1")
+
+		// Then
+		assert.Equal(t, uint64(4), watch.next(t))
+	})
+
+	t.Run("should disconnect a watcher that falls behind", func(t *testing.T) {
+		// Setup
+		repo := &memoryRepository{}
+		broker := newTestBroker(repo, 1)
+		release := make(chan struct{})
+		errs := make(chan error, 1)
+
+		go func() {
+			errs <- broker.Watch(context.Background(), &dto.SessionWatchFilter{}, 0, func(event *dto.SessionEventResponse) error {
+				<-release
+				return nil
+			})
+		}()
+		waitForWatchers(t, broker, 1)
+
+		// When
+		repo.add("alice", "10.0.0.1")
+		repo.add("alice", "10.0.0.1")
+		repo.add("alice", "10.0.0.1")
+		waitForWatchers(t, broker, 0)
+		close(release)
+
+		// Then
+		select {
+		case err := <-errs:
+			assert.ErrorIs(t, err, ErrWatcherLagging)
+		case <-time.After(2 * time.Second):
+			t.Fatal("watcher was not disconnected")
+		}
+	})
+
+	t.Run("should end watchers and reject new ones after close", func(t *testing.T) {
+		// Setup
+		repo := &memoryRepository{}
+		broker := newTestBroker(repo, 10)
+		watch := startWatch(context.Background(), broker, &dto.SessionWatchFilter{}, 0)
+		waitForWatchers(t, broker, 1)
+
+		// When
+		broker.Close()
+
+		// Then
+		assert.ErrorIs(t, <-watch.done, ErrBrokerClosed)
+		err := broker.Watch(context.Background(), &dto.SessionWatchFilter{}, 0, func(*dto.SessionEventResponse) error { return nil })
+		assert.ErrorIs(t, err, ErrBrokerClosed)
+	})
+
+	t.Run("should stop polling when the last watcher leaves", func(t *testing.T) {
+		// Setup
+		repo := &memoryRepository{}
+		broker := newTestBroker(repo, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		watch := startWatch(ctx, broker, &dto.SessionWatchFilter{}, 0)
+		waitForWatchers(t, broker, 1)
+
+		// When
+		cancel()
+		<-watch.done
+
+		// Then
+		assert.Eventually(t, func() bool {
+			broker.mu.Lock()
+			defer broker.mu.Unlock()
+			return !broker.running
+		}, 2*time.Second, time.Millisecond)
+	})
+}
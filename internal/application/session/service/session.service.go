@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+)
+
+type SessionService interface {
+	RecordEvent(ctx context.Context, req *dto.RecordSessionEventRequest) (*dto.SessionEventResponse, error)
+	// WatchSessions calls send for every event matching filter, starting after
+	// cursor (0 means only new events), until ctx is done, send fails or the
+	// watcher is disconnected.
+	WatchSessions(ctx context.Context, filter *dto.SessionWatchFilter, cursor uint64, send func(*dto.SessionEventResponse) error) error
+}
+
+type sessionService struct {
+	repo   repository.SessionRepository
+	broker *EventBroker
+	logger *zap.Logger
+}
+
+func NewSessionService(repo repository.SessionRepository, broker *EventBroker, logger *zap.Logger) SessionService {
+	return &sessionService{
+		repo:   repo,
+		broker: broker,
+		logger: logger,
+	}
+}
+
+func (s *sessionService) RecordEvent(ctx context.Context, req *dto.RecordSessionEventRequest) (*dto.SessionEventResponse, error) {
+	eventTime := time.Now().UTC()
+	if req.EventTime != nil {
+		eventTime = req.EventTime.UTC()
+	}
+
+	event := &entity.SessionEvent{
+		EventType:       req.EventType,
+		AcctSessionID:   req.AcctSessionID,
+		AcctUniqueID:    req.AcctUniqueID,
+		Username:        req.Username,
+		GroupName:       req.GroupName,
+		NASIPAddress:    req.NASIPAddress,
+		NASPortID:       req.NASPortID,
+		FramedIPAddress: req.FramedIPAddress,
+		SessionTime:     req.SessionTime,
+		InputOctets:     req.InputOctets,
+		OutputOctets:    req.OutputOctets,
+		TerminateCause:  req.TerminateCause,
+		EventTime:       eventTime,
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to record session event",
+			zap.String("acct_session_id", req.AcctSessionID),
+			zap.String("event_type", req.EventType),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return toResponse(event), nil
+}
+
+func (s *sessionService) WatchSessions(
+	ctx context.Context,
+	filter *dto.SessionWatchFilter,
+	cursor uint64,
+	send func(*dto.SessionEventResponse) error,
+) error {
+	username, err := tenant.QualifyUsername(ctx, filter.Username)
+	if err != nil {
+		return err
+	}
+	filter.Username = username
+	return s.broker.Watch(ctx, filter, cursor, send)
+}
+
+func toResponse(event *entity.SessionEvent) *dto.SessionEventResponse {
+	return &dto.SessionEventResponse{
+		ID:              event.ID,
+		EventType:       event.EventType,
+		AcctSessionID:   event.AcctSessionID,
+		AcctUniqueID:    event.AcctUniqueID,
+		Username:        event.Username,
+		GroupName:       event.GroupName,
+		NASIPAddress:    event.NASIPAddress,
+		NASPortID:       event.NASPortID,
+		FramedIPAddress: event.FramedIPAddress,
+		SessionTime:     event.SessionTime,
+		InputOctets:     event.InputOctets,
+		OutputOctets:    event.OutputOctets,
+		TerminateCause:  event.TerminateCause,
+		EventTime:       event.EventTime,
+	}
+}
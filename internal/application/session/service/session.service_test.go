@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionService_RecordEvent(t *testing.T) {
+	t.Run("should record event with the given event time", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockSessionRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewSessionService(mockRepo, NewEventBroker(mockRepo, logger, &config.Config{}), logger)
+
+		// Given
+		eventTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+		req := &dto.RecordSessionEventRequest{
+			EventType:     entity.EventTypeInterim,
+			AcctSessionID: "s1",
+			Username:      "alice",
+			GroupName:     "gold",
+			NASIPAddress:  "10.0.0.1",
+			SessionTime:   300,
+			InputOctets:   1024,
+			OutputOctets:  2048,
+			EventTime:     &eventTime,
+		}
+
+		// Mock expectations
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(event *entity.SessionEvent) bool {
+			return event.EventType == entity.EventTypeInterim &&
+				event.Username == "alice" &&
+				event.GroupName == "gold" &&
+				event.InputOctets == 1024 &&
+				event.EventTime.Equal(eventTime) &&
+				event.EventTime.Location() == time.UTC
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.SessionEvent).ID = 7
+		}).Return(nil)
+
+		// When
+		result, err := service.RecordEvent(context.Background(), req)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), result.ID)
+		assert.Equal(t, "s1", result.AcctSessionID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should default event time to now", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockSessionRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewSessionService(mockRepo, NewEventBroker(mockRepo, logger, &config.Config{}), logger)
+
+		// Mock expectations
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(event *entity.SessionEvent) bool {
+			return time.Since(event.EventTime) < time.Minute
+		})).Return(nil)
+
+		// When
+		_, err := service.RecordEvent(context.Background(), &dto.RecordSessionEventRequest{
+			EventType:     entity.EventTypeStart,
+			AcctSessionID: "s1",
+			Username:      "alice",
+			NASIPAddress:  "10.0.0.1",
+		})
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return repository error", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockSessionRepository{}
+		logger := testutil.NewSilentLogger()
+		service := NewSessionService(mockRepo, NewEventBroker(mockRepo, logger, &config.Config{}), logger)
+
+		// Mock expectations
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+		// When
+		result, err := service.RecordEvent(context.Background(), &dto.RecordSessionEventRequest{EventType: entity.EventTypeStart})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
+// CreateTenantRequest describes a new tenant. Realm is a lowercase domain
+// such as "isp-a.example" that suffixes the usernames of its subscribers.
+type CreateTenantRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Realm string `json:"realm" binding:"required,max=48"`
+}
+
+type UpdateTenantRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type TenantResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Realm     string `json:"realm"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ListTenantsResponse struct {
+	Data           []TenantResponse `json:"data"`
+	Total          *int64           `json:"total,omitempty"`
+	TotalEstimated bool             `json:"total_estimated,omitempty"`
+	Page           int              `json:"page"`
+	PageSize       int              `json:"page_size"`
+	TotalPage      *int             `json:"total_page,omitempty"`
+	NextCursor     string           `json:"next_cursor,omitempty"`
+}
+
+type TenantFilter struct {
+	Page     int `json:"page" form:"page" binding:"min=1"`
+	PageSize int `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Tenant is an ISP reselling the service. The subscriber usernames of a
+// tenant end in "@<Realm>", which is fixed once the tenant is created.
+type Tenant struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;size:100"`
+	Realm     string    `json:"realm" gorm:"not null;size:48;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (t Tenant) TableName() string {
+	return "tenants"
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"go.uber.org/zap"
+)
+
+type TenantHandler struct {
+	tenantService service.TenantService
+	logger        *zap.Logger
+}
+
+func NewTenantHandler(tenantService service.TenantService, logger *zap.Logger) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+		logger:        logger,
+	}
+}
+
+func (h *TenantHandler) RegisterRoutes(r *gin.RouterGroup) {
+	tenants := r.Group("/tenants")
+	{
+		tenants.POST("", h.CreateTenant)
+		tenants.GET("", h.ListTenants)
+		tenants.GET("/:id", h.GetTenant)
+		tenants.PUT("/:id", h.UpdateTenant)
+	}
+}
+
+// CreateTenant godoc
+// @Summary Create a tenant
+// @Description Add an ISP to the deployment. Only users without a tenant may create tenants.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateTenantRequest true "Create Tenant Request"
+// @Success 201 {object} dto.TenantResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tenants [post]
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req dto.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.tenantService.CreateTenant(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create tenant", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListTenants godoc
+// @Summary List tenants
+// @Description Get a paginated list of tenants. Users of a tenant only see their own.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Success 200 {object} dto.ListTenantsResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tenants [get]
+func (h *TenantHandler) ListTenants(c *gin.Context) {
+	var filter dto.TenantFilter
+	filter.Page = 1
+	filter.PageSize = 10
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.tenantService.ListTenants(c.Request.Context(), &filter)
+	if err != nil {
+		h.logger.Error("Failed to list tenants", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTenant godoc
+// @Summary Get tenant by ID
+// @Description Get a tenant by ID
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path int true "Tenant ID"
+// @Success 200 {object} dto.TenantResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tenants/{id} [get]
+func (h *TenantHandler) GetTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.tenantService.GetTenant(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get tenant", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateTenant godoc
+// @Summary Rename tenant
+// @Description Rename a tenant. The realm cannot be changed.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path int true "Tenant ID"
+// @Param request body dto.UpdateTenantRequest true "Update Tenant Request"
+// @Success 200 {object} dto.TenantResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tenants/{id} [put]
+func (h *TenantHandler) UpdateTenant(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.tenantService.UpdateTenant(c.Request.Context(), id, &req)
+	if err != nil {
+		h.logger.Error("Failed to update tenant", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseID parses the id path parameter, answering 400 when it is invalid.
+func (h *TenantHandler) parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid ID", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField("id", "must be a positive integer"))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tenantDto "github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTenantHandler() (*gin.Engine, *testutil.MockTenantService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockTenantService{}
+	handler := NewTenantHandler(mockService, testutil.NewSilentLogger())
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+	return router, mockService
+}
+
+func TestTenantHandler_CreateTenant(t *testing.T) {
+	t.Run("should create the tenant", func(t *testing.T) {
+		// Setup
+		router, mockService := setupTenantHandler()
+		response := &tenantDto.TenantResponse{ID: 1, Name: "ISP A", Realm: "isp-a.example"}
+
+		// Mock expectations
+		mockService.On("CreateTenant", mock.Anything, &tenantDto.CreateTenantRequest{Name: "ISP A", Realm: "isp-a.example"}).Return(response, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/tenants",
+			bytes.NewBufferString(`{"name":"ISP A","realm":"isp-a.example"}`)))
+
+		// Then
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var result tenantDto.TenantResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "isp-a.example", result.Realm)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return conflict for a realm in use", func(t *testing.T) {
+		// Setup
+		router, mockService := setupTenantHandler()
+
+		// Mock expectations
+		mockService.On("CreateTenant", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: isp-a.example", service.ErrRealmTaken))
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/tenants",
+			bytes.NewBufferString(`{"name":"ISP A","realm":"isp-a.example"}`)))
+
+		// Then
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("should return bad request for an invalid realm", func(t *testing.T) {
+		// Setup
+		router, mockService := setupTenantHandler()
+
+		// Mock expectations
+		mockService.On("CreateTenant", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidRealm)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/tenants",
+			bytes.NewBufferString(`{"name":"ISP A","realm":"isp-a"}`)))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTenantHandler_GetTenant(t *testing.T) {
+	t.Run("should return not found error", func(t *testing.T) {
+		// Setup
+		router, mockService := setupTenantHandler()
+
+		// Mock expectations
+		mockService.On("GetTenant", mock.Anything, uint(999)).Return(nil, tenant.ErrNotFound)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tenants/999", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return bad request for an invalid id", func(t *testing.T) {
+		// Setup
+		router, mockService := setupTenantHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tenants/abc", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetTenant", mock.Anything, mock.Anything)
+	})
+}
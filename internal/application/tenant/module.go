@@ -0,0 +1,23 @@
+package tenant
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/fx"
+)
+
+// Module provides all tenant domain dependencies
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewTenantRepository,
+		service.NewTenantService,
+		handler.NewTenantHandler,
+		// The servers resolve the tenant of each request with the service
+		func(s service.TenantService) tenant.Resolver {
+			return s
+		},
+	),
+)
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *entity.Tenant) error
+	GetByID(ctx context.Context, id uint) (*entity.Tenant, error)
+	RealmExists(ctx context.Context, realm string) (bool, error)
+	List(ctx context.Context, filter *dto.TenantFilter) ([]entity.Tenant, pagination.Page, error)
+	Update(ctx context.Context, tenant *entity.Tenant) error
+}
+
+type tenantRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewTenantRepository(db *gorm.DB, logger *zap.Logger) TenantRepository {
+	return &tenantRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *tenantRepository) Create(ctx context.Context, t *entity.Tenant) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating tenant", zap.String("realm", t.Realm))
+	return db.Create(t).Error
+}
+
+func (r *tenantRepository) GetByID(ctx context.Context, id uint) (*entity.Tenant, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var t entity.Tenant
+	err := db.Scopes(ownTenant(ctx)).First(&t, id).Error
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get tenant by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &t, nil
+}
+
+// RealmExists checks every tenant, since realms are unique across the
+// deployment.
+func (r *tenantRepository) RealmExists(ctx context.Context, realm string) (bool, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var count int64
+	err := db.Model(&entity.Tenant{}).Where("realm = ?", realm).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tenantRepository) List(ctx context.Context, filter *dto.TenantFilter) ([]entity.Tenant, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+
+	query := db.Model(&entity.Tenant{}).Scopes(ownTenant(ctx))
+	tenants, page, err := pagination.Find[entity.Tenant](query, filter.Params, filter.Page, filter.PageSize, pagination.Order{})
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list tenants", zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+	return tenants, page, nil
+}
+
+func (r *tenantRepository) Update(ctx context.Context, t *entity.Tenant) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Updating tenant", zap.Uint("id", t.ID))
+	return db.Save(t).Error
+}
+
+// ownTenant restricts a scoped caller to their own tenant row.
+func ownTenant(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope, ok := tenant.From(ctx); ok {
+			return db.Where("id = ?", scope.ID)
+		}
+		return db
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	tenantDto "github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	tenantEntity "github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTenantRepository(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	repo := NewTenantRepository(db, testutil.NewTestLogger(t))
+
+	ispA := &tenantEntity.Tenant{Name: "ISP A", Realm: "isp-a.example"}
+	ispB := &tenantEntity.Tenant{Name: "ISP B", Realm: "isp-b.example"}
+	require.NoError(t, repo.Create(context.Background(), ispA))
+	require.NoError(t, repo.Create(context.Background(), ispB))
+	scoped := tenant.WithScope(context.Background(), tenant.Scope{ID: ispA.ID, Realm: ispA.Realm})
+
+	t.Run("should list every tenant without a scope", func(t *testing.T) {
+		// When
+		tenants, page, err := repo.List(context.Background(), &tenantDto.TenantFilter{Page: 1, PageSize: 10})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *page.Total)
+		assert.Len(t, tenants, 2)
+	})
+
+	t.Run("should only show a scoped caller their own tenant", func(t *testing.T) {
+		// When
+		tenants, page, err := repo.List(scoped, &tenantDto.TenantFilter{Page: 1, PageSize: 10})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *page.Total)
+		require.Len(t, tenants, 1)
+		assert.Equal(t, ispA.ID, tenants[0].ID)
+
+		_, err = repo.GetByID(scoped, ispB.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("should check realms across tenants", func(t *testing.T) {
+		// When
+		exists, err := repo.RealmExists(scoped, ispB.Realm)
+
+		// Then
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TenantService interface {
+	tenant.Resolver
+	CreateTenant(ctx context.Context, req *dto.CreateTenantRequest) (*dto.TenantResponse, error)
+	GetTenant(ctx context.Context, id uint) (*dto.TenantResponse, error)
+	ListTenants(ctx context.Context, filter *dto.TenantFilter) (*dto.ListTenantsResponse, error)
+	UpdateTenant(ctx context.Context, id uint, req *dto.UpdateTenantRequest) (*dto.TenantResponse, error)
+}
+
+var (
+	// ErrInvalidRealm is returned for realms that are not lowercase domains.
+	ErrInvalidRealm = apperror.InvalidField("realm", "must be a lowercase domain such as isp-a.example")
+	// ErrRealmTaken is returned when another tenant already uses the realm.
+	ErrRealmTaken = apperror.Conflict("realm already exists")
+)
+
+var realmPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+type tenantService struct {
+	repo      repository.TenantRepository
+	txManager database.TransactionManagerI
+	auditor   audit.Recorder
+	logger    *zap.Logger
+}
+
+func NewTenantService(
+	repo repository.TenantRepository,
+	txManager database.TransactionManagerI,
+	auditor audit.Recorder,
+	logger *zap.Logger,
+) TenantService {
+	return &tenantService{
+		repo:      repo,
+		txManager: txManager,
+		auditor:   auditor,
+		logger:    logger,
+	}
+}
+
+func (s *tenantService) CreateTenant(ctx context.Context, req *dto.CreateTenantRequest) (*dto.TenantResponse, error) {
+	logger.For(ctx, s.logger).Info("Creating tenant", zap.String("realm", req.Realm))
+
+	realm := strings.TrimSpace(req.Realm)
+	if !realmPattern.MatchString(realm) {
+		return nil, ErrInvalidRealm
+	}
+	exists, err := s.repo.RealmExists(ctx, realm)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to check realm existence", zap.Error(err))
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrRealmTaken, realm)
+	}
+
+	t := &entity.Tenant{Name: req.Name, Realm: realm}
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, t); err != nil {
+			return err
+		}
+		return s.auditor.Record(txCtx, tenantChange(audit.ActionCreate, nil, t))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create tenant", zap.Error(err))
+		return nil, err
+	}
+
+	return tenantToResponse(t), nil
+}
+
+func (s *tenantService) GetTenant(ctx context.Context, id uint) (*dto.TenantResponse, error) {
+	t, err := s.getTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tenantToResponse(t), nil
+}
+
+func (s *tenantService) ListTenants(ctx context.Context, filter *dto.TenantFilter) (*dto.ListTenantsResponse, error) {
+	tenants, page, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TenantResponse, 0, len(tenants))
+	for i := range tenants {
+		responses = append(responses, *tenantToResponse(&tenants[i]))
+	}
+
+	return &dto.ListTenantsResponse{
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
+	}, nil
+}
+
+// UpdateTenant renames the tenant. The realm cannot change, since the
+// usernames of its subscribers end in it.
+func (s *tenantService) UpdateTenant(ctx context.Context, id uint, req *dto.UpdateTenantRequest) (*dto.TenantResponse, error) {
+	t, err := s.getTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *t
+	t.Name = req.Name
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, t); err != nil {
+			return err
+		}
+		return s.auditor.Record(txCtx, tenantChange(audit.ActionUpdate, &before, t))
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to update tenant", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return tenantToResponse(t), nil
+}
+
+// Resolve returns the scope requests acting for the tenant run in.
+func (s *tenantService) Resolve(ctx context.Context, id uint) (tenant.Scope, error) {
+	t, err := s.getTenant(ctx, id)
+	if err != nil {
+		return tenant.Scope{}, err
+	}
+	return tenant.Scope{ID: t.ID, Realm: t.Realm}, nil
+}
+
+func (s *tenantService) getTenant(ctx context.Context, id uint) (*entity.Tenant, error) {
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, tenant.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func tenantChange(action string, before, after *entity.Tenant) audit.Change {
+	change := audit.Change{EntityType: audit.EntityTenant, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = before
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = after
+	}
+	return change
+}
+
+func tenantToResponse(t *entity.Tenant) *dto.TenantResponse {
+	return &dto.TenantResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Realm:     t.Realm,
+		CreatedAt: t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	tenantDto "github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	tenantEntity "github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupTenantService() (*tenantService, *testutil.MockTenantRepository, *testutil.MockAuditRecorder) {
+	mockRepo := &testutil.MockTenantRepository{}
+	mockAuditor := &testutil.MockAuditRecorder{}
+	service := NewTenantService(
+		mockRepo,
+		&testutil.MockTransactionManager{},
+		mockAuditor,
+		testutil.NewSilentLogger(),
+	).(*tenantService)
+	return service, mockRepo, mockAuditor
+}
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	t.Run("should create the tenant", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockAuditor := setupTenantService()
+		req := &tenantDto.CreateTenantRequest{Name: "ISP A", Realm: "isp-a.example"}
+
+		// Mock expectations
+		mockRepo.On("RealmExists", mock.Anything, "isp-a.example").Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Tenant")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*tenantEntity.Tenant).ID = 1
+		})
+
+		// When
+		response, err := service.CreateTenant(context.Background(), req)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), response.ID)
+		assert.Equal(t, "isp-a.example", response.Realm)
+		require.Len(t, mockAuditor.Changes, 1)
+		assert.Equal(t, audit.EntityTenant, mockAuditor.Changes[0].EntityType)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject realms that are not domains", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupTenantService()
+
+		for _, realm := range []string{"isp-a", "ISP-A.example", "isp_a.example", "-isp.example", "isp-a.example."} {
+			// When
+			_, err := service.CreateTenant(context.Background(), &tenantDto.CreateTenantRequest{Name: "ISP A", Realm: realm})
+
+			// Then
+			assert.ErrorIs(t, err, ErrInvalidRealm, realm)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a realm in use", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupTenantService()
+
+		// Mock expectations
+		mockRepo.On("RealmExists", mock.Anything, "isp-a.example").Return(true, nil)
+
+		// When
+		_, err := service.CreateTenant(context.Background(), &tenantDto.CreateTenantRequest{Name: "ISP A", Realm: "isp-a.example"})
+
+		// Then
+		assert.ErrorIs(t, err, ErrRealmTaken)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTenantService_Resolve(t *testing.T) {
+	t.Run("should return the scope of the tenant", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupTenantService()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&tenantEntity.Tenant{ID: 1, Name: "ISP A", Realm: "isp-a.example"}, nil)
+
+		// When
+		scope, err := service.Resolve(context.Background(), 1)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, tenant.Scope{ID: 1, Realm: "isp-a.example"}, scope)
+	})
+
+	t.Run("should return not found error", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupTenantService()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		_, err := service.Resolve(context.Background(), 999)
+
+		// Then
+		assert.ErrorIs(t, err, tenant.ErrNotFound)
+	})
+}
+
+func TestTenantService_UpdateTenant(t *testing.T) {
+	t.Run("should rename the tenant and keep its realm", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockAuditor := setupTenantService()
+		existing := &tenantEntity.Tenant{ID: 1, Name: "ISP A", Realm: "isp-a.example"}
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, existing).Return(nil)
+
+		// When
+		response, err := service.UpdateTenant(context.Background(), 1, &tenantDto.UpdateTenantRequest{Name: "ISP A Networks"})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "ISP A Networks", response.Name)
+		assert.Equal(t, "isp-a.example", response.Realm)
+		require.Len(t, mockAuditor.Changes, 1)
+		assert.Equal(t, audit.ActionUpdate, mockAuditor.Changes[0].Action)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+)
 
 type CreateUserRequest struct {
 	Name     string `json:"name" binding:"required"`
@@ -18,19 +22,28 @@ type UpdateUserPasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8"`
 }
 
+// UpdateUserRoleRequest assigns one of the roles of the rbac package.
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin operator billing support readonly"`
+}
+
 type UserResponse struct {
 	ID        uint      `json:"id"`
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TenantID  *uint     `json:"tenant_id,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type UserListResponse struct {
-	Data       []UserResponse `json:"data"`
-	TotalCount int64          `json:"total_count"`
-	Page       int            `json:"page"`
-	PageSize   int            `json:"page_size"`
+	Data           []UserResponse `json:"data"`
+	TotalCount     *int64         `json:"total_count,omitempty"`
+	TotalEstimated bool           `json:"total_estimated,omitempty"`
+	Page           int            `json:"page"`
+	PageSize       int            `json:"page_size"`
+	NextCursor     string         `json:"next_cursor,omitempty"`
 }
 
 type UserFilter struct {
@@ -38,4 +51,5 @@ type UserFilter struct {
 	Email    string `form:"email"`
 	Page     int    `form:"page"`
 	PageSize int    `form:"page_size"`
+	pagination.Params
 }
@@ -11,6 +11,8 @@ type User struct {
 	Name      string         `json:"name" gorm:"not null"`
 	Email     string         `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
 	Password  string         `json:"-" gorm:"not null"`
+	Role      string         `json:"role" gorm:"size:32;not null;default:readonly"`
+	TenantID  *uint          `json:"tenant_id" gorm:"index"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
@@ -6,10 +6,11 @@ import (
 	"github.com/novriyantoAli/freeradius-service/api/proto/user"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
 
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -36,10 +37,10 @@ func (h *UserGrpcHandler) CreateUser(
 		Password: req.Password,
 	}
 
-	userResponse, err := h.userService.CreateUser(createReq)
+	userResponse, err := h.userService.CreateUser(ctx, createReq)
 	if err != nil {
 		h.logger.Error("Failed to create user via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &user.CreateUserResponse{
@@ -48,10 +49,10 @@ func (h *UserGrpcHandler) CreateUser(
 }
 
 func (h *UserGrpcHandler) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.GetUserResponse, error) {
-	userResponse, err := h.userService.GetUserByID(uint(req.Id))
+	userResponse, err := h.userService.GetUserByID(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to get user via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &user.GetUserResponse{
@@ -73,12 +74,13 @@ func (h *UserGrpcHandler) ListUsers(ctx context.Context, req *user.ListUsersRequ
 	filter := &dto.UserFilter{
 		Page:     page,
 		PageSize: pageSize,
+		Params:   pagination.Params{Cursor: req.Cursor, Count: pagination.Count(req.Count)},
 	}
 
-	listResponse, err := h.userService.GetUsers(filter)
+	listResponse, err := h.userService.GetUsers(ctx, filter)
 	if err != nil {
 		h.logger.Error("Failed to list users via gRPC", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	protoUsers := make([]*user.User, len(listResponse.Data))
@@ -87,10 +89,12 @@ func (h *UserGrpcHandler) ListUsers(ctx context.Context, req *user.ListUsersRequ
 	}
 
 	return &user.ListUsersResponse{
-		Users:    protoUsers,
-		Total:    listResponse.TotalCount,
-		Page:     int32(listResponse.Page),
-		PageSize: int32(listResponse.PageSize),
+		Users:          protoUsers,
+		Total:          pagination.TotalOrZero(listResponse.TotalCount),
+		Page:           int32(listResponse.Page),
+		PageSize:       int32(listResponse.PageSize),
+		NextCursor:     listResponse.NextCursor,
+		TotalEstimated: listResponse.TotalEstimated,
 	}, nil
 }
 
@@ -103,10 +107,10 @@ func (h *UserGrpcHandler) UpdateUser(
 		Email: req.Email,
 	}
 
-	userResponse, err := h.userService.UpdateUser(uint(req.Id), updateReq)
+	userResponse, err := h.userService.UpdateUser(ctx, uint(req.Id), updateReq)
 	if err != nil {
 		h.logger.Error("Failed to update user via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &user.UpdateUserResponse{
@@ -118,10 +122,10 @@ func (h *UserGrpcHandler) DeleteUser(
 	ctx context.Context,
 	req *user.DeleteUserRequest,
 ) (*user.DeleteUserResponse, error) {
-	err := h.userService.DeleteUser(uint(req.Id))
+	err := h.userService.DeleteUser(ctx, uint(req.Id))
 	if err != nil {
 		h.logger.Error("Failed to delete user via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &user.DeleteUserResponse{
@@ -138,10 +142,10 @@ func (h *UserGrpcHandler) UpdateUserPassword(
 		NewPassword:     req.NewPassword,
 	}
 
-	err := h.userService.UpdateUserPassword(uint(req.Id), updateReq)
+	err := h.userService.UpdateUserPassword(ctx, uint(req.Id), updateReq)
 	if err != nil {
 		h.logger.Error("Failed to update user password via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to update password: %v", err)
+		return nil, apperror.GRPCStatus(err)
 	}
 
 	return &user.UpdateUserPasswordResponse{
@@ -149,11 +153,31 @@ func (h *UserGrpcHandler) UpdateUserPassword(
 	}, nil
 }
 
+func (h *UserGrpcHandler) UpdateUserRole(
+	ctx context.Context,
+	req *user.UpdateUserRoleRequest,
+) (*user.UpdateUserRoleResponse, error) {
+	if !rbac.ValidRole(req.Role) {
+		return nil, apperror.GRPCStatus(apperror.InvalidField("role", "is not a known role"))
+	}
+
+	resp, err := h.userService.UpdateUserRole(ctx, uint(req.Id), &dto.UpdateUserRoleRequest{Role: req.Role})
+	if err != nil {
+		h.logger.Error("Failed to update user role via gRPC", zap.Uint32("id", req.Id), zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	return &user.UpdateUserRoleResponse{
+		User: h.toProtoUser(resp),
+	}, nil
+}
+
 func (h *UserGrpcHandler) toProtoUser(u *dto.UserResponse) *user.User {
 	return &user.User{
 		Id:        uint32(u.ID),
 		Name:      u.Name,
 		Email:     u.Email,
+		Role:      u.Role,
 		CreatedAt: timestamppb.New(u.CreatedAt),
 		UpdatedAt: timestamppb.New(u.UpdatedAt),
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -31,26 +32,24 @@ func NewUserHandler(service service.UserService, logger *zap.Logger) *UserHandle
 // @Produce json
 // @Param user body dto.CreateUserRequest true "User creation request"
 // @Success 201 {object} map[string]interface{} "Created user"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
-// @Failure 409 {object} map[string]interface{} "Email already exists"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request body"
+// @Failure 409 {object} apperror.Problem "Email already exists"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users [post]
 func (h *UserHandler) CreateUser(ctx *gin.Context) {
 	var req dto.CreateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	user, err := h.service.CreateUser(&req)
+	user, err := h.service.CreateUser(ctx.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to create user", zap.Error(err))
-		if err.Error() == "email already exists" {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -65,21 +64,23 @@ func (h *UserHandler) CreateUser(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} map[string]interface{} "User details"
-// @Failure 400 {object} map[string]interface{} "Invalid user ID"
-// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 400 {object} apperror.Problem "Invalid user ID"
+// @Failure 404 {object} apperror.Problem "User not found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUser(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	user, err := h.service.GetUserByID(uint(id))
+	user, err := h.service.GetUserByID(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to get user", zap.Error(err))
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -96,22 +97,26 @@ func (h *UserHandler) GetUser(ctx *gin.Context) {
 // @Param email query string false "Filter by email"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Number of items per page" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
 // @Success 200 {object} dto.UserListResponse "List of users"
-// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid query parameters"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users [get]
 func (h *UserHandler) GetUsers(ctx *gin.Context) {
 	var filter dto.UserFilter
 	if err := ctx.ShouldBindQuery(&filter); err != nil {
 		h.logger.Error("Invalid query parameters", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	users, err := h.service.GetUsers(&filter)
+	users, err := h.service.GetUsers(ctx.Request.Context(), &filter)
 	if err != nil {
 		h.logger.Error("Failed to get users", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get users"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -127,38 +132,32 @@ func (h *UserHandler) GetUsers(ctx *gin.Context) {
 // @Param id path int true "User ID"
 // @Param user body dto.UpdateUserRequest true "User update request"
 // @Success 200 {object} map[string]interface{} "Updated user"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
-// @Failure 404 {object} map[string]interface{} "User not found"
-// @Failure 409 {object} map[string]interface{} "Email already exists"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request"
+// @Failure 404 {object} apperror.Problem "User not found"
+// @Failure 409 {object} apperror.Problem "Email already exists"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	var req dto.UpdateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	user, err := h.service.UpdateUser(uint(id), &req)
+	user, err := h.service.UpdateUser(ctx.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update user", zap.Error(err))
-		if err.Error() == "user not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		if err.Error() == "email already exists" {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -174,44 +173,79 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 // @Param id path int true "User ID"
 // @Param password body dto.UpdateUserPasswordRequest true "Password update request"
 // @Success 200 {object} map[string]interface{} "Password updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
-// @Failure 401 {object} map[string]interface{} "Current password is incorrect"
-// @Failure 404 {object} map[string]interface{} "User not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid request"
+// @Failure 401 {object} apperror.Problem "Current password is incorrect"
+// @Failure 404 {object} apperror.Problem "User not found"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users/{id}/password [put]
 func (h *UserHandler) UpdateUserPassword(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
 	var req dto.UpdateUserPasswordRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
 		return
 	}
 
-	err = h.service.UpdateUserPassword(uint(id), &req)
+	err = h.service.UpdateUserPassword(ctx.Request.Context(), uint(id), &req)
 	if err != nil {
 		h.logger.Error("Failed to update user password", zap.Error(err))
-		if err.Error() == "user not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		if err.Error() == "current password is incorrect" {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Password updated successfully"})
 }
 
+// UpdateUserRole godoc
+// @Summary Assign a role to a user
+// @Description Assign the role deciding which endpoints the user may call. The last admin cannot be demoted.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body dto.UpdateUserRoleRequest true "Role assignment request"
+// @Success 200 {object} map[string]interface{} "Updated user"
+// @Failure 400 {object} apperror.Problem "Invalid request"
+// @Failure 404 {object} apperror.Problem "User not found"
+// @Failure 409 {object} apperror.Problem "Last admin"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /users/{id}/role [put]
+func (h *UserHandler) UpdateUserRole(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		apperror.Respond(ctx, apperror.InvalidRequest(err))
+		return
+	}
+
+	user, err := h.service.UpdateUserRole(ctx.Request.Context(), uint(id), &req)
+	if err != nil {
+		h.logger.Error("Failed to update user role", zap.Error(err))
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": user})
+}
+
 // DeleteUser godoc
 // @Summary Delete a user
 // @Description Delete a user by ID
@@ -220,26 +254,24 @@ func (h *UserHandler) UpdateUserPassword(ctx *gin.Context) {
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} map[string]interface{} "User deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid user ID"
-// @Failure 404 {object} map[string]interface{} "User not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 400 {object} apperror.Problem "Invalid user ID"
+// @Failure 404 {object} apperror.Problem "User not found"
+// @Failure 500 {object} apperror.Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Respond(ctx, apperror.InvalidField("id", "must be a positive integer"))
 		return
 	}
 
-	err = h.service.DeleteUser(uint(id))
+	err = h.service.DeleteUser(ctx.Request.Context(), uint(id))
 	if err != nil {
 		h.logger.Error("Failed to delete user", zap.Error(err))
-		if err.Error() == "user not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -255,5 +287,6 @@ func (h *UserHandler) RegisterRoutes(api *gin.RouterGroup) {
 		users.PUT("/:id", h.UpdateUser)
 		users.DELETE("/:id", h.DeleteUser)
 		users.PUT("/:id/password", h.UpdateUserPassword)
+		users.PUT("/:id/role", h.UpdateUserRole)
 	}
 }
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/user/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/gin-gonic/gin"
@@ -39,7 +41,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("CreateUser", mock.AnythingOfType("*dto.CreateUserRequest")).Return(response, nil)
+		mockService.On("CreateUser", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return(response, nil)
 
 		// Prepare request
 		reqBody, _ := json.Marshal(req)
@@ -86,7 +88,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		handler, mockService := setupUserHandler()
 
 		req := testutil.CreateUserRequestFixture()
-		mockService.On("CreateUser", mock.AnythingOfType("*dto.CreateUserRequest")).Return(nil, errors.New("email already exists"))
+		mockService.On("CreateUser", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return(nil, service.ErrEmailTaken)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -107,7 +109,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 		handler, mockService := setupUserHandler()
 
 		req := testutil.CreateUserRequestFixture()
-		mockService.On("CreateUser", mock.AnythingOfType("*dto.CreateUserRequest")).Return(nil, errors.New("database error"))
+		mockService.On("CreateUser", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return(nil, errors.New("database error"))
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -138,7 +140,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("GetUserByID", userID).Return(response, nil)
+		mockService.On("GetUserByID", mock.Anything, userID).Return(response, nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -185,7 +187,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		handler, mockService := setupUserHandler()
 
 		userID := uint(999)
-		mockService.On("GetUserByID", userID).Return(nil, errors.New("user not found"))
+		mockService.On("GetUserByID", mock.Anything, userID).Return(nil, service.ErrUserNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -213,12 +215,12 @@ func TestUserHandler_GetUsers(t *testing.T) {
 				{ID: 1, Name: "User 1", Email: "user1@example.com"},
 				{ID: 2, Name: "User 2", Email: "user2@example.com"},
 			},
-			TotalCount: 2,
+			TotalCount: pagination.Exact(2).Total,
 			Page:       1,
 			PageSize:   10,
 		}
 
-		mockService.On("GetUsers", mock.AnythingOfType("*dto.UserFilter")).Return(response, nil)
+		mockService.On("GetUsers", mock.Anything, mock.AnythingOfType("*dto.UserFilter")).Return(response, nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -234,7 +236,7 @@ func TestUserHandler_GetUsers(t *testing.T) {
 		var result dto.UserListResponse
 		json.Unmarshal(w.Body.Bytes(), &result)
 		assert.Len(t, result.Data, 2)
-		assert.Equal(t, int64(2), result.TotalCount)
+		assert.Equal(t, int64(2), *result.TotalCount)
 	})
 
 	t.Run("should return bad request for invalid query parameters", func(t *testing.T) {
@@ -257,7 +259,7 @@ func TestUserHandler_GetUsers(t *testing.T) {
 		// Setup
 		handler, mockService := setupUserHandler()
 
-		mockService.On("GetUsers", mock.AnythingOfType("*dto.UserFilter")).Return(nil, errors.New("database error"))
+		mockService.On("GetUsers", mock.Anything, mock.AnythingOfType("*dto.UserFilter")).Return(nil, errors.New("database error"))
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -287,7 +289,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 			UpdatedAt: time.Now(),
 		}
 
-		mockService.On("UpdateUser", userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(response, nil)
+		mockService.On("UpdateUser", mock.Anything, userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(response, nil)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -340,7 +342,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		userID := uint(999)
 		req := testutil.CreateUpdateUserRequestFixture()
-		mockService.On("UpdateUser", userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(nil, errors.New("user not found"))
+		mockService.On("UpdateUser", mock.Anything, userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(nil, service.ErrUserNotFound)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -365,7 +367,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 
 		userID := uint(1)
 		req := testutil.CreateUpdateUserRequestFixture()
-		mockService.On("UpdateUser", userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(nil, errors.New("email already exists"))
+		mockService.On("UpdateUser", mock.Anything, userID, mock.AnythingOfType("*dto.UpdateUserRequest")).Return(nil, service.ErrEmailTaken)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -396,7 +398,7 @@ func TestUserHandler_UpdateUserPassword(t *testing.T) {
 			NewPassword:     "newpassword123",
 		}
 
-		mockService.On("UpdateUserPassword", userID, mock.AnythingOfType("*dto.UpdateUserPasswordRequest")).Return(nil)
+		mockService.On("UpdateUserPassword", mock.Anything, userID, mock.AnythingOfType("*dto.UpdateUserPasswordRequest")).Return(nil)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -420,7 +422,7 @@ func TestUserHandler_UpdateUserPassword(t *testing.T) {
 		assert.Equal(t, "Password updated successfully", result["message"])
 	})
 
-	t.Run("should return unauthorized when current password is incorrect", func(t *testing.T) {
+	t.Run("should return bad request when current password is incorrect", func(t *testing.T) {
 		// Setup
 		handler, mockService := setupUserHandler()
 
@@ -430,7 +432,7 @@ func TestUserHandler_UpdateUserPassword(t *testing.T) {
 			NewPassword:     "newpassword123",
 		}
 
-		mockService.On("UpdateUserPassword", userID, mock.AnythingOfType("*dto.UpdateUserPasswordRequest")).Return(errors.New("current password is incorrect"))
+		mockService.On("UpdateUserPassword", mock.Anything, userID, mock.AnythingOfType("*dto.UpdateUserPasswordRequest")).Return(service.ErrIncorrectPassword)
 
 		reqBody, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -445,7 +447,7 @@ func TestUserHandler_UpdateUserPassword(t *testing.T) {
 		handler.UpdateUserPassword(ctx)
 
 		// Then
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -456,7 +458,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		handler, mockService := setupUserHandler()
 
 		userID := uint(1)
-		mockService.On("DeleteUser", userID).Return(nil)
+		mockService.On("DeleteUser", mock.Anything, userID).Return(nil)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -483,7 +485,7 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		handler, mockService := setupUserHandler()
 
 		userID := uint(999)
-		mockService.On("DeleteUser", userID).Return(errors.New("user not found"))
+		mockService.On("DeleteUser", mock.Anything, userID).Return(service.ErrUserNotFound)
 
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
@@ -520,6 +522,66 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 	})
 }
 
+func TestUserHandler_UpdateUserRole(t *testing.T) {
+	t.Run("should assign the role", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupUserHandler()
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("/api/v1"))
+
+		// Mock expectations
+		mockService.On("UpdateUserRole", mock.Anything, uint(1), &dto.UpdateUserRoleRequest{Role: "support"}).
+			Return(&dto.UserResponse{ID: 1, Role: "support"}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1/role", bytes.NewBufferString(`{"role":"support"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject an unknown role", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupUserHandler()
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("/api/v1"))
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1/role", bytes.NewBufferString(`{"role":"root"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return conflict for the last admin", func(t *testing.T) {
+		// Setup
+		handler, mockService := setupUserHandler()
+		router := gin.New()
+		handler.RegisterRoutes(router.Group("/api/v1"))
+
+		// Mock expectations
+		mockService.On("UpdateUserRole", mock.Anything, uint(1), mock.Anything).
+			Return(nil, service.ErrLastAdmin)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1/role", bytes.NewBufferString(`{"role":"readonly"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
 func TestUserHandler_RegisterRoutes(t *testing.T) {
 	t.Run("should register all routes correctly", func(t *testing.T) {
 		// Setup
@@ -539,6 +601,7 @@ func TestUserHandler_RegisterRoutes(t *testing.T) {
 			"PUT /api/v1/users/:id",
 			"DELETE /api/v1/users/:id",
 			"PUT /api/v1/users/:id/password",
+			"PUT /api/v1/users/:id/role",
 		}
 
 		assert.Len(t, routes, len(expectedRoutes))
@@ -1,21 +1,28 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	Create(user *entity.User) error
-	GetByID(id uint) (*entity.User, error)
-	GetByEmail(email string) (*entity.User, error)
-	GetAll(filter *dto.UserFilter) ([]entity.User, int64, error)
-	Update(user *entity.User) error
-	Delete(id uint) error
-	EmailExists(email string) (bool, error)
+	Create(ctx context.Context, user *entity.User) error
+	GetByID(ctx context.Context, id uint) (*entity.User, error)
+	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetAll(ctx context.Context, filter *dto.UserFilter) ([]entity.User, pagination.Page, error)
+	Update(ctx context.Context, user *entity.User) error
+	Delete(ctx context.Context, id uint) error
+	EmailExists(ctx context.Context, email string) (bool, error)
+	CountByRole(ctx context.Context, tenantID *uint, role string) (int64, error)
 }
 
 type userRepository struct {
@@ -30,36 +37,39 @@ func NewUserRepository(db *gorm.DB, logger *zap.Logger) UserRepository {
 	}
 }
 
-func (r *userRepository) Create(user *entity.User) error {
-	r.logger.Info("Creating user", zap.String("email", user.Email))
-	return r.db.Create(user).Error
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating user", zap.String("email", user.Email))
+	user.TenantID = tenant.ID(ctx)
+	return db.Create(user).Error
 }
 
-func (r *userRepository) GetByID(id uint) (*entity.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*entity.User, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var user entity.User
-	err := r.db.First(&user, id).Error
+	err := db.Scopes(tenant.Filter(ctx)).First(&user, id).Error
 	if err != nil {
-		r.logger.Error("Failed to get user by ID", zap.Uint("id", id), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get user by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var user entity.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := db.Scopes(tenant.Filter(ctx)).Where("email = ?", email).First(&user).Error
 	if err != nil {
-		r.logger.Error("Failed to get user by email", zap.String("email", email), zap.Error(err))
+		logger.For(ctx, r.logger).Error("Failed to get user by email", zap.String("email", email), zap.Error(err))
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetAll(filter *dto.UserFilter) ([]entity.User, int64, error) {
-	var users []entity.User
-	var totalCount int64
+func (r *userRepository) GetAll(ctx context.Context, filter *dto.UserFilter) ([]entity.User, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 
-	query := r.db.Model(&entity.User{})
+	query := db.Model(&entity.User{}).Scopes(tenant.Filter(ctx))
 
 	if filter.Name != "" {
 		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
@@ -68,34 +78,46 @@ func (r *userRepository) GetAll(filter *dto.UserFilter) ([]entity.User, int64, e
 		query = query.Where("email LIKE ?", "%"+filter.Email+"%")
 	}
 
-	query.Count(&totalCount)
-
-	if filter.Page > 0 && filter.PageSize > 0 {
-		offset := (filter.Page - 1) * filter.PageSize
-		query = query.Offset(offset).Limit(filter.PageSize)
-	}
-
-	err := query.Find(&users).Error
+	users, page, err := pagination.Find[entity.User](query, filter.Params, filter.Page, filter.PageSize, pagination.Order{})
 	if err != nil {
-		r.logger.Error("Failed to get users", zap.Error(err))
-		return nil, 0, err
+		logger.For(ctx, r.logger).Error("Failed to get users", zap.Error(err))
+		return nil, pagination.Page{}, err
 	}
 
-	return users, totalCount, nil
+	return users, page, nil
 }
 
-func (r *userRepository) Update(user *entity.User) error {
-	r.logger.Info("Updating user", zap.Uint("id", user.ID))
-	return r.db.Save(user).Error
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Updating user", zap.Uint("id", user.ID))
+	return db.Save(user).Error
 }
 
-func (r *userRepository) Delete(id uint) error {
-	r.logger.Info("Deleting user", zap.Uint("id", id))
-	return r.db.Delete(&entity.User{}, id).Error
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Deleting user", zap.Uint("id", id))
+	return db.Scopes(tenant.Filter(ctx)).Delete(&entity.User{}, id).Error
 }
 
-func (r *userRepository) EmailExists(email string) (bool, error) {
+// EmailExists looks across all tenants, since users sign in by email alone.
+func (r *userRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
 	var count int64
-	err := r.db.Model(&entity.User{}).Where("email = ?", email).Count(&count).Error
+	err := db.Model(&entity.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+// CountByRole counts the users with role in the tenant tenantID, or the
+// super-admins when tenantID is nil.
+func (r *userRepository) CountByRole(ctx context.Context, tenantID *uint, role string) (int64, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var count int64
+	query := db.Model(&entity.User{}).Where("role = ?", role)
+	if tenantID != nil {
+		query = query.Where("tenant_id = ?", *tenantID)
+	} else {
+		query = query.Where("tenant_id IS NULL")
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
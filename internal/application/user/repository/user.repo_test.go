@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +28,7 @@ func TestUserRepository_Create(t *testing.T) {
 		user.ID = 0 // Reset ID for creation
 
 		// When
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 
 		// Then
 		assert.NoError(t, err)
@@ -51,8 +53,8 @@ func TestUserRepository_Create(t *testing.T) {
 		user2.Email = "duplicate@example.com"
 
 		// When
-		err1 := repo.Create(user1)
-		err2 := repo.Create(user2)
+		err1 := repo.Create(context.Background(), user1)
+		err2 := repo.Create(context.Background(), user2)
 
 		// Then
 		assert.NoError(t, err1)
@@ -74,11 +76,11 @@ func TestUserRepository_GetByID(t *testing.T) {
 		// Given
 		user := testutil.CreateUserFixture()
 		user.ID = 0
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		require.NoError(t, err)
 
 		// When
-		foundUser, err := repo.GetByID(user.ID)
+		foundUser, err := repo.GetByID(context.Background(), user.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -89,7 +91,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 
 	t.Run("should return error when user not found", func(t *testing.T) {
 		// When
-		_, err := repo.GetByID(999)
+		_, err := repo.GetByID(context.Background(), 999)
 
 		// Then
 		assert.Error(t, err)
@@ -111,11 +113,11 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 		// Given
 		user := testutil.CreateUserFixture()
 		user.ID = 0
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		require.NoError(t, err)
 
 		// When
-		foundUser, err := repo.GetByEmail(user.Email)
+		foundUser, err := repo.GetByEmail(context.Background(), user.Email)
 
 		// Then
 		assert.NoError(t, err)
@@ -126,7 +128,7 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 
 	t.Run("should return error when user email not found", func(t *testing.T) {
 		// When
-		_, err := repo.GetByEmail("nonexistent@example.com")
+		_, err := repo.GetByEmail(context.Background(), "nonexistent@example.com")
 
 		// Then
 		assert.Error(t, err)
@@ -151,7 +153,7 @@ func TestUserRepository_GetAll(t *testing.T) {
 			user.ID = 0
 			user.Email = fmt.Sprintf("user%d@example.com", i)
 			user.Name = fmt.Sprintf("User %d", i)
-			err := repo.Create(user)
+			err := repo.Create(context.Background(), user)
 			require.NoError(t, err)
 		}
 
@@ -161,12 +163,12 @@ func TestUserRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		users, totalCount, err := repo.GetAll(filter)
+		users, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
-		assert.Len(t, users, 3)               // Should return 3 users due to page size
-		assert.Equal(t, int64(5), totalCount) // Total count should be 5
+		assert.Len(t, users, 3)                // Should return 3 users due to page size
+		assert.Equal(t, int64(5), *page.Total) // Total count should be 5
 	})
 
 	t.Run("should filter users by name", func(t *testing.T) {
@@ -175,14 +177,14 @@ func TestUserRepository_GetAll(t *testing.T) {
 		user1.ID = 0
 		user1.Email = "alice@example.com"
 		user1.Name = "Alice Smith"
-		err := repo.Create(user1)
+		err := repo.Create(context.Background(), user1)
 		require.NoError(t, err)
 
 		user2 := testutil.CreateUserFixture()
 		user2.ID = 0
 		user2.Email = "bob@example.com"
 		user2.Name = "Bob Johnson"
-		err = repo.Create(user2)
+		err = repo.Create(context.Background(), user2)
 		require.NoError(t, err)
 
 		filter := &dto.UserFilter{
@@ -190,12 +192,12 @@ func TestUserRepository_GetAll(t *testing.T) {
 		}
 
 		// When
-		users, totalCount, err := repo.GetAll(filter)
+		users, page, err := repo.GetAll(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.Len(t, users, 1)
-		assert.Equal(t, int64(1), totalCount)
+		assert.Equal(t, int64(1), *page.Total)
 		assert.Equal(t, "Alice Smith", users[0].Name)
 	})
 
@@ -214,13 +216,13 @@ func TestUserRepository_Update(t *testing.T) {
 		// Given
 		user := testutil.CreateUserFixture()
 		user.ID = 0
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		require.NoError(t, err)
 
 		// When
 		user.Name = "Updated Name"
 		user.Email = "updated@example.com"
-		err = repo.Update(user)
+		err = repo.Update(context.Background(), user)
 
 		// Then
 		assert.NoError(t, err)
@@ -248,11 +250,11 @@ func TestUserRepository_Delete(t *testing.T) {
 		// Given
 		user := testutil.CreateUserFixture()
 		user.ID = 0
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		require.NoError(t, err)
 
 		// When
-		err = repo.Delete(user.ID)
+		err = repo.Delete(context.Background(), user.ID)
 
 		// Then
 		assert.NoError(t, err)
@@ -279,11 +281,11 @@ func TestUserRepository_EmailExists(t *testing.T) {
 		// Given
 		user := testutil.CreateUserFixture()
 		user.ID = 0
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		require.NoError(t, err)
 
 		// When
-		exists, err := repo.EmailExists(user.Email)
+		exists, err := repo.EmailExists(context.Background(), user.Email)
 
 		// Then
 		assert.NoError(t, err)
@@ -292,7 +294,7 @@ func TestUserRepository_EmailExists(t *testing.T) {
 
 	t.Run("should return false for non-existing email", func(t *testing.T) {
 		// When
-		exists, err := repo.EmailExists("nonexistent@example.com")
+		exists, err := repo.EmailExists(context.Background(), "nonexistent@example.com")
 
 		// Then
 		assert.NoError(t, err)
@@ -302,3 +304,40 @@ func TestUserRepository_EmailExists(t *testing.T) {
 	// Cleanup
 	testutil.CleanDB(db)
 }
+
+func TestUserRepository_CountByRole(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewUserRepository(db, logger)
+	tenantID := uint(1)
+	scoped := tenant.WithScope(context.Background(), tenant.Scope{ID: tenantID, Realm: "isp-a.example"})
+
+	// Given
+	superAdmin := &entity.User{Name: "Root", Email: "root@example.com", Password: "hashed", Role: "admin"}
+	require.NoError(t, repo.Create(context.Background(), superAdmin))
+	tenantAdmin := &entity.User{Name: "ISP A", Email: "admin@isp-a.example", Password: "hashed", Role: "admin"}
+	require.NoError(t, repo.Create(scoped, tenantAdmin))
+
+	t.Run("should count the admins of a tenant", func(t *testing.T) {
+		// When
+		count, err := repo.CountByRole(context.Background(), &tenantID, "admin")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should count the super-admins without a tenant", func(t *testing.T) {
+		// When
+		count, err := repo.CountByRole(context.Background(), nil, "admin")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
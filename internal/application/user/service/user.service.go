@@ -1,53 +1,86 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrUserNotFound is returned for user IDs or emails that do not exist.
+	ErrUserNotFound = apperror.NotFound("user not found")
+	// ErrEmailTaken is returned when another user already has the email.
+	ErrEmailTaken = apperror.Conflict("email already exists")
+	// ErrIncorrectPassword is returned when the current password given to
+	// change it is wrong.
+	ErrIncorrectPassword = apperror.Validation("current password is incorrect", apperror.FieldError{Field: "current_password", Message: "is incorrect"})
+	// ErrLastAdmin is returned when the change would leave no admin.
+	ErrLastAdmin = apperror.Conflict("cannot remove the last admin")
+)
+
 type UserService interface {
-	CreateUser(req *dto.CreateUserRequest) (*dto.UserResponse, error)
-	GetUserByID(id uint) (*dto.UserResponse, error)
-	GetUserByEmail(email string) (*dto.UserResponse, error)
-	GetUsers(filter *dto.UserFilter) (*dto.UserListResponse, error)
-	UpdateUser(id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
-	UpdateUserPassword(id uint, req *dto.UpdateUserPasswordRequest) error
-	DeleteUser(id uint) error
+	CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error)
+	GetUserByID(ctx context.Context, id uint) (*dto.UserResponse, error)
+	GetUserByEmail(ctx context.Context, email string) (*dto.UserResponse, error)
+	GetUsers(ctx context.Context, filter *dto.UserFilter) (*dto.UserListResponse, error)
+	UpdateUser(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
+	UpdateUserPassword(ctx context.Context, id uint, req *dto.UpdateUserPasswordRequest) error
+	UpdateUserRole(ctx context.Context, id uint, req *dto.UpdateUserRoleRequest) (*dto.UserResponse, error)
+	DeleteUser(ctx context.Context, id uint) error
 }
 
 type userService struct {
-	repo   repository.UserRepository
-	logger *zap.Logger
+	repo      repository.UserRepository
+	txManager database.TransactionManagerI
+	outbox    outbox.Outbox
+	auditor   audit.Recorder
+	logger    *zap.Logger
 }
 
-func NewUserService(repo repository.UserRepository, logger *zap.Logger) UserService {
+func NewUserService(
+	repo repository.UserRepository,
+	txManager database.TransactionManagerI,
+	outbox outbox.Outbox,
+	auditor audit.Recorder,
+	logger *zap.Logger,
+) UserService {
 	return &userService{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		txManager: txManager,
+		outbox:    outbox,
+		auditor:   auditor,
+		logger:    logger,
 	}
 }
 
-func (s *userService) CreateUser(req *dto.CreateUserRequest) (*dto.UserResponse, error) {
-	exists, err := s.repo.EmailExists(req.Email)
+func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+	exists, err := s.repo.EmailExists(ctx, req.Email)
 	if err != nil {
-		s.logger.Error("Failed to check email existence", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to check email existence", zap.Error(err))
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("email already exists")
+		return nil, ErrEmailTaken
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		s.logger.Error("Failed to hash password", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to hash password", zap.Error(err))
 		return nil, err
 	}
 
@@ -55,24 +88,33 @@ func (s *userService) CreateUser(req *dto.CreateUserRequest) (*dto.UserResponse,
 		Name:      req.Name,
 		Email:     req.Email,
 		Password:  string(hashedPassword),
+		Role:      string(rbac.RoleReadOnly),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	err = s.repo.Create(user)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, userChange(audit.ActionCreate, nil, user)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email})
+	})
 	if err != nil {
-		s.logger.Error("Failed to create user", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to create user", zap.Error(err))
 		return nil, err
 	}
 
 	return s.entityToResponse(user), nil
 }
 
-func (s *userService) GetUserByID(id uint) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByID(id)
+func (s *userService) GetUserByID(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -80,11 +122,11 @@ func (s *userService) GetUserByID(id uint) (*dto.UserResponse, error) {
 	return s.entityToResponse(user), nil
 }
 
-func (s *userService) GetUserByEmail(email string) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByEmail(email)
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (*dto.UserResponse, error) {
+	user, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -92,7 +134,7 @@ func (s *userService) GetUserByEmail(email string) (*dto.UserResponse, error) {
 	return s.entityToResponse(user), nil
 }
 
-func (s *userService) GetUsers(filter *dto.UserFilter) (*dto.UserListResponse, error) {
+func (s *userService) GetUsers(ctx context.Context, filter *dto.UserFilter) (*dto.UserListResponse, error) {
 	if filter.Page <= 0 {
 		filter.Page = 1
 	}
@@ -100,7 +142,7 @@ func (s *userService) GetUsers(filter *dto.UserFilter) (*dto.UserListResponse, e
 		filter.PageSize = 10
 	}
 
-	users, totalCount, err := s.repo.GetAll(filter)
+	users, page, err := s.repo.GetAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -111,82 +153,188 @@ func (s *userService) GetUsers(filter *dto.UserFilter) (*dto.UserListResponse, e
 	}
 
 	return &dto.UserListResponse{
-		Data:       responses,
-		TotalCount: totalCount,
-		Page:       filter.Page,
-		PageSize:   filter.PageSize,
+		Data:           responses,
+		TotalCount:     page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		NextCursor:     page.NextCursor,
 	}, nil
 }
 
-func (s *userService) UpdateUser(id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByID(id)
+func (s *userService) UpdateUser(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, err
 	}
 
 	if req.Email != user.Email {
-		exists, err := s.repo.EmailExists(req.Email)
+		exists, err := s.repo.EmailExists(ctx, req.Email)
 		if err != nil {
-			s.logger.Error("Failed to check email existence", zap.Error(err))
+			logger.For(ctx, s.logger).Error("Failed to check email existence", zap.Error(err))
 			return nil, err
 		}
 		if exists {
-			return nil, errors.New("email already exists")
+			return nil, ErrEmailTaken
 		}
 	}
 
+	before := *user
 	user.Name = req.Name
 	user.Email = req.Email
 	user.UpdatedAt = time.Now()
 
-	err = s.repo.Update(user)
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, userChange(audit.ActionUpdate, &before, user)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.UserUpdated{UserID: user.ID, Name: user.Name, Email: user.Email})
+	})
 	if err != nil {
-		s.logger.Error("Failed to update user", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to update user", zap.Error(err))
 		return nil, err
 	}
 
 	return s.entityToResponse(user), nil
 }
 
-func (s *userService) UpdateUserPassword(id uint, req *dto.UpdateUserPasswordRequest) error {
-	user, err := s.repo.GetByID(id)
+func (s *userService) UpdateUserPassword(ctx context.Context, id uint, req *dto.UpdateUserPasswordRequest) error {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return ErrUserNotFound
 		}
 		return err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword))
 	if err != nil {
-		return errors.New("current password is incorrect")
+		return ErrIncorrectPassword
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
-		s.logger.Error("Failed to hash new password", zap.Error(err))
+		logger.For(ctx, s.logger).Error("Failed to hash new password", zap.Error(err))
 		return err
 	}
 
+	before := *user
 	user.Password = string(hashedPassword)
 	user.UpdatedAt = time.Now()
 
-	return s.repo.Update(user)
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, userChange(audit.ActionUpdate, &before, user)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.UserPasswordChanged{UserID: user.ID})
+	})
 }
 
-func (s *userService) DeleteUser(id uint) error {
-	_, err := s.repo.GetByID(id)
+// UpdateUserRole assigns a role. The change applies to the next request of
+// the user, since the role is read on every authentication.
+func (s *userService) UpdateUserRole(ctx context.Context, id uint, req *dto.UpdateUserRoleRequest) (*dto.UserResponse, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
+		return nil, err
+	}
+	if user.Role == req.Role {
+		return s.entityToResponse(user), nil
+	}
+	if err := s.ensureNotLastAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	before := *user
+	user.Role = req.Role
+	user.UpdatedAt = time.Now()
+
+	err = s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, userChange(audit.ActionUpdate, &before, user)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.UserRoleChanged{UserID: user.ID, Role: user.Role})
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to update user role", zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("User role changed", zap.Uint("user_id", user.ID), zap.String("from", before.Role), zap.String("to", user.Role))
+	return s.entityToResponse(user), nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, id uint) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if err := s.ensureNotLastAdmin(ctx, user); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Delete(txCtx, id); err != nil {
+			return err
+		}
+		if err := s.auditor.Record(txCtx, userChange(audit.ActionDelete, user, nil)); err != nil {
+			return err
+		}
+		return s.outbox.Add(txCtx, events.UserDeleted{UserID: id})
+	})
+}
+
+// ensureNotLastAdmin refuses to demote or delete the only admin of a tenant,
+// or the only super-admin, who would be the only one able to assign roles.
+func (s *userService) ensureNotLastAdmin(ctx context.Context, user *entity.User) error {
+	if user.Role != string(rbac.RoleAdmin) {
+		return nil
+	}
+	admins, err := s.repo.CountByRole(ctx, user.TenantID, string(rbac.RoleAdmin))
+	if err != nil {
 		return err
 	}
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// userSnapshot is the audited state of a user. Unlike the entity it encodes
+// the password hash, so that password changes are recorded, redacted.
+type userSnapshot struct {
+	entity.User
+	Password string `json:"password"`
+}
 
-	return s.repo.Delete(id)
+func userChange(action string, before, after *entity.User) audit.Change {
+	change := audit.Change{EntityType: audit.EntityUser, Action: action}
+	if before != nil {
+		change.EntityID = audit.EntityID(before.ID)
+		change.Before = userSnapshot{User: *before, Password: before.Password}
+	}
+	if after != nil {
+		change.EntityID = audit.EntityID(after.ID)
+		change.After = userSnapshot{User: *after, Password: after.Password}
+	}
+	return change
 }
 
 func (s *userService) entityToResponse(user *entity.User) *dto.UserResponse {
@@ -194,6 +342,8 @@ func (s *userService) entityToResponse(user *entity.User) *dto.UserResponse {
 		ID:        user.ID,
 		Name:      user.Name,
 		Email:     user.Email,
+		Role:      user.Role,
+		TenantID:  user.TenantID,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -1,16 +1,21 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -20,19 +25,21 @@ func TestUserService_CreateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		mockAuditor := &testutil.MockAuditRecorder{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, mockAuditor, logger)
 
 		req := testutil.CreateUserRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("EmailExists", req.Email).Return(false, nil)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil).Run(func(args mock.Arguments) {
-			user := args.Get(0).(*entity.User)
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil).Run(func(args mock.Arguments) {
+			user := args.Get(1).(*entity.User)
 			user.ID = 1
 		})
 
 		// When
-		response, err := service.CreateUser(req)
+		response, err := service.CreateUser(context.Background(), req)
 
 		// Then
 		assert.NoError(t, err)
@@ -40,6 +47,17 @@ func TestUserService_CreateUser(t *testing.T) {
 		assert.Equal(t, uint(1), response.ID)
 		assert.Equal(t, req.Name, response.Name)
 		assert.Equal(t, req.Email, response.Email)
+		assert.Equal(t, []events.Event{events.UserCreated{UserID: 1, Name: req.Name, Email: req.Email}}, mockOutbox.Events)
+
+		require.Len(t, mockAuditor.Changes, 1)
+		change := mockAuditor.Changes[0]
+		assert.Equal(t, audit.EntityUser, change.EntityType)
+		assert.Equal(t, "1", change.EntityID)
+		assert.Equal(t, audit.ActionCreate, change.Action)
+		fields, err := audit.Diff(change.Before, change.After)
+		require.NoError(t, err)
+		assert.Equal(t, audit.FieldChange{After: req.Email}, fields["email"])
+		assert.Equal(t, audit.FieldChange{After: audit.Redacted}, fields["password"])
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -47,15 +65,15 @@ func TestUserService_CreateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateUserRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("EmailExists", req.Email).Return(true, nil)
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(true, nil)
 
 		// When
-		response, err := service.CreateUser(req)
+		response, err := service.CreateUser(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -68,15 +86,15 @@ func TestUserService_CreateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateUserRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("EmailExists", req.Email).Return(false, errors.New("database error"))
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(false, errors.New("database error"))
 
 		// When
-		response, err := service.CreateUser(req)
+		response, err := service.CreateUser(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -89,16 +107,16 @@ func TestUserService_CreateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		req := testutil.CreateUserRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("EmailExists", req.Email).Return(false, nil)
-		mockRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(errors.New("create failed"))
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(errors.New("create failed"))
 
 		// When
-		response, err := service.CreateUser(req)
+		response, err := service.CreateUser(context.Background(), req)
 
 		// Then
 		assert.Error(t, err)
@@ -106,6 +124,33 @@ func TestUserService_CreateUser(t *testing.T) {
 		assert.Contains(t, err.Error(), "create failed")
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("should return error when the event cannot be written", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockUserRepository{}
+		logger := testutil.NewSilentLogger()
+		mockOutbox := &testutil.MockOutbox{
+			AddFn: func(ctx context.Context, evts ...events.Event) error {
+				return errors.New("outbox error")
+			},
+		}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
+
+		req := testutil.CreateUserRequestFixture()
+
+		// Mock expectations
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+
+		// When
+		response, err := service.CreateUser(context.Background(), req)
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "outbox error")
+		mockRepo.AssertExpectations(t)
+	})
 }
 
 func TestUserService_GetUserByID(t *testing.T) {
@@ -113,17 +158,17 @@ func TestUserService_GetUserByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		user := testutil.CreateUserFixture()
 		user.ID = userID
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(user, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
 
 		// When
-		response, err := service.GetUserByID(userID)
+		response, err := service.GetUserByID(context.Background(), userID)
 
 		// Then
 		assert.NoError(t, err)
@@ -138,15 +183,15 @@ func TestUserService_GetUserByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.GetUserByID(userID)
+		response, err := service.GetUserByID(context.Background(), userID)
 
 		// Then
 		assert.Error(t, err)
@@ -159,15 +204,15 @@ func TestUserService_GetUserByID(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(nil, errors.New("database error"))
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, errors.New("database error"))
 
 		// When
-		response, err := service.GetUserByID(userID)
+		response, err := service.GetUserByID(context.Background(), userID)
 
 		// Then
 		assert.Error(t, err)
@@ -182,17 +227,17 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		email := "test@example.com"
 		user := testutil.CreateUserFixture()
 		user.Email = email
 
 		// Mock expectations
-		mockRepo.On("GetByEmail", email).Return(user, nil)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
 
 		// When
-		response, err := service.GetUserByEmail(email)
+		response, err := service.GetUserByEmail(context.Background(), email)
 
 		// Then
 		assert.NoError(t, err)
@@ -206,15 +251,15 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		email := "nonexistent@example.com"
 
 		// Mock expectations
-		mockRepo.On("GetByEmail", email).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.GetUserByEmail(email)
+		response, err := service.GetUserByEmail(context.Background(), email)
 
 		// Then
 		assert.Error(t, err)
@@ -229,7 +274,7 @@ func TestUserService_GetUsers(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.UserFilter{
 			Page:     1,
@@ -245,16 +290,16 @@ func TestUserService_GetUsers(t *testing.T) {
 		users[1].Email = "user2@example.com"
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(users, int64(2), nil)
+		mockRepo.On("GetAll", mock.Anything, filter).Return(users, pagination.Exact(2), nil)
 
 		// When
-		response, err := service.GetUsers(filter)
+		response, err := service.GetUsers(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Len(t, response.Data, 2)
-		assert.Equal(t, int64(2), response.TotalCount)
+		assert.Equal(t, int64(2), *response.TotalCount)
 		assert.Equal(t, 1, response.Page)
 		assert.Equal(t, 10, response.PageSize)
 		mockRepo.AssertExpectations(t)
@@ -264,7 +309,7 @@ func TestUserService_GetUsers(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.UserFilter{
 			Page:     0,
@@ -277,10 +322,10 @@ func TestUserService_GetUsers(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", expectedFilter).Return([]entity.User{}, int64(0), nil)
+		mockRepo.On("GetAll", mock.Anything, expectedFilter).Return([]entity.User{}, pagination.Exact(0), nil)
 
 		// When
-		response, err := service.GetUsers(filter)
+		response, err := service.GetUsers(context.Background(), filter)
 
 		// Then
 		assert.NoError(t, err)
@@ -294,7 +339,7 @@ func TestUserService_GetUsers(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		filter := &dto.UserFilter{
 			Page:     1,
@@ -302,10 +347,10 @@ func TestUserService_GetUsers(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetAll", filter).Return(nil, int64(0), errors.New("database error"))
+		mockRepo.On("GetAll", mock.Anything, filter).Return(nil, pagination.Page{}, errors.New("database error"))
 
 		// When
-		response, err := service.GetUsers(filter)
+		response, err := service.GetUsers(context.Background(), filter)
 
 		// Then
 		assert.Error(t, err)
@@ -320,7 +365,8 @@ func TestUserService_UpdateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		existingUser := testutil.CreateUserFixture()
@@ -331,12 +377,12 @@ func TestUserService_UpdateUser(t *testing.T) {
 		req.Email = "new@example.com"
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(existingUser, nil)
-		mockRepo.On("EmailExists", req.Email).Return(false, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(false, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 
 		// When
-		response, err := service.UpdateUser(userID, req)
+		response, err := service.UpdateUser(context.Background(), userID, req)
 
 		// Then
 		assert.NoError(t, err)
@@ -344,6 +390,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 		assert.Equal(t, userID, response.ID)
 		assert.Equal(t, req.Name, response.Name)
 		assert.Equal(t, req.Email, response.Email)
+		assert.Equal(t, []events.Event{events.UserUpdated{UserID: userID, Name: req.Name, Email: req.Email}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -351,16 +398,16 @@ func TestUserService_UpdateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(999)
 		req := testutil.CreateUpdateUserRequestFixture()
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		response, err := service.UpdateUser(userID, req)
+		response, err := service.UpdateUser(context.Background(), userID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -373,7 +420,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		existingUser := testutil.CreateUserFixture()
@@ -384,11 +431,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 		req.Email = "exists@example.com"
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(existingUser, nil)
-		mockRepo.On("EmailExists", req.Email).Return(true, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
+		mockRepo.On("EmailExists", mock.Anything, req.Email).Return(true, nil)
 
 		// When
-		response, err := service.UpdateUser(userID, req)
+		response, err := service.UpdateUser(context.Background(), userID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -401,7 +448,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		existingUser := testutil.CreateUserFixture()
@@ -412,17 +459,17 @@ func TestUserService_UpdateUser(t *testing.T) {
 		req.Email = "same@example.com"
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(existingUser, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 
 		// When
-		response, err := service.UpdateUser(userID, req)
+		response, err := service.UpdateUser(context.Background(), userID, req)
 
 		// Then
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		mockRepo.AssertExpectations(t)
-		mockRepo.AssertNotCalled(t, "EmailExists")
+		mockRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything)
 	})
 }
 
@@ -431,7 +478,8 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		currentPassword := "currentpassword"
@@ -447,14 +495,15 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(existingUser, nil)
-		mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 
 		// When
-		err := service.UpdateUserPassword(userID, req)
+		err := service.UpdateUserPassword(context.Background(), userID, req)
 
 		// Then
 		assert.NoError(t, err)
+		assert.Equal(t, []events.Event{events.UserPasswordChanged{UserID: userID}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -462,7 +511,7 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(999)
 		req := &dto.UpdateUserPasswordRequest{
@@ -471,10 +520,10 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		err := service.UpdateUserPassword(userID, req)
+		err := service.UpdateUserPassword(context.Background(), userID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -486,7 +535,7 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
@@ -501,10 +550,10 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 		}
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(existingUser, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
 
 		// When
-		err := service.UpdateUserPassword(userID, req)
+		err := service.UpdateUserPassword(context.Background(), userID, req)
 
 		// Then
 		assert.Error(t, err)
@@ -518,21 +567,23 @@ func TestUserService_DeleteUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		user := testutil.CreateUserFixture()
 		user.ID = userID
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(user, nil)
-		mockRepo.On("Delete", userID).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mockRepo.On("Delete", mock.Anything, userID).Return(nil)
 
 		// When
-		err := service.DeleteUser(userID)
+		err := service.DeleteUser(context.Background(), userID)
 
 		// Then
 		assert.NoError(t, err)
+		assert.Equal(t, []events.Event{events.UserDeleted{UserID: userID}}, mockOutbox.Events)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -540,15 +591,15 @@ func TestUserService_DeleteUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(999)
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
 
 		// When
-		err := service.DeleteUser(userID)
+		err := service.DeleteUser(context.Background(), userID)
 
 		// Then
 		assert.Error(t, err)
@@ -560,18 +611,18 @@ func TestUserService_DeleteUser(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger)
 
 		userID := uint(1)
 		user := testutil.CreateUserFixture()
 		user.ID = userID
 
 		// Mock expectations
-		mockRepo.On("GetByID", userID).Return(user, nil)
-		mockRepo.On("Delete", userID).Return(errors.New("delete failed"))
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mockRepo.On("Delete", mock.Anything, userID).Return(errors.New("delete failed"))
 
 		// When
-		err := service.DeleteUser(userID)
+		err := service.DeleteUser(context.Background(), userID)
 
 		// Then
 		assert.Error(t, err)
@@ -585,7 +636,7 @@ func TestUserService_entityToResponse(t *testing.T) {
 		// Setup
 		mockRepo := &testutil.MockUserRepository{}
 		logger := testutil.NewSilentLogger()
-		service := NewUserService(mockRepo, logger).(*userService)
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, logger).(*userService)
 
 		user := testutil.CreateUserFixture()
 		user.ID = 1
@@ -606,3 +657,76 @@ func TestUserService_entityToResponse(t *testing.T) {
 		// Password should not be included in response (UserResponse doesn't have Password field)
 	})
 }
+
+func TestUserService_UpdateUserRole(t *testing.T) {
+	t.Run("should assign the role", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockUserRepository{}
+		mockOutbox := &testutil.MockOutbox{}
+		mockAuditor := &testutil.MockAuditRecorder{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, mockAuditor, testutil.NewSilentLogger())
+
+		user := testutil.CreateUserFixture()
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(user, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return u.Role == "operator"
+		})).Return(nil)
+
+		// When
+		response, err := service.UpdateUserRole(context.Background(), 1, &dto.UpdateUserRoleRequest{Role: "operator"})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "operator", response.Role)
+		assert.Equal(t, []events.Event{events.UserRoleChanged{UserID: 1, Role: "operator"}}, mockOutbox.Events)
+		require.Len(t, mockAuditor.Changes, 1)
+		assert.Equal(t, audit.ActionUpdate, mockAuditor.Changes[0].Action)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not demote the last admin", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockUserRepository{}
+		mockOutbox := &testutil.MockOutbox{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, mockOutbox, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
+
+		user := testutil.CreateUserFixture()
+		user.Role = "admin"
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(user, nil)
+		mockRepo.On("CountByRole", mock.Anything, (*uint)(nil), "admin").Return(int64(1), nil)
+
+		// When
+		response, err := service.UpdateUserRole(context.Background(), 1, &dto.UpdateUserRoleRequest{Role: "readonly"})
+
+		// Then
+		assert.EqualError(t, err, "cannot remove the last admin")
+		assert.Nil(t, response)
+		assert.Empty(t, mockOutbox.Events)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should demote an admin when another one remains", func(t *testing.T) {
+		// Setup
+		mockRepo := &testutil.MockUserRepository{}
+		service := NewUserService(mockRepo, &testutil.MockTransactionManager{}, &testutil.MockOutbox{}, &testutil.MockAuditRecorder{}, testutil.NewSilentLogger())
+
+		user := testutil.CreateUserFixture()
+		user.Role = "admin"
+
+		// Mock expectations
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(user, nil)
+		mockRepo.On("CountByRole", mock.Anything, (*uint)(nil), "admin").Return(int64(2), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		// When
+		response, err := service.UpdateUserRole(context.Background(), 1, &dto.UpdateUserRoleRequest{Role: "billing"})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "billing", response.Role)
+	})
+}
@@ -0,0 +1,83 @@
+package dto
+
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1,dive,required,max=64"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=128"`
+	Description string   `json:"description" binding:"omitempty,max=200"`
+	Active      *bool    `json:"active"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" binding:"omitempty,url,max=2048"`
+	EventTypes  []string `json:"event_types" binding:"omitempty,min=1,dive,required,max=64"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=128"`
+	Description string   `json:"description" binding:"omitempty,max=200"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookResponse describes a subscription. Secret is only set in the
+// response to the create request, when a secret was generated or given.
+type WebhookResponse struct {
+	ID          uint     `json:"id"`
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Secret      string   `json:"secret,omitempty"`
+	Description string   `json:"description"`
+	Active      bool     `json:"active"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type ListWebhooksResponse struct {
+	Data           []WebhookResponse `json:"data"`
+	Total          *int64            `json:"total,omitempty"`
+	TotalEstimated bool              `json:"total_estimated,omitempty"`
+	Page           int               `json:"page"`
+	PageSize       int               `json:"page_size"`
+	TotalPage      *int              `json:"total_page,omitempty"`
+	NextCursor     string            `json:"next_cursor,omitempty"`
+}
+
+type WebhookFilter struct {
+	Active   *bool `json:"active" form:"active"`
+	Page     int   `json:"page" form:"page" binding:"min=1"`
+	PageSize int   `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
+
+type DeliveryResponse struct {
+	ID             uint    `json:"id"`
+	WebhookID      uint    `json:"webhook_id"`
+	EventID        string  `json:"event_id"`
+	EventType      string  `json:"event_type"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	ResponseStatus int     `json:"response_status"`
+	ResponseBody   string  `json:"response_body,omitempty"`
+	LastError      string  `json:"last_error,omitempty"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+type ListDeliveriesResponse struct {
+	Data           []DeliveryResponse `json:"data"`
+	Total          *int64             `json:"total,omitempty"`
+	TotalEstimated bool               `json:"total_estimated,omitempty"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+	TotalPage      *int               `json:"total_page,omitempty"`
+	NextCursor     string             `json:"next_cursor,omitempty"`
+}
+
+type DeliveryFilter struct {
+	Status    string `json:"status" form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	EventType string `json:"event_type" form:"event_type"`
+	Page      int    `json:"page" form:"page" binding:"min=1"`
+	PageSize  int    `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	pagination.Params
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Subscription is an endpoint that receives the events it subscribed to.
+// EventTypes is a comma separated list of event types, "*" matching every
+// event.
+type Subscription struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	URL         string    `json:"url" gorm:"not null;size:2048"`
+	EventTypes  string    `json:"event_types" gorm:"not null;size:1024"`
+	Secret      string    `json:"-" gorm:"not null"`
+	Description string    `json:"description" gorm:"size:200"`
+	Active      bool      `json:"active" gorm:"default:true;index"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WildcardEventType subscribes to every event.
+const WildcardEventType = "*"
+
+func (s Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// EventTypeList returns the subscribed event types.
+func (s Subscription) EventTypeList() []string {
+	if s.EventTypes == "" {
+		return nil
+	}
+	return strings.Split(s.EventTypes, ",")
+}
+
+// Matches reports whether the subscription wants events of eventType.
+func (s Subscription) Matches(eventType string) bool {
+	for _, t := range s.EventTypeList() {
+		if t == WildcardEventType || t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is one event sent to one subscription, with the outcome of its
+// latest attempt.
+type Delivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey;index:idx_webhook_deliveries_subscription_id_id,priority:2"`
+	SubscriptionID uint       `json:"subscription_id" gorm:"not null;uniqueIndex:idx_webhook_deliveries_event;index:idx_webhook_deliveries_subscription_id_id,priority:1"`
+	EventID        string     `json:"event_id" gorm:"not null;size:36;uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string     `json:"event_type" gorm:"not null;size:64"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"size:16;not null;default:'pending';index"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body" gorm:"type:text"`
+	LastError      string     `json:"last_error" gorm:"type:text"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Delivery states. A pending delivery is retried until it succeeds or runs
+// out of attempts.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+func (d Delivery) TableName() string {
+	return "webhook_deliveries"
+}
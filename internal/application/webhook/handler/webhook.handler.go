@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+	logger         *zap.Logger
+}
+
+func NewWebhookHandler(webhookService service.WebhookService, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+func (h *WebhookHandler) RegisterRoutes(r *gin.RouterGroup) {
+	webhooks := r.Group("/webhooks")
+	{
+		webhooks.POST("", h.CreateWebhook)
+		webhooks.GET("", h.ListWebhooks)
+		webhooks.GET("/:id", h.GetWebhook)
+		webhooks.PUT("/:id", h.UpdateWebhook)
+		webhooks.DELETE("/:id", h.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", h.ListDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.RedeliverDelivery)
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Subscribe a URL to domain events. Deliveries are signed with HMAC-SHA256 using the secret, which is generated when omitted and only returned by this call.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Create Webhook Request"
+// @Success 201 {object} dto.WebhookResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.webhookService.CreateWebhook(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create webhook", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description Get a paginated list of webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param active query bool false "Filter by active flag"
+// @Success 200 {object} dto.ListWebhooksResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	var filter dto.WebhookFilter
+	filter.Page = 1
+	filter.PageSize = 10
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.webhookService.ListWebhooks(c.Request.Context(), &filter)
+	if err != nil {
+		h.logger.Error("Failed to list webhooks", zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetWebhook godoc
+// @Summary Get webhook by ID
+// @Description Get a webhook subscription by ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.webhookService.GetWebhookByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get webhook", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook
+// @Description Update a webhook subscription. Omitted fields keep their value.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param request body dto.UpdateWebhookRequest true "Update Webhook Request"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.webhookService.UpdateWebhook(c.Request.Context(), id, &req)
+	if err != nil {
+		h.logger.Error("Failed to update webhook", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook
+// @Description Delete a webhook subscription together with its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete webhook", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Get the delivery log of a webhook, newest first
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param cursor query string false "next_cursor of the previous page; page is ignored when set"
+// @Param count query string false "How the total is counted: exact by default, none with a cursor" Enums(exact, estimated, none)
+// @Param status query string false "Filter by status" Enums(pending, succeeded, failed)
+// @Param event_type query string false "Filter by event type"
+// @Success 200 {object} dto.ListDeliveriesResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var filter dto.DeliveryFilter
+	filter.Page = 1
+	filter.PageSize = 10
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Error("Invalid query parameters", zap.Error(err))
+		apperror.Respond(c, apperror.InvalidRequest(err))
+		return
+	}
+
+	resp, err := h.webhookService.ListDeliveries(c.Request.Context(), id, &filter)
+	if err != nil {
+		h.logger.Error("Failed to list webhook deliveries", zap.Uint("id", id), zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RedeliverDelivery godoc
+// @Summary Redeliver a webhook delivery
+// @Description Reset a delivery to pending with a fresh attempt budget and send it again right away
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} dto.DeliveryResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverDelivery(c *gin.Context) {
+	id, ok := h.parseID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := h.parseID(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	resp, err := h.webhookService.RedeliverDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		h.logger.Error("Failed to redeliver webhook delivery",
+			zap.Uint("id", id),
+			zap.Uint("delivery_id", deliveryID),
+			zap.Error(err))
+		apperror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// parseID parses the path parameter name, answering 400 when it is invalid.
+func (h *WebhookHandler) parseID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid ID", zap.String("param", name), zap.Error(err))
+		apperror.Respond(c, apperror.InvalidField(name, "must be a positive integer"))
+		return 0, false
+	}
+	return uint(id), true
+}
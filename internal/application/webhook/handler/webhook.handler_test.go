@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webhookDto "github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWebhookHandler() (*gin.Engine, *testutil.MockWebhookService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &testutil.MockWebhookService{}
+	handler := NewWebhookHandler(mockService, testutil.NewSilentLogger())
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/v1"))
+	return router, mockService
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	t.Run("should create webhook successfully", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+		req := testutil.CreateWebhookRequestFixture()
+		response := &webhookDto.WebhookResponse{ID: 1, URL: req.URL, EventTypes: req.EventTypes, Secret: "whsec_abc", Active: true}
+
+		// Mock expectations
+		mockService.On("CreateWebhook", mock.Anything, mock.AnythingOfType("*dto.CreateWebhookRequest")).Return(response, nil)
+
+		// When
+		reqBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBuffer(reqBody)))
+
+		// Then
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var result webhookDto.WebhookResponse
+		json.Unmarshal(w.Body.Bytes(), &result)
+		assert.Equal(t, "whsec_abc", result.Secret)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return bad request without event types", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks",
+			bytes.NewBufferString(`{"url":"https://billing.example.com/hooks"}`)))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return bad request for unknown event types", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+		req := testutil.CreateWebhookRequestFixture()
+
+		// Mock expectations
+		mockService.On("CreateWebhook", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidEventType)
+
+		// When
+		reqBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBuffer(reqBody)))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebhookHandler_GetWebhook(t *testing.T) {
+	t.Run("should return not found", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// Mock expectations
+		mockService.On("GetWebhookByID", mock.Anything, uint(9)).Return(nil, service.ErrWebhookNotFound)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/9", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return bad request for invalid id", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/abc", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetWebhookByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	t.Run("should list deliveries with filter", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+		response := &webhookDto.ListDeliveriesResponse{
+			Data:     []webhookDto.DeliveryResponse{{ID: 3, WebhookID: 1, Status: "failed"}},
+			Total:    pagination.Exact(1).Total,
+			Page:     1,
+			PageSize: 10,
+		}
+
+		// Mock expectations
+		mockService.On("ListDeliveries", mock.Anything, uint(1), mock.MatchedBy(func(filter *webhookDto.DeliveryFilter) bool {
+			return filter.Status == "failed" && filter.Page == 1 && filter.PageSize == 10
+		})).Return(response, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries?status=failed", nil))
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should reject unknown status", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries?status=lost", nil))
+
+		// Then
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListDeliveries", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookHandler_RedeliverDelivery(t *testing.T) {
+	t.Run("should accept the redelivery", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// Mock expectations
+		mockService.On("RedeliverDelivery", mock.Anything, uint(1), uint(3)).
+			Return(&webhookDto.DeliveryResponse{ID: 3, WebhookID: 1, Status: "pending"}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/1/deliveries/3/redeliver", nil))
+
+		// Then
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found for deliveries of other webhooks", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// Mock expectations
+		mockService.On("RedeliverDelivery", mock.Anything, uint(2), uint(3)).Return(nil, service.ErrDeliveryNotFound)
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/2/deliveries/3/redeliver", nil))
+
+		// Then
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return internal error when enqueueing fails", func(t *testing.T) {
+		// Setup
+		router, mockService := setupWebhookHandler()
+
+		// Mock expectations
+		mockService.On("RedeliverDelivery", mock.Anything, uint(1), uint(3)).Return(nil, errors.New("redis unavailable"))
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/1/deliveries/3/redeliver", nil))
+
+		// Then
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
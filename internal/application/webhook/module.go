@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/service"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/worker"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
+
+	"go.uber.org/fx"
+)
+
+// Module provides all webhook domain dependencies
+var Module = fx.Options(
+	fx.Provide(
+		repository.NewWebhookRepository,
+		service.NewWebhookService,
+		handler.NewWebhookHandler,
+		// Redeliveries are queued for the worker
+		func(client *queue.Client) worker.AsynqClient {
+			return client
+		},
+		worker.NewDeliveryQueue,
+	),
+)
+
+// WorkerModule provides only worker dependencies for worker api
+var WorkerModule = fx.Options(
+	fx.Provide(
+		repository.NewWebhookRepository,
+		service.NewWebhookService,
+		func(client *queue.Client) worker.AsynqClient {
+			return client
+		},
+		worker.NewDeliveryQueue,
+		worker.NewDeliveryWorker,
+	),
+)
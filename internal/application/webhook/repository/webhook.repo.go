@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *entity.Subscription) error
+	GetSubscriptionByID(ctx context.Context, id uint) (*entity.Subscription, error)
+	ListSubscriptions(ctx context.Context, filter *dto.WebhookFilter) ([]entity.Subscription, pagination.Page, error)
+	ListActiveSubscriptions(ctx context.Context) ([]entity.Subscription, error)
+	UpdateSubscription(ctx context.Context, sub *entity.Subscription) error
+	DeleteSubscription(ctx context.Context, id uint) error
+	CreateDelivery(ctx context.Context, delivery *entity.Delivery) error
+	GetDeliveryByID(ctx context.Context, id uint) (*entity.Delivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uint, filter *dto.DeliveryFilter) ([]entity.Delivery, pagination.Page, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.Delivery) error
+}
+
+// webhookRepository encrypts the subscription secrets on write and decrypts
+// them on read, so callers only ever see plaintext secrets.
+type webhookRepository struct {
+	db      *gorm.DB
+	logger  *zap.Logger
+	keyring *secretbox.Keyring
+}
+
+func NewWebhookRepository(db *gorm.DB, logger *zap.Logger, keyring *secretbox.Keyring) WebhookRepository {
+	return &webhookRepository{
+		db:      db,
+		logger:  logger,
+		keyring: keyring,
+	}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, sub *entity.Subscription) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Creating webhook subscription", zap.String("url", sub.URL))
+	return r.withEncryptedSecret(db, sub, func(tx *gorm.DB) error {
+		return tx.Create(sub).Error
+	})
+}
+
+func (r *webhookRepository) GetSubscriptionByID(ctx context.Context, id uint) (*entity.Subscription, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var sub entity.Subscription
+	if err := db.First(&sub, id).Error; err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get webhook subscription by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	if err := r.decryptSecret(&sub); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context, filter *dto.WebhookFilter) ([]entity.Subscription, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+
+	query := db.Model(&entity.Subscription{})
+
+	if filter.Active != nil {
+		query = query.Where("active = ?", *filter.Active)
+	}
+
+	subs, page, err := pagination.Find[entity.Subscription](query, filter.Params, filter.Page, filter.PageSize, pagination.Order{})
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+	for i := range subs {
+		if err := r.decryptSecret(&subs[i]); err != nil {
+			return nil, pagination.Page{}, err
+		}
+	}
+
+	return subs, page, nil
+}
+
+func (r *webhookRepository) ListActiveSubscriptions(ctx context.Context) ([]entity.Subscription, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var subs []entity.Subscription
+	if err := db.Where("active = ?", true).Order("id ASC").Find(&subs).Error; err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list active webhook subscriptions", zap.Error(err))
+		return nil, err
+	}
+	for i := range subs {
+		if err := r.decryptSecret(&subs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return subs, nil
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, sub *entity.Subscription) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Updating webhook subscription", zap.Uint("id", sub.ID))
+	return r.withEncryptedSecret(db, sub, func(tx *gorm.DB) error {
+		return tx.Save(sub).Error
+	})
+}
+
+// DeleteSubscription deletes the subscription together with its delivery log.
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uint) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	logger.For(ctx, r.logger).Info("Deleting webhook subscription", zap.Uint("id", id))
+	if err := db.Where("subscription_id = ?", id).Delete(&entity.Delivery{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&entity.Subscription{}, id).Error
+}
+
+// CreateDelivery inserts the delivery unless the subscription already has one
+// for the same event, in which case delivery is loaded with the existing row.
+// This keeps redelivered events from being sent twice.
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.Delivery) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	if result.Error != nil {
+		logger.For(ctx, r.logger).Error("Failed to create webhook delivery",
+			zap.Uint("subscription_id", delivery.SubscriptionID),
+			zap.String("event_id", delivery.EventID),
+			zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	return db.Where("subscription_id = ? AND event_id = ?", delivery.SubscriptionID, delivery.EventID).
+		First(delivery).Error
+}
+
+func (r *webhookRepository) GetDeliveryByID(ctx context.Context, id uint) (*entity.Delivery, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	var delivery entity.Delivery
+	if err := db.First(&delivery, id).Error; err != nil {
+		logger.For(ctx, r.logger).Error("Failed to get webhook delivery by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uint,
+	filter *dto.DeliveryFilter,
+) ([]entity.Delivery, pagination.Page, error) {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+
+	query := db.Model(&entity.Delivery{}).Where("subscription_id = ?", subscriptionID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+
+	deliveries, page, err := pagination.Find[entity.Delivery](query, filter.Params, filter.Page, filter.PageSize, pagination.Order{{Column: "id", Desc: true}})
+	if err != nil {
+		logger.For(ctx, r.logger).Error("Failed to list webhook deliveries", zap.Uint("subscription_id", subscriptionID), zap.Error(err))
+		return nil, pagination.Page{}, err
+	}
+
+	return deliveries, page, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.Delivery) error {
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	if err := db.Save(delivery).Error; err != nil {
+		logger.For(ctx, r.logger).Error("Failed to update webhook delivery", zap.Uint("id", delivery.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// withEncryptedSecret runs fn in a transaction of db with sub.Secret
+// encrypted, bound to the row, and restores the plaintext afterwards. A new
+// row has no ID to bind to before fn inserts it, so its secret is written by
+// a second statement.
+func (r *webhookRepository) withEncryptedSecret(db *gorm.DB, sub *entity.Subscription, fn func(tx *gorm.DB) error) error {
+	plaintext := sub.Secret
+	defer func() { sub.Secret = plaintext }()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		inserted := sub.ID == 0 && r.keyring.Enabled()
+		if inserted {
+			sub.Secret = ""
+		} else if err := r.encryptSecret(sub, plaintext); err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil || !inserted {
+			return err
+		}
+
+		if err := r.encryptSecret(sub, plaintext); err != nil {
+			return err
+		}
+		return tx.Model(&entity.Subscription{}).Where("id = ?", sub.ID).UpdateColumn("secret", sub.Secret).Error
+	})
+}
+
+func (r *webhookRepository) encryptSecret(sub *entity.Subscription, plaintext string) error {
+	encrypted, err := r.keyring.Encrypt(plaintext, secretbox.AAD(sub.TableName(), sub.ID))
+	if err != nil {
+		r.logger.Error("Failed to encrypt webhook secret", zap.String("url", sub.URL), zap.Error(err))
+		return err
+	}
+	sub.Secret = encrypted
+	return nil
+}
+
+func (r *webhookRepository) decryptSecret(sub *entity.Subscription) error {
+	plaintext, err := r.keyring.Decrypt(sub.Secret, secretbox.AAD(sub.TableName(), sub.ID))
+	if err != nil {
+		r.logger.Error("Failed to decrypt webhook secret", zap.Uint("id", sub.ID), zap.Error(err))
+		return err
+	}
+	sub.Secret = plaintext
+	return nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	webhookDto "github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	webhookEntity "github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestWebhookRepository_Subscription(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewWebhookRepository(db, logger, testutil.NewTestKeyring())
+
+	t.Run("should encrypt the secret at rest and decrypt it on read", func(t *testing.T) {
+		// Given
+		sub := testutil.CreateWebhookSubscriptionFixture()
+		sub.ID = 0
+		secret := sub.Secret
+
+		// When
+		err := repo.CreateSubscription(context.Background(), sub)
+
+		// Then
+		assert.NoError(t, err)
+		assert.NotZero(t, sub.ID)
+		assert.Equal(t, secret, sub.Secret)
+
+		var stored webhookEntity.Subscription
+		require.NoError(t, db.First(&stored, sub.ID).Error)
+		assert.NotEqual(t, secret, stored.Secret)
+
+		found, err := repo.GetSubscriptionByID(context.Background(), sub.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, found.Secret)
+		assert.Equal(t, []string{"payment.completed", "subscriber.created"}, found.EventTypeList())
+	})
+
+	t.Run("should list only active subscriptions", func(t *testing.T) {
+		// Given
+		inactive := testutil.CreateWebhookSubscriptionFixture()
+		inactive.ID = 0
+		inactive.URL = "https://crm.example.com/hooks"
+		require.NoError(t, repo.CreateSubscription(context.Background(), inactive))
+		require.NoError(t, db.Model(inactive).Update("active", false).Error)
+
+		// When
+		active, err := repo.ListActiveSubscriptions(context.Background())
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.True(t, active[0].Active)
+
+		activeFilter := false
+		subs, page, err := repo.ListSubscriptions(context.Background(), &webhookDto.WebhookFilter{Active: &activeFilter, Page: 1, PageSize: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *page.Total)
+		require.Len(t, subs, 1)
+		assert.Equal(t, inactive.URL, subs[0].URL)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
+
+func TestWebhookRepository_Delivery(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	logger := testutil.NewTestLogger(t)
+	repo := NewWebhookRepository(db, logger, testutil.NewTestKeyring())
+
+	sub := testutil.CreateWebhookSubscriptionFixture()
+	sub.ID = 0
+	require.NoError(t, repo.CreateSubscription(context.Background(), sub))
+
+	t.Run("should reuse the existing delivery of the same event", func(t *testing.T) {
+		// Given
+		first := testutil.CreateWebhookDeliveryFixture()
+		first.ID = 0
+		first.SubscriptionID = sub.ID
+		require.NoError(t, repo.CreateDelivery(context.Background(), first))
+		first.Attempts = 2
+		require.NoError(t, repo.UpdateDelivery(context.Background(), first))
+
+		again := testutil.CreateWebhookDeliveryFixture()
+		again.ID = 0
+		again.SubscriptionID = sub.ID
+
+		// When
+		err := repo.CreateDelivery(context.Background(), again)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, again.ID)
+		assert.Equal(t, 2, again.Attempts)
+
+		var count int64
+		db.Model(&webhookEntity.Delivery{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should list deliveries of a subscription newest first", func(t *testing.T) {
+		// Given
+		second := testutil.CreateWebhookDeliveryFixture()
+		second.ID = 0
+		second.SubscriptionID = sub.ID
+		second.EventID = "0d4c3f1a-7a8e-4f0e-8d43-5c8f3f6e9b21"
+		second.Status = webhookEntity.DeliveryStatusFailed
+		require.NoError(t, repo.CreateDelivery(context.Background(), second))
+
+		// When
+		all, page, err := repo.ListDeliveries(context.Background(), sub.ID, &webhookDto.DeliveryFilter{Page: 1, PageSize: 10})
+		failed, failedPage, failedErr := repo.ListDeliveries(context.Background(), sub.ID, &webhookDto.DeliveryFilter{
+			Status:   webhookEntity.DeliveryStatusFailed,
+			Page:     1,
+			PageSize: 10,
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *page.Total)
+		require.Len(t, all, 2)
+		assert.Equal(t, second.ID, all[0].ID)
+
+		assert.NoError(t, failedErr)
+		assert.Equal(t, int64(1), *failedPage.Total)
+		require.Len(t, failed, 1)
+		assert.Equal(t, second.ID, failed[0].ID)
+	})
+
+	t.Run("should delete the deliveries with the subscription", func(t *testing.T) {
+		// When
+		err := repo.DeleteSubscription(context.Background(), sub.ID)
+
+		// Then
+		assert.NoError(t, err)
+
+		_, err = repo.GetSubscriptionByID(context.Background(), sub.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		var count int64
+		db.Model(&webhookEntity.Delivery{}).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	// Cleanup
+	testutil.CleanDB(db)
+}
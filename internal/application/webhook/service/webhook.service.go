@@ -0,0 +1,613 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/repository"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	GetWebhookByID(ctx context.Context, id uint) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context, filter *dto.WebhookFilter) (*dto.ListWebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, id uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, webhookID uint, filter *dto.DeliveryFilter) (*dto.ListDeliveriesResponse, error)
+	RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint) (*dto.DeliveryResponse, error)
+	DispatchEvent(ctx context.Context, env events.Envelope) error
+	DeliverWebhook(ctx context.Context, deliveryID uint, attempt int) error
+}
+
+// DeliveryQueue schedules a delivery attempt. attempt is the number of
+// attempts already made, so that stale tasks can be told apart. A
+// redelivery is scheduled apart from the attempts of the original delivery.
+type DeliveryQueue interface {
+	EnqueueDelivery(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error
+	EnqueueRedelivery(ctx context.Context, deliveryID uint) error
+}
+
+var (
+	// ErrWebhookNotFound is returned when the subscription does not exist.
+	ErrWebhookNotFound = apperror.NotFound("webhook not found")
+	// ErrDeliveryNotFound is returned when the delivery does not exist or
+	// belongs to another subscription.
+	ErrDeliveryNotFound = apperror.NotFound("webhook delivery not found")
+	// ErrInvalidEventType is returned for event types outside the catalog.
+	ErrInvalidEventType = apperror.Validation("unknown event type", apperror.FieldError{Field: "event_types", Message: "has an unknown event type"})
+	// ErrInvalidURL is returned for URLs that are not absolute http(s) URLs.
+	ErrInvalidURL = apperror.Validation("webhook url must be an absolute http or https url", apperror.FieldError{Field: "url", Message: "must be an absolute http or https url"})
+)
+
+const (
+	// maxResponseBody bounds the part of the receiver's response kept in the
+	// delivery log.
+	maxResponseBody = 1024
+	// generatedSecretBytes is the entropy of generated secrets.
+	generatedSecretBytes  = 32
+	generatedSecretPrefix = "whsec_"
+)
+
+// Defaults for webhooks settings left unset.
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultMaxAttempts  = 8
+	defaultRetryBackoff = 30 * time.Second
+	defaultMaxBackoff   = 6 * time.Hour
+)
+
+type webhookService struct {
+	repo         repository.WebhookRepository
+	txManager    database.TransactionManagerI
+	queue        DeliveryQueue
+	httpClient   *http.Client
+	maxAttempts  int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+	now          func() time.Time
+	logger       *zap.Logger
+}
+
+func NewWebhookService(
+	repo repository.WebhookRepository,
+	txManager database.TransactionManagerI,
+	queue DeliveryQueue,
+	cfg *config.Config,
+	logger *zap.Logger,
+) WebhookService {
+	return &webhookService{
+		repo:         repo,
+		txManager:    txManager,
+		queue:        queue,
+		httpClient:   &http.Client{Timeout: durationOr(cfg.Webhooks.Timeout, defaultTimeout)},
+		maxAttempts:  intOr(cfg.Webhooks.MaxAttempts, defaultMaxAttempts),
+		retryBackoff: durationOr(cfg.Webhooks.RetryBackoff, defaultRetryBackoff),
+		maxBackoff:   durationOr(cfg.Webhooks.MaxBackoff, defaultMaxBackoff),
+		now:          time.Now,
+		logger:       logger,
+	}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	logger.For(ctx, s.logger).Info("Creating webhook", zap.String("url", req.URL))
+
+	if err := validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			logger.For(ctx, s.logger).Error("Failed to generate webhook secret", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	sub := &entity.Subscription{
+		URL:         req.URL,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Description: req.Description,
+		Active:      true,
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to create webhook", zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("Webhook created successfully", zap.Uint("id", sub.ID))
+	response := subscriptionToResponse(sub)
+	response.Secret = sub.Secret
+	return response, nil
+}
+
+func (s *webhookService) GetWebhookByID(ctx context.Context, id uint) (*dto.WebhookResponse, error) {
+	logger.For(ctx, s.logger).Info("Getting webhook by ID", zap.Uint("id", id))
+
+	sub, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionToResponse(sub), nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, filter *dto.WebhookFilter) (*dto.ListWebhooksResponse, error) {
+	logger.For(ctx, s.logger).Info("Listing webhooks", zap.Int("page", filter.Page), zap.Int("page_size", filter.PageSize))
+
+	// Set defaults
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 10
+	}
+
+	subs, page, err := s.repo.ListSubscriptions(ctx, filter)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list webhooks", zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.WebhookResponse, 0, len(subs))
+	for i := range subs {
+		responses = append(responses, *subscriptionToResponse(&subs[i]))
+	}
+
+	return &dto.ListWebhooksResponse{
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
+	}, nil
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, id uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	logger.For(ctx, s.logger).Info("Updating webhook", zap.Uint("id", id))
+
+	sub, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields if provided
+	if req.URL != "" {
+		if err := validateURL(req.URL); err != nil {
+			return nil, err
+		}
+		sub.URL = req.URL
+	}
+	if len(req.EventTypes) > 0 {
+		eventTypes, err := normalizeEventTypes(req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		sub.EventTypes = eventTypes
+	}
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	if req.Description != "" {
+		sub.Description = req.Description
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+
+	if err := s.repo.UpdateSubscription(ctx, sub); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to update webhook", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	logger.For(ctx, s.logger).Info("Webhook updated successfully", zap.Uint("id", id))
+	return subscriptionToResponse(sub), nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id uint) error {
+	logger.For(ctx, s.logger).Info("Deleting webhook", zap.Uint("id", id))
+
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.repo.DeleteSubscription(txCtx, id)
+	})
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to delete webhook", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	logger.For(ctx, s.logger).Info("Webhook deleted successfully", zap.Uint("id", id))
+	return nil
+}
+
+func (s *webhookService) ListDeliveries(
+	ctx context.Context,
+	webhookID uint,
+	filter *dto.DeliveryFilter,
+) (*dto.ListDeliveriesResponse, error) {
+	logger.For(ctx, s.logger).Info("Listing webhook deliveries", zap.Uint("webhook_id", webhookID))
+
+	if _, err := s.getSubscription(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 10
+	}
+
+	deliveries, page, err := s.repo.ListDeliveries(ctx, webhookID, filter)
+	if err != nil {
+		logger.For(ctx, s.logger).Error("Failed to list webhook deliveries", zap.Uint("webhook_id", webhookID), zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		responses = append(responses, *deliveryToResponse(&deliveries[i]))
+	}
+
+	return &dto.ListDeliveriesResponse{
+		Data:           responses,
+		Total:          page.Total,
+		TotalEstimated: page.Estimated,
+		Page:           filter.Page,
+		PageSize:       filter.PageSize,
+		TotalPage:      page.TotalPages(filter.PageSize),
+		NextCursor:     page.NextCursor,
+	}, nil
+}
+
+// RedeliverDelivery resets the delivery to a fresh pending delivery, whatever
+// its outcome so far, and schedules it right away.
+func (s *webhookService) RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint) (*dto.DeliveryResponse, error) {
+	logger.For(ctx, s.logger).Info("Redelivering webhook delivery", zap.Uint("webhook_id", webhookID), zap.Uint("delivery_id", deliveryID))
+
+	delivery, err := s.repo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	if delivery.SubscriptionID != webhookID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery.Status = entity.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.LastError = ""
+	delivery.NextAttemptAt = s.now()
+	delivery.DeliveredAt = nil
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	if err := s.queue.EnqueueRedelivery(ctx, delivery.ID); err != nil {
+		logger.For(ctx, s.logger).Error("Failed to enqueue webhook redelivery", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return deliveryToResponse(delivery), nil
+}
+
+// DispatchEvent records a delivery of env for every active subscription that
+// wants it and schedules the pending ones. It is idempotent: an event that is
+// dispatched again reuses the deliveries recorded the first time.
+func (s *webhookService) DispatchEvent(ctx context.Context, env events.Envelope) error {
+	subs, err := s.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for i := range subs {
+		if !subs[i].Matches(env.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(env); err != nil {
+				return err
+			}
+		}
+
+		now := s.now()
+		delivery := &entity.Delivery{
+			SubscriptionID: subs[i].ID,
+			EventID:        env.ID,
+			EventType:      env.Type,
+			Payload:        string(payload),
+			Status:         entity.DeliveryStatusPending,
+			NextAttemptAt:  now,
+		}
+		if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		if delivery.Status != entity.DeliveryStatusPending {
+			continue
+		}
+
+		delay := delivery.NextAttemptAt.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		if err := s.queue.EnqueueDelivery(ctx, delivery.ID, delivery.Attempts, delay); err != nil {
+			logger.For(ctx, s.logger).Error("Failed to enqueue webhook delivery",
+				zap.Uint("delivery_id", delivery.ID),
+				zap.String("event_id", env.ID),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliverWebhook makes attempt number attempt+1 of the delivery. Deliveries
+// that are no longer pending, or whose attempt count moved on since the task
+// was scheduled, are skipped. A failed attempt schedules the next one before
+// it is recorded, so that an error leaves the delivery to be retried by the
+// queue.
+func (s *webhookService) DeliverWebhook(ctx context.Context, deliveryID uint, attempt int) error {
+	delivery, err := s.repo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Warn("Webhook delivery no longer exists", zap.Uint("delivery_id", deliveryID))
+			return nil
+		}
+		return err
+	}
+	if delivery.Status != entity.DeliveryStatusPending || delivery.Attempts != attempt {
+		logger.For(ctx, s.logger).Info("Skipping stale webhook delivery task",
+			zap.Uint("delivery_id", deliveryID),
+			zap.String("status", delivery.Status),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Int("task_attempt", attempt))
+		return nil
+	}
+
+	sub, err := s.repo.GetSubscriptionByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Warn("Webhook of delivery no longer exists", zap.Uint("delivery_id", deliveryID))
+			return nil
+		}
+		return err
+	}
+	if !sub.Active {
+		delivery.Status = entity.DeliveryStatusFailed
+		delivery.LastError = "webhook is inactive"
+		return s.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	now := s.now()
+	delivery.Attempts++
+	delivery.ResponseStatus, delivery.ResponseBody, err = s.post(ctx, sub, delivery, now)
+
+	if err == nil {
+		delivery.Status = entity.DeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		logger.For(ctx, s.logger).Info("Webhook delivered",
+			zap.Uint("delivery_id", delivery.ID),
+			zap.Uint("webhook_id", sub.ID),
+			zap.Int("attempts", delivery.Attempts))
+		return s.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= s.maxAttempts {
+		delivery.Status = entity.DeliveryStatusFailed
+		logger.For(ctx, s.logger).Warn("Webhook delivery failed permanently",
+			zap.Uint("delivery_id", delivery.ID),
+			zap.Uint("webhook_id", sub.ID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.Error(err))
+		return s.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	delay := s.backoff(delivery.Attempts)
+	delivery.NextAttemptAt = now.Add(delay)
+	logger.For(ctx, s.logger).Warn("Webhook delivery attempt failed",
+		zap.Uint("delivery_id", delivery.ID),
+		zap.Uint("webhook_id", sub.ID),
+		zap.Int("attempts", delivery.Attempts),
+		zap.Duration("retry_in", delay),
+		zap.Error(err))
+
+	if err := s.queue.EnqueueDelivery(ctx, delivery.ID, delivery.Attempts, delay); err != nil {
+		return fmt.Errorf("failed to schedule webhook retry: %w", err)
+	}
+	return s.repo.UpdateDelivery(ctx, delivery)
+}
+
+// post sends the delivery and returns the response status and the start of
+// the response body. Any status outside 2xx is an error.
+func (s *webhookService) post(
+	ctx context.Context,
+	sub *entity.Subscription,
+	delivery *entity.Delivery,
+	now time.Time,
+) (int, string, error) {
+	body := []byte(delivery.Payload)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, strconv.FormatUint(uint64(sub.ID), 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(responseBody), fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(responseBody), nil
+}
+
+// backoff returns the delay after the given number of failed attempts:
+// RetryBackoff doubled for every attempt after the first, capped at
+// MaxBackoff.
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.retryBackoff
+	for i := 1; i < attempts && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.maxBackoff {
+		delay = s.maxBackoff
+	}
+	return delay
+}
+
+func (s *webhookService) getSubscription(ctx context.Context, id uint) (*entity.Subscription, error) {
+	sub, err := s.repo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.For(ctx, s.logger).Warn("Webhook not found", zap.Uint("id", id))
+			return nil, ErrWebhookNotFound
+		}
+		logger.For(ctx, s.logger).Error("Failed to get webhook", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return sub, nil
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// normalizeEventTypes checks the requested event types against the catalog
+// and returns them as stored, without duplicates.
+func normalizeEventTypes(eventTypes []string) (string, error) {
+	seen := make(map[string]bool, len(eventTypes))
+	normalized := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		t = strings.TrimSpace(t)
+		if t != entity.WildcardEventType && !events.IsKnownType(t) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidEventType, t)
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return generatedSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intOr(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// Helper function to convert entity to response
+func subscriptionToResponse(sub *entity.Subscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:          sub.ID,
+		URL:         sub.URL,
+		EventTypes:  sub.EventTypeList(),
+		Description: sub.Description,
+		Active:      sub.Active,
+		CreatedAt:   sub.CreatedAt.String(),
+		UpdatedAt:   sub.UpdatedAt.String(),
+	}
+}
+
+func deliveryToResponse(delivery *entity.Delivery) *dto.DeliveryResponse {
+	response := &dto.DeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DeliveredAt:    formatTimestamp(delivery.DeliveredAt),
+		CreatedAt:      delivery.CreatedAt.String(),
+		UpdatedAt:      delivery.UpdatedAt.String(),
+	}
+	if delivery.Status == entity.DeliveryStatusPending {
+		response.NextAttemptAt = formatTimestamp(&delivery.NextAttemptAt)
+	}
+	return response
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	webhookDto "github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	webhookEntity "github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Webhooks: config.WebhooksConfig{
+			Timeout:      time.Second,
+			MaxAttempts:  3,
+			RetryBackoff: 30 * time.Second,
+			MaxBackoff:   time.Minute,
+		},
+	}
+}
+
+func setupWebhookService() (*webhookService, *testutil.MockWebhookRepository, *testutil.MockDeliveryQueue) {
+	mockRepo := &testutil.MockWebhookRepository{}
+	mockQueue := &testutil.MockDeliveryQueue{}
+	service := NewWebhookService(
+		mockRepo,
+		&testutil.MockTransactionManager{},
+		mockQueue,
+		testConfig(),
+		testutil.NewSilentLogger(),
+	).(*webhookService)
+	service.now = func() time.Time { return testNow }
+	return service, mockRepo, mockQueue
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	t.Run("should create webhook with a generated secret", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+		req := testutil.CreateWebhookRequestFixture()
+
+		// Mock expectations
+		mockRepo.On("CreateSubscription", mock.Anything, mock.AnythingOfType("*entity.Subscription")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*webhookEntity.Subscription).ID = 1
+		})
+
+		// When
+		response, err := service.CreateWebhook(context.Background(), req)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), response.ID)
+		assert.Equal(t, req.EventTypes, response.EventTypes)
+		assert.True(t, response.Active)
+		assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, response.Secret)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject unknown event types", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+		req := testutil.CreateWebhookRequestFixture()
+		req.EventTypes = []string{"payment.completed", "payment.refunded"}
+
+		// When
+		response, err := service.CreateWebhook(context.Background(), req)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidEventType)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject non http urls", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+		req := testutil.CreateWebhookRequestFixture()
+		req.URL = "ftp://billing.example.com/hooks"
+
+		// When
+		response, err := service.CreateWebhook(context.Background(), req)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidURL)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_GetWebhookByID(t *testing.T) {
+	t.Run("should not expose the secret", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+		sub := testutil.CreateWebhookSubscriptionFixture()
+
+		// Mock expectations
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(1)).Return(sub, nil)
+
+		// When
+		response, err := service.GetWebhookByID(context.Background(), 1)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, response.Secret)
+		assert.Equal(t, sub.URL, response.URL)
+	})
+
+	t.Run("should return not found error", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+
+		// Mock expectations
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		response, err := service.GetWebhookByID(context.Background(), 9)
+
+		// Then
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+		assert.Nil(t, response)
+	})
+}
+
+func TestWebhookService_DispatchEvent(t *testing.T) {
+	env := events.Envelope{
+		ID:            "7b0f9c9e-3c1d-4f4e-9a51-6f3f4f7f2a10",
+		Type:          events.TypePaymentCompleted,
+		AggregateType: events.AggregatePayment,
+		AggregateID:   "42",
+		OccurredAt:    testNow,
+		Payload:       json.RawMessage(`{"payment_id":42}`),
+	}
+
+	t.Run("should record and enqueue a delivery per matching subscription", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+
+		billing := *testutil.CreateWebhookSubscriptionFixture()
+		crm := *testutil.CreateWebhookSubscriptionFixture()
+		crm.ID = 2
+		crm.EventTypes = events.TypeUserCreated
+		all := *testutil.CreateWebhookSubscriptionFixture()
+		all.ID = 3
+		all.EventTypes = webhookEntity.WildcardEventType
+
+		var created []*webhookEntity.Delivery
+
+		// Mock expectations
+		mockRepo.On("ListActiveSubscriptions", mock.Anything).Return([]webhookEntity.Subscription{billing, crm, all}, nil)
+		mockRepo.On("CreateDelivery", mock.Anything, mock.AnythingOfType("*entity.Delivery")).Return(nil).Run(func(args mock.Arguments) {
+			delivery := args.Get(1).(*webhookEntity.Delivery)
+			delivery.ID = uint(len(created) + 1)
+			created = append(created, delivery)
+		})
+
+		// When
+		err := service.DispatchEvent(context.Background(), env)
+
+		// Then
+		assert.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, uint(1), created[0].SubscriptionID)
+		assert.Equal(t, uint(3), created[1].SubscriptionID)
+		assert.Equal(t, env.ID, created[0].EventID)
+		assert.Equal(t, webhookEntity.DeliveryStatusPending, created[0].Status)
+
+		var payload events.Envelope
+		require.NoError(t, json.Unmarshal([]byte(created[0].Payload), &payload))
+		assert.Equal(t, env.ID, payload.ID)
+		assert.JSONEq(t, `{"payment_id":42}`, string(payload.Payload))
+
+		assert.Equal(t, []testutil.EnqueuedDelivery{{DeliveryID: 1}, {DeliveryID: 2}}, mockQueue.Enqueued)
+	})
+
+	t.Run("should not enqueue deliveries that already finished", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+
+		// Mock expectations
+		mockRepo.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhookEntity.Subscription{*testutil.CreateWebhookSubscriptionFixture()}, nil)
+		mockRepo.On("CreateDelivery", mock.Anything, mock.AnythingOfType("*entity.Delivery")).Return(nil).Run(func(args mock.Arguments) {
+			delivery := args.Get(1).(*webhookEntity.Delivery)
+			delivery.ID = 7
+			delivery.Status = webhookEntity.DeliveryStatusSucceeded
+		})
+
+		// When
+		err := service.DispatchEvent(context.Background(), env)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, mockQueue.Enqueued)
+	})
+
+	t.Run("should return enqueue errors so the event is relayed again", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+		mockQueue.EnqueueDeliveryFn = func(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error {
+			return errors.New("redis unavailable")
+		}
+
+		// Mock expectations
+		mockRepo.On("ListActiveSubscriptions", mock.Anything).
+			Return([]webhookEntity.Subscription{*testutil.CreateWebhookSubscriptionFixture()}, nil)
+		mockRepo.On("CreateDelivery", mock.Anything, mock.AnythingOfType("*entity.Delivery")).Return(nil)
+
+		// When
+		err := service.DispatchEvent(context.Background(), env)
+
+		// Then
+		assert.EqualError(t, err, "redis unavailable")
+	})
+}
+
+func TestWebhookService_DeliverWebhook(t *testing.T) {
+	t.Run("should post a signed delivery and mark it succeeded", func(t *testing.T) {
+		// Setup
+		var received *http.Request
+		var receivedBody []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		service, mockRepo, mockQueue := setupWebhookService()
+		sub := testutil.CreateWebhookSubscriptionFixture()
+		sub.URL = receiver.URL
+		delivery := testutil.CreateWebhookDeliveryFixture()
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(1)).Return(sub, nil)
+		mockRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+		// When
+		err := service.DeliverWebhook(context.Background(), 1, 0)
+
+		// Then
+		assert.NoError(t, err)
+		require.NotNil(t, received)
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		assert.Equal(t, "1", received.Header.Get(HeaderWebhookID))
+		assert.Equal(t, delivery.EventType, received.Header.Get(HeaderEvent))
+		assert.Equal(t, delivery.EventID, received.Header.Get(HeaderDelivery))
+		assert.Equal(t, delivery.Payload, string(receivedBody))
+
+		timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, testNow.Unix(), timestamp)
+		assert.True(t, Verify(sub.Secret, timestamp, receivedBody, received.Header.Get(HeaderSignature)))
+
+		assert.Equal(t, webhookEntity.DeliveryStatusSucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+		require.NotNil(t, delivery.DeliveredAt)
+		assert.Empty(t, mockQueue.Enqueued)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should schedule a retry with backoff when the receiver fails", func(t *testing.T) {
+		// Setup
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("maintenance"))
+		}))
+		defer receiver.Close()
+
+		service, mockRepo, mockQueue := setupWebhookService()
+		sub := testutil.CreateWebhookSubscriptionFixture()
+		sub.URL = receiver.URL
+		delivery := testutil.CreateWebhookDeliveryFixture()
+		delivery.Attempts = 1
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(1)).Return(sub, nil)
+		mockRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+		// When
+		err := service.DeliverWebhook(context.Background(), 1, 1)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, webhookEntity.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+		assert.Equal(t, "maintenance", delivery.ResponseBody)
+		assert.Equal(t, "unexpected response status 503", delivery.LastError)
+		assert.Equal(t, testNow.Add(time.Minute), delivery.NextAttemptAt)
+		assert.Equal(t, []testutil.EnqueuedDelivery{{DeliveryID: 1, Attempt: 2, Delay: time.Minute}}, mockQueue.Enqueued)
+	})
+
+	t.Run("should mark the delivery failed after the last attempt", func(t *testing.T) {
+		// Setup
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		service, mockRepo, mockQueue := setupWebhookService()
+		sub := testutil.CreateWebhookSubscriptionFixture()
+		sub.URL = receiver.URL
+		delivery := testutil.CreateWebhookDeliveryFixture()
+		delivery.Attempts = 2
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(1)).Return(sub, nil)
+		mockRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+		// When
+		err := service.DeliverWebhook(context.Background(), 1, 2)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, webhookEntity.DeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Empty(t, mockQueue.Enqueued)
+	})
+
+	t.Run("should not record the attempt when the retry cannot be scheduled", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+		mockQueue.EnqueueDeliveryFn = func(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error {
+			return errors.New("redis unavailable")
+		}
+		sub := testutil.CreateWebhookSubscriptionFixture()
+		sub.URL = "http://127.0.0.1:1"
+		delivery := testutil.CreateWebhookDeliveryFixture()
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(1)).Return(sub, nil)
+
+		// When
+		err := service.DeliverWebhook(context.Background(), 1, 0)
+
+		// Then
+		assert.ErrorContains(t, err, "redis unavailable")
+		mockRepo.AssertNotCalled(t, "UpdateDelivery", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should skip stale tasks", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+		delivery := testutil.CreateWebhookDeliveryFixture()
+		delivery.Attempts = 3
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+
+		// When
+		err := service.DeliverWebhook(context.Background(), 1, 1)
+
+		// Then
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "GetSubscriptionByID", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateDelivery", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_RedeliverDelivery(t *testing.T) {
+	t.Run("should reset the delivery and enqueue it", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+		deliveredAt := testNow.Add(-time.Hour)
+		delivery := testutil.CreateWebhookDeliveryFixture()
+		delivery.Status = webhookEntity.DeliveryStatusFailed
+		delivery.Attempts = 3
+		delivery.LastError = "unexpected response status 500"
+		delivery.DeliveredAt = &deliveredAt
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(delivery, nil)
+		mockRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+		// When
+		response, err := service.RedeliverDelivery(context.Background(), 1, 1)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, webhookEntity.DeliveryStatusPending, response.Status)
+		assert.Zero(t, response.Attempts)
+		assert.Empty(t, response.LastError)
+		assert.Nil(t, response.DeliveredAt)
+		assert.Equal(t, []uint{1}, mockQueue.Redelivered)
+		assert.Empty(t, mockQueue.Enqueued)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not redeliver a delivery of another webhook", func(t *testing.T) {
+		// Setup
+		service, mockRepo, mockQueue := setupWebhookService()
+
+		// Mock expectations
+		mockRepo.On("GetDeliveryByID", mock.Anything, uint(1)).Return(testutil.CreateWebhookDeliveryFixture(), nil)
+
+		// When
+		response, err := service.RedeliverDelivery(context.Background(), 2, 1)
+
+		// Then
+		assert.ErrorIs(t, err, ErrDeliveryNotFound)
+		assert.Nil(t, response)
+		assert.Empty(t, mockQueue.Redelivered)
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	t.Run("should return not found for unknown webhooks", func(t *testing.T) {
+		// Setup
+		service, mockRepo, _ := setupWebhookService()
+
+		// Mock expectations
+		mockRepo.On("GetSubscriptionByID", mock.Anything, uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		// When
+		response, err := service.ListDeliveries(context.Background(), 9, &webhookDto.DeliveryFilter{})
+
+		// Then
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "ListDeliveries", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_Backoff(t *testing.T) {
+	// Setup
+	service, _, _ := setupWebhookService()
+
+	// Then
+	assert.Equal(t, 30*time.Second, service.backoff(1))
+	assert.Equal(t, time.Minute, service.backoff(2))
+	assert.Equal(t, time.Minute, service.backoff(5))
+}
+
+func TestSignature(t *testing.T) {
+	// Given
+	body := []byte(`{"id":"1"}`)
+	signature := Sign("secret", 1714564800, body)
+
+	// Then
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.True(t, Verify("secret", 1714564800, body, signature))
+	assert.False(t, Verify("other", 1714564800, body, signature))
+	assert.False(t, Verify("secret", 1714564801, body, signature))
+	assert.False(t, Verify("secret", 1714564800, []byte(`{"id":"2"}`), signature))
+}
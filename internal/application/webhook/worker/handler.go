@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type DeliveryWorker struct {
+	webhookService service.WebhookService
+	logger         *zap.Logger
+}
+
+func NewDeliveryWorker(webhookService service.WebhookService, logger *zap.Logger) *DeliveryWorker {
+	return &DeliveryWorker{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// Subscribe makes the worker dispatch every relayed event to the matching
+// webhooks.
+func (w *DeliveryWorker) Subscribe(bus *events.Bus) {
+	bus.SubscribeAll(w.HandleEvent)
+}
+
+func (w *DeliveryWorker) HandleEvent(ctx context.Context, env events.Envelope) error {
+	return w.webhookService.DispatchEvent(ctx, env)
+}
+
+func (w *DeliveryWorker) HandleDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload DeliverWebhookPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		logger.For(ctx, w.logger).Error("Failed to unmarshal webhook delivery payload",
+			zap.Error(err),
+			zap.ByteString("payload", task.Payload()))
+		return fmt.Errorf("json.Unmarshal failed: %w", err)
+	}
+
+	if err := w.webhookService.DeliverWebhook(ctx, payload.DeliveryID, payload.Attempt); err != nil {
+		logger.For(ctx, w.logger).Error("Failed to deliver webhook",
+			zap.Uint("delivery_id", payload.DeliveryID),
+			zap.Error(err))
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+
+	return nil
+}
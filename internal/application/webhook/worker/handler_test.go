@@ -0,0 +1,208 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAsynqClient struct {
+	mock.Mock
+}
+
+func (m *MockAsynqClient) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	args := m.Called(task, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*asynq.TaskInfo), args.Error(1)
+}
+
+func TestDeliveryWorker_HandleDeliverWebhook(t *testing.T) {
+	t.Run("should deliver the attempt of the payload", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockWebhookService{}
+		worker := NewDeliveryWorker(mockService, testutil.NewSilentLogger())
+		payload, _ := json.Marshal(DeliverWebhookPayload{DeliveryID: 5, Attempt: 2})
+
+		// Mock expectations
+		mockService.On("DeliverWebhook", mock.Anything, uint(5), 2).Return(nil)
+
+		// When
+		err := worker.HandleDeliverWebhook(context.Background(), asynq.NewTask(TypeDeliverWebhook, payload))
+
+		// Then
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("should return error for invalid payload", func(t *testing.T) {
+		// Setup
+		mockService := &testutil.MockWebhookService{}
+		worker := NewDeliveryWorker(mockService, testutil.NewSilentLogger())
+
+		// When
+		err := worker.HandleDeliverWebhook(context.Background(), asynq.NewTask(TypeDeliverWebhook, []byte("{")))
+
+		// Then
+		assert.Error(t, err)
+		mockService.AssertNotCalled(t, "DeliverWebhook", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeliveryWorker_Subscribe(t *testing.T) {
+	// Setup
+	mockService := &testutil.MockWebhookService{}
+	worker := NewDeliveryWorker(mockService, testutil.NewSilentLogger())
+	bus := events.NewBus()
+	worker.Subscribe(bus)
+	env := events.Envelope{ID: "1", Type: events.TypeUserCreated}
+
+	// Mock expectations
+	mockService.On("DispatchEvent", mock.Anything, env).Return(nil)
+
+	// When
+	err := bus.Dispatch(context.Background(), env)
+
+	// Then
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeliveryQueue_EnqueueDelivery(t *testing.T) {
+	cfg := &config.Config{Worker: config.WorkerConfig{RetryMaxAttempts: 3}}
+
+	t.Run("should enqueue a task identified by delivery and attempt", func(t *testing.T) {
+		// Setup
+		mockClient := &MockAsynqClient{}
+		queue := NewDeliveryQueue(mockClient, testutil.NewSilentLogger(), cfg)
+
+		// Mock expectations
+		mockClient.On("Enqueue", mock.AnythingOfType("*asynq.Task"), mock.Anything).
+			Return(&asynq.TaskInfo{ID: "webhook-delivery:5:2"}, nil)
+
+		// When
+		err := queue.EnqueueDelivery(context.Background(), 5, 2, time.Minute)
+
+		// Then
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+
+		task := mockClient.Calls[0].Arguments.Get(0).(*asynq.Task)
+		assert.Equal(t, TypeDeliverWebhook, task.Type())
+		assert.JSONEq(t, `{"delivery_id":5,"attempt":2}`, string(task.Payload()))
+
+		opts := mockClient.Calls[0].Arguments.Get(1).([]asynq.Option)
+		var taskID string
+		var processIn time.Duration
+		for _, opt := range opts {
+			switch opt.Type() {
+			case asynq.TaskIDOpt:
+				taskID = opt.Value().(string)
+			case asynq.ProcessInOpt:
+				processIn = opt.Value().(time.Duration)
+			}
+		}
+		assert.Equal(t, "webhook-delivery:5:2", taskID)
+		assert.Equal(t, time.Minute, processIn)
+	})
+
+	t.Run("should ignore attempts that are already queued", func(t *testing.T) {
+		// Setup
+		mockClient := &MockAsynqClient{}
+		queue := NewDeliveryQueue(mockClient, testutil.NewSilentLogger(), cfg)
+
+		// Mock expectations
+		mockClient.On("Enqueue", mock.Anything, mock.Anything).Return(nil, asynq.ErrTaskIDConflict)
+
+		// When
+		err := queue.EnqueueDelivery(context.Background(), 5, 0, 0)
+
+		// Then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return other enqueue errors", func(t *testing.T) {
+		// Setup
+		mockClient := &MockAsynqClient{}
+		queue := NewDeliveryQueue(mockClient, testutil.NewSilentLogger(), cfg)
+
+		// Mock expectations
+		mockClient.On("Enqueue", mock.Anything, mock.Anything).Return(nil, errors.New("redis unavailable"))
+
+		// When
+		err := queue.EnqueueDelivery(context.Background(), 5, 0, 0)
+
+		// Then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redis unavailable")
+	})
+}
+
+// conflictingClient rejects the task IDs it already holds, as asynq does for
+// tasks still queued, running or archived.
+type conflictingClient struct {
+	taskIDs map[string]bool
+}
+
+func (c *conflictingClient) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	var taskID string
+	for _, opt := range opts {
+		if opt.Type() == asynq.TaskIDOpt {
+			taskID = opt.Value().(string)
+		}
+	}
+	if c.taskIDs[taskID] {
+		return nil, asynq.ErrTaskIDConflict
+	}
+	c.taskIDs[taskID] = true
+	return &asynq.TaskInfo{ID: taskID}, nil
+}
+
+func TestDeliveryQueue_EnqueueRedelivery(t *testing.T) {
+	cfg := &config.Config{Worker: config.WorkerConfig{RetryMaxAttempts: 3}}
+
+	t.Run("should schedule a redelivery while the first attempt is still held", func(t *testing.T) {
+		// Setup
+		client := &conflictingClient{taskIDs: map[string]bool{"webhook-delivery:5:0": true}}
+		queue := NewDeliveryQueue(client, testutil.NewSilentLogger(), cfg)
+
+		// When
+		err := queue.EnqueueRedelivery(context.Background(), 5)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, client.taskIDs, 2)
+		for taskID := range client.taskIDs {
+			if taskID != "webhook-delivery:5:0" {
+				assert.Contains(t, taskID, "webhook-redelivery:5:")
+			}
+		}
+	})
+
+	t.Run("should return a conflict instead of ignoring it", func(t *testing.T) {
+		// Setup
+		mockClient := &MockAsynqClient{}
+		queue := NewDeliveryQueue(mockClient, testutil.NewSilentLogger(), cfg)
+
+		// Mock expectations
+		mockClient.On("Enqueue", mock.Anything, mock.Anything).Return(nil, asynq.ErrTaskIDConflict)
+
+		// When
+		err := queue.EnqueueRedelivery(context.Background(), 5)
+
+		// Then
+		assert.ErrorIs(t, err, asynq.ErrTaskIDConflict)
+	})
+}
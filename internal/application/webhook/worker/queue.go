@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook/service"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/logger"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type AsynqClient interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+type DeliverWebhookPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+	Attempt    int  `json:"attempt"`
+	queue.Correlation
+}
+
+// deliveryQueue schedules delivery attempts as asynq tasks. The task ID is
+// derived from the delivery and attempt, so an attempt is queued only once.
+type deliveryQueue struct {
+	client AsynqClient
+	logger *zap.Logger
+	cfg    *config.Config
+}
+
+func NewDeliveryQueue(client AsynqClient, logger *zap.Logger, cfg *config.Config) service.DeliveryQueue {
+	return &deliveryQueue{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (q *deliveryQueue) EnqueueDelivery(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error {
+	taskID := fmt.Sprintf("webhook-delivery:%d:%d", deliveryID, attempt)
+	err := q.enqueue(ctx, taskID, deliveryID, attempt, delay)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		logger.For(ctx, q.logger).Debug("Webhook delivery already scheduled",
+			zap.Uint("delivery_id", deliveryID),
+			zap.Int("attempt", attempt))
+		return nil
+	}
+	return err
+}
+
+// EnqueueRedelivery schedules the first attempt of a redelivery right away.
+// Its task ID is its own, as the task of the first attempt of the original
+// delivery may still be queued, running or archived.
+func (q *deliveryQueue) EnqueueRedelivery(ctx context.Context, deliveryID uint) error {
+	taskID := fmt.Sprintf("webhook-redelivery:%d:%d", deliveryID, time.Now().UnixNano())
+	return q.enqueue(ctx, taskID, deliveryID, 0, 0)
+}
+
+func (q *deliveryQueue) enqueue(ctx context.Context, taskID string, deliveryID uint, attempt int, delay time.Duration) error {
+	ctx, span := queue.StartEnqueue(ctx, TypeDeliverWebhook)
+	defer span.End()
+
+	payloadBytes, err := json.Marshal(DeliverWebhookPayload{
+		DeliveryID:  deliveryID,
+		Attempt:     attempt,
+		Correlation: queue.NewCorrelation(ctx),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeDeliverWebhook, payloadBytes)
+	opts := []asynq.Option{
+		asynq.TaskID(taskID),
+		asynq.Queue("default"),
+		asynq.MaxRetry(q.cfg.Worker.RetryMaxAttempts),
+	}
+	if delay > 0 {
+		opts = append(opts, asynq.ProcessIn(delay))
+	}
+
+	info, err := q.client.Enqueue(task, opts...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+	queue.RecordEnqueue(span, info, err)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	logger.For(ctx, q.logger).Info("Scheduled webhook delivery",
+		zap.Uint("delivery_id", deliveryID),
+		zap.Int("attempt", attempt),
+		zap.Duration("delay", delay),
+		zap.String("task_id", info.ID))
+
+	return nil
+}
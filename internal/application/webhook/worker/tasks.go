@@ -0,0 +1,5 @@
+package worker
+
+const (
+	TypeDeliverWebhook = "webhook:deliver"
+)
@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -12,28 +13,58 @@ const (
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"api"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	Worker   WorkerConfig   `mapstructure:"worker"`
+	Server    ServerConfig    `mapstructure:"api"`
+	Database  DatabaseConfig  `mapstructure:"database"`
+	Logger    LoggerConfig    `mapstructure:"logger"`
+	Redis     RedisConfig     `mapstructure:"redis"`
+	Worker    WorkerConfig    `mapstructure:"worker"`
+	Secrets   SecretsConfig   `mapstructure:"secrets"`
+	Sessions  SessionsConfig  `mapstructure:"sessions"`
+	Outbox    OutboxConfig    `mapstructure:"outbox"`
+	Webhooks  WebhooksConfig  `mapstructure:"webhooks"`
+	Auth      AuthConfig      `mapstructure:"auth"`
+	RBAC      RBACConfig      `mapstructure:"rbac"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	Metrics   MetricsConfig   `mapstructure:"metrics"`
+	Tracing   TracingConfig   `mapstructure:"tracing"`
+	Health    HealthConfig    `mapstructure:"health"`
 }
 
+// ServerConfig configures the HTTP API. TrustedProxies lists the addresses or
+// CIDR ranges of reverse proxies whose X-Forwarded-For header is believed;
+// by default the client IP is the address of the connection.
 type ServerConfig struct {
-	Host         string        `mapstructure:"host"`
-	Port         int           `mapstructure:"port"`
-	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout time.Duration `mapstructure:"write_timeout"`
-	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port"`
+	ReadTimeout    time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout   time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout    time.Duration `mapstructure:"idle_timeout"`
+	TrustedProxies []string      `mapstructure:"trusted_proxies"`
 }
 
+// DatabaseConfig selects the database. Driver is "mysql", "postgres" or
+// "sqlite"; Port defaults to the port of the driver, and DBName is the file
+// of a SQLite database. SSLMode takes the PostgreSQL modes (disable, prefer,
+// require, verify-ca, verify-full) for MySQL as well, verifying against
+// SSLRootCert when set. The pool keeps at most MaxOpenConns connections, of
+// which MaxIdleConns idle, each closed after ConnMaxLifetime, or
+// ConnMaxIdleTime unused.
 type DatabaseConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
-	DBName   string `mapstructure:"db_name"`
-	SSLMode  string `mapstructure:"ssl_mode"`
+	Driver          string        `mapstructure:"driver"`
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	User            string        `mapstructure:"user"`
+	Password        string        `mapstructure:"password"`
+	DBName          string        `mapstructure:"db_name"`
+	SSLMode         string        `mapstructure:"ssl_mode"`
+	SSLRootCert     string        `mapstructure:"ssl_root_cert"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+	// MigrationLockTimeout is how long a migration waits for another one
+	// to finish.
+	MigrationLockTimeout time.Duration `mapstructure:"migration_lock_timeout"`
 }
 
 type LoggerConfig struct {
@@ -54,6 +85,137 @@ type WorkerConfig struct {
 	PaymentCheckInterval time.Duration `mapstructure:"payment_check_interval"`
 	RetryMaxAttempts     int           `mapstructure:"retry_max_attempts"`
 	RetryDelay           time.Duration `mapstructure:"retry_delay"`
+	NASHealthInterval    time.Duration `mapstructure:"nas_health_interval"`
+	NASHealthTimeout     time.Duration `mapstructure:"nas_health_timeout"`
+	NASHealthRetries     int           `mapstructure:"nas_health_retries"`
+	NASHealthPort        int           `mapstructure:"nas_health_port"`
+}
+
+// SecretsConfig holds the AES-GCM keys used to encrypt NAS secrets at rest.
+// Keys maps a key ID to a base64 encoded 16, 24 or 32 byte key; ActiveKey
+// selects the key used for new writes. Leaving ActiveKey empty disables
+// encryption.
+type SecretsConfig struct {
+	ActiveKey string            `mapstructure:"active_key"`
+	Keys      map[string]string `mapstructure:"keys"`
+}
+
+// SessionsConfig tunes WatchSessions streaming. The event log is polled every
+// WatchPollInterval while at least one watcher is connected; a watcher whose
+// WatchBufferSize pending events fill up is disconnected and has to resume
+// from its last cursor.
+type SessionsConfig struct {
+	WatchPollInterval time.Duration `mapstructure:"watch_poll_interval"`
+	WatchBufferSize   int           `mapstructure:"watch_buffer_size"`
+}
+
+// OutboxConfig tunes the worker's outbox relay. A failed delivery is retried
+// after RetryBackoff, doubling up to MaxBackoff, until MaxAttempts is reached.
+// A relay holds the batch it claimed for LeaseDuration; another relay takes
+// over what it did not deliver by then.
+// RedisStream, when set, is the Redis stream every event is also added to.
+type OutboxConfig struct {
+	PollInterval   time.Duration `mapstructure:"poll_interval"`
+	BatchSize      int           `mapstructure:"batch_size"`
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	RetryBackoff   time.Duration `mapstructure:"retry_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	PublishTimeout time.Duration `mapstructure:"publish_timeout"`
+	LeaseDuration  time.Duration `mapstructure:"lease_duration"`
+	RedisStream    string        `mapstructure:"redis_stream"`
+}
+
+// WebhooksConfig tunes webhook delivery. Each attempt times out after
+// Timeout; a failed attempt is retried after RetryBackoff, doubling up to
+// MaxBackoff, until MaxAttempts attempts were made.
+type WebhooksConfig struct {
+	Timeout      time.Duration `mapstructure:"timeout"`
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+}
+
+// AuthConfig configures login to the admin API. Access tokens are JWTs
+// signed with JWTSecret (at least 32 bytes) and expire after AccessTokenTTL;
+// a login session, renewed by rotating its refresh token, ends after
+// RefreshTokenTTL. BootstrapEmail and BootstrapPassword create the first user
+// when the migration seed runs on an empty users table.
+type AuthConfig struct {
+	JWTSecret         string        `mapstructure:"jwt_secret"`
+	Issuer            string        `mapstructure:"issuer"`
+	AccessTokenTTL    time.Duration `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL   time.Duration `mapstructure:"refresh_token_ttl"`
+	BootstrapEmail    string        `mapstructure:"bootstrap_email"`
+	BootstrapPassword string        `mapstructure:"bootstrap_password"`
+}
+
+// RBACConfig maps each role to the permissions it grants, such as
+// "nas:read", "nas:*" or "*". Changes to the config file take effect without
+// a restart.
+type RBACConfig struct {
+	Roles map[string][]string `mapstructure:"roles"`
+}
+
+// RateLimitConfig throttles the clients of the REST and gRPC APIs, each API
+// key, user or, before login, IP address on its own. A client may send Burst
+// requests at once, refilled at Requests per Window; Default applies to every
+// route not listed in Routes. Backend "memory" limits each replica on its
+// own, "redis" shares the limits through the Redis server of the job queue.
+type RateLimitConfig struct {
+	Enabled bool             `mapstructure:"enabled"`
+	Backend string           `mapstructure:"backend"`
+	Default RateLimit        `mapstructure:"default"`
+	Routes  []RouteRateLimit `mapstructure:"routes"`
+}
+
+type RateLimit struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+	Burst    int           `mapstructure:"burst"`
+}
+
+// RouteRateLimit gives a route its own limit. Route is "<METHOD> <path>" as
+// registered with gin, such as "POST /api/v1/auth/login", or a gRPC method
+// such as "/radcheck.RadcheckService/CreateRadcheck".
+type RouteRateLimit struct {
+	Route string    `mapstructure:"route"`
+	Limit RateLimit `mapstructure:",squash"`
+}
+
+// MetricsConfig exposes Prometheus metrics at /metrics. The API serves them
+// on its own port; the gRPC server and the worker, which have no HTTP server,
+// listen on GRPCAddress and WorkerAddress. The RADIUS data gauges of the API
+// are queried at most once per DomainRefresh.
+type MetricsConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	GRPCAddress   string        `mapstructure:"grpc_address"`
+	WorkerAddress string        `mapstructure:"worker_address"`
+	DomainRefresh time.Duration `mapstructure:"domain_refresh"`
+}
+
+// TracingConfig exports OpenTelemetry spans of REST calls, gRPC calls,
+// database queries and tasks. Exporter is "otlp" (gRPC) or "otlp-http",
+// sending to Endpoint, or "stdout" or "file", writing to FilePath, for local
+// debugging. SampleRatio is the share of new traces recorded; calls of a
+// sampled trace are always recorded.
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	ServiceName string  `mapstructure:"service_name"`
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	FilePath    string  `mapstructure:"file_path"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// HealthConfig bounds every dependency probe of the readiness checks to
+// Timeout and reuses their results for CacheTTL, so that frequent probes do
+// not load the dependencies. The worker, which has no HTTP server, serves its
+// checks on WorkerAddress.
+type HealthConfig struct {
+	Timeout       time.Duration `mapstructure:"timeout"`
+	CacheTTL      time.Duration `mapstructure:"cache_ttl"`
+	WorkerAddress string        `mapstructure:"worker_address"`
 }
 
 func NewConfig() (*Config, error) {
@@ -67,13 +229,21 @@ func NewConfig() (*Config, error) {
 	viper.SetDefault("api.read_timeout", "10s")
 	viper.SetDefault("api.write_timeout", "10s")
 	viper.SetDefault("api.idle_timeout", "60s")
+	viper.SetDefault("api.trusted_proxies", []string{})
 
+	viper.SetDefault("database.driver", "mysql")
 	viper.SetDefault("database.host", "localhost")
-	viper.SetDefault("database.port", 5432)
+	viper.SetDefault("database.port", 0)
 	viper.SetDefault("database.user", "postgres")
 	viper.SetDefault("database.password", "postgres")
 	viper.SetDefault("database.db_name", "vibe_db")
 	viper.SetDefault("database.ssl_mode", "disable")
+	viper.SetDefault("database.ssl_root_cert", "")
+	viper.SetDefault("database.max_open_conns", 25)
+	viper.SetDefault("database.max_idle_conns", 10)
+	viper.SetDefault("database.conn_max_lifetime", "30m")
+	viper.SetDefault("database.conn_max_idle_time", "5m")
+	viper.SetDefault("database.migration_lock_timeout", "1m")
 
 	viper.SetDefault("logger.level", "info")
 	viper.SetDefault("logger.format", "json")
@@ -88,6 +258,75 @@ func NewConfig() (*Config, error) {
 	viper.SetDefault("worker.payment_check_interval", "5m")
 	viper.SetDefault("worker.retry_max_attempts", 3)
 	viper.SetDefault("worker.retry_delay", "30s")
+	viper.SetDefault("worker.nas_health_interval", "1m")
+	viper.SetDefault("worker.nas_health_timeout", "2s")
+	viper.SetDefault("worker.nas_health_retries", 3)
+	viper.SetDefault("worker.nas_health_port", 1812)
+
+	viper.SetDefault("secrets.active_key", "")
+
+	viper.SetDefault("sessions.watch_poll_interval", "1s")
+	viper.SetDefault("sessions.watch_buffer_size", 256)
+
+	viper.SetDefault("outbox.poll_interval", "1s")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.max_attempts", 12)
+	viper.SetDefault("outbox.retry_backoff", "5s")
+	viper.SetDefault("outbox.max_backoff", "1h")
+	viper.SetDefault("outbox.publish_timeout", "10s")
+	viper.SetDefault("outbox.lease_duration", "1m")
+	viper.SetDefault("outbox.redis_stream", "")
+
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.max_attempts", 8)
+	viper.SetDefault("webhooks.retry_backoff", "30s")
+	viper.SetDefault("webhooks.max_backoff", "6h")
+
+	viper.SetDefault("auth.jwt_secret", "")
+	viper.SetDefault("auth.issuer", "freeradius-service")
+	viper.SetDefault("auth.access_token_ttl", "15m")
+	viper.SetDefault("auth.refresh_token_ttl", "720h")
+	viper.SetDefault("auth.bootstrap_email", "")
+	viper.SetDefault("auth.bootstrap_password", "")
+
+	viper.SetDefault("rbac.roles.admin", []string{"*"})
+	viper.SetDefault("rbac.roles.operator", []string{
+		"users:read", "payments:read", "nas:*", "radcheck:*", "radreply:*", "sessions:*", "webhooks:read", "audit:read",
+	})
+	viper.SetDefault("rbac.roles.billing", []string{"users:read", "payments:*"})
+	viper.SetDefault("rbac.roles.support", []string{
+		"users:read", "payments:read", "nas:read", "radcheck:*", "radreply:read", "sessions:read",
+	})
+	viper.SetDefault("rbac.roles.readonly", []string{
+		"users:read", "payments:read", "nas:read", "radcheck:read", "radreply:read", "sessions:read", "webhooks:read", "audit:read",
+	})
+
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.backend", "memory")
+	viper.SetDefault("rate_limit.default.requests", 600)
+	viper.SetDefault("rate_limit.default.window", "1m")
+	viper.SetDefault("rate_limit.default.burst", 100)
+	viper.SetDefault("rate_limit.routes", []map[string]interface{}{
+		{"route": "POST /api/v1/auth/login", "requests": 10, "window": "1m"},
+		{"route": "POST /api/v1/auth/refresh", "requests": 30, "window": "1m"},
+	})
+
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.grpc_address", ":9091")
+	viper.SetDefault("metrics.worker_address", ":9092")
+	viper.SetDefault("metrics.domain_refresh", "30s")
+
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.service_name", "freeradius-service")
+	viper.SetDefault("tracing.exporter", "otlp")
+	viper.SetDefault("tracing.endpoint", "localhost:4317")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.file_path", "traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	viper.SetDefault("health.timeout", "2s")
+	viper.SetDefault("health.cache_ttl", "5s")
+	viper.SetDefault("health.worker_address", ":8081")
 
 	viper.AutomaticEnv()
 
@@ -104,3 +343,17 @@ func NewConfig() (*Config, error) {
 
 	return &config, nil
 }
+
+// Watch calls onChange with the reloaded configuration, or the error reading
+// it, whenever the config file changes. It does nothing when the
+// configuration came from defaults alone.
+func Watch(onChange func(*Config, error)) {
+	if viper.ConfigFileUsed() == "" {
+		return
+	}
+	viper.OnConfigChange(func(fsnotify.Event) {
+		var config Config
+		onChange(&config, viper.Unmarshal(&config))
+	})
+	viper.WatchConfig()
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader carries the API key of machine clients.
+const APIKeyHeader = "X-API-Key"
+
+// Authenticate requires an "Authorization: Bearer <access token>" header or
+// an X-API-Key header and attaches the resolved principal to the request
+// context, also as the actor of audited changes.
+func Authenticate(authenticator identity.Authenticator, keys identity.KeyAuthenticator, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var principal *identity.Principal
+		var err error
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			principal, err = keys.AuthenticateKey(c.Request.Context(), key, c.ClientIP())
+		} else {
+			scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				unauthorized(c, "Bearer")
+				return
+			}
+			principal, err = authenticator.Authenticate(c.Request.Context(), token)
+		}
+		if err != nil {
+			if !errors.Is(err, identity.ErrUnauthenticated) {
+				logger.Error("Failed to authenticate request", zap.Error(err))
+				apperror.Respond(c, err)
+				return
+			}
+			unauthorized(c, "Bearer")
+			return
+		}
+
+		c.Request = c.Request.WithContext(withPrincipal(c, principal))
+		c.Next()
+	}
+}
+
+// BasicAuth requires HTTP basic credentials of a user, for pages opened in a
+// browser such as the Swagger UI.
+func BasicAuth(verifier identity.CredentialVerifier, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email, password, ok := c.Request.BasicAuth()
+		if !ok {
+			unauthorized(c, `Basic realm="freeradius-service"`)
+			return
+		}
+
+		principal, err := verifier.VerifyCredentials(c.Request.Context(), email, password)
+		if err != nil {
+			if !errors.Is(err, identity.ErrUnauthenticated) {
+				logger.Error("Failed to verify credentials", zap.Error(err))
+			}
+			unauthorized(c, `Basic realm="freeradius-service"`)
+			return
+		}
+
+		c.Request = c.Request.WithContext(withPrincipal(c, principal))
+		c.Next()
+	}
+}
+
+// Authorize requires the role of the principal to grant the permission the
+// permissions table maps the matched route to, keyed by "<METHOD> <path>"
+// such as "GET /api/v1/nas/:id". Routes missing from the table are denied.
+func Authorize(policy *rbac.Policy, permissions map[string]rbac.Permission, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := identity.PrincipalFrom(c.Request.Context())
+		if !ok {
+			unauthorized(c, "Bearer")
+			return
+		}
+
+		route := c.Request.Method + " " + c.FullPath()
+		permission, ok := permissions[route]
+		if !ok {
+			logger.Error("Route has no permission assigned", zap.String("route", route))
+			apperror.Respond(c, rbac.ErrForbidden)
+			return
+		}
+		if err := policy.Authorize(principal, permission); err != nil {
+			logger.Warn("Permission denied",
+				zap.String("route", route),
+				zap.String("actor", principal.Actor()),
+				zap.String("role", principal.Role))
+			apperror.Respond(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+func withPrincipal(c *gin.Context, principal *identity.Principal) context.Context {
+	ctx := identity.WithPrincipal(c.Request.Context(), principal)
+	md := audit.MetadataFrom(ctx)
+	md.Actor = principal.Actor()
+	return audit.WithMetadata(ctx, md)
+}
+
+func unauthorized(c *gin.Context, challenge string) {
+	c.Header("WWW-Authenticate", challenge)
+	apperror.Respond(c, identity.ErrUnauthenticated)
+}
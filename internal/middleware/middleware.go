@@ -5,9 +5,29 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// RequestID tags the request with the X-Request-ID header sent by the
+// client, or a generated ID, and echoes it in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Accept(c.GetHeader(requestid.Header))
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
+
 func Logger(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -31,16 +51,65 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 			zap.Int("status", statusCode),
 			zap.Duration("latency", latency),
 			zap.String("client_ip", clientIP),
+			zap.String("request_id", requestid.FromContext(c.Request.Context())),
 		)
 	}
 }
 
+// Metrics records the latency and status of every request. A nil m records
+// nothing.
+func Metrics(m *metrics.HTTP) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		m.Observe(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// Tracing gives every request a server span, continuing the trace of the
+// traceparent header sent by the client. The span is named after the route
+// the request matched, so that IDs in paths do not make every name unique.
+func Tracing(tp trace.TracerProvider) gin.HandlerFunc {
+	tracer := tp.Tracer(tracing.InstrumentationName)
+	return func(c *gin.Context) {
+		ctx := tracing.Propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracer.Start(ctx, c.Request.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(c.Request.Method),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.ClientAddress(c.ClientIP()),
+				attribute.String("request_id", requestid.FromContext(ctx)),
+			))
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		if route := c.FullPath(); route != "" {
+			span.SetName(c.Request.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
 func Recovery(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
 				logger.Error("Panic recovered",
 					zap.Any("error", err),
+					zap.String("request_id", requestid.FromContext(c.Request.Context())),
 					zap.String("path", c.Request.URL.Path),
 					zap.String("method", c.Request.Method),
 				)
@@ -59,8 +128,10 @@ func CORS() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers",
-			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID, traceparent, tracestate")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers",
+			"X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -70,3 +141,16 @@ func CORS() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AuditMetadata tags the changes made by a request with its source and the
+// ID RequestID gave it. Authenticate adds the actor.
+func AuditMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := audit.WithMetadata(c.Request.Context(), audit.Metadata{
+			Source:    audit.SourceREST,
+			RequestID: requestid.FromContext(c.Request.Context()),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
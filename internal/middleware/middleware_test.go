@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestID(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	var seen, audited string
+	router := gin.New()
+	router.Use(RequestID(), AuditMetadata())
+	router.GET("/nas", func(c *gin.Context) {
+		seen = requestid.FromContext(c.Request.Context())
+		audited = audit.MetadataFrom(c.Request.Context()).RequestID
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("should accept the ID of the client", func(t *testing.T) {
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/nas", nil)
+		req.Header.Set(requestid.Header, "req-123")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, "req-123", seen)
+		assert.Equal(t, "req-123", audited)
+		assert.Equal(t, "req-123", w.Header().Get(requestid.Header))
+	})
+
+	t.Run("should generate an ID when the client sends none", func(t *testing.T) {
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nas", nil))
+
+		// Then
+		assert.NotEmpty(t, seen)
+		assert.Equal(t, seen, w.Header().Get(requestid.Header))
+	})
+}
+
+func TestTracing(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	router := gin.New()
+	router.Use(Tracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	router.GET("/nas/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	// When
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/nas/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(w, req)
+
+	// Then
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /nas/:id", spans[0].Name())
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
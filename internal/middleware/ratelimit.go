@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"go.uber.org/zap"
+)
+
+// RateLimit throttles requests by the API key or user that sent them, or by
+// client IP ahead of authentication, answering 429 with Retry-After once the
+// client's bucket is empty. Every response carries the RateLimit-* headers.
+// Requests are let through when the limiter fails, and always with a nil
+// limiter.
+func RateLimit(limiter *ratelimit.Limiter, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		principal, _ := identity.PrincipalFrom(c.Request.Context())
+		client := ratelimit.Client(principal, c.ClientIP())
+		result, err := limiter.Allow(c.Request.Context(), c.Request.Method+" "+c.FullPath(), client)
+		if err != nil {
+			logger.Error("Failed to rate limit request", zap.String("client", client), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		for name, value := range result.Headers() {
+			c.Header(name, value)
+		}
+		if !result.Allowed {
+			logger.Warn("Rate limit exceeded", zap.String("client", client), zap.String("route", c.FullPath()))
+			apperror.Respond(c, ratelimit.ErrLimited)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should answer 429 once the client's bucket is empty", func(t *testing.T) {
+		// Setup
+		limiter, err := ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 1, Window: time.Minute}, nil)
+		require.NoError(t, err)
+		router := gin.New()
+		router.GET("/nas", RateLimit(limiter, testutil.NewSilentLogger()), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		// When
+		var codes []int
+		var last *httptest.ResponseRecorder
+		for _, addr := range []string{"192.0.2.10:51000", "192.0.2.10:51001", "192.0.2.11:51000"} {
+			last = httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/nas", nil)
+			req.RemoteAddr = addr
+			router.ServeHTTP(last, req)
+			codes = append(codes, last.Code)
+			if last.Code == http.StatusTooManyRequests {
+				assert.Equal(t, "60", last.Header().Get("Retry-After"))
+			}
+		}
+
+		// Then
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK}, codes)
+		assert.Equal(t, "1", last.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", last.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("should let every request through without a limiter", func(t *testing.T) {
+		// Setup
+		router := gin.New()
+		router.GET("/nas", RateLimit(nil, testutil.NewSilentLogger()), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nas", nil))
+
+		// Then
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+}
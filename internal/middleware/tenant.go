@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"go.uber.org/zap"
+)
+
+// TenantHeader lets super-admins act for a single tenant.
+const TenantHeader = "X-Tenant-ID"
+
+// Scope attaches the tenant the principal acts for to the request context,
+// so that repositories only see its rows. Principals bound to a tenant
+// always act for it; super-admins act for the tenant named by TenantHeader,
+// or for every tenant without it.
+func Scope(resolver tenant.Resolver, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := identity.PrincipalFrom(c.Request.Context())
+		if !ok {
+			unauthorized(c, "Bearer")
+			return
+		}
+
+		scope, ok, err := tenant.Resolve(c.Request.Context(), resolver, principal, c.GetHeader(TenantHeader))
+		switch {
+		case errors.Is(err, tenant.ErrNotFound):
+			apperror.Respond(c, apperror.Wrap(apperror.KindValidation, err))
+			return
+		case errors.Is(err, tenant.ErrForeignTenant):
+			logger.Warn("Tenant access denied",
+				zap.String("actor", principal.Actor()),
+				zap.String("tenant", c.GetHeader(TenantHeader)))
+			apperror.Respond(c, err)
+			return
+		case err != nil:
+			logger.Error("Failed to resolve tenant", zap.Error(err))
+			apperror.Respond(c, err)
+			return
+		}
+
+		if ok {
+			c.Request = c.Request.WithContext(tenant.WithScope(c.Request.Context(), scope))
+		}
+		c.Next()
+	}
+}
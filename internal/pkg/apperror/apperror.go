@@ -0,0 +1,119 @@
+// Package apperror types the errors of the domain services, so that the
+// REST and gRPC APIs translate them the same way: to an RFC 7807 problem
+// with the matching HTTP status, and to a gRPC status with details.
+//
+// Services declare their errors with the constructors of a kind, such as
+// NotFound("nas not found"), and may wrap them with fmt.Errorf to add
+// context; errors.Is keeps matching the declared error and KindOf its kind.
+// Any other error is internal and its message is not shown to clients.
+package apperror
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind classifies an error by what the client can do about it.
+type Kind string
+
+const (
+	KindInternal         Kind = "internal"
+	KindNotFound         Kind = "not_found"
+	KindConflict         Kind = "conflict"
+	KindValidation       Kind = "validation"
+	KindPermissionDenied Kind = "permission_denied"
+	KindUnauthenticated  Kind = "unauthenticated"
+	KindRateLimited      Kind = "rate_limited"
+)
+
+// FieldError is a violation of the rules of a request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error of a kind, with the fields at fault for validation
+// errors. Err is the error it wraps, if any.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+func PermissionDenied(message string) *Error {
+	return &Error{Kind: KindPermissionDenied, Message: message}
+}
+
+func Unauthenticated(message string) *Error {
+	return &Error{Kind: KindUnauthenticated, Message: message}
+}
+
+func RateLimited(message string) *Error {
+	return &Error{Kind: KindRateLimited, Message: message}
+}
+
+// Validation returns a validation error. Without fields, message describes
+// the whole request.
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+// InvalidField returns a validation error of a single field, such as
+// InvalidField("username", "is required"), reading "username is required".
+func InvalidField(field, message string) *Error {
+	return Validation(field+" "+message, FieldError{Field: field, Message: message})
+}
+
+// Fields joins the violations of several fields into one validation error.
+// It returns nil without violations.
+func Fields(violations ...FieldError) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Field + " " + v.Message
+	}
+	return Validation(strings.Join(messages, "; "), violations...)
+}
+
+// KindOf returns the kind of err, KindInternal for untyped errors.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// FieldsOf returns the fields at fault of a validation error.
+func FieldsOf(err error) []FieldError {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
+
+// Wrap gives err, such as an error of a package outside the domain, a kind.
+// errors.Is keeps matching err.
+func Wrap(kind Kind, err error) *Error {
+	return &Error{Kind: kind, Message: err.Error(), Err: err}
+}
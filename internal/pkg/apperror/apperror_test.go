@@ -0,0 +1,196 @@
+package apperror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errNASNotFound = NotFound("nas not found")
+
+func TestKindOf(t *testing.T) {
+	t.Run("should keep the kind of wrapped errors", func(t *testing.T) {
+		// Setup
+		err := fmt.Errorf("get nas 7: %w", errNASNotFound)
+
+		// When
+		kind := KindOf(err)
+
+		// Then
+		assert.Equal(t, KindNotFound, kind)
+		assert.ErrorIs(t, err, errNASNotFound)
+	})
+
+	t.Run("should treat untyped errors as internal", func(t *testing.T) {
+		// When
+		kind := KindOf(errors.New("connection refused"))
+
+		// Then
+		assert.Equal(t, KindInternal, kind)
+	})
+}
+
+func TestFields(t *testing.T) {
+	t.Run("should join the violations of several fields", func(t *testing.T) {
+		// When
+		err := Fields(
+			FieldError{Field: "username", Message: "is required"},
+			FieldError{Field: "value", Message: "must be at most 253"},
+		)
+
+		// Then
+		assert.EqualError(t, err, "username is required; value must be at most 253")
+		assert.Equal(t, KindValidation, KindOf(err))
+		assert.Len(t, FieldsOf(err), 2)
+	})
+
+	t.Run("should return nil without violations", func(t *testing.T) {
+		// When
+		err := Fields()
+
+		// Then
+		assert.NoError(t, err)
+	})
+}
+
+func TestRespond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{name: "not found", err: errNASNotFound, status: http.StatusNotFound, detail: "nas not found"},
+		{name: "conflict", err: Conflict("email already exists"), status: http.StatusConflict, detail: "email already exists"},
+		{name: "validation", err: InvalidField("username", "is required"), status: http.StatusBadRequest, detail: "username is required"},
+		{name: "permission denied", err: PermissionDenied("forbidden"), status: http.StatusForbidden, detail: "forbidden"},
+		{name: "unauthenticated", err: Unauthenticated("unauthenticated"), status: http.StatusUnauthorized, detail: "unauthenticated"},
+		{name: "rate limited", err: RateLimited("rate limit exceeded"), status: http.StatusTooManyRequests, detail: "rate limit exceeded"},
+		{name: "internal", err: errors.New("dial tcp 10.0.0.5:3306: connection refused"), status: http.StatusInternalServerError, detail: internalDetail},
+	}
+	for _, tt := range tests {
+		t.Run("should describe "+tt.name+" errors as problems", func(t *testing.T) {
+			// Setup
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/nas/7", nil)
+
+			// When
+			Respond(c, tt.err)
+
+			// Then
+			var problem Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, http.StatusText(tt.status), problem.Title)
+			assert.Equal(t, typePrefix+string(KindOf(tt.err)), problem.Type)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, "/api/v1/nas/7", problem.Instance)
+			assert.True(t, c.IsAborted())
+		})
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		Username string `json:"username" binding:"required"`
+		Value    string `json:"value" binding:"max=3"`
+	}
+
+	t.Run("should list a field error per failed binding rule", func(t *testing.T) {
+		// Setup
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/radcheck", bytes.NewBufferString(`{"value":"long"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		var req request
+
+		// When
+		err := InvalidRequest(c.ShouldBindJSON(&req))
+
+		// Then
+		assert.Equal(t, KindValidation, err.Kind)
+		assert.Equal(t, []FieldError{
+			{Field: "username", Message: "is required"},
+			{Field: "value", Message: "must be at most 3"},
+		}, err.Fields)
+	})
+
+	t.Run("should keep the message of malformed bodies", func(t *testing.T) {
+		// Setup
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/radcheck", bytes.NewBufferString(`{`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		var req request
+
+		// When
+		err := InvalidRequest(c.ShouldBindJSON(&req))
+
+		// Then
+		assert.Equal(t, KindValidation, err.Kind)
+		assert.Empty(t, err.Fields)
+		assert.Equal(t, http.StatusBadRequest, HTTPStatus(err))
+	})
+}
+
+func TestGRPCStatus(t *testing.T) {
+	t.Run("should carry the kind and the fields at fault as details", func(t *testing.T) {
+		// When
+		err := GRPCStatus(fmt.Errorf("create radcheck: %w", InvalidField("username", "is required")))
+
+		// Then
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "create radcheck: username is required", st.Message())
+		require.Len(t, st.Details(), 2)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, string(KindValidation), info.Reason)
+		assert.Equal(t, Domain, info.Domain)
+		violations := st.Details()[1].(*errdetails.BadRequest).FieldViolations
+		require.Len(t, violations, 1)
+		assert.Equal(t, "username", violations[0].Field)
+		assert.Equal(t, "is required", violations[0].Description)
+	})
+
+	t.Run("should map every kind to its code", func(t *testing.T) {
+		tests := map[error]codes.Code{
+			errNASNotFound:                     codes.NotFound,
+			Conflict("realm already exists"):   codes.AlreadyExists,
+			PermissionDenied("forbidden"):      codes.PermissionDenied,
+			Unauthenticated("unauthenticated"): codes.Unauthenticated,
+			RateLimited("rate limit exceeded"): codes.ResourceExhausted,
+		}
+		for err, code := range tests {
+			assert.Equal(t, code, status.Code(GRPCStatus(err)), err.Error())
+		}
+	})
+
+	t.Run("should hide the message of internal errors", func(t *testing.T) {
+		// When
+		err := GRPCStatus(errors.New("dial tcp 10.0.0.5:3306: connection refused"))
+
+		// Then
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, internalDetail, st.Message())
+	})
+}
@@ -0,0 +1,55 @@
+package apperror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain names the service in the ErrorInfo details of gRPC errors.
+const Domain = "freeradius-service"
+
+// GRPCCode returns the gRPC status code of err.
+func GRPCCode(err error) codes.Code {
+	switch KindOf(err) {
+	case KindNotFound:
+		return codes.NotFound
+	case KindConflict:
+		return codes.AlreadyExists
+	case KindValidation:
+		return codes.InvalidArgument
+	case KindPermissionDenied:
+		return codes.PermissionDenied
+	case KindUnauthenticated:
+		return codes.Unauthenticated
+	case KindRateLimited:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
+
+// GRPCStatus returns the gRPC status error describing err. Its details hold
+// an ErrorInfo with the kind of err as reason and, for validation errors, a
+// BadRequest listing the fields at fault.
+func GRPCStatus(err error) error {
+	kind := KindOf(err)
+	message := err.Error()
+	if kind == KindInternal {
+		message = internalDetail
+	}
+
+	st := status.New(GRPCCode(err), message)
+	info := &errdetails.ErrorInfo{Reason: string(kind), Domain: Domain}
+	detailed, detailErr := st.WithDetails(info)
+	if fields := FieldsOf(err); len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, f := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		detailed, detailErr = st.WithDetails(info, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,133 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType is the media type of problem responses.
+const ProblemContentType = "application/problem+json"
+
+// typePrefix prefixes the kind of an error in the type of its problem.
+const typePrefix = "urn:freeradius-service:problem:"
+
+// internalDetail replaces the message of internal errors, which may reveal
+// implementation details.
+const internalDetail = "an internal error occurred"
+
+// Problem is an RFC 7807 problem, extended with the fields at fault of
+// validation errors and the ID of the request.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// Field errors name the fields of a request by their JSON or query name,
+// as clients know them, rather than by their Go name.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name != "" && name != "-" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	}
+}
+
+// HTTPStatus returns the HTTP status of err.
+func HTTPStatus(err error) int {
+	switch KindOf(err) {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindPermissionDenied:
+		return http.StatusForbidden
+	case KindUnauthenticated:
+		return http.StatusUnauthorized
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem describes err as a problem of the request to instance.
+func NewProblem(err error, instance string) Problem {
+	kind := KindOf(err)
+	status := HTTPStatus(err)
+	detail := err.Error()
+	if kind == KindInternal {
+		detail = internalDetail
+	}
+	return Problem{
+		Type:     typePrefix + string(kind),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Errors:   FieldsOf(err),
+	}
+}
+
+// Respond aborts the request with the problem describing err.
+func Respond(c *gin.Context, err error) {
+	problem := NewProblem(err, c.Request.URL.Path)
+	problem.RequestID = requestid.FromContext(c.Request.Context())
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// InvalidRequest types an error binding the body, query or path of a
+// request as a validation error, with a field error per failed binding
+// rule.
+func InvalidRequest(err error) *Error {
+	var violations validator.ValidationErrors
+	if !errors.As(err, &violations) {
+		return Wrap(KindValidation, err)
+	}
+
+	fields := make([]FieldError, len(violations))
+	for i, v := range violations {
+		fields[i] = FieldError{Field: v.Field(), Message: ruleMessage(v)}
+	}
+	invalid := Fields(fields...).(*Error)
+	invalid.Err = err
+	return invalid
+}
+
+func ruleMessage(v validator.FieldError) string {
+	switch v.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "min", "gte":
+		return "must be at least " + v.Param()
+	case "max", "lte":
+		return "must be at most " + v.Param()
+	case "oneof":
+		return "must be one of " + v.Param()
+	case "url":
+		return "must be a URL"
+	}
+	return "fails the " + v.Tag() + " rule"
+}
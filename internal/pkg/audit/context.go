@@ -0,0 +1,50 @@
+package audit
+
+import "context"
+
+// Sources of a change.
+const (
+	SourceREST   = "rest"
+	SourceGRPC   = "grpc"
+	SourceWorker = "worker"
+	SourceCLI    = "cli"
+	// SourceUnknown is recorded for changes made without metadata in ctx.
+	SourceUnknown = "unknown"
+)
+
+// Actors recorded when no caller is known.
+const (
+	// AnonymousActor is recorded when the caller did not identify itself.
+	AnonymousActor = "anonymous"
+	// WorkerActor is recorded for changes made by background tasks.
+	WorkerActor = "worker"
+)
+
+// Metadata identifies who made a change and through which entry point. The
+// REST middleware, the gRPC interceptor and the worker attach it to the
+// request context so that the services need not pass it around.
+type Metadata struct {
+	Actor     string
+	Source    string
+	RequestID string
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a copy of ctx carrying md.
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// MetadataFrom returns the metadata carried by ctx, defaulting the actor to
+// AnonymousActor and the source to SourceUnknown.
+func MetadataFrom(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataKey{}).(Metadata)
+	if md.Actor == "" {
+		md.Actor = AnonymousActor
+	}
+	if md.Source == "" {
+		md.Source = SourceUnknown
+	}
+	return md
+}
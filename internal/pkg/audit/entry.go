@@ -0,0 +1,24 @@
+package audit
+
+import "time"
+
+// Entry is one recorded change of an administrative entity. Changes holds
+// the JSON object of the changed fields, each as {"before": ..., "after": ...}.
+// Lists are paged newest first by (occurred_at, id), which is indexed on its
+// own and within a tenant.
+type Entry struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;autoIncrement;index:idx_audit_entries_occurred_at_id,priority:2;index:idx_audit_entries_tenant_id_occurred_at_id,priority:3"`
+	OccurredAt time.Time `json:"occurred_at" gorm:"not null;index:idx_audit_entries_occurred_at_id,priority:1;index:idx_audit_entries_tenant_id_occurred_at_id,priority:2"`
+	Actor      string    `json:"actor" gorm:"not null;size:128;index"`
+	Source     string    `json:"source" gorm:"not null;size:16"`
+	RequestID  string    `json:"request_id" gorm:"size:64;index"`
+	TenantID   *uint     `json:"tenant_id,omitempty" gorm:"index:idx_audit_entries_tenant_id_occurred_at_id,priority:1"`
+	EntityType string    `json:"entity_type" gorm:"not null;size:32;index:idx_audit_entries_entity"`
+	EntityID   string    `json:"entity_id" gorm:"not null;size:64;index:idx_audit_entries_entity"`
+	Action     string    `json:"action" gorm:"not null;size:16"`
+	Changes    string    `json:"changes" gorm:"type:text;not null"`
+}
+
+func (e Entry) TableName() string {
+	return "audit_entries"
+}
@@ -0,0 +1,191 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+
+	"gorm.io/gorm"
+)
+
+// Audited entity types.
+const (
+	EntityUser     = "user"
+	EntityPayment  = "payment"
+	EntityNAS      = "nas"
+	EntityRadcheck = "radcheck"
+	EntityRadreply = "radreply"
+	EntityAPIKey   = "api_key"
+	EntityTenant   = "tenant"
+)
+
+// Actions of a change.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Redacted replaces the values of sensitive fields. A redacted field still
+// shows up in the changes when its value changed.
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are redacted in every change. community is the SNMP
+// community of a NAS.
+var sensitiveFields = map[string]bool{
+	"secret":    true,
+	"password":  true,
+	"community": true,
+}
+
+// ErrNoTransaction is returned by Record outside WithinTransaction: an entry
+// written separately from its change could describe a change that was
+// rolled back.
+var ErrNoTransaction = errors.New("audit: entries must be recorded within a transaction")
+
+// Change describes a change of one entity. Before is nil for a create and
+// After is nil for a delete; both are snapshots that encode to a JSON object,
+// usually the entity itself. Redact lists further fields to redact.
+type Change struct {
+	EntityType string
+	EntityID   string
+	Action     string
+	Before     any
+	After      any
+	Redact     []string
+}
+
+// FieldChange is the before and after value of a changed field.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Recorder records audit entries.
+type Recorder interface {
+	Record(ctx context.Context, changes ...Change) error
+}
+
+type recorder struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewRecorder(db *gorm.DB) Recorder {
+	return &recorder{db: db, now: time.Now}
+}
+
+// Record stores an entry per change in the transaction carried by ctx,
+// attributed to the metadata of ctx.
+func (r *recorder) Record(ctx context.Context, changes ...Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if !database.HasTx(ctx) {
+		return ErrNoTransaction
+	}
+
+	md := MetadataFrom(ctx)
+	now := r.now().UTC()
+	entries := make([]Entry, 0, len(changes))
+	for _, change := range changes {
+		fields, err := Diff(change.Before, change.After, change.Redact...)
+		if err != nil {
+			return fmt.Errorf("audit: diff %s %s: %w", change.EntityType, change.EntityID, err)
+		}
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("audit: encode %s %s: %w", change.EntityType, change.EntityID, err)
+		}
+		entries = append(entries, Entry{
+			OccurredAt: now,
+			Actor:      md.Actor,
+			Source:     md.Source,
+			RequestID:  md.RequestID,
+			TenantID:   tenant.ID(ctx),
+			EntityType: change.EntityType,
+			EntityID:   change.EntityID,
+			Action:     change.Action,
+			Changes:    string(encoded),
+		})
+	}
+
+	db := database.GetDB(ctx, r.db).(*gorm.DB)
+	return db.Create(&entries).Error
+}
+
+// Diff returns the fields whose JSON value differs between before and after,
+// with sensitive fields and the fields in redact replaced by Redacted.
+func Diff(before, after any, redact ...string) (map[string]FieldChange, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make(map[string]bool, len(redact))
+	for _, field := range redact {
+		redacted[field] = true
+	}
+
+	changes := make(map[string]FieldChange)
+	for field, value := range afterFields {
+		old, ok := beforeFields[field]
+		if (ok && reflect.DeepEqual(old, value)) || (!ok && value == nil) {
+			continue
+		}
+		changes[field] = FieldChange{Before: old, After: value}
+	}
+	for field, value := range beforeFields {
+		if _, ok := afterFields[field]; !ok && value != nil {
+			changes[field] = FieldChange{Before: value}
+		}
+	}
+
+	for field, change := range changes {
+		if sensitiveFields[field] || redacted[field] {
+			changes[field] = FieldChange{Before: redactValue(change.Before), After: redactValue(change.After)}
+		}
+	}
+	return changes, nil
+}
+
+// EntityID formats a numeric entity ID.
+func EntityID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func toFields(snapshot any) (map[string]any, error) {
+	fields := make(map[string]any)
+	if snapshot == nil {
+		return fields, nil
+	}
+	if v := reflect.ValueOf(snapshot); v.Kind() == reflect.Pointer && v.IsNil() {
+		return fields, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func redactValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	return Redacted
+}
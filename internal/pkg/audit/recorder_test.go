@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type snapshot struct {
+	ID        uint    `json:"id"`
+	Name      string  `json:"name"`
+	Secret    string  `json:"secret"`
+	Community string  `json:"community,omitempty"`
+	Value     string  `json:"value"`
+	Note      *string `json:"note"`
+}
+
+// setupAuditDB opens a single connection in-memory database; testutil cannot
+// be used here as it imports the audit package.
+func setupAuditDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&Entry{}))
+	return db
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("should list every field of a created entity", func(t *testing.T) {
+		// When
+		changes, err := Diff(nil, &snapshot{ID: 1, Name: "nas-1", Secret: "s3cret", Community: "public"})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"id":        {After: float64(1)},
+			"name":      {After: "nas-1"},
+			"secret":    {After: Redacted},
+			"community": {After: Redacted},
+			"value":     {After: ""},
+		}, changes)
+	})
+
+	t.Run("should list only changed fields of an updated entity", func(t *testing.T) {
+		// Given
+		before := snapshot{ID: 1, Name: "nas-1", Secret: "old", Community: "public", Value: "a"}
+		after := snapshot{ID: 1, Name: "nas-2", Secret: "new", Community: "private", Value: "a"}
+
+		// When
+		changes, err := Diff(before, after)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{
+			"name":      {Before: "nas-1", After: "nas-2"},
+			"secret":    {Before: Redacted, After: Redacted},
+			"community": {Before: Redacted, After: Redacted},
+		}, changes)
+	})
+
+	t.Run("should redact requested fields", func(t *testing.T) {
+		// When
+		changes, err := Diff(snapshot{Value: "old"}, snapshot{Value: "new"}, "value")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]FieldChange{"value": {Before: Redacted, After: Redacted}}, changes)
+	})
+
+	t.Run("should list every field of a deleted entity", func(t *testing.T) {
+		// When
+		var deleted *snapshot
+		changes, err := Diff(&snapshot{ID: 1, Name: "nas-1"}, deleted)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, FieldChange{Before: "nas-1"}, changes["name"])
+		assert.NotContains(t, changes, "note")
+	})
+}
+
+func TestRecorder_Record(t *testing.T) {
+	t.Run("should record entries with the metadata of the context", func(t *testing.T) {
+		// Setup
+		db := setupAuditDB(t)
+		recorder := NewRecorder(db).(*recorder)
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		recorder.now = func() time.Time { return now }
+		ctx := WithMetadata(context.Background(), Metadata{Actor: "alice", Source: SourceREST, RequestID: "req-1"})
+
+		// When
+		err := database.NewTransactionManager(db).WithinTransaction(ctx, func(txCtx context.Context) error {
+			return recorder.Record(txCtx, Change{
+				EntityType: EntityNAS,
+				EntityID:   EntityID(7),
+				Action:     ActionUpdate,
+				Before:     snapshot{ID: 7, Name: "nas-1"},
+				After:      snapshot{ID: 7, Name: "nas-2"},
+			})
+		})
+
+		// Then
+		assert.NoError(t, err)
+		var entries []Entry
+		require.NoError(t, db.Find(&entries).Error)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "alice", entries[0].Actor)
+		assert.Equal(t, SourceREST, entries[0].Source)
+		assert.Equal(t, "req-1", entries[0].RequestID)
+		assert.Equal(t, EntityNAS, entries[0].EntityType)
+		assert.Equal(t, "7", entries[0].EntityID)
+		assert.Equal(t, ActionUpdate, entries[0].Action)
+		assert.True(t, now.Equal(entries[0].OccurredAt))
+
+		var changes map[string]FieldChange
+		require.NoError(t, json.Unmarshal([]byte(entries[0].Changes), &changes))
+		assert.Equal(t, map[string]FieldChange{"name": {Before: "nas-1", After: "nas-2"}}, changes)
+	})
+
+	t.Run("should not record entries of a rolled back transaction", func(t *testing.T) {
+		// Setup
+		db := setupAuditDB(t)
+		recorder := NewRecorder(db)
+
+		// When
+		err := database.NewTransactionManager(db).WithinTransaction(context.Background(), func(txCtx context.Context) error {
+			if err := recorder.Record(txCtx, Change{EntityType: EntityUser, EntityID: "1", Action: ActionDelete}); err != nil {
+				return err
+			}
+			return assert.AnError
+		})
+
+		// Then
+		assert.ErrorIs(t, err, assert.AnError)
+		var count int64
+		db.Model(&Entry{}).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("should require a transaction", func(t *testing.T) {
+		// Setup
+		recorder := NewRecorder(setupAuditDB(t))
+
+		// When
+		err := recorder.Record(context.Background(), Change{EntityType: EntityUser, EntityID: "1", Action: ActionDelete})
+
+		// Then
+		assert.ErrorIs(t, err, ErrNoTransaction)
+	})
+}
+
+func TestMetadataFrom(t *testing.T) {
+	// When
+	md := MetadataFrom(context.Background())
+
+	// Then
+	assert.Equal(t, Metadata{Actor: AnonymousActor, Source: SourceUnknown}, md)
+}
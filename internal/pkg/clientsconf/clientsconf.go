@@ -0,0 +1,151 @@
+package clientsconf
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Header is written at the top of every generated file.
+const Header = "# Generated by freeradius-service from the nas table. Do not edit by hand."
+
+// Client is a single FreeRADIUS client definition.
+type Client struct {
+	Name                        string
+	Address                     string
+	Secret                      string
+	ShortName                   string
+	NASType                     string
+	VirtualServer               string
+	Description                 string
+	RequireMessageAuthenticator string
+	LimitProxyState             string
+}
+
+// Render builds a clients.conf document from the given clients. The output is
+// deterministic: clients are sorted by address and every block lists its
+// attributes in the same order, so two renders of the same data are
+// byte-identical and diff cleanly.
+func Render(clients []Client) ([]byte, error) {
+	sorted := make([]Client, len(clients))
+	copy(sorted, clients)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Address < sorted[j].Address
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(Header)
+	buf.WriteString("\n")
+
+	for _, client := range sorted {
+		if err := writeClient(&buf, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeClient(buf *bytes.Buffer, client Client) error {
+	if client.Address == "" {
+		return fmt.Errorf("client %q has no address", client.Name)
+	}
+
+	requireMa, err := normalizeTriState(client.RequireMessageAuthenticator)
+	if err != nil {
+		return fmt.Errorf("client %q: require_message_authenticator: %w", client.Address, err)
+	}
+	limitProxyState, err := normalizeTriState(client.LimitProxyState)
+	if err != nil {
+		return fmt.Errorf("client %q: limit_proxy_state: %w", client.Address, err)
+	}
+
+	name := client.Name
+	if name == "" {
+		name = client.Address
+	}
+
+	buf.WriteString("\n")
+	if client.Description != "" {
+		fmt.Fprintf(buf, "# %s\n", singleLine(client.Description))
+	}
+	fmt.Fprintf(buf, "client %s {\n", sectionName(name))
+	fmt.Fprintf(buf, "\t%s = %s\n", addressAttribute(client.Address), client.Address)
+	fmt.Fprintf(buf, "\tsecret = %s\n", quote(client.Secret))
+	if client.ShortName != "" {
+		fmt.Fprintf(buf, "\tshortname = %s\n", quote(client.ShortName))
+	}
+	if client.NASType != "" {
+		fmt.Fprintf(buf, "\tnas_type = %s\n", quote(client.NASType))
+	}
+	if client.VirtualServer != "" {
+		fmt.Fprintf(buf, "\tvirtual_server = %s\n", quote(client.VirtualServer))
+	}
+	if requireMa != "" {
+		fmt.Fprintf(buf, "\trequire_message_authenticator = %s\n", requireMa)
+	}
+	if limitProxyState != "" {
+		fmt.Fprintf(buf, "\tlimit_proxy_state = %s\n", limitProxyState)
+	}
+	buf.WriteString("}\n")
+
+	return nil
+}
+
+// addressAttribute picks ipv6addr for IPv6 addresses and networks and ipaddr
+// for everything else, including hostnames which FreeRADIUS resolves itself.
+func addressAttribute(address string) string {
+	host := address
+	if ip, _, err := net.ParseCIDR(address); err == nil {
+		host = ip.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		return "ipv6addr"
+	}
+	return "ipaddr"
+}
+
+// normalizeTriState maps the nas table's yes/no/auto columns onto the values
+// FreeRADIUS accepts. An empty value means "use the server default".
+func normalizeTriState(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return "", nil
+	case "yes", "y", "true", "1", "on":
+		return "yes", nil
+	case "no", "n", "false", "0", "off":
+		return "no", nil
+	case "auto":
+		return "auto", nil
+	default:
+		return "", fmt.Errorf("invalid value %q, expected yes, no or auto", value)
+	}
+}
+
+// sectionName turns a client name into a bare word the FreeRADIUS config
+// parser accepts as a section name.
+func sectionName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.', r == '-', r == '_', r == ':', r == '/':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func quote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func singleLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,119 @@
+package clientsconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("should render clients sorted by address", func(t *testing.T) {
+		// Given
+		clients := []Client{
+			{Address: "2001:db8::/32", Secret: "v6secret", NASType: "cisco"},
+			{
+				Address:                     "192.0.2.10",
+				Secret:                      `se"cret`,
+				ShortName:                   "bras-01",
+				NASType:                     "other",
+				Description:                 "Core\nBRAS",
+				RequireMessageAuthenticator: "yes",
+				LimitProxyState:             "auto",
+			},
+		}
+
+		// When
+		data, err := Render(clients)
+
+		// Then
+		require.NoError(t, err)
+		expected := Header + "\n" +
+			"\n" +
+			"# Core BRAS\n" +
+			"client 192.0.2.10 {\n" +
+			"\tipaddr = 192.0.2.10\n" +
+			"\tsecret = \"se\\\"cret\"\n" +
+			"\tshortname = \"bras-01\"\n" +
+			"\tnas_type = \"other\"\n" +
+			"\trequire_message_authenticator = yes\n" +
+			"\tlimit_proxy_state = auto\n" +
+			"}\n" +
+			"\n" +
+			"client 2001:db8::/32 {\n" +
+			"\tipv6addr = 2001:db8::/32\n" +
+			"\tsecret = \"v6secret\"\n" +
+			"\tnas_type = \"cisco\"\n" +
+			"}\n"
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("should be deterministic regardless of input order", func(t *testing.T) {
+		// Given
+		a := Client{Address: "10.0.0.1", Secret: "a"}
+		b := Client{Address: "10.0.0.2", Secret: "b"}
+
+		// When
+		first, err1 := Render([]Client{a, b})
+		second, err2 := Render([]Client{b, a})
+
+		// Then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should reject invalid limit_proxy_state", func(t *testing.T) {
+		// When
+		data, err := Render([]Client{{Address: "10.0.0.1", Secret: "a", LimitProxyState: "sometimes"}})
+
+		// Then
+		assert.Error(t, err)
+		assert.Nil(t, data)
+		assert.Contains(t, err.Error(), "limit_proxy_state")
+	})
+
+	t.Run("should reject client without address", func(t *testing.T) {
+		// When
+		_, err := Render([]Client{{Name: "orphan", Secret: "a"}})
+
+		// Then
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("should replace existing file and leave no temp files", func(t *testing.T) {
+		// Given
+		dir := t.TempDir()
+		path := filepath.Join(dir, "clients.conf")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+		// When
+		err := WriteFileAtomic(path, []byte("new"), 0o640)
+
+		// Then
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("should fail when directory does not exist", func(t *testing.T) {
+		// When
+		err := WriteFileAtomic(filepath.Join(t.TempDir(), "missing", "clients.conf"), []byte("x"), 0o640)
+
+		// Then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,137 @@
+package clientsconf
+
+import "strings"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenError
+	tokenWord
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenEquals
+	tokenDirective
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// lexer splits a FreeRADIUS configuration file into tokens. It understands
+// comments, bare words, single/double/back-quoted strings, braces, "=" and
+// $-prefixed directives such as $INCLUDE, which are returned whole.
+type lexer struct {
+	input string
+	pos   int
+	line  int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input, line: 1}
+}
+
+func (l *lexer) next() token {
+	l.skipSpaceAndComments()
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, line: l.line}
+	}
+
+	c := l.input[l.pos]
+	switch {
+	case c == '{':
+		l.pos++
+		return token{kind: tokenOpen, value: "{", line: l.line}
+	case c == '}':
+		l.pos++
+		return token{kind: tokenClose, value: "}", line: l.line}
+	case c == '=':
+		l.pos++
+		return token{kind: tokenEquals, value: "=", line: l.line}
+	case c == '"' || c == '\'' || c == '`':
+		return l.quoted(c)
+	case c == '$':
+		return l.directive()
+	default:
+		return l.word()
+	}
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',' || c == ';':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) quoted(quote byte) token {
+	line := l.line
+	l.pos++
+
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == quote:
+			l.pos++
+			return token{kind: tokenString, value: b.String(), line: line}
+		case c == '\n':
+			return token{kind: tokenError, value: "unterminated string", line: line}
+		case c == '\\' && l.pos+1 < len(l.input):
+			l.pos++
+			switch esc := l.input[l.pos]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(esc)
+			}
+			l.pos++
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{kind: tokenError, value: "unterminated string", line: line}
+}
+
+func (l *lexer) directive() token {
+	line := l.line
+	start := l.pos
+	for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+		l.pos++
+	}
+	return token{kind: tokenDirective, value: strings.TrimSpace(l.input[start:l.pos]), line: line}
+}
+
+func (l *lexer) word() token {
+	line := l.line
+	start := l.pos
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '{' || c == '}' ||
+			c == '=' || c == '#' || c == '"' || c == '\'' || c == ',' || c == ';' {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokenWord, value: l.input[start:l.pos], line: line}
+}
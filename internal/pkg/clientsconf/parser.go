@@ -0,0 +1,245 @@
+package clientsconf
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/nasaddr"
+)
+
+// ParseError reports a syntax or semantic problem in a clients.conf document.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("clients.conf line %d: %s", e.Line, e.Msg)
+}
+
+// Parse extracts every top-level client block from a clients.conf document.
+// Sections other than client, nested subsections and $INCLUDE-style
+// directives are skipped. Addresses are normalized so that a parsed client
+// compares equal to the nasname stored in the database: host addresses lose
+// their /32 or /128 suffix and networks are reduced to their base address.
+func Parse(data []byte) ([]Client, error) {
+	p := &parser{lex: newLexer(string(data))}
+	return p.parse()
+}
+
+type parser struct {
+	lex  *lexer
+	peek *token
+}
+
+func (p *parser) next() token {
+	if p.peek != nil {
+		tok := *p.peek
+		p.peek = nil
+		return tok
+	}
+	return p.lex.next()
+}
+
+func (p *parser) unread(tok token) {
+	p.peek = &tok
+}
+
+func (p *parser) parse() ([]Client, error) {
+	var clients []Client
+	seen := make(map[string]int)
+
+	for {
+		tok := p.next()
+		switch tok.kind {
+		case tokenEOF:
+			return clients, nil
+		case tokenError:
+			return nil, &ParseError{Line: tok.line, Msg: tok.value}
+		case tokenDirective:
+			continue
+		case tokenWord:
+			if tok.value != "client" {
+				if err := p.skipItem(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			client, err := p.parseClient(tok.line)
+			if err != nil {
+				return nil, err
+			}
+			if line, ok := seen[client.Address]; ok {
+				return nil, &ParseError{
+					Line: tok.line,
+					Msg:  fmt.Sprintf("client address %s already defined on line %d", client.Address, line),
+				}
+			}
+			seen[client.Address] = tok.line
+			clients = append(clients, client)
+		default:
+			return nil, &ParseError{Line: tok.line, Msg: fmt.Sprintf("unexpected %q", tok.value)}
+		}
+	}
+}
+
+func (p *parser) parseClient(line int) (Client, error) {
+	name := p.next()
+	if name.kind != tokenWord && name.kind != tokenString {
+		return Client{}, &ParseError{Line: name.line, Msg: "client block requires a name"}
+	}
+	if open := p.next(); open.kind != tokenOpen {
+		return Client{}, &ParseError{Line: open.line, Msg: fmt.Sprintf("expected '{' after client %s", name.value)}
+	}
+
+	attrs := make(map[string]string)
+	for {
+		tok := p.next()
+		switch tok.kind {
+		case tokenClose:
+			return buildClient(line, name.value, attrs)
+		case tokenWord:
+			following := p.next()
+			switch following.kind {
+			case tokenEquals:
+				value := p.next()
+				if value.kind != tokenWord && value.kind != tokenString {
+					return Client{}, &ParseError{Line: value.line, Msg: fmt.Sprintf("missing value for %s", tok.value)}
+				}
+				attrs[tok.value] = value.value
+			default:
+				// Nested subsection such as "limit { ... }" or "coa_server foo { ... }".
+				p.unread(following)
+				if err := p.skipItem(); err != nil {
+					return Client{}, err
+				}
+			}
+		case tokenDirective:
+			continue
+		case tokenEOF:
+			return Client{}, &ParseError{Line: tok.line, Msg: fmt.Sprintf("unterminated client %s", name.value)}
+		case tokenError:
+			return Client{}, &ParseError{Line: tok.line, Msg: tok.value}
+		default:
+			return Client{}, &ParseError{Line: tok.line, Msg: fmt.Sprintf("unexpected %q in client %s", tok.value, name.value)}
+		}
+	}
+}
+
+// skipItem consumes the remainder of an item whose leading word has already
+// been read: either "= value", "{ ... }" or "name2 { ... }".
+func (p *parser) skipItem() error {
+	tok := p.next()
+	switch tok.kind {
+	case tokenEquals:
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return &ParseError{Line: value.line, Msg: "missing value"}
+		}
+		return nil
+	case tokenOpen:
+		return p.skipBlock()
+	case tokenWord, tokenString:
+		open := p.next()
+		if open.kind == tokenOpen {
+			return p.skipBlock()
+		}
+		p.unread(open)
+		return nil
+	default:
+		p.unread(tok)
+		return nil
+	}
+}
+
+func (p *parser) skipBlock() error {
+	depth := 1
+	for depth > 0 {
+		tok := p.next()
+		switch tok.kind {
+		case tokenOpen:
+			depth++
+		case tokenClose:
+			depth--
+		case tokenEOF:
+			return &ParseError{Line: tok.line, Msg: "unterminated section"}
+		case tokenError:
+			return &ParseError{Line: tok.line, Msg: tok.value}
+		}
+	}
+	return nil
+}
+
+func buildClient(line int, name string, attrs map[string]string) (Client, error) {
+	client := Client{
+		Name:          name,
+		Secret:        attrs["secret"],
+		ShortName:     attrs["shortname"],
+		NASType:       attrs["nas_type"],
+		VirtualServer: attrs["virtual_server"],
+	}
+
+	address := firstNonEmpty(attrs["ipaddr"], attrs["ipv4addr"], attrs["ipv6addr"], name)
+	if mask := attrs["netmask"]; mask != "" && !strings.Contains(address, "/") {
+		address += "/" + mask
+	}
+
+	normalized, err := NormalizeAddress(address)
+	if err != nil {
+		return Client{}, &ParseError{Line: line, Msg: fmt.Sprintf("client %s: %v", name, err)}
+	}
+	client.Address = normalized
+
+	if client.Secret == "" {
+		return Client{}, &ParseError{Line: line, Msg: fmt.Sprintf("client %s has no secret", name)}
+	}
+
+	if client.RequireMessageAuthenticator, err = normalizeTriState(attrs["require_message_authenticator"]); err != nil {
+		return Client{}, &ParseError{Line: line, Msg: fmt.Sprintf("client %s: require_message_authenticator: %v", name, err)}
+	}
+	if client.LimitProxyState, err = normalizeTriState(attrs["limit_proxy_state"]); err != nil {
+		return Client{}, &ParseError{Line: line, Msg: fmt.Sprintf("client %s: limit_proxy_state: %v", name, err)}
+	}
+
+	return client, nil
+}
+
+// NormalizeAddress returns the canonical form of an IPv4/IPv6 address, CIDR
+// network or hostname as used for the nasname column.
+func NormalizeAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", fmt.Errorf("empty address")
+	}
+
+	if strings.Contains(address, "/") {
+		ip, network, err := net.ParseCIDR(address)
+		if err != nil {
+			return "", fmt.Errorf("invalid network %q", address)
+		}
+		ones, bits := network.Mask.Size()
+		if ones == bits {
+			return ip.String(), nil
+		}
+		return network.String(), nil
+	}
+
+	if ip := net.ParseIP(address); ip != nil {
+		return ip.String(), nil
+	}
+
+	if !nasaddr.IsHostname(address) {
+		return "", fmt.Errorf("invalid address %q", address)
+	}
+	return strings.ToLower(address), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
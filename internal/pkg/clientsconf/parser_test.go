@@ -0,0 +1,184 @@
+package clientsconf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse IPv4, IPv6 and CIDR clients", func(t *testing.T) {
+		// Given
+		conf := `
+# Hand maintained
+$INCLUDE clients.d/
+
+client localhost {
+	ipaddr = 127.0.0.1
+	proto = *
+	secret = testing123
+	require_message_authenticator = no
+	nas_type = other	# comment after value
+	limit {
+		max_connections = 16
+		lifetime = 0
+	}
+}
+
+client bras-v6 {
+	ipv6addr = 2001:DB8::1/128
+	secret = "quoted # secret"
+	shortname = bras6
+	limit_proxy_state = Yes
+}
+
+client private-network-1 {
+	ipaddr = 192.0.2.17/24
+	secret = 'single'
+	nas_type = cisco
+	virtual_server = inner
+}
+
+client legacy {
+	ipaddr = 10.10.0.0
+	netmask = 16
+	secret = legacy
+}
+
+client 198.51.100.7 {
+	secret = bare
+}
+
+server other {
+	listen {
+		ipaddr = *
+	}
+}
+`
+
+		// When
+		clients, err := Parse([]byte(conf))
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, clients, 5)
+
+		assert.Equal(t, Client{
+			Name:                        "localhost",
+			Address:                     "127.0.0.1",
+			Secret:                      "testing123",
+			NASType:                     "other",
+			RequireMessageAuthenticator: "no",
+		}, clients[0])
+
+		assert.Equal(t, "2001:db8::1", clients[1].Address)
+		assert.Equal(t, "quoted # secret", clients[1].Secret)
+		assert.Equal(t, "bras6", clients[1].ShortName)
+		assert.Equal(t, "yes", clients[1].LimitProxyState)
+
+		assert.Equal(t, "192.0.2.0/24", clients[2].Address)
+		assert.Equal(t, "single", clients[2].Secret)
+		assert.Equal(t, "cisco", clients[2].NASType)
+		assert.Equal(t, "inner", clients[2].VirtualServer)
+
+		assert.Equal(t, "10.10.0.0/16", clients[3].Address)
+		assert.Equal(t, "198.51.100.7", clients[4].Address)
+	})
+
+	t.Run("should round-trip rendered output", func(t *testing.T) {
+		// Given
+		clients := []Client{
+			{Address: "192.0.2.10", Secret: `a"b\c`, ShortName: "bras", NASType: "other",
+				RequireMessageAuthenticator: "auto", LimitProxyState: "no"},
+			{Address: "2001:db8::/32", Secret: "v6", VirtualServer: "inner"},
+		}
+		data, err := Render(clients)
+		require.NoError(t, err)
+
+		// When
+		parsed, err := Parse(data)
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, parsed, 2)
+		for i := range clients {
+			parsed[i].Name = ""
+			assert.Equal(t, clients[i], parsed[i])
+		}
+	})
+
+	t.Run("should reject client without secret", func(t *testing.T) {
+		// When
+		_, err := Parse([]byte("client a {\n\tipaddr = 10.0.0.1\n}\n"))
+
+		// Then
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 1, parseErr.Line)
+		assert.Contains(t, err.Error(), "no secret")
+	})
+
+	t.Run("should reject invalid address", func(t *testing.T) {
+		// When
+		_, err := Parse([]byte("client a {\n\tipaddr = 10.0.0.300/33\n\tsecret = x\n}\n"))
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid network")
+	})
+
+	t.Run("should reject duplicate addresses", func(t *testing.T) {
+		// Given
+		conf := "client a {\n ipaddr = 10.0.0.1\n secret = x\n}\nclient b {\n ipaddr = 10.0.0.1/32\n secret = y\n}\n"
+
+		// When
+		_, err := Parse([]byte(conf))
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already defined on line 1")
+	})
+
+	t.Run("should reject unterminated client", func(t *testing.T) {
+		// When
+		_, err := Parse([]byte("client a {\n\tipaddr = 10.0.0.1\n\tsecret = x\n"))
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unterminated client")
+	})
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "192.0.2.1", expected: "192.0.2.1"},
+		{input: "192.0.2.1/32", expected: "192.0.2.1"},
+		{input: "192.0.2.99/24", expected: "192.0.2.0/24"},
+		{input: "2001:DB8:0::1", expected: "2001:db8::1"},
+		{input: "2001:db8::1/64", expected: "2001:db8::/64"},
+		{input: "NAS-01.Example.net", expected: "nas-01.example.net"},
+		{input: "", wantErr: true},
+		{input: "bad host!", wantErr: true},
+		{input: "10.0.0.1/40", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// When
+			address, err := NormalizeAddress(tt.input)
+
+			// Then
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, address)
+		})
+	}
+}
@@ -12,9 +12,17 @@ func WithTx(ctx context.Context, txTx *gorm.DB) context.Context {
 	return context.WithValue(ctx, txKey{}, txTx)
 }
 
+// GetDB returns the transaction of ctx, or db bound to ctx so that queries
+// outside a transaction are cancelled and traced with the request.
 func GetDB(ctx context.Context, db *gorm.DB) interface{} {
 	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
 		return tx
 	}
-	return db
+	return db.WithContext(ctx)
+}
+
+// HasTx reports whether ctx carries a transaction started by WithinTransaction.
+func HasTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
 }
@@ -1,35 +1,59 @@
 package database
 
 import (
-	"fmt"
+	"database/sql"
 
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-func NewDatabase(cfg *config.Config, log *zap.Logger) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8&parseTime=True&loc=Local",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.DBName,
-	)
+func NewDatabase(cfg *config.Config, log *zap.Logger, tp trace.TracerProvider) (*gorm.DB, error) {
+	dialector, err := Dialector(cfg.Database)
+	if err != nil {
+		log.Error("Invalid database configuration", zap.Error(err))
+		return nil, err
+	}
 
 	gormConfig := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	}
 
-	db, err := gorm.Open(mysql.Open(dsn), gormConfig)
+	db, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.String("driver", cfg.Database.Driver), zap.Error(err))
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Error("Failed to connect to database", zap.Error(err))
 		return nil, err
 	}
+	configurePool(sqlDB, cfg.Database)
 
-	log.Info("Database connected and migrated successfully")
+	if err := tracing.InstrumentGorm(db, tp); err != nil {
+		log.Error("Failed to instrument database", zap.Error(err))
+		return nil, err
+	}
+
+	log.Info("Database connected", zap.String("driver", cfg.Database.Driver))
 	return db, nil
 }
+
+// configurePool sizes the connection pool. SQLite takes one writer at a
+// time, so it gets a single connection rather than connections waiting on
+// each other's locks.
+func configurePool(db *sql.DB, cfg config.DatabaseConfig) {
+	if cfg.Driver == DriverSQLite {
+		db.SetMaxOpenConns(1)
+		return
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Drivers of the databases FreeRADIUS keeps its tables in.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// mysqlTLSConfig is the name the TLS settings of ssl_mode are registered
+// under with the MySQL driver.
+const mysqlTLSConfig = "freeradius-service"
+
+// Dialector returns the dialector of the configured driver, connecting with
+// the DSN of DSN.
+func Dialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.Driver {
+	case DriverMySQL:
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	default:
+		return sqlite.Open(dsn), nil
+	}
+}
+
+// DSN builds the data source name of the configured driver, escaping the
+// credentials and mapping ssl_mode to the TLS options of the driver.
+func DSN(cfg config.DatabaseConfig) (string, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		return mysqlDSN(cfg)
+	case DriverPostgres:
+		return postgresDSN(cfg)
+	case DriverSQLite:
+		return sqliteDSN(cfg)
+	default:
+		return "", fmt.Errorf("database driver %q is not one of mysql, postgres or sqlite", cfg.Driver)
+	}
+}
+
+func mysqlDSN(cfg config.DatabaseConfig) (string, error) {
+	dsn := mysqldriver.NewConfig()
+	dsn.User = cfg.User
+	dsn.Passwd = cfg.Password
+	dsn.Net = "tcp"
+	dsn.Addr = address(cfg, 3306)
+	dsn.DBName = cfg.DBName
+	dsn.ParseTime = true
+	dsn.Loc = time.Local
+	dsn.Params = map[string]string{"charset": "utf8"}
+
+	switch cfg.SSLMode {
+	case "", "disable":
+	case "prefer", "allow":
+		dsn.TLSConfig = "preferred"
+	case "require":
+		// As with PostgreSQL, require encrypts without verifying the server
+		// unless a CA is given
+		if cfg.SSLRootCert == "" {
+			dsn.TLSConfig = "skip-verify"
+			break
+		}
+		fallthrough
+	case "verify-ca", "verify-full":
+		if cfg.SSLRootCert == "" {
+			dsn.TLSConfig = "true"
+			break
+		}
+		if err := registerMySQLTLS(cfg); err != nil {
+			return "", err
+		}
+		dsn.TLSConfig = mysqlTLSConfig
+	default:
+		return "", fmt.Errorf("database ssl_mode %q is not one of disable, prefer, require, verify-ca or verify-full", cfg.SSLMode)
+	}
+	return dsn.FormatDSN(), nil
+}
+
+// registerMySQLTLS registers the TLS settings verifying the server against
+// ssl_root_cert; verify-ca skips the check of the host name.
+func registerMySQLTLS(cfg config.DatabaseConfig) error {
+	pem, err := os.ReadFile(cfg.SSLRootCert)
+	if err != nil {
+		return fmt.Errorf("read database ssl_root_cert: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("database ssl_root_cert %s holds no PEM certificate", cfg.SSLRootCert)
+	}
+	tlsConfig := &tls.Config{RootCAs: roots, ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+	if cfg.SSLMode != "verify-full" {
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyChain(roots)
+	}
+	return mysqldriver.RegisterTLSConfig(mysqlTLSConfig, tlsConfig)
+}
+
+// verifyChain verifies the server certificate against roots without
+// checking its host name.
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(raw [][]byte, _ [][]*x509.Certificate) error {
+		certs := make([]*x509.Certificate, len(raw))
+		for i, der := range raw {
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return err
+			}
+			certs[i] = cert
+		}
+		if len(certs) == 0 {
+			return fmt.Errorf("database server sent no certificate")
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
+
+func postgresDSN(cfg config.DatabaseConfig) (string, error) {
+	switch cfg.SSLMode {
+	case "", "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		return "", fmt.Errorf("database ssl_mode %q is not one of disable, prefer, require, verify-ca or verify-full", cfg.SSLMode)
+	}
+	query := url.Values{}
+	query.Set("sslmode", cfg.SSLMode)
+	if cfg.SSLMode == "" {
+		query.Set("sslmode", "disable")
+	}
+	if cfg.SSLRootCert != "" {
+		query.Set("sslrootcert", cfg.SSLRootCert)
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     address(cfg, 5432),
+		Path:     "/" + cfg.DBName,
+		RawQuery: query.Encode(),
+	}
+	return dsn.String(), nil
+}
+
+// sqliteDSN opens the file in WAL mode, so that readers do not wait for the
+// writer, and waits for locks rather than failing at once.
+func sqliteDSN(cfg config.DatabaseConfig) (string, error) {
+	if cfg.DBName == "" {
+		return "", fmt.Errorf("database db_name must name the sqlite file")
+	}
+	return "file:" + cfg.DBName + "?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on", nil
+}
+
+func address(cfg config.DatabaseConfig, defaultPort int) string {
+	port := cfg.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+)
+
+func TestDSN(t *testing.T) {
+	base := config.DatabaseConfig{
+		Host:     "db.example.com",
+		User:     "radius",
+		Password: "p@ss:w/rd?",
+		DBName:   "radius",
+	}
+
+	t.Run("should build a MySQL DSN on the default port", func(t *testing.T) {
+		// Setup
+		cfg := base
+		cfg.Driver = DriverMySQL
+		cfg.SSLMode = "require"
+
+		// When
+		dsn, err := DSN(cfg)
+
+		// Then
+		require.NoError(t, err)
+		parsed, err := mysqldriver.ParseDSN(dsn)
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com:3306", parsed.Addr)
+		assert.Equal(t, "p@ss:w/rd?", parsed.Passwd)
+		assert.Equal(t, "radius", parsed.DBName)
+		assert.True(t, parsed.ParseTime)
+		assert.Equal(t, "skip-verify", parsed.TLSConfig)
+	})
+
+	t.Run("should build a PostgreSQL DSN with the SSL mode", func(t *testing.T) {
+		// Setup
+		cfg := base
+		cfg.Driver = DriverPostgres
+		cfg.Port = 6432
+		cfg.SSLMode = "verify-full"
+		cfg.SSLRootCert = "/etc/ssl/db-ca.pem"
+
+		// When
+		dsn, err := DSN(cfg)
+
+		// Then
+		require.NoError(t, err)
+		parsed, err := url.Parse(dsn)
+		require.NoError(t, err)
+		password, _ := parsed.User.Password()
+		assert.Equal(t, "p@ss:w/rd?", password)
+		assert.Equal(t, "db.example.com:6432", parsed.Host)
+		assert.Equal(t, "/radius", parsed.Path)
+		assert.Equal(t, "verify-full", parsed.Query().Get("sslmode"))
+		assert.Equal(t, "/etc/ssl/db-ca.pem", parsed.Query().Get("sslrootcert"))
+	})
+
+	t.Run("should open the SQLite file in WAL mode", func(t *testing.T) {
+		// Setup
+		cfg := base
+		cfg.Driver = DriverSQLite
+		cfg.DBName = "/var/lib/radius/radius.db"
+
+		// When
+		dsn, err := DSN(cfg)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "file:/var/lib/radius/radius.db?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on", dsn)
+	})
+
+	t.Run("should reject unknown drivers and SSL modes", func(t *testing.T) {
+		// Setup
+		oracle := base
+		oracle.Driver = "oracle"
+		mysql := base
+		mysql.Driver = DriverMySQL
+		mysql.SSLMode = "on"
+		postgres := base
+		postgres.Driver = DriverPostgres
+		postgres.SSLMode = "on"
+
+		// When
+		_, oracleErr := DSN(oracle)
+		_, mysqlErr := DSN(mysql)
+		_, postgresErr := DSN(postgres)
+
+		// Then
+		assert.Error(t, oracleErr)
+		assert.Error(t, mysqlErr)
+		assert.Error(t, postgresErr)
+	})
+}
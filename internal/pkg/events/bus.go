@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Bus dispatches relayed events to in-process subscribers.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers h for events of eventType.
+func (b *Bus) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+// SubscribeAll registers h for every event.
+func (b *Bus) SubscribeAll(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, h)
+}
+
+// Dispatch runs every handler subscribed to env and returns their joined
+// errors. All handlers run even if one fails, so on redelivery the ones that
+// succeeded see the event again and must be idempotent.
+func (b *Bus) Dispatch(ctx context.Context, env Envelope) error {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[env.Type])+len(b.all))
+	handlers = append(handlers, b.handlers[env.Type]...)
+	handlers = append(handlers, b.all...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, env); err != nil {
+			errs = append(errs, fmt.Errorf("%s handler: %w", env.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
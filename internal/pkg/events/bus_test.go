@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Dispatch(t *testing.T) {
+	t.Run("should run handlers of the event type and catch-all handlers", func(t *testing.T) {
+		// Setup
+		bus := NewBus()
+		var got []string
+		bus.Subscribe(TypeUserCreated, func(ctx context.Context, env Envelope) error {
+			got = append(got, "typed:"+env.ID)
+			return nil
+		})
+		bus.Subscribe(TypeUserDeleted, func(ctx context.Context, env Envelope) error {
+			got = append(got, "other:"+env.ID)
+			return nil
+		})
+		bus.SubscribeAll(func(ctx context.Context, env Envelope) error {
+			got = append(got, "all:"+env.ID)
+			return nil
+		})
+
+		// When
+		err := bus.Dispatch(context.Background(), Envelope{ID: "e1", Type: TypeUserCreated})
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"typed:e1", "all:e1"}, got)
+	})
+
+	t.Run("should run every handler and join their errors", func(t *testing.T) {
+		// Setup
+		bus := NewBus()
+		calls := 0
+		bus.Subscribe(TypeUserCreated, func(ctx context.Context, env Envelope) error {
+			calls++
+			return errors.New("first failed")
+		})
+		bus.SubscribeAll(func(ctx context.Context, env Envelope) error {
+			calls++
+			return nil
+		})
+
+		// When
+		err := bus.Dispatch(context.Background(), Envelope{ID: "e1", Type: TypeUserCreated})
+
+		// Then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "first failed")
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestEnvelope_Decode(t *testing.T) {
+	t.Run("should decode the payload", func(t *testing.T) {
+		// Given
+		env := Envelope{Type: TypeUserCreated, Payload: []byte(`{"user_id":7,"name":"John","email":"john@example.com"}`)}
+
+		// When
+		var event UserCreated
+		err := env.Decode(&event)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, UserCreated{UserID: 7, Name: "John", Email: "john@example.com"}, event)
+		assert.Equal(t, "7", event.AggregateID())
+	})
+}
@@ -0,0 +1,239 @@
+package events
+
+import "strconv"
+
+const (
+	AggregateUser       = "user"
+	AggregatePayment    = "payment"
+	AggregateNAS        = "nas"
+	AggregateSubscriber = "subscriber"
+
+	TypeUserCreated         = "user.created"
+	TypeUserUpdated         = "user.updated"
+	TypeUserPasswordChanged = "user.password_changed"
+	TypeUserRoleChanged     = "user.role_changed"
+	TypeUserDeleted         = "user.deleted"
+
+	TypePaymentCreated       = "payment.created"
+	TypePaymentStatusChanged = "payment.status_changed"
+	TypePaymentCompleted     = "payment.completed"
+	TypePaymentDeleted       = "payment.deleted"
+
+	TypeNASCreated       = "nas.created"
+	TypeNASUpdated       = "nas.updated"
+	TypeNASDeleted       = "nas.deleted"
+	TypeNASSecretRotated = "nas.secret_rotated"
+	TypeNASHealthChanged = "nas.health_changed"
+
+	TypeSubscriberCreated = "subscriber.created"
+	TypeAttributesChanged = "subscriber.attributes_changed"
+)
+
+// Attribute change actions and the tables they apply to.
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+
+	TableRadcheck = "radcheck"
+	TableRadreply = "radreply"
+)
+
+var knownTypes = map[string]bool{
+	TypeUserCreated:          true,
+	TypeUserUpdated:          true,
+	TypeUserPasswordChanged:  true,
+	TypeUserRoleChanged:      true,
+	TypeUserDeleted:          true,
+	TypePaymentCreated:       true,
+	TypePaymentStatusChanged: true,
+	TypePaymentCompleted:     true,
+	TypePaymentDeleted:       true,
+	TypeNASCreated:           true,
+	TypeNASUpdated:           true,
+	TypeNASDeleted:           true,
+	TypeNASSecretRotated:     true,
+	TypeNASHealthChanged:     true,
+	TypeSubscriberCreated:    true,
+	TypeAttributesChanged:    true,
+}
+
+// IsKnownType reports whether eventType is an event type of the catalog.
+func IsKnownType(eventType string) bool {
+	return knownTypes[eventType]
+}
+
+func uintID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+type UserCreated struct {
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+func (e UserCreated) EventType() string     { return TypeUserCreated }
+func (e UserCreated) AggregateType() string { return AggregateUser }
+func (e UserCreated) AggregateID() string   { return uintID(e.UserID) }
+
+type UserUpdated struct {
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+func (e UserUpdated) EventType() string     { return TypeUserUpdated }
+func (e UserUpdated) AggregateType() string { return AggregateUser }
+func (e UserUpdated) AggregateID() string   { return uintID(e.UserID) }
+
+type UserPasswordChanged struct {
+	UserID uint `json:"user_id"`
+}
+
+func (e UserPasswordChanged) EventType() string     { return TypeUserPasswordChanged }
+func (e UserPasswordChanged) AggregateType() string { return AggregateUser }
+func (e UserPasswordChanged) AggregateID() string   { return uintID(e.UserID) }
+
+type UserRoleChanged struct {
+	UserID uint   `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func (e UserRoleChanged) EventType() string     { return TypeUserRoleChanged }
+func (e UserRoleChanged) AggregateType() string { return AggregateUser }
+func (e UserRoleChanged) AggregateID() string   { return uintID(e.UserID) }
+
+type UserDeleted struct {
+	UserID uint `json:"user_id"`
+}
+
+func (e UserDeleted) EventType() string     { return TypeUserDeleted }
+func (e UserDeleted) AggregateType() string { return AggregateUser }
+func (e UserDeleted) AggregateID() string   { return uintID(e.UserID) }
+
+type PaymentCreated struct {
+	PaymentID uint    `json:"payment_id"`
+	UserID    uint    `json:"user_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Status    string  `json:"status"`
+}
+
+func (e PaymentCreated) EventType() string     { return TypePaymentCreated }
+func (e PaymentCreated) AggregateType() string { return AggregatePayment }
+func (e PaymentCreated) AggregateID() string   { return uintID(e.PaymentID) }
+
+// PaymentStatusChanged is recorded for every status transition, in addition
+// to PaymentCompleted for the transition to completed.
+type PaymentStatusChanged struct {
+	PaymentID uint   `json:"payment_id"`
+	UserID    uint   `json:"user_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+func (e PaymentStatusChanged) EventType() string     { return TypePaymentStatusChanged }
+func (e PaymentStatusChanged) AggregateType() string { return AggregatePayment }
+func (e PaymentStatusChanged) AggregateID() string   { return uintID(e.PaymentID) }
+
+type PaymentCompleted struct {
+	PaymentID uint    `json:"payment_id"`
+	UserID    uint    `json:"user_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+}
+
+func (e PaymentCompleted) EventType() string     { return TypePaymentCompleted }
+func (e PaymentCompleted) AggregateType() string { return AggregatePayment }
+func (e PaymentCompleted) AggregateID() string   { return uintID(e.PaymentID) }
+
+type PaymentDeleted struct {
+	PaymentID uint `json:"payment_id"`
+	UserID    uint `json:"user_id"`
+}
+
+func (e PaymentDeleted) EventType() string     { return TypePaymentDeleted }
+func (e PaymentDeleted) AggregateType() string { return AggregatePayment }
+func (e PaymentDeleted) AggregateID() string   { return uintID(e.PaymentID) }
+
+// NASCreated and NASUpdated never carry the shared secret; NASSecretRotated
+// only signals that it changed.
+type NASCreated struct {
+	NASID     uint   `json:"nas_id"`
+	NASName   string `json:"nasname"`
+	ShortName string `json:"shortname"`
+	Type      string `json:"type"`
+}
+
+func (e NASCreated) EventType() string     { return TypeNASCreated }
+func (e NASCreated) AggregateType() string { return AggregateNAS }
+func (e NASCreated) AggregateID() string   { return uintID(e.NASID) }
+
+type NASUpdated struct {
+	NASID     uint   `json:"nas_id"`
+	NASName   string `json:"nasname"`
+	ShortName string `json:"shortname"`
+	Type      string `json:"type"`
+}
+
+func (e NASUpdated) EventType() string     { return TypeNASUpdated }
+func (e NASUpdated) AggregateType() string { return AggregateNAS }
+func (e NASUpdated) AggregateID() string   { return uintID(e.NASID) }
+
+type NASDeleted struct {
+	NASID   uint   `json:"nas_id"`
+	NASName string `json:"nasname"`
+}
+
+func (e NASDeleted) EventType() string     { return TypeNASDeleted }
+func (e NASDeleted) AggregateType() string { return AggregateNAS }
+func (e NASDeleted) AggregateID() string   { return uintID(e.NASID) }
+
+type NASSecretRotated struct {
+	NASID uint `json:"nas_id"`
+}
+
+func (e NASSecretRotated) EventType() string     { return TypeNASSecretRotated }
+func (e NASSecretRotated) AggregateType() string { return AggregateNAS }
+func (e NASSecretRotated) AggregateID() string   { return uintID(e.NASID) }
+
+type NASHealthChanged struct {
+	NASID   uint   `json:"nas_id"`
+	NASName string `json:"nasname"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (e NASHealthChanged) EventType() string     { return TypeNASHealthChanged }
+func (e NASHealthChanged) AggregateType() string { return AggregateNAS }
+func (e NASHealthChanged) AggregateID() string   { return uintID(e.NASID) }
+
+// SubscriberCreated is recorded when RADIUS credentials are provisioned for a
+// username. Attribute values are left out so that passwords never reach the
+// outbox.
+type SubscriberCreated struct {
+	Username        string   `json:"username"`
+	CheckAttributes []string `json:"check_attributes"`
+	ReplyAttributes []string `json:"reply_attributes"`
+}
+
+func (e SubscriberCreated) EventType() string     { return TypeSubscriberCreated }
+func (e SubscriberCreated) AggregateType() string { return AggregateSubscriber }
+func (e SubscriberCreated) AggregateID() string   { return e.Username }
+
+// AttributesChanged is recorded for every radcheck or radreply write. The
+// value is left out for the same reason as in SubscriberCreated.
+type AttributesChanged struct {
+	Username    string `json:"username"`
+	Table       string `json:"table"`
+	Action      string `json:"action"`
+	AttributeID uint   `json:"attribute_id"`
+	Attribute   string `json:"attribute"`
+	Op          string `json:"op"`
+}
+
+func (e AttributesChanged) EventType() string     { return TypeAttributesChanged }
+func (e AttributesChanged) AggregateType() string { return AggregateSubscriber }
+func (e AttributesChanged) AggregateID() string   { return e.Username }
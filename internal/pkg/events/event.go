@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a domain event: a fact about a state change that other components
+// may react to. The event value itself is the JSON payload.
+type Event interface {
+	EventType() string
+	AggregateType() string
+	AggregateID() string
+}
+
+// Envelope is an event as it is stored in the outbox and delivered to
+// subscribers. ID is unique per event and stays the same across redeliveries,
+// so subscribers can use it to discard duplicates.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Decode unmarshals the payload of env into event, which must be a pointer
+// to the event type matching env.Type.
+func (env Envelope) Decode(event Event) error {
+	return json.Unmarshal(env.Payload, event)
+}
+
+// Handler reacts to a delivered event. Returning an error makes the relay
+// deliver the event again later.
+type Handler func(ctx context.Context, env Envelope) error
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Services answered by GRPCServer. The empty name, the server as a whole,
+// is the readiness of the binary.
+const (
+	ServiceReadiness = ""
+	ServiceLiveness  = "liveness"
+)
+
+// GRPCServer implements the standard gRPC health service with the checks of
+// a Checker, so that Kubernetes gRPC probes see the same status as the REST
+// readiness endpoint.
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+	checker *Checker
+	// interval between the checks of Watch streams
+	interval time.Duration
+}
+
+func NewGRPCServer(checker *Checker) *GRPCServer {
+	interval := checker.ttl
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &GRPCServer{checker: checker, interval: interval}
+}
+
+func (s *GRPCServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	serving, err := s.status(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	return &healthpb.HealthCheckResponse{Status: serving}, nil
+}
+
+// Watch sends the status of the service, then every change of it, until the
+// client ends the stream.
+func (s *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		serving, err := s.status(ctx, req.GetService())
+		if status.Code(err) == codes.NotFound {
+			// Watchers of unknown services wait for them, as the spec asks
+			serving, err = healthpb.HealthCheckResponse_SERVICE_UNKNOWN, nil
+		}
+		if err != nil {
+			return err
+		}
+		if serving != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: serving}); err != nil {
+				return err
+			}
+			last = serving
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *GRPCServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	switch service {
+	case ServiceLiveness:
+		return healthpb.HealthCheckResponse_SERVING, nil
+	case ServiceReadiness:
+		if s.checker.Report(ctx).Ready() {
+			return healthpb.HealthCheckResponse_SERVING, nil
+		}
+		return healthpb.HealthCheckResponse_NOT_SERVING, nil
+	default:
+		return healthpb.HealthCheckResponse_UNKNOWN, status.Errorf(codes.NotFound, "unknown service %q", service)
+	}
+}
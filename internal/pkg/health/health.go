@@ -0,0 +1,130 @@
+// Package health probes the dependencies of a binary for its readiness
+// checks: the database, Redis and the task queue. Liveness needs no probe,
+// a process able to answer is alive. The results back the REST readiness
+// endpoint and the standard gRPC health service.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+)
+
+// Statuses of a check and of a report.
+const (
+	StatusOK       = "ok"
+	StatusDown     = "down"
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusNotReady = "not_ready"
+)
+
+// Probe returns an error when the dependency it checks is unavailable.
+type Probe func(ctx context.Context) error
+
+// Check probes a dependency. A binary whose critical dependency is down is
+// not ready; one whose other dependency is down is degraded.
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    Probe
+}
+
+// Result is the outcome of a check.
+type Result struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report holds the results of every check, by name, as of CheckedAt.
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// Ready reports whether every critical dependency is up.
+func (r Report) Ready() bool {
+	return r.Status != StatusNotReady
+}
+
+// Checker runs the checks of a binary concurrently, each bounded by the
+// timeout, and reuses the report for the TTL.
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.Mutex
+	report *Report
+	now    func() time.Time
+}
+
+func NewChecker(cfg config.HealthConfig, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: cfg.Timeout, ttl: cfg.CacheTTL, now: time.Now}
+}
+
+// Report returns the cached report, running the checks again once the TTL
+// passed. Concurrent callers wait for a single run.
+func (c *Checker) Report(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report != nil && c.now().Sub(c.report.CheckedAt) < c.ttl {
+		return *c.report
+	}
+
+	report := c.run(ctx)
+	c.report = &report
+	return report
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]Result, len(c.checks)), CheckedAt: c.now()}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+		if result.Status == StatusOK {
+			continue
+		}
+		if check.Critical {
+			report.Status = StatusNotReady
+		} else if report.Status == StatusReady {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+	result := Result{
+		Status:    StatusOK,
+		Critical:  check.Critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var testConfig = config.HealthConfig{Timeout: time.Second, CacheTTL: 5 * time.Second}
+
+func up(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_Report(t *testing.T) {
+	t.Run("should be ready when every dependency is up", func(t *testing.T) {
+		// Setup
+		db, err := testutil.SetupTestDB()
+		require.NoError(t, err)
+		checker := NewChecker(testConfig,
+			Check{Name: "database", Critical: true, Probe: Database(db)},
+			Check{Name: "redis", Critical: true, Probe: up},
+		)
+
+		// When
+		report := checker.Report(context.Background())
+
+		// Then
+		assert.Equal(t, StatusReady, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, StatusOK, report.Checks["redis"].Status)
+		assert.Equal(t, 200, StatusCode(report))
+	})
+
+	t.Run("should not be ready when a critical dependency is down", func(t *testing.T) {
+		// Setup
+		checker := NewChecker(testConfig,
+			Check{Name: "database", Critical: true, Probe: up},
+			Check{Name: "redis", Critical: true, Probe: down},
+		)
+
+		// When
+		report := checker.Report(context.Background())
+
+		// Then
+		assert.Equal(t, StatusNotReady, report.Status)
+		assert.Equal(t, Result{Status: StatusDown, Critical: true, LatencyMS: report.Checks["redis"].LatencyMS, Error: "connection refused"}, report.Checks["redis"])
+		assert.Equal(t, 503, StatusCode(report))
+	})
+
+	t.Run("should be degraded when another dependency is down", func(t *testing.T) {
+		// Setup
+		checker := NewChecker(testConfig,
+			Check{Name: "database", Critical: true, Probe: up},
+			Check{Name: "redis", Probe: down},
+		)
+
+		// When
+		report := checker.Report(context.Background())
+
+		// Then
+		assert.Equal(t, StatusDegraded, report.Status)
+		assert.Equal(t, 200, StatusCode(report))
+	})
+
+	t.Run("should time out slow probes", func(t *testing.T) {
+		// Setup
+		checker := NewChecker(config.HealthConfig{Timeout: 10 * time.Millisecond},
+			Check{Name: "database", Critical: true, Probe: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		)
+
+		// When
+		report := checker.Report(context.Background())
+
+		// Then
+		assert.Equal(t, StatusNotReady, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+	})
+
+	t.Run("should reuse the report for the TTL", func(t *testing.T) {
+		// Setup
+		probes := 0
+		checker := NewChecker(testConfig, Check{Name: "database", Critical: true, Probe: func(ctx context.Context) error {
+			probes++
+			return nil
+		}})
+		now := time.Now()
+		checker.now = func() time.Time { return now }
+
+		// When
+		checker.Report(context.Background())
+		checker.Report(context.Background())
+		now = now.Add(testConfig.CacheTTL)
+		checker.Report(context.Background())
+
+		// Then
+		assert.Equal(t, 2, probes)
+	})
+}
+
+func TestGRPCServer_Check(t *testing.T) {
+	// Setup
+	server := NewGRPCServer(NewChecker(testConfig, Check{Name: "database", Critical: true, Probe: down}))
+
+	t.Run("should report the readiness of the server", func(t *testing.T) {
+		// When
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	})
+
+	t.Run("should report the server alive whatever its dependencies", func(t *testing.T) {
+		// When
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: ServiceLiveness})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("should reject unknown services", func(t *testing.T) {
+		// When
+		_, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "payment.PaymentService"})
+
+		// Then
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
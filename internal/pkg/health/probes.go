@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Database pings the database of db.
+func Database(db *gorm.DB) Probe {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Redis pings the Redis server of client.
+func Redis(client redis.UniversalClient) Probe {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// Queue lists the queues of inspector, proving the task queue reachable.
+// The inspector takes no context, so a probe that times out leaves the call
+// running until the Redis client times out.
+func Queue(inspector *asynq.Inspector) Probe {
+	return func(ctx context.Context) error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := inspector.Queues()
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// NewRedisProbe pings the Redis server of the job queue with a client of
+// its own, closed on stop.
+func NewRedisProbe(lc fx.Lifecycle, cfg *config.Config) Probe {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return client.Close()
+		},
+	})
+	return Redis(client)
+}
+
+// NewQueueProbe checks the job queue with an inspector of its own, closed
+// on stop.
+func NewQueueProbe(lc fx.Lifecycle, cfg *config.Config) Probe {
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return inspector.Close()
+		},
+	})
+	return Queue(inspector)
+}
@@ -0,0 +1,66 @@
+// Package identity carries the authenticated caller of a request.
+package identity
+
+import (
+	"context"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+)
+
+// ErrUnauthenticated is returned by an Authenticator for missing, invalid,
+// expired or revoked credentials.
+var ErrUnauthenticated = apperror.Unauthenticated("unauthenticated")
+
+// Principal is the authenticated caller of a request: a logged in user, or
+// a machine client holding an API key.
+type Principal struct {
+	UserID    uint
+	Email     string
+	Role      string
+	SessionID string
+	// TenantID is the tenant the principal is bound to, nil for super-admins
+	// who see every tenant.
+	TenantID *uint
+	// APIKey is the public prefix of the API key the caller presented, empty
+	// for users. Scopes then lists the permissions granted to the key.
+	APIKey string
+	Scopes []string
+}
+
+// Actor names the principal in the audit trail.
+func (p *Principal) Actor() string {
+	if p.APIKey != "" {
+		return "api-key:" + p.APIKey
+	}
+	return p.Email
+}
+
+// Authenticator resolves the bearer access token of a request. The REST
+// middleware and the gRPC interceptors share it.
+type Authenticator interface {
+	Authenticate(ctx context.Context, accessToken string) (*Principal, error)
+}
+
+// KeyAuthenticator resolves the API key of a request made from clientIP.
+type KeyAuthenticator interface {
+	AuthenticateKey(ctx context.Context, key, clientIP string) (*Principal, error)
+}
+
+// CredentialVerifier checks an email and password directly, for clients that
+// cannot obtain a token first such as a browser opening the Swagger UI.
+type CredentialVerifier interface {
+	VerifyCredentials(ctx context.Context, email, password string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal carried by ctx, if any.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
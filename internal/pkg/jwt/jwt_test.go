@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSigner(t *testing.T) {
+	t.Run("should reject short keys", func(t *testing.T) {
+		// When
+		_, err := NewSigner([]byte("short"), "freeradius-service")
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should round trip the claims", func(t *testing.T) {
+		// Given
+		signer, err := NewSigner(testKey, "freeradius-service")
+		require.NoError(t, err)
+		expiresAt := time.Now().Add(time.Minute).Unix()
+
+		// When
+		token, err := signer.Sign(Claims{Subject: "7", SessionID: "abc", ExpiresAt: expiresAt})
+		require.NoError(t, err)
+		claims, err := signer.Parse(token)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "freeradius-service", claims.Issuer)
+		assert.Equal(t, "7", claims.Subject)
+		assert.Equal(t, "abc", claims.SessionID)
+		assert.Equal(t, expiresAt, claims.ExpiresAt)
+		assert.NotZero(t, claims.IssuedAt)
+	})
+
+	t.Run("should reject expired tokens", func(t *testing.T) {
+		// Given
+		signer, err := NewSigner(testKey, "freeradius-service")
+		require.NoError(t, err)
+		token, err := signer.Sign(Claims{Subject: "7", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+		require.NoError(t, err)
+		signer.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+		// When
+		_, err = signer.Parse(token)
+
+		// Then
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+
+	t.Run("should reject tampered tokens and other keys", func(t *testing.T) {
+		// Given
+		signer, err := NewSigner(testKey, "freeradius-service")
+		require.NoError(t, err)
+		other, err := NewSigner([]byte("fedcba9876543210fedcba9876543210"), "freeradius-service")
+		require.NoError(t, err)
+		token, err := signer.Sign(Claims{Subject: "7", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+		require.NoError(t, err)
+
+		forged, err := signer.Sign(Claims{Subject: "1", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+		require.NoError(t, err)
+		parts := strings.Split(token, ".")
+		tampered := parts[0] + "." + strings.Split(forged, ".")[1] + "." + parts[2]
+
+		// When
+		_, tamperedErr := signer.Parse(tampered)
+		_, otherErr := other.Parse(token)
+
+		// Then
+		assert.ErrorIs(t, tamperedErr, ErrInvalidSignature)
+		assert.ErrorIs(t, otherErr, ErrInvalidSignature)
+	})
+
+	t.Run("should reject other algorithms and issuers", func(t *testing.T) {
+		// Given
+		signer, err := NewSigner(testKey, "freeradius-service")
+		require.NoError(t, err)
+		other, err := NewSigner(testKey, "someone-else")
+		require.NoError(t, err)
+		token, err := other.Sign(Claims{Subject: "7", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+		require.NoError(t, err)
+		unsigned := encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + strings.Split(token, ".")[1] + "."
+
+		// When
+		_, issuerErr := signer.Parse(token)
+		_, noneErr := signer.Parse(unsigned)
+
+		// Then
+		assert.ErrorIs(t, issuerErr, ErrInvalidIssuer)
+		assert.ErrorIs(t, noneErr, ErrMalformed)
+	})
+}
@@ -0,0 +1,268 @@
+// Package listquery translates the filter and sort parameters of lists into
+// SQL. Every list whitelists the fields it takes, and one grammar applies to
+// all of them:
+//
+//	username=alice                exact
+//	username=prefix:ali           starts with
+//	username=contains:lic         contains
+//	status=in:pending,failed      one of, at most 100 values
+//	amount=10..100                between, inclusive; either end may be left out
+//	created_at=2026-01-01..2026-01-31
+//	sort=-created_at,username     ascending, or descending with a leading -
+//
+// Dates stand for whole days in UTC and times are RFC 3339. Prefix a value
+// with eq: when it starts with the name of an operator. Columns come from the
+// whitelist and values are bound, so no parameter reaches the SQL as text.
+package listquery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxValues bounds the values of an in: list.
+const maxValues = 100
+
+// Kind is the type of a field, which decides the operators it takes.
+type Kind int
+
+const (
+	// Text fields take exact, prefix:, contains: and in: values.
+	Text Kind = iota
+	// Number fields take exact, in: and range values.
+	Number
+	// Time fields take exact and range values.
+	Time
+)
+
+// Field is a field of a list.
+type Field struct {
+	Column   string
+	Kind     Kind
+	Sortable bool
+	// Qualify, when set, rewrites the values compared for equality, such as
+	// usernames into the realm of the tenant.
+	Qualify func(ctx context.Context, value string) (string, error)
+}
+
+// Fields whitelists the fields of a list by parameter name.
+type Fields map[string]Field
+
+// Where limits query to the rows matching values, the filter expressions by
+// parameter name. Empty expressions are skipped.
+func (f Fields) Where(ctx context.Context, query *gorm.DB, values map[string]string) (*gorm.DB, error) {
+	names := make([]string, 0, len(values))
+	for name, expr := range values {
+		if expr != "" {
+			names = append(names, name)
+		}
+	}
+	// A stable order keeps the statements, and their plans, cacheable
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, ok := f[name]
+		if !ok {
+			return nil, fmt.Errorf("listquery: %s is not a field of the list", name)
+		}
+		condition, err := field.condition(ctx, name, values[name])
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition)
+	}
+	return query, nil
+}
+
+// Order parses sort, a comma separated list of sortable fields, each
+// descending with a leading -. An empty sort returns fallback.
+func (f Fields) Order(sort string, fallback pagination.Order) (pagination.Order, error) {
+	if sort == "" {
+		return fallback, nil
+	}
+	var order pagination.Order
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(sort, ",") {
+		name = strings.TrimSpace(name)
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		field, ok := f[name]
+		if !ok || !field.Sortable {
+			return nil, apperror.InvalidField("sort", fmt.Sprintf("cannot sort by %q", name))
+		}
+		if seen[name] {
+			return nil, apperror.InvalidField("sort", fmt.Sprintf("sorts by %s twice", name))
+		}
+		seen[name] = true
+		order = append(order, pagination.Key{Column: field.Column, Desc: desc})
+	}
+	return order, nil
+}
+
+// parse splits expr into its operator and argument.
+func (f Field) parse(expr string) (string, string) {
+	if op, arg, ok := strings.Cut(expr, ":"); ok {
+		switch op {
+		case "eq", "prefix", "contains", "in":
+			return op, arg
+		}
+	}
+	if f.Kind != Text && strings.Contains(expr, "..") {
+		return "range", expr
+	}
+	return "eq", expr
+}
+
+func (f Field) condition(ctx context.Context, name, expr string) (clause.Expression, error) {
+	column := clause.Column{Name: f.Column}
+	op, arg := f.parse(expr)
+	switch {
+	case op == "eq":
+		if f.Kind == Time {
+			return f.day(name, arg)
+		}
+		value, err := f.value(ctx, name, arg)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Eq{Column: column, Value: value}, nil
+	case op == "in" && f.Kind != Time:
+		args := strings.Split(arg, ",")
+		if len(args) > maxValues {
+			return nil, apperror.InvalidField(name, fmt.Sprintf("takes at most %d values", maxValues))
+		}
+		values := make([]interface{}, len(args))
+		for i, a := range args {
+			value, err := f.value(ctx, name, a)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return clause.IN{Column: column, Values: values}, nil
+	case (op == "prefix" || op == "contains") && f.Kind == Text:
+		pattern := EscapeLike(arg) + "%"
+		if op == "contains" {
+			pattern = "%" + pattern
+		}
+		// ! rather than \ escapes, as MySQL reads \ in string literals
+		return clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []interface{}{column, pattern}}, nil
+	case op == "range":
+		return f.between(name, arg)
+	default:
+		return nil, apperror.InvalidField(name, fmt.Sprintf("cannot be filtered by %s:", op))
+	}
+}
+
+// value parses a value compared for equality.
+func (f Field) value(ctx context.Context, name, arg string) (interface{}, error) {
+	switch f.Kind {
+	case Number:
+		return number(name, arg)
+	default:
+		if f.Qualify == nil {
+			return arg, nil
+		}
+		return f.Qualify(ctx, arg)
+	}
+}
+
+// day matches the day of a date, or the instant of a time.
+func (f Field) day(name, arg string) (clause.Expression, error) {
+	at, date, err := moment(name, arg)
+	if err != nil {
+		return nil, err
+	}
+	column := clause.Column{Name: f.Column}
+	if !date {
+		return clause.Eq{Column: column, Value: at}, nil
+	}
+	return clause.And(clause.Gte{Column: column, Value: at}, clause.Lt{Column: column, Value: at.AddDate(0, 0, 1)}), nil
+}
+
+// between matches a range of numbers or times, including both ends. The end
+// of a range of dates is the end of its day.
+func (f Field) between(name, arg string) (clause.Expression, error) {
+	from, to, _ := strings.Cut(arg, "..")
+	if from == "" && to == "" {
+		return nil, apperror.InvalidField(name, "needs at least one end of the range")
+	}
+	column := clause.Column{Name: f.Column}
+	var conditions []clause.Expression
+	if from != "" {
+		value, err := f.bound(name, from)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, clause.Gte{Column: column, Value: value})
+	}
+	if to != "" {
+		if f.Kind == Time {
+			at, date, err := moment(name, to)
+			if err != nil {
+				return nil, err
+			}
+			if date {
+				conditions = append(conditions, clause.Lt{Column: column, Value: at.AddDate(0, 0, 1)})
+			} else {
+				conditions = append(conditions, clause.Lte{Column: column, Value: at})
+			}
+		} else {
+			value, err := number(name, to)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, clause.Lte{Column: column, Value: value})
+		}
+	}
+	return clause.And(conditions...), nil
+}
+
+func (f Field) bound(name, arg string) (interface{}, error) {
+	if f.Kind == Time {
+		at, _, err := moment(name, arg)
+		return at, err
+	}
+	return number(name, arg)
+}
+
+// number parses arg as an integer, or else a decimal, so that integer
+// columns are not compared with floats.
+func number(name, arg string) (interface{}, error) {
+	if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return n, nil
+	}
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return nil, apperror.InvalidField(name, fmt.Sprintf("%q is not a number", arg))
+	}
+	return n, nil
+}
+
+// moment parses arg as an RFC 3339 time or a date, reporting which.
+func moment(name, arg string) (time.Time, bool, error) {
+	if at, err := time.Parse(time.DateOnly, arg); err == nil {
+		return at, true, nil
+	}
+	at, err := time.Parse(time.RFC3339, arg)
+	if err != nil {
+		return time.Time{}, false, apperror.InvalidField(name, fmt.Sprintf("%q is neither a date nor an RFC 3339 time", arg))
+	}
+	return at.UTC(), false, nil
+}
+
+// EscapeLike escapes the wildcards of LIKE in s, for patterns compared with
+// ESCAPE '!'.
+func EscapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,142 @@
+package listquery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	paymentEntity "github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+)
+
+var fields = listquery.Fields{
+	"id":          {Column: "id", Kind: listquery.Number, Sortable: true},
+	"status":      {Column: "status", Kind: listquery.Text, Sortable: true},
+	"currency":    {Column: "currency", Kind: listquery.Text, Sortable: true},
+	"description": {Column: "description", Kind: listquery.Text},
+	"amount":      {Column: "amount", Kind: listquery.Number, Sortable: true},
+	"created_at":  {Column: "created_at", Kind: listquery.Time, Sortable: true},
+}
+
+func TestFields(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	payments := []paymentEntity.Payment{
+		{Amount: 10, Currency: "USD", Status: "pending", Description: "100% off", CreatedAt: day},
+		{Amount: 25.5, Currency: "USD", Status: "completed", Description: "monthly plan", CreatedAt: day.AddDate(0, 0, 1)},
+		{Amount: 50, Currency: "EUR", Status: "failed", Description: "monthly_plan", CreatedAt: day.AddDate(0, 0, 2)},
+		{Amount: 50, Currency: "IDR", Status: "completed", Description: "yearly plan", CreatedAt: day.AddDate(0, 0, 3)},
+	}
+	for i := range payments {
+		payments[i].UserID = 1
+		require.NoError(t, db.Create(&payments[i]).Error)
+	}
+
+	find := func(values map[string]string, sort string) ([]uint, error) {
+		query, err := fields.Where(context.Background(), db.Model(&paymentEntity.Payment{}), values)
+		if err != nil {
+			return nil, err
+		}
+		order, err := fields.Order(sort, pagination.Order{})
+		if err != nil {
+			return nil, err
+		}
+		rows, _, err := pagination.Find[paymentEntity.Payment](query, pagination.Params{}, 1, 10, order)
+		ids := make([]uint, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
+		return ids, err
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		sort   string
+		ids    []uint
+	}{
+		{name: "exact", values: map[string]string{"currency": "USD"}, ids: []uint{1, 2}},
+		{name: "exact number", values: map[string]string{"amount": "25.5"}, ids: []uint{2}},
+		{name: "prefix", values: map[string]string{"description": "prefix:monthly"}, ids: []uint{2, 3}},
+		{name: "contains", values: map[string]string{"description": "contains:plan"}, ids: []uint{2, 3, 4}},
+		{name: "wildcards match themselves", values: map[string]string{"description": "contains:_"}, ids: []uint{3}},
+		{name: "percent matches itself", values: map[string]string{"description": "contains:%"}, ids: []uint{1}},
+		{name: "in list", values: map[string]string{"status": "in:pending,failed"}, ids: []uint{1, 3}},
+		{name: "range", values: map[string]string{"amount": "20..50"}, ids: []uint{2, 3, 4}},
+		{name: "open range", values: map[string]string{"amount": "..25.5"}, ids: []uint{1, 2}},
+		{name: "date", values: map[string]string{"created_at": "2026-03-02"}, ids: []uint{2}},
+		{name: "date range includes its last day", values: map[string]string{"created_at": "2026-03-02..2026-03-03"}, ids: []uint{2, 3}},
+		{name: "time range", values: map[string]string{"created_at": "2026-03-02T12:00:01Z.."}, ids: []uint{3, 4}},
+		{name: "combined", values: map[string]string{"status": "completed", "amount": "30.."}, ids: []uint{4}},
+		{name: "sorted", sort: "-amount,currency", ids: []uint{3, 4, 2, 1}},
+		{name: "no match", values: map[string]string{"currency": "' OR 1=1 --"}, ids: []uint{}},
+	}
+	for _, tt := range tests {
+		t.Run("should filter by "+tt.name, func(t *testing.T) {
+			// When
+			ids, err := find(tt.values, tt.sort)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+
+	t.Run("should page through a sort by cursor", func(t *testing.T) {
+		// Setup
+		order, err := fields.Order("-amount,currency", pagination.Order{})
+		require.NoError(t, err)
+		var ids []uint
+		params := pagination.Params{}
+
+		// When
+		for pages := 0; pages < 5; pages++ {
+			rows, page, err := pagination.Find[paymentEntity.Payment](db.Model(&paymentEntity.Payment{}), params, 1, 1, order)
+			require.NoError(t, err)
+			for _, row := range rows {
+				ids = append(ids, row.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+
+		// Then
+		assert.Equal(t, []uint{3, 4, 2, 1}, ids)
+	})
+
+	invalid := []struct {
+		name   string
+		values map[string]string
+		sort   string
+		field  string
+	}{
+		{name: "a number", values: map[string]string{"amount": "ten"}, field: "amount"},
+		{name: "a time", values: map[string]string{"created_at": "yesterday.."}, field: "created_at"},
+		{name: "an empty range", values: map[string]string{"amount": ".."}, field: "amount"},
+		{name: "an operator of another kind", values: map[string]string{"amount": "prefix:1"}, field: "amount"},
+		{name: "an unsortable field", sort: "description", field: "sort"},
+		{name: "an unknown field", sort: "secret", field: "sort"},
+		{name: "a field sorted twice", sort: "amount,-amount", field: "sort"},
+	}
+	for _, tt := range invalid {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			// When
+			_, err := find(tt.values, tt.sort)
+
+			// Then
+			assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+			require.Len(t, apperror.FieldsOf(err), 1)
+			assert.Equal(t, tt.field, apperror.FieldsOf(err)[0].Field)
+		})
+	}
+}
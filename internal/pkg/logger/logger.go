@@ -1,7 +1,10 @@
 package logger
 
 import (
+	"context"
+
 	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -33,3 +36,13 @@ func NewLogger(cfg *config.Config) (*zap.Logger, error) {
 
 	return logger, nil
 }
+
+// For returns l tagged with the request ID of ctx, so that the lines logged
+// while serving a call or task can be told apart. It returns l unchanged
+// when ctx carries no request ID.
+func For(ctx context.Context, l *zap.Logger) *zap.Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return l.With(zap.String("request_id", id))
+	}
+	return l
+}
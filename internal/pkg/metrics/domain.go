@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// domainQueryTimeout bounds the queries of a scrape.
+const domainQueryTimeout = 5 * time.Second
+
+var (
+	subscribersDesc = prometheus.NewDesc(namespace+"_subscribers",
+		"Usernames with at least one radcheck attribute.", nil, nil)
+	activeSessionsDesc = prometheus.NewDesc(namespace+"_active_sessions",
+		"Accounting sessions whose last event is not a stop.", nil, nil)
+	paymentsDesc = prometheus.NewDesc(namespace+"_payments",
+		"Payments by status.", []string{"status"}, nil)
+	nasDesc = prometheus.NewDesc(namespace+"_nas",
+		"Health checked NAS entries by health status: up, down or unknown.", []string{"status"}, nil)
+)
+
+type countByStatus struct {
+	Status string
+	Count  int64
+}
+
+type domainStats struct {
+	subscribers    int64
+	activeSessions int64
+	payments       []countByStatus
+	nas            []countByStatus
+}
+
+// Domain gauges the RADIUS data of every tenant: subscribers, active
+// sessions, payments and NAS health. The counts are queried on scrape and
+// reused for refresh, so that frequent scrapes of several Prometheus servers
+// do not load the database.
+type Domain struct {
+	db      *gorm.DB
+	refresh time.Duration
+	logger  *zap.Logger
+
+	mu      sync.Mutex
+	stats   *domainStats
+	fetched time.Time
+	now     func() time.Time
+}
+
+func NewDomain(db *gorm.DB, refresh time.Duration, logger *zap.Logger) *Domain {
+	return &Domain{db: db, refresh: refresh, logger: logger, now: time.Now}
+}
+
+func (d *Domain) Describe(ch chan<- *prometheus.Desc) {
+	ch <- subscribersDesc
+	ch <- activeSessionsDesc
+	ch <- paymentsDesc
+	ch <- nasDesc
+}
+
+func (d *Domain) Collect(ch chan<- prometheus.Metric) {
+	stats, err := d.load()
+	if err != nil {
+		d.logger.Error("Failed to collect domain metrics", zap.Error(err))
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(subscribersDesc, prometheus.GaugeValue, float64(stats.subscribers))
+	ch <- prometheus.MustNewConstMetric(activeSessionsDesc, prometheus.GaugeValue, float64(stats.activeSessions))
+	for _, row := range stats.payments {
+		ch <- prometheus.MustNewConstMetric(paymentsDesc, prometheus.GaugeValue, float64(row.Count), row.Status)
+	}
+	for _, row := range stats.nas {
+		ch <- prometheus.MustNewConstMetric(nasDesc, prometheus.GaugeValue, float64(row.Count), row.Status)
+	}
+}
+
+// load returns the cached counts, querying them again once refresh passed.
+func (d *Domain) load() (*domainStats, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stats != nil && d.now().Sub(d.fetched) < d.refresh {
+		return d.stats, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), domainQueryTimeout)
+	defer cancel()
+	db := d.db.WithContext(ctx)
+
+	var stats domainStats
+	if err := db.Table("radcheck").Distinct("username").Count(&stats.subscribers).Error; err != nil {
+		return nil, err
+	}
+	lastEvents := db.Table("session_events").Select("MAX(id)").Group("acct_session_id")
+	if err := db.Table("session_events").
+		Where("id IN (?) AND event_type <> ?", lastEvents, "stop").
+		Count(&stats.activeSessions).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("payments").Select("status, COUNT(*) AS count").
+		Where("deleted_at IS NULL").Group("status").Scan(&stats.payments).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("nas").Select("health_status AS status, COUNT(*) AS count").
+		Where("deleted_at IS NULL AND health_check = ?", true).Group("health_status").Scan(&stats.nas).Error; err != nil {
+		return nil, err
+	}
+
+	d.stats, d.fetched = &stats, d.now()
+	return d.stats, nil
+}
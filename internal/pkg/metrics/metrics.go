@@ -0,0 +1,78 @@
+// Package metrics exposes Prometheus metrics: request latency of the REST
+// and gRPC APIs, asynq task outcomes, database pool statistics and gauges of
+// the RADIUS data. Every binary has its own registry, served at /metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const namespace = "freeradius_service"
+
+// Path is where every binary serves its metrics.
+const Path = "/metrics"
+
+// NewRegistry returns the registry of a binary, holding the Go runtime,
+// process and database pool collectors.
+func NewRegistry(db *gorm.DB) (*prometheus.Registry, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(sqlDB, db.Migrator().CurrentDatabase()),
+	)
+	return registry, nil
+}
+
+// Handler serves the metrics of registry.
+func Handler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// Serve serves the metrics of registry at Path on address, for binaries
+// without an HTTP server of their own. An empty address serves nothing.
+func Serve(lc fx.Lifecycle, registry *prometheus.Registry, address string, logger *zap.Logger) {
+	if address == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler(registry))
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("metrics: listen on %s: %w", address, err)
+			}
+			logger.Info("Serving metrics", zap.String("addr", listener.Addr().String()))
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("Metrics server failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	paymentEntity "github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	sessionEntity "github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_Observe(t *testing.T) {
+	// Setup
+	m := NewHTTP(prometheus.NewRegistry())
+
+	// When
+	m.Observe("GET", "/api/v1/nas/:id", 200, 30*time.Millisecond)
+	m.Observe("GET", "/api/v1/nas/:id", 200, 40*time.Millisecond)
+	m.Observe("GET", "", 404, time.Millisecond)
+
+	// Then
+	assert.Equal(t, 2, promtest.CollectAndCount(m.duration))
+	assert.True(t, m.duration.DeleteLabelValues("GET", "unmatched", "404"))
+}
+
+func TestTasks_Middleware(t *testing.T) {
+	// Setup
+	m := NewTasks(prometheus.NewRegistry())
+	failing := m.Middleware(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return errors.New("gateway timeout")
+	}))
+	succeeding := m.Middleware(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return nil
+	}))
+
+	// When
+	_ = failing.ProcessTask(context.Background(), asynq.NewTask("payment:process", nil))
+	_ = succeeding.ProcessTask(context.Background(), asynq.NewTask("payment:process", nil))
+
+	// Then
+	assert.Equal(t, 2.0, promtest.ToFloat64(m.processed.WithLabelValues("payment:process")))
+	assert.Equal(t, 1.0, promtest.ToFloat64(m.failed.WithLabelValues("payment:process")))
+	assert.Equal(t, 0.0, promtest.ToFloat64(m.retried.WithLabelValues("payment:process")))
+}
+
+func TestDomain_Collect(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, db.Create([]radcheckEntity.Radcheck{
+		{Username: "john", Attribute: "Cleartext-Password", Op: ":=", Value: "secret"},
+		{Username: "john", Attribute: "Simultaneous-Use", Op: ":=", Value: "1"},
+		{Username: "jane", Attribute: "Cleartext-Password", Op: ":=", Value: "secret"},
+	}).Error)
+	require.NoError(t, db.Create([]sessionEntity.SessionEvent{
+		{EventType: sessionEntity.EventTypeStart, AcctSessionID: "a", Username: "john", NASIPAddress: "192.0.2.1", EventTime: now},
+		{EventType: sessionEntity.EventTypeStart, AcctSessionID: "b", Username: "jane", NASIPAddress: "192.0.2.1", EventTime: now},
+		{EventType: sessionEntity.EventTypeInterim, AcctSessionID: "a", Username: "john", NASIPAddress: "192.0.2.1", EventTime: now},
+		{EventType: sessionEntity.EventTypeStop, AcctSessionID: "b", Username: "jane", NASIPAddress: "192.0.2.1", EventTime: now},
+	}).Error)
+	require.NoError(t, db.Create([]paymentEntity.Payment{
+		{Amount: 10, Currency: "USD", Status: paymentEntity.PaymentStatusPending, UserID: 1},
+		{Amount: 10, Currency: "USD", Status: paymentEntity.PaymentStatusCompleted, UserID: 1},
+		{Amount: 10, Currency: "USD", Status: paymentEntity.PaymentStatusCompleted, UserID: 1},
+	}).Error)
+	require.NoError(t, db.Create([]nasEntity.NAS{
+		{NASName: "192.0.2.1", ShortName: "edge-1", Secret: "s", HealthCheck: true, HealthStatus: nasEntity.HealthStatusUp},
+		{NASName: "192.0.2.2", ShortName: "edge-2", Secret: "s", HealthCheck: true, HealthStatus: nasEntity.HealthStatusDown},
+		{NASName: "192.0.2.3", ShortName: "edge-3", Secret: "s", HealthStatus: nasEntity.HealthStatusUnknown},
+	}).Error)
+	domain := NewDomain(db, time.Minute, testutil.NewSilentLogger())
+
+	// When
+	err = promtest.CollectAndCompare(domain, strings.NewReader(`
+# HELP freeradius_service_active_sessions Accounting sessions whose last event is not a stop.
+# TYPE freeradius_service_active_sessions gauge
+freeradius_service_active_sessions 1
+# HELP freeradius_service_nas Health checked NAS entries by health status: up, down or unknown.
+# TYPE freeradius_service_nas gauge
+freeradius_service_nas{status="down"} 1
+freeradius_service_nas{status="up"} 1
+# HELP freeradius_service_payments Payments by status.
+# TYPE freeradius_service_payments gauge
+freeradius_service_payments{status="completed"} 2
+freeradius_service_payments{status="pending"} 1
+# HELP freeradius_service_subscribers Usernames with at least one radcheck attribute.
+# TYPE freeradius_service_subscribers gauge
+freeradius_service_subscribers 2
+`))
+
+	// Then
+	assert.NoError(t, err)
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTP records the latency of REST requests by method, route and status.
+type HTTP struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewHTTP(registry *prometheus.Registry) *HTTP {
+	m := &HTTP{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of REST requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+	registry.MustRegister(m.duration)
+	return m
+}
+
+// Observe records a request to route, the path pattern it matched, so that
+// IDs in paths do not multiply the series.
+func (m *HTTP) Observe(method, route string, status int, elapsed time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	m.duration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// GRPC records the latency of gRPC calls by method and status code.
+type GRPC struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewGRPC(registry *prometheus.Registry) *GRPC {
+	m := &GRPC{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "call_duration_seconds",
+			Help:      "Latency of gRPC calls by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+	registry.MustRegister(m.duration)
+	return m
+}
+
+// Observe records a call of method, a full method name such as
+// "/nas.NASService/GetNAS", that ended with code.
+func (m *GRPC) Observe(method, code string, elapsed time.Duration) {
+	m.duration.WithLabelValues(method, code).Observe(elapsed.Seconds())
+}
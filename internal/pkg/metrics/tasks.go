@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Tasks counts the asynq tasks processed by the worker, by task type.
+type Tasks struct {
+	processed *prometheus.CounterVec
+	failed    *prometheus.CounterVec
+	retried   *prometheus.CounterVec
+}
+
+func NewTasks(registry *prometheus.Registry) *Tasks {
+	counter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "tasks",
+			Name:      name,
+			Help:      help,
+		}, []string{"type"})
+	}
+	m := &Tasks{
+		processed: counter("processed_total", "Tasks processed, successfully or not."),
+		failed:    counter("failed_total", "Tasks whose handler returned an error."),
+		retried:   counter("retried_total", "Tasks processed again after failing."),
+	}
+	registry.MustRegister(m.processed, m.failed, m.retried)
+	return m
+}
+
+// Middleware is task middleware counting every task the worker processes.
+func (m *Tasks) Middleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		if retried, _ := asynq.GetRetryCount(ctx); retried > 0 {
+			m.retried.WithLabelValues(task.Type()).Inc()
+		}
+		err := next.ProcessTask(ctx, task)
+		m.processed.WithLabelValues(task.Type()).Inc()
+		if err != nil {
+			m.failed.WithLabelValues(task.Type()).Inc()
+		}
+		return err
+	})
+}
@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrLocked is returned when another migrator holds the lock past the lock
+// timeout.
+var ErrLocked = errors.New("migrate: another migration holds the lock")
+
+// lockName names the MySQL lock; lockKey is the PostgreSQL advisory lock key,
+// the FNV-1a hash of the same name.
+const (
+	lockName = "freeradius-service:schema_migrations"
+	lockKey  = int64(5888247534432867472)
+)
+
+const lockPoll = 500 * time.Millisecond
+
+// lockRow is the row SQLite, which has no named locks, is locked with.
+type lockRow struct {
+	ID       int `gorm:"primaryKey;autoIncrement:false"`
+	LockedAt time.Time
+}
+
+func (lockRow) TableName() string {
+	return "schema_migrations_lock"
+}
+
+// lock takes the migrations lock, waiting up to timeout, and returns its
+// release. MySQL and PostgreSQL hold a session lock on a connection of its
+// own, so that it goes away with a killed migrator.
+func lock(ctx context.Context, db *gorm.DB, timeout time.Duration) (func() error, error) {
+	switch db.Dialector.Name() {
+	case "mysql":
+		return sessionLock(ctx, db, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			var acquired sql.NullInt64
+			err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(timeout.Seconds())).Scan(&acquired)
+			return acquired.Int64 == 1, err
+		}, func(conn *sql.Conn) error {
+			_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+			return err
+		})
+	case "postgres":
+		return sessionLock(ctx, db, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			return poll(ctx, timeout, func() (bool, error) {
+				var acquired bool
+				err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey).Scan(&acquired)
+				return acquired, err
+			})
+		}, func(conn *sql.Conn) error {
+			_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+			return err
+		})
+	default:
+		return rowLock(ctx, db, timeout)
+	}
+}
+
+func sessionLock(ctx context.Context, db *gorm.DB, acquire func(context.Context, *sql.Conn) (bool, error), release func(*sql.Conn) error) (func() error, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acquired, err := acquire(ctx, conn)
+	if err == nil && !acquired {
+		err = ErrLocked
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return func() error {
+		defer conn.Close()
+		return release(conn)
+	}, nil
+}
+
+func rowLock(ctx context.Context, db *gorm.DB, timeout time.Duration) (func() error, error) {
+	if err := createTable(db, &lockRow{}); err != nil {
+		return nil, err
+	}
+	var lastErr error
+	acquired, err := poll(ctx, timeout, func() (bool, error) {
+		lastErr = db.Create(&lockRow{ID: 1, LockedAt: time.Now().UTC()}).Error
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, fmt.Errorf("%w: %v", ErrLocked, lastErr)
+	}
+	return func() error {
+		return db.WithContext(context.Background()).Delete(&lockRow{}, 1).Error
+	}, nil
+}
+
+// ReleaseLock removes the SQLite lock row a killed migrator left behind,
+// once it was taken more than staleAfter ago: a younger one may belong to a
+// run still going, and is kept. MySQL and PostgreSQL release their session
+// locks with the connection of a killed migrator; there is nothing to remove.
+func (m *Migrator) ReleaseLock(ctx context.Context, staleAfter time.Duration) error {
+	db := m.db.WithContext(ctx)
+	switch db.Dialector.Name() {
+	case "mysql", "postgres":
+		return nil
+	}
+	if !db.Migrator().HasTable(&lockRow{}) {
+		return nil
+	}
+	var row lockRow
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", 1).Limit(1).Find(&row).Error; err != nil || row.ID == 0 {
+			return err
+		}
+		if age := time.Since(row.LockedAt); age < staleAfter {
+			return fmt.Errorf("%w: taken %s ago, not yet stale", ErrLocked, age.Round(time.Second))
+		}
+		return tx.Delete(&lockRow{}, 1).Error
+	})
+	if err != nil || row.ID == 0 {
+		return err
+	}
+	m.logger.Warn("Stale migrations lock released", zap.Time("locked_at", row.LockedAt))
+	return nil
+}
+
+// poll calls try until it succeeds, fails or timeout passes.
+func poll(ctx context.Context, timeout time.Duration, try func() (bool, error)) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		acquired, err := try()
+		if err != nil || acquired {
+			return acquired, err
+		}
+		if !time.Now().Before(deadline) {
+			return false, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(lockPoll):
+		}
+	}
+}
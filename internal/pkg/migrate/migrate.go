@@ -0,0 +1,490 @@
+// Package migrate applies versioned schema migrations. Migrations run in
+// order of version, each recorded in the schema_migrations table. A
+// migration that fails halfway on a database without transactional DDL, such
+// as MySQL, is left dirty: nothing runs until the schema is repaired by hand
+// and the version forced. A database lock keeps concurrent deploys from
+// applying a migration twice. The tables a migration creates are recorded,
+// so that its down drops those and not the tables it found in place.
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrDirty is returned while a migration is dirty.
+var ErrDirty = errors.New("migrate: a migration is dirty; repair the schema and force the version")
+
+// Migration changes the schema from the previous version to Version and
+// back.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// Status is the state of a migration known to the binary or recorded in the
+// database.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	Dirty     bool
+	AppliedAt *time.Time
+	// Unknown is set for versions recorded by another, newer, binary.
+	Unknown bool
+}
+
+// record is a row of schema_migrations. CreatedTables lists the tables the
+// up created, comma separated.
+type record struct {
+	Version       int64  `gorm:"primaryKey;autoIncrement:false"`
+	Name          string `gorm:"size:255;not null"`
+	Dirty         bool   `gorm:"not null"`
+	AppliedAt     time.Time
+	CreatedTables string `gorm:"type:text"`
+}
+
+func (record) TableName() string {
+	return "schema_migrations"
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the SQL migrations of fsys, files named like
+// 0001_create_users.up.sql and 0001_create_users.down.sql. Statements end
+// with a semicolon at the end of a line. A version without a down file is
+// reverted by DropCreated.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrate: version %d is both %s and %s", version, m.Name, match[2])
+		}
+
+		b, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		statements := split(string(b))
+		if match[3] == "up" {
+			m.Up = exec(statements)
+		} else {
+			m.Down = exec(statements)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migrate: version %d has no up file", m.Version)
+		}
+		if m.Down == nil {
+			m.Down = DropCreated
+		}
+		migrations = append(migrations, *m)
+	}
+	return migrations, nil
+}
+
+// split splits a file into its statements, dropping comment lines.
+func split(sql string) []string {
+	var statements []string
+	var statement strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(sql))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if strings.HasSuffix(line, ";") {
+			statements = append(statements, strings.TrimSpace(statement.String()))
+			statement.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(statement.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+func exec(statements []string) func(*gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("%w, running %s", err, statement)
+			}
+		}
+		return nil
+	}
+}
+
+// createdKey is the setting a down finds the tables its up created under.
+const createdKey = "migrate:created_tables"
+
+// DropCreated is the down of a migration that creates tables: it drops the
+// tables its up created, and none it found in place, such as
+// the tables of FreeRADIUS or of a schema older than the migrations. A
+// version forced or applied before tables were recorded drops none.
+func DropCreated(tx *gorm.DB) error {
+	value, _ := tx.Get(createdKey)
+	tables, _ := value.([]string)
+	for i := len(tables) - 1; i >= 0; i-- {
+		if err := tx.Migrator().DropTable(tables[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrator applies migrations to a database.
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []Migration
+	logger      *zap.Logger
+	lockTimeout time.Duration
+}
+
+// New returns a migrator of migrations, which waits up to lockTimeout for
+// the migrations lock.
+func New(db *gorm.DB, migrations []Migration, lockTimeout time.Duration, logger *zap.Logger) (*Migrator, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return nil, fmt.Errorf("migrate: version %d is both %s and %s", sorted[i].Version, sorted[i-1].Name, sorted[i].Name)
+		}
+	}
+	return &Migrator{db: db, migrations: sorted, logger: logger, lockTimeout: lockTimeout}, nil
+}
+
+// Up applies the pending migrations and returns how many it applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.locked(ctx, func(db *gorm.DB, records map[int64]record) error {
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(db, migration, true); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations and returns how many it
+// reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.locked(ctx, func(db *gorm.DB, records map[int64]record) error {
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(db, migration, false); err != nil {
+				return err
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force records the migrations up to version as applied, and the later ones
+// as not, without running any. It clears a dirty state once the schema was
+// repaired by hand, and baselines databases whose schema predates the
+// migrations. It holds the migrations lock, like Up and Down.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.withLock(ctx, func(db *gorm.DB) error {
+		return m.force(db, version)
+	})
+}
+
+func (m *Migrator) force(db *gorm.DB, version int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version > ?", version).Delete(&record{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&record{}).Where("dirty = ?", true).Update("dirty", false).Error; err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			var count int64
+			if err := tx.Model(&record{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			r := record{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}
+			if err := tx.Create(&r).Error; err != nil {
+				return err
+			}
+		}
+		m.logger.Warn("Migration version forced", zap.Int64("version", version))
+		return nil
+	})
+}
+
+// Status returns the state of every migration, by version.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTable(db); err != nil {
+		return nil, err
+	}
+	records, err := m.records(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if r, ok := records[migration.Version]; ok {
+			appliedAt := r.AppliedAt
+			status.Applied, status.Dirty, status.AppliedAt = true, r.Dirty, &appliedAt
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, r := range records {
+		appliedAt := r.AppliedAt
+		statuses = append(statuses, Status{
+			Version: r.Version, Name: r.Name, Applied: true, Dirty: r.Dirty, AppliedAt: &appliedAt, Unknown: true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// locked runs fn holding the migrations lock, with the recorded migrations,
+// refusing to while one is dirty.
+func (m *Migrator) locked(ctx context.Context, fn func(*gorm.DB, map[int64]record) error) error {
+	return m.withLock(ctx, func(db *gorm.DB) error {
+		records, err := m.records(db)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			if r.Dirty {
+				return fmt.Errorf("%w: version %d (%s)", ErrDirty, r.Version, r.Name)
+			}
+		}
+		return fn(db, records)
+	})
+}
+
+// withLock runs fn holding the migrations lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(*gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTable(db); err != nil {
+		return err
+	}
+	unlock, err := lock(ctx, db, m.lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			m.logger.Error("Failed to release the migrations lock", zap.Error(err))
+		}
+	}()
+	return fn(db)
+}
+
+// apply runs a migration up or down and records it. Where DDL is
+// transactional the migration and its record commit together; elsewhere the
+// record is dirty until the migration succeeded.
+func (m *Migrator) apply(db *gorm.DB, migration Migration, up bool) error {
+	direction, run := "up", migration.Up
+	if !up {
+		direction, run = "down", migration.Down
+	}
+	log := m.logger.With(zap.Int64("version", migration.Version), zap.String("name", migration.Name), zap.String("direction", direction))
+	log.Info("Applying migration")
+	started := time.Now()
+
+	r := record{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}
+	var before []string
+	execute := func(tx *gorm.DB) error {
+		if !up {
+			var recorded record
+			if err := tx.First(&recorded, migration.Version).Error; err != nil {
+				return err
+			}
+			return run(tx.Set(createdKey, splitTables(recorded.CreatedTables)))
+		}
+		var err error
+		if before, err = tables(tx); err != nil {
+			return err
+		}
+		return run(tx)
+	}
+	finish := func(tx *gorm.DB) error {
+		if !up {
+			return tx.Delete(&record{}, migration.Version).Error
+		}
+		after, err := tables(tx)
+		if err != nil {
+			return err
+		}
+		r.CreatedTables = strings.Join(created(before, after), ",")
+		return tx.Save(&r).Error
+	}
+
+	var err error
+	if transactionalDDL(db) {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := execute(tx); err != nil {
+				return err
+			}
+			return finish(tx)
+		})
+	} else {
+		if up {
+			r.Dirty = true
+			err = db.Save(&r).Error
+		} else {
+			err = db.Model(&record{}).Where("version = ?", migration.Version).Update("dirty", true).Error
+		}
+		if err != nil {
+			return err
+		}
+		if err = execute(db); err == nil {
+			r.Dirty = false
+			err = finish(db)
+		}
+	}
+	if err != nil {
+		log.Error("Migration failed", zap.Error(err))
+		return fmt.Errorf("migration %d (%s) %s: %w", migration.Version, migration.Name, direction, err)
+	}
+	log.Info("Migration applied", zap.Duration("duration", time.Since(started)))
+	return nil
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	if err := createTable(db, &record{}); err != nil {
+		return err
+	}
+	// schema_migrations of a release that did not record created tables
+	if db.Migrator().HasColumn(&record{}, "CreatedTables") {
+		return nil
+	}
+	if err := db.Migrator().AddColumn(&record{}, "CreatedTables"); err != nil && !db.Migrator().HasColumn(&record{}, "CreatedTables") {
+		return err
+	}
+	return nil
+}
+
+// tables lists the tables of db, but for those of the migrator and the
+// database itself.
+func tables(db *gorm.DB) ([]string, error) {
+	names, err := db.Migrator().GetTables()
+	if err != nil {
+		return nil, err
+	}
+	own := []string{record{}.TableName(), lockRow{}.TableName()}
+	kept := names[:0]
+	for _, name := range names {
+		if !strings.HasPrefix(name, "sqlite_") && !contains(own, name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept, nil
+}
+
+// created returns the tables of after missing from before.
+func created(before, after []string) []string {
+	var tables []string
+	for _, name := range after {
+		if !contains(before, name) {
+			tables = append(tables, name)
+		}
+	}
+	return tables
+}
+
+func splitTables(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// createTable creates the table of model unless it exists, tolerating a
+// concurrent migrator creating it first.
+func createTable(db *gorm.DB, model interface{}) error {
+	if db.Migrator().HasTable(model) {
+		return nil
+	}
+	if err := db.Migrator().CreateTable(model); err != nil && !db.Migrator().HasTable(model) {
+		return err
+	}
+	return nil
+}
+
+func (m *Migrator) records(db *gorm.DB) (map[int64]record, error) {
+	var rows []record
+	if err := db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	records := make(map[int64]record, len(rows))
+	for _, r := range rows {
+		records[r.Version] = r
+	}
+	return records, nil
+}
+
+// transactionalDDL reports whether schema changes of db roll back with their
+// transaction. MySQL commits before every DDL statement.
+func transactionalDDL(db *gorm.DB) bool {
+	return db.Dialector.Name() != "mysql"
+}
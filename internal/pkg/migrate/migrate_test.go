@@ -0,0 +1,329 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/migrate"
+)
+
+var files = fstest.MapFS{
+	"0001_create_plans.up.sql": {Data: []byte(`-- Plans
+CREATE TABLE plans (
+  id INTEGER PRIMARY KEY,
+  name text NOT NULL DEFAULT 'basic;plan'
+);
+CREATE INDEX plans_name ON plans (name);
+`)},
+	"0001_create_plans.down.sql":  {Data: []byte("DROP TABLE plans;\n")},
+	"0002_create_quotas.up.sql":   {Data: []byte("CREATE TABLE quotas (id INTEGER PRIMARY KEY, octets integer);\n")},
+	"0002_create_quotas.down.sql": {Data: []byte("DROP TABLE quotas;\n")},
+	"README.md":                   {Data: []byte("not a migration")},
+}
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := "file:" + filepath.Join(t.TempDir(), "migrate.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db
+}
+
+func newMigrator(t *testing.T, db *gorm.DB, extra ...migrate.Migration) *migrate.Migrator {
+	t.Helper()
+	migrations, err := migrate.Load(files)
+	require.NoError(t, err)
+	migrator, err := migrate.New(db, append(migrations, extra...), time.Second, zap.NewNop())
+	require.NoError(t, err)
+	return migrator
+}
+
+func versions(t *testing.T, migrator *migrate.Migrator) map[int64]bool {
+	t.Helper()
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	applied := make(map[int64]bool)
+	for _, status := range statuses {
+		applied[status.Version] = status.Applied
+	}
+	return applied
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	t.Run("should apply pending migrations in order, once", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+
+		// When
+		applied, err := migrator.Up(context.Background())
+		again, againErr := migrator.Up(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		require.NoError(t, againErr)
+		assert.Equal(t, 2, applied)
+		assert.Equal(t, 0, again)
+		assert.True(t, db.Migrator().HasTable("plans"))
+		assert.True(t, db.Migrator().HasIndex("plans", "plans_name"))
+		assert.True(t, db.Migrator().HasTable("quotas"))
+		assert.Equal(t, map[int64]bool{1: true, 2: true}, versions(t, migrator))
+	})
+
+	t.Run("should revert the last migrations", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		_, err := migrator.Up(context.Background())
+		require.NoError(t, err)
+
+		// When
+		reverted, err := migrator.Down(context.Background(), 1)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+		assert.True(t, db.Migrator().HasTable("plans"))
+		assert.False(t, db.Migrator().HasTable("quotas"))
+		assert.Equal(t, map[int64]bool{1: true, 2: false}, versions(t, migrator))
+	})
+
+	t.Run("should drop only the tables a version created", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrations, err := migrate.Load(fstest.MapFS{
+			"0001_radius.up.sql": {Data: []byte(`CREATE TABLE IF NOT EXISTS radcheck (id INTEGER PRIMARY KEY);
+CREATE TABLE IF NOT EXISTS radacct (id INTEGER PRIMARY KEY AUTOINCREMENT);
+`)},
+		})
+		require.NoError(t, err)
+		migrator, err := migrate.New(db, migrations, time.Second, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("CREATE TABLE radcheck (id INTEGER PRIMARY KEY, username text)").Error)
+		_, err = migrator.Up(context.Background())
+		require.NoError(t, err)
+
+		// When
+		reverted, err := migrator.Down(context.Background(), 1)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+		assert.True(t, db.Migrator().HasTable("radcheck"))
+		assert.False(t, db.Migrator().HasTable("radacct"))
+		assert.Equal(t, map[int64]bool{1: false}, versions(t, migrator))
+	})
+
+	t.Run("should roll a failed migration back with its record", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		failing := migrate.Migration{
+			Version: 3,
+			Name:    "failing",
+			Up: func(tx *gorm.DB) error {
+				if err := tx.Exec("CREATE TABLE partial (id INTEGER PRIMARY KEY)").Error; err != nil {
+					return err
+				}
+				return errors.New("boom")
+			},
+			Down: func(tx *gorm.DB) error { return nil },
+		}
+		migrator := newMigrator(t, db, failing)
+
+		// When
+		applied, err := migrator.Up(context.Background())
+
+		// Then
+		require.Error(t, err)
+		assert.Equal(t, 2, applied)
+		assert.False(t, db.Migrator().HasTable("partial"))
+		assert.Equal(t, map[int64]bool{1: true, 2: true, 3: false}, versions(t, migrator))
+	})
+
+	t.Run("should let one of concurrent migrators apply each migration", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		var wg sync.WaitGroup
+		results := make([]int, 4)
+		errs := make([]error, 4)
+
+		// When
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = newMigrator(t, db).Up(context.Background())
+			}(i)
+		}
+		wg.Wait()
+
+		// Then
+		total := 0
+		for i := range results {
+			assert.NoError(t, errs[i])
+			total += results[i]
+		}
+		assert.Equal(t, 2, total)
+	})
+}
+
+func TestMigrator_Force(t *testing.T) {
+	t.Run("should refuse to migrate while a migration is dirty", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		_, err := migrator.Up(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("UPDATE schema_migrations SET dirty = ? WHERE version = 2", true).Error)
+
+		// When
+		_, upErr := migrator.Up(context.Background())
+		_, downErr := migrator.Down(context.Background(), 1)
+
+		// Then
+		assert.ErrorIs(t, upErr, migrate.ErrDirty)
+		assert.ErrorIs(t, downErr, migrate.ErrDirty)
+		statuses, err := migrator.Status(context.Background())
+		require.NoError(t, err)
+		assert.True(t, statuses[1].Dirty)
+	})
+
+	t.Run("should record versions without running them", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		_, err := migrator.Up(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("UPDATE schema_migrations SET dirty = ? WHERE version = 2", true).Error)
+
+		// When
+		err = migrator.Force(context.Background(), 1)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]bool{1: true, 2: false}, versions(t, migrator))
+		assert.True(t, db.Migrator().HasTable("quotas"))
+	})
+
+	t.Run("should baseline a database", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+
+		// When
+		err := migrator.Force(context.Background(), 1)
+		applied, upErr := migrator.Up(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		require.NoError(t, upErr)
+		assert.Equal(t, 1, applied)
+		assert.False(t, db.Migrator().HasTable("plans"))
+		assert.True(t, db.Migrator().HasTable("quotas"))
+	})
+
+	t.Run("should wait for the lock of another migrator", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		lockAt(t, db, time.Now().UTC())
+
+		// When
+		err := migrator.Force(context.Background(), 1)
+
+		// Then
+		assert.ErrorIs(t, err, migrate.ErrLocked)
+		assert.Equal(t, map[int64]bool{1: false, 2: false}, versions(t, migrator))
+	})
+}
+
+func lockAt(t *testing.T, db *gorm.DB, lockedAt time.Time) {
+	t.Helper()
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations_lock (id integer PRIMARY KEY, locked_at datetime)").Error)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations_lock (id, locked_at) VALUES (1, ?)", lockedAt).Error)
+}
+
+func TestMigrator_ReleaseLock(t *testing.T) {
+	t.Run("should release a stale lock", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		lockAt(t, db, time.Now().UTC().Add(-2*time.Hour))
+		_, lockedErr := migrator.Up(context.Background())
+
+		// When
+		err := migrator.ReleaseLock(context.Background(), time.Hour)
+		applied, upErr := migrator.Up(context.Background())
+
+		// Then
+		assert.ErrorIs(t, lockedErr, migrate.ErrLocked)
+		require.NoError(t, err)
+		require.NoError(t, upErr)
+		assert.Equal(t, 2, applied)
+	})
+
+	t.Run("should keep a lock taken recently", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+		lockAt(t, db, time.Now().UTC().Add(-time.Minute))
+
+		// When
+		err := migrator.ReleaseLock(context.Background(), time.Hour)
+
+		// Then
+		assert.ErrorIs(t, err, migrate.ErrLocked)
+		var count int64
+		require.NoError(t, db.Table("schema_migrations_lock").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should do nothing without a lock", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		migrator := newMigrator(t, db)
+
+		// When
+		err := migrator.ReleaseLock(context.Background(), time.Hour)
+
+		// Then
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should require the up of a version", func(t *testing.T) {
+		// Setup
+		fsys := fstest.MapFS{"0001_plans.down.sql": {Data: []byte("SELECT 1;\n")}}
+
+		// When
+		_, err := migrate.Load(fsys)
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject a version used twice", func(t *testing.T) {
+		// Setup
+		migrations, err := migrate.Load(files)
+		require.NoError(t, err)
+
+		// When
+		_, err = migrate.New(nil, append(migrations, migrate.Migration{Version: 2, Name: "other"}), time.Second, zap.NewNop())
+
+		// Then
+		assert.Error(t, err)
+	})
+}
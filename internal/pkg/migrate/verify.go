@@ -0,0 +1,249 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Kind is the family of column types a column is expected to be of, so that
+// one expectation holds across drivers.
+type Kind int
+
+const (
+	// Text is a character column.
+	Text Kind = iota
+	// Integer is an integer column of any width.
+	Integer
+	// Time is a date and time column.
+	Time
+	// Address is an IP address, inet on PostgreSQL and text elsewhere.
+	Address
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Integer:
+		return "integer"
+	case Time:
+		return "time"
+	case Address:
+		return "address"
+	default:
+		return "text"
+	}
+}
+
+// Table is the expected shape of a table.
+type Table struct {
+	Name       string
+	PrimaryKey string
+	Columns    []Column
+	Indexes    []Index
+}
+
+// Column is an expected column. Length is the least width of a character
+// column, checked where the database enforces widths.
+type Column struct {
+	Name   string
+	Kind   Kind
+	Length int64
+}
+
+// Index is an expected index: one whose leading columns are Columns
+// satisfies it. Drivers limits it to some drivers, all when empty.
+type Index struct {
+	Columns []string
+	Unique  bool
+	Drivers []string
+}
+
+// Drift is a difference between the database and the expected schema.
+type Drift struct {
+	Table   string
+	Object  string
+	Problem string
+}
+
+func (d Drift) String() string {
+	if d.Object == "" {
+		return d.Table + ": " + d.Problem
+	}
+	return d.Table + "." + d.Object + ": " + d.Problem
+}
+
+// Verify compares the tables of db with the expected ones. Columns and
+// indexes the database has beyond them are not drift.
+func Verify(ctx context.Context, db *gorm.DB, tables []Table) ([]Drift, error) {
+	db = db.WithContext(ctx)
+	driver := db.Dialector.Name()
+	var drifts []Drift
+	for _, table := range tables {
+		if !db.Migrator().HasTable(table.Name) {
+			drifts = append(drifts, Drift{Table: table.Name, Problem: "table is missing"})
+			continue
+		}
+
+		columnTypes, err := db.Migrator().ColumnTypes(table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read the columns of %s: %w", table.Name, err)
+		}
+		actual := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, columnType := range columnTypes {
+			actual[strings.ToLower(columnType.Name())] = columnType
+		}
+		for _, column := range table.Columns {
+			columnType, ok := actual[column.Name]
+			if !ok {
+				drifts = append(drifts, Drift{Table: table.Name, Object: column.Name, Problem: "column is missing"})
+				continue
+			}
+			if problem := compareColumn(driver, column, columnType); problem != "" {
+				drifts = append(drifts, Drift{Table: table.Name, Object: column.Name, Problem: problem})
+			}
+		}
+		if table.PrimaryKey != "" {
+			if columnType, ok := actual[table.PrimaryKey]; ok {
+				if primary, known := columnType.PrimaryKey(); known && !primary {
+					drifts = append(drifts, Drift{Table: table.Name, Object: table.PrimaryKey, Problem: "column is not the primary key"})
+				}
+			}
+		}
+
+		indexes, err := readIndexes(db, table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read the indexes of %s: %w", table.Name, err)
+		}
+		for _, index := range table.Indexes {
+			if !appliesTo(index, driver) || satisfied(index, indexes) {
+				continue
+			}
+			kind := "index"
+			if index.Unique {
+				kind = "unique index"
+			}
+			drifts = append(drifts, Drift{
+				Table:   table.Name,
+				Object:  "(" + strings.Join(index.Columns, ", ") + ")",
+				Problem: kind + " is missing",
+			})
+		}
+	}
+	return drifts, nil
+}
+
+func compareColumn(driver string, column Column, columnType gorm.ColumnType) string {
+	typeName := strings.ToLower(columnType.DatabaseTypeName())
+	if kind, ok := kindOf(typeName); !ok || !compatible(column.Kind, kind) {
+		return fmt.Sprintf("is %s, want %s", typeName, column.Kind)
+	}
+	if column.Length == 0 || driver == "sqlite" {
+		return ""
+	}
+	if length, ok := columnType.Length(); ok && length > 0 && length < column.Length {
+		return fmt.Sprintf("holds %d characters, want at least %d", length, column.Length)
+	}
+	return ""
+}
+
+func kindOf(typeName string) (Kind, bool) {
+	switch {
+	case strings.Contains(typeName, "inet"):
+		return Address, true
+	case strings.Contains(typeName, "char"), strings.Contains(typeName, "text"), strings.Contains(typeName, "clob"):
+		return Text, true
+	case strings.Contains(typeName, "time"), strings.Contains(typeName, "date"):
+		return Time, true
+	case strings.Contains(typeName, "int"), strings.Contains(typeName, "serial"):
+		return Integer, true
+	}
+	return 0, false
+}
+
+func compatible(want, got Kind) bool {
+	return want == got || want == Address && got == Text
+}
+
+func appliesTo(index Index, driver string) bool {
+	if len(index.Drivers) == 0 {
+		return true
+	}
+	for _, d := range index.Drivers {
+		if d == driver {
+			return true
+		}
+	}
+	return false
+}
+
+// actualIndex is an index of the database, its columns in order.
+type actualIndex struct {
+	unique  bool
+	columns []string
+}
+
+func satisfied(index Index, indexes []actualIndex) bool {
+	for _, actual := range indexes {
+		if index.Unique && !actual.unique || len(actual.columns) < len(index.Columns) {
+			continue
+		}
+		match := true
+		for i, column := range index.Columns {
+			if actual.columns[i] != column {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// indexQueries list the indexes of a table, a row per column in index order.
+// Primary keys and the indexes of unique constraints are included.
+var indexQueries = map[string]string{
+	"mysql": `SELECT index_name, non_unique = 0, column_name FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? ORDER BY index_name, seq_in_index`,
+	"postgres": `SELECT ci.relname, i.indisunique, a.attname FROM pg_index i
+		JOIN pg_class ct ON ct.oid = i.indrelid
+		JOIN pg_class ci ON ci.oid = i.indexrelid
+		JOIN pg_namespace n ON n.oid = ct.relnamespace
+		CROSS JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, position)
+		JOIN pg_attribute a ON a.attrelid = ct.oid AND a.attnum = k.attnum
+		WHERE ct.relname = ? AND n.nspname = current_schema() ORDER BY ci.relname, k.position`,
+	"sqlite": `SELECT il.name, il."unique", ii.name FROM pragma_index_list(?) il, pragma_index_info(il.name) ii
+		ORDER BY il.name, ii.seqno`,
+}
+
+func readIndexes(db *gorm.DB, table string) ([]actualIndex, error) {
+	query, ok := indexQueries[db.Dialector.Name()]
+	if !ok {
+		return nil, fmt.Errorf("migrate: cannot read the indexes of a %s database", db.Dialector.Name())
+	}
+	rows, err := db.Raw(query, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []actualIndex
+	last := ""
+	for rows.Next() {
+		var name, column string
+		var unique bool
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return nil, err
+		}
+		if name != last || len(indexes) == 0 {
+			indexes = append(indexes, actualIndex{unique: unique})
+			last = name
+		}
+		current := &indexes[len(indexes)-1]
+		current.columns = append(current.columns, strings.ToLower(column))
+	}
+	return indexes, rows.Err()
+}
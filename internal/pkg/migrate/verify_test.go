@@ -0,0 +1,82 @@
+package migrate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/migrate"
+)
+
+var expected = []migrate.Table{
+	{
+		Name:       "radacct",
+		PrimaryKey: "radacctid",
+		Columns: []migrate.Column{
+			{Name: "radacctid", Kind: migrate.Integer},
+			{Name: "acctuniqueid", Kind: migrate.Text, Length: 32},
+			{Name: "nasipaddress", Kind: migrate.Address, Length: 15},
+			{Name: "acctstarttime", Kind: migrate.Time},
+		},
+		Indexes: []migrate.Index{
+			{Columns: []string{"acctuniqueid"}, Unique: true},
+			{Columns: []string{"nasipaddress"}},
+			{Columns: []string{"acctstarttime"}, Drivers: []string{"postgres"}},
+		},
+	},
+	{Name: "nasreload", PrimaryKey: "nasipaddress"},
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("should find no drift in a matching schema", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		require.NoError(t, db.Exec(`CREATE TABLE radacct (
+  radacctid INTEGER PRIMARY KEY AUTOINCREMENT,
+  acctuniqueid varchar(32) NOT NULL default '',
+  nasipaddress varchar(15) NOT NULL default '',
+  acctstarttime datetime NULL default NULL,
+  class varchar(64)
+)`).Error)
+		require.NoError(t, db.Exec("CREATE UNIQUE INDEX acctuniqueid ON radacct (acctuniqueid)").Error)
+		require.NoError(t, db.Exec("CREATE INDEX nasipaddress ON radacct (nasipaddress, acctstarttime)").Error)
+		require.NoError(t, db.Exec("CREATE TABLE nasreload (nasipaddress varchar(15) PRIMARY KEY, reloadtime datetime NOT NULL)").Error)
+
+		// When
+		drifts, err := migrate.Verify(context.Background(), db, expected)
+
+		// Then
+		require.NoError(t, err)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("should report missing tables, columns and indexes and other types", func(t *testing.T) {
+		// Setup
+		db := setupDB(t)
+		require.NoError(t, db.Exec(`CREATE TABLE radacct (
+  radacctid INTEGER PRIMARY KEY AUTOINCREMENT,
+  acctuniqueid varchar(32) NOT NULL default '',
+  acctstarttime integer
+)`).Error)
+		require.NoError(t, db.Exec("CREATE INDEX acctuniqueid ON radacct (acctuniqueid)").Error)
+
+		// When
+		drifts, err := migrate.Verify(context.Background(), db, expected)
+
+		// Then
+		require.NoError(t, err)
+		var report []string
+		for _, drift := range drifts {
+			report = append(report, drift.String())
+		}
+		assert.Equal(t, []string{
+			"radacct.nasipaddress: column is missing",
+			"radacct.acctstarttime: is integer, want time",
+			"radacct.(acctuniqueid): unique index is missing",
+			"radacct.(nasipaddress): index is missing",
+			"nasreload: table is missing",
+		}, report)
+	})
+}
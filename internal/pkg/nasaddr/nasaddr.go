@@ -0,0 +1,177 @@
+package nasaddr
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+)
+
+// Kind tells what a nasname refers to.
+type Kind int
+
+const (
+	KindIP Kind = iota
+	KindNetwork
+	KindHostname
+)
+
+var (
+	ErrInvalidAddress = apperror.Validation("invalid nas address")
+	ErrUnresolvable   = apperror.Validation("nas hostname does not resolve")
+)
+
+// Address is a parsed nasname: a single IP, a CIDR network or a hostname.
+// Prefix is set for IPs (as a full-length prefix) and networks.
+type Address struct {
+	Kind     Kind
+	Prefix   netip.Prefix
+	Hostname string
+}
+
+// Resolver looks up the addresses of a hostname; *net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Parse checks the syntax of a nasname the way FreeRADIUS reads the ipaddr
+// of a client. Networks must not have host bits set, so that the stored
+// value is the one FreeRADIUS uses for matching.
+func Parse(nasname string) (Address, error) {
+	value := strings.TrimSpace(nasname)
+	if value == "" || value != nasname {
+		return Address{}, fmt.Errorf("%w %q", ErrInvalidAddress, nasname)
+	}
+
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return Address{}, fmt.Errorf("%w %q: not a valid CIDR prefix", ErrInvalidAddress, nasname)
+		}
+		if prefix.Addr().Zone() != "" {
+			return Address{}, fmt.Errorf("%w %q: zones are not supported", ErrInvalidAddress, nasname)
+		}
+		prefix = unmapPrefix(prefix)
+		if masked := prefix.Masked(); masked != prefix {
+			return Address{}, fmt.Errorf("%w %q: host bits set, use %s", ErrInvalidAddress, nasname, masked)
+		}
+		if prefix.IsSingleIP() {
+			return Address{Kind: KindIP, Prefix: prefix}, nil
+		}
+		return Address{Kind: KindNetwork, Prefix: prefix}, nil
+	}
+
+	if addr, err := netip.ParseAddr(value); err == nil {
+		if addr.Zone() != "" {
+			return Address{}, fmt.Errorf("%w %q: zones are not supported", ErrInvalidAddress, nasname)
+		}
+		addr = addr.Unmap()
+		return Address{Kind: KindIP, Prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+	}
+
+	if !IsHostname(value) {
+		return Address{}, fmt.Errorf("%w %q: not an IP address, CIDR prefix or hostname", ErrInvalidAddress, nasname)
+	}
+	return Address{Kind: KindHostname, Hostname: strings.ToLower(strings.TrimSuffix(value, "."))}, nil
+}
+
+// IsHostname reports whether name is a syntactically valid DNS name. Names
+// whose last label is numeric are rejected, so a mistyped IPv4 address such
+// as 10.0.0.256 is not taken for a hostname.
+func IsHostname(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			isAlnum := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+			if !isAlnum && r != '-' && r != '_' {
+				return false
+			}
+		}
+	}
+
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
+
+// Resolve returns the prefixes an address covers. Hostnames are looked up
+// with resolver and yield one full-length prefix per address.
+func Resolve(ctx context.Context, resolver Resolver, address Address) ([]netip.Prefix, error) {
+	if address.Kind != KindHostname {
+		return []netip.Prefix{address.Prefix}, nil
+	}
+
+	addrs, err := resolver.LookupNetIP(ctx, "ip", address.Hostname)
+	if err != nil || len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnresolvable, address.Hostname)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(addrs))
+	for _, addr := range addrs {
+		addr = addr.Unmap().WithZone("")
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Conflicts reports whether two prefixes cannot both be client definitions:
+// equal prefixes, or overlapping networks. A single IP inside a network is
+// allowed, since FreeRADIUS picks the longest matching prefix.
+func Conflicts(a, b netip.Prefix) bool {
+	if a == b {
+		return true
+	}
+	if a.IsSingleIP() || b.IsSingleIP() {
+		return false
+	}
+	return a.Overlaps(b)
+}
+
+// Candidate is a client definition that Match can choose from; ID is
+// opaque to Match and lets the caller identify the winner.
+type Candidate struct {
+	ID     uint
+	Prefix netip.Prefix
+}
+
+// Match returns the candidate FreeRADIUS would use for a packet from ip:
+// the one with the longest prefix containing it.
+func Match(ip netip.Addr, candidates []Candidate) (Candidate, bool) {
+	ip = ip.Unmap().WithZone("")
+
+	var best Candidate
+	found := false
+	for _, candidate := range candidates {
+		if !candidate.Prefix.Contains(ip) {
+			continue
+		}
+		if !found || candidate.Prefix.Bits() > best.Prefix.Bits() {
+			best = candidate
+			found = true
+		}
+	}
+	return best, found
+}
+
+func unmapPrefix(prefix netip.Prefix) netip.Prefix {
+	addr := prefix.Addr()
+	if !addr.Is4In6() {
+		return prefix
+	}
+	bits := prefix.Bits() - 96
+	if bits < 0 {
+		return prefix
+	}
+	return netip.PrefixFrom(addr.Unmap(), bits)
+}
@@ -0,0 +1,168 @@
+package nasaddr
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		nasname  string
+		kind     Kind
+		prefix   string
+		hostname string
+		wantErr  bool
+	}{
+		{name: "ipv4", nasname: "192.0.2.1", kind: KindIP, prefix: "192.0.2.1/32"},
+		{name: "ipv6", nasname: "2001:db8::1", kind: KindIP, prefix: "2001:db8::1/128"},
+		{name: "ipv4-mapped ipv6", nasname: "::ffff:192.0.2.1", kind: KindIP, prefix: "192.0.2.1/32"},
+		{name: "host prefix", nasname: "192.0.2.1/32", kind: KindIP, prefix: "192.0.2.1/32"},
+		{name: "ipv4 network", nasname: "10.0.0.0/8", kind: KindNetwork, prefix: "10.0.0.0/8"},
+		{name: "ipv6 network", nasname: "2001:db8::/32", kind: KindNetwork, prefix: "2001:db8::/32"},
+		{name: "hostname", nasname: "BRAS-01.Example.com.", kind: KindHostname, hostname: "bras-01.example.com"},
+		{name: "empty", nasname: "", wantErr: true},
+		{name: "surrounding spaces", nasname: " 192.0.2.1", wantErr: true},
+		{name: "octet out of range", nasname: "10.0.0.256", wantErr: true},
+		{name: "numeric only", nasname: "12345", wantErr: true},
+		{name: "host bits set", nasname: "10.0.0.1/8", wantErr: true},
+		{name: "bad prefix length", nasname: "10.0.0.0/33", wantErr: true},
+		{name: "zone", nasname: "fe80::1%eth0", wantErr: true},
+		{name: "invalid characters", nasname: "nas one", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			address, err := Parse(tt.nasname)
+
+			// Then
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAddress)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, address.Kind)
+			if tt.prefix != "" {
+				assert.Equal(t, netip.MustParsePrefix(tt.prefix), address.Prefix)
+			}
+			assert.Equal(t, tt.hostname, address.Hostname)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	resolver := fakeResolver{
+		"radius.example.com": {netip.MustParseAddr("192.0.2.10"), netip.MustParseAddr("2001:db8::10")},
+	}
+
+	t.Run("should return the prefix of an IP without lookup", func(t *testing.T) {
+		// Given
+		address, err := Parse("10.0.0.0/8")
+		require.NoError(t, err)
+
+		// When
+		prefixes, err := Resolve(context.Background(), resolver, address)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, prefixes)
+	})
+
+	t.Run("should resolve a hostname to host prefixes", func(t *testing.T) {
+		// Given
+		address, err := Parse("radius.example.com")
+		require.NoError(t, err)
+
+		// When
+		prefixes, err := Resolve(context.Background(), resolver, address)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, []netip.Prefix{
+			netip.MustParsePrefix("192.0.2.10/32"),
+			netip.MustParsePrefix("2001:db8::10/128"),
+		}, prefixes)
+	})
+
+	t.Run("should fail for an unknown hostname", func(t *testing.T) {
+		// Given
+		address, err := Parse("missing.example.com")
+		require.NoError(t, err)
+
+		// When
+		_, err = Resolve(context.Background(), resolver, address)
+
+		// Then
+		assert.ErrorIs(t, err, ErrUnresolvable)
+	})
+}
+
+func TestConflicts(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "192.0.2.1/32", b: "192.0.2.1/32", want: true},
+		{a: "10.0.0.0/8", b: "10.1.0.0/16", want: true},
+		{a: "10.0.0.0/16", b: "10.0.0.0/16", want: true},
+		{a: "10.0.0.0/16", b: "10.1.0.0/16", want: false},
+		{a: "10.0.0.0/8", b: "10.0.0.1/32", want: false},
+		{a: "192.0.2.1/32", b: "192.0.2.2/32", want: false},
+		{a: "10.0.0.0/8", b: "2001:db8::/32", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got := Conflicts(netip.MustParsePrefix(tt.a), netip.MustParsePrefix(tt.b))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	candidates := []Candidate{
+		{ID: 1, Prefix: netip.MustParsePrefix("10.0.0.0/8")},
+		{ID: 2, Prefix: netip.MustParsePrefix("10.1.2.3/32")},
+		{ID: 3, Prefix: netip.MustParsePrefix("2001:db8::/32")},
+	}
+
+	tests := []struct {
+		name  string
+		ip    string
+		id    uint
+		found bool
+	}{
+		{name: "network", ip: "10.9.9.9", id: 1, found: true},
+		{name: "longest prefix wins", ip: "10.1.2.3", id: 2, found: true},
+		{name: "ipv4-mapped ipv6", ip: "::ffff:10.1.2.3", id: 2, found: true},
+		{name: "ipv6", ip: "2001:db8::1", id: 3, found: true},
+		{name: "no match", ip: "192.0.2.1", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			match, found := Match(netip.MustParseAddr(tt.ip), candidates)
+
+			// Then
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.id, match.ID)
+		})
+	}
+}
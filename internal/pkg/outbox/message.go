@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+)
+
+// Message is a domain event waiting in the outbox table. It is written in the
+// same transaction as the state change it describes and taken off the
+// relay queue by setting PublishedAt once every sink accepted it. A relay
+// delivering it holds it until LockedUntil.
+type Message struct {
+	ID            uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventID       string     `json:"event_id" gorm:"uniqueIndex;not null;size:36"`
+	EventType     string     `json:"event_type" gorm:"index;not null;size:64"`
+	AggregateType string     `json:"aggregate_type" gorm:"not null;size:32"`
+	AggregateID   string     `json:"aggregate_id" gorm:"not null;size:64"`
+	Payload       string     `json:"payload" gorm:"type:text;not null"`
+	OccurredAt    time.Time  `json:"occurred_at" gorm:"not null"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index;not null"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	PublishedAt   *time.Time `json:"published_at" gorm:"index"`
+	LockedUntil   *time.Time `json:"locked_until"`
+}
+
+func (m Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Envelope returns the message in the form delivered to sinks.
+func (m Message) Envelope() events.Envelope {
+	return events.Envelope{
+		ID:            m.EventID,
+		Type:          m.EventType,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		OccurredAt:    m.OccurredAt,
+		Payload:       []byte(m.Payload),
+	}
+}
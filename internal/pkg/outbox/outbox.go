@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrNoTransaction is returned by Add outside WithinTransaction: an event
+// written separately from its state change could be lost or describe a
+// change that was rolled back.
+var ErrNoTransaction = errors.New("outbox: events must be added within a transaction")
+
+// Outbox records domain events for the relay to deliver.
+type Outbox interface {
+	Add(ctx context.Context, evts ...events.Event) error
+}
+
+type outbox struct {
+	db *gorm.DB
+}
+
+func NewOutbox(db *gorm.DB) Outbox {
+	return &outbox{db: db}
+}
+
+// Add stores evts in the transaction carried by ctx.
+func (o *outbox) Add(ctx context.Context, evts ...events.Event) error {
+	if len(evts) == 0 {
+		return nil
+	}
+	if !database.HasTx(ctx) {
+		return ErrNoTransaction
+	}
+
+	now := time.Now().UTC()
+	messages := make([]Message, 0, len(evts))
+	for _, event := range evts {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("outbox: encode %s: %w", event.EventType(), err)
+		}
+		messages = append(messages, Message{
+			EventID:       uuid.NewString(),
+			EventType:     event.EventType(),
+			AggregateType: event.AggregateType(),
+			AggregateID:   event.AggregateID(),
+			Payload:       string(payload),
+			OccurredAt:    now,
+			NextAttemptAt: now,
+		})
+	}
+
+	db := database.GetDB(ctx, o.db).(*gorm.DB)
+	return db.Create(&messages).Error
+}
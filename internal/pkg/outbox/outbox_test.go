@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupOutboxDB opens an in-memory database with only the outbox table;
+// testutil.SetupTestDB cannot be used here since it imports this package.
+func setupOutboxDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&Message{}))
+	return db
+}
+
+func TestOutbox_Add(t *testing.T) {
+	t.Run("should store events within the transaction", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		txManager := database.NewTransactionManager(db)
+		box := NewOutbox(db)
+
+		// When
+		err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			return box.Add(ctx,
+				events.UserCreated{UserID: 1, Name: "John", Email: "john@example.com"},
+				events.UserDeleted{UserID: 2},
+			)
+		})
+
+		// Then
+		require.NoError(t, err)
+		var messages []Message
+		require.NoError(t, db.Order("id").Find(&messages).Error)
+		require.Len(t, messages, 2)
+		assert.Equal(t, events.TypeUserCreated, messages[0].EventType)
+		assert.Equal(t, events.AggregateUser, messages[0].AggregateType)
+		assert.Equal(t, "1", messages[0].AggregateID)
+		assert.JSONEq(t, `{"user_id":1,"name":"John","email":"john@example.com"}`, messages[0].Payload)
+		assert.Len(t, messages[0].EventID, 36)
+		assert.NotEqual(t, messages[0].EventID, messages[1].EventID)
+		assert.Nil(t, messages[0].PublishedAt)
+		assert.Equal(t, events.TypeUserDeleted, messages[1].EventType)
+	})
+
+	t.Run("should discard events when the transaction rolls back", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		txManager := database.NewTransactionManager(db)
+		box := NewOutbox(db)
+
+		// When
+		err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			if err := box.Add(ctx, events.UserDeleted{UserID: 1}); err != nil {
+				return err
+			}
+			return errors.New("state change failed")
+		})
+
+		// Then
+		assert.Error(t, err)
+		var count int64
+		require.NoError(t, db.Model(&Message{}).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+
+	t.Run("should refuse events outside a transaction", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		box := NewOutbox(db)
+
+		// When
+		err := box.Add(context.Background(), events.UserDeleted{UserID: 1})
+
+		// Then
+		assert.ErrorIs(t, err, ErrNoTransaction)
+	})
+}
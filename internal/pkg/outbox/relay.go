@@ -0,0 +1,262 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultPollInterval   = time.Second
+	defaultBatchSize      = 100
+	defaultMaxAttempts    = 12
+	defaultRetryBackoff   = 5 * time.Second
+	defaultMaxBackoff     = time.Hour
+	defaultPublishTimeout = 10 * time.Second
+	defaultLeaseDuration  = time.Minute
+)
+
+// Relay delivers outbox messages to the sinks, oldest first, and marks them
+// published once every sink accepted them. A batch is leased in a short
+// transaction, SELECT ... FOR UPDATE SKIP LOCKED setting locked_until, so
+// several workers can relay concurrently without delivering the same batch
+// twice; it is published outside of any transaction, and each outcome stored
+// on its own. A crash between publishing and storing the outcome redelivers
+// the message once the lease ran out: delivery is at-least-once and consumers
+// should deduplicate on the envelope ID.
+type Relay struct {
+	db     *gorm.DB
+	sinks  []Sink
+	logger *zap.Logger
+
+	interval       time.Duration
+	batchSize      int
+	maxAttempts    int
+	retryBackoff   time.Duration
+	maxBackoff     time.Duration
+	publishTimeout time.Duration
+	lease          time.Duration
+
+	now  func() time.Time
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewRelay(db *gorm.DB, sinks []Sink, cfg *config.Config, logger *zap.Logger) *Relay {
+	c := cfg.Outbox
+	publishTimeout := durationOr(c.PublishTimeout, defaultPublishTimeout)
+	// A lease must outlast publishing at least one message to every sink
+	lease := durationOr(c.LeaseDuration, defaultLeaseDuration)
+	if least := publishTimeout * time.Duration(len(sinks)+1); lease < least {
+		lease = least
+	}
+	return &Relay{
+		db:             db,
+		sinks:          sinks,
+		logger:         logger,
+		interval:       durationOr(c.PollInterval, defaultPollInterval),
+		batchSize:      intOr(c.BatchSize, defaultBatchSize),
+		maxAttempts:    intOr(c.MaxAttempts, defaultMaxAttempts),
+		retryBackoff:   durationOr(c.RetryBackoff, defaultRetryBackoff),
+		maxBackoff:     durationOr(c.MaxBackoff, defaultMaxBackoff),
+		publishTimeout: publishTimeout,
+		lease:          lease,
+		now:            func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (r *Relay) Start(lifecycle fx.Lifecycle) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			r.logger.Info("Starting outbox relay", zap.Int("sinks", len(r.sinks)), zap.Duration("interval", r.interval))
+			r.stop = make(chan struct{})
+			r.done = make(chan struct{})
+			go r.run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			r.logger.Info("Stopping outbox relay")
+			close(r.stop)
+			select {
+			case <-r.done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+func (r *Relay) run() {
+	defer close(r.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-r.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+
+		// Drain the backlog before waiting for the next tick.
+		for {
+			n, err := r.RelayBatch(ctx)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					r.logger.Error("Failed to relay outbox batch", zap.Error(err))
+				}
+				break
+			}
+			if n < r.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// RelayBatch claims up to one batch of due messages, delivers them and
+// records the outcome. It returns the number of messages claimed.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	messages, leasedUntil, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range messages {
+		// Leave the rest to another batch rather than publish past the lease
+		if ctx.Err() != nil || r.now().Add(r.publishTimeout*time.Duration(len(r.sinks))).After(leasedUntil) {
+			return len(messages), r.release(ctx, messages[i:])
+		}
+		if err := r.deliver(ctx, &messages[i]); err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+// claim leases up to one batch of due messages that no other relay holds.
+func (r *Relay) claim(ctx context.Context) ([]Message, time.Time, error) {
+	now := r.now()
+	leasedUntil := now.Add(r.lease)
+
+	var messages []Message
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND attempts < ? AND next_attempt_at <= ?", r.maxAttempts, now).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Order("id ASC").
+			Limit(r.batchSize).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		return tx.Model(&Message{}).Where("id IN ?", ids(messages)).UpdateColumn("locked_until", leasedUntil).Error
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return messages, leasedUntil, nil
+}
+
+// release ends the lease of messages left undelivered.
+func (r *Relay) release(ctx context.Context, messages []Message) error {
+	return r.db.WithContext(context.WithoutCancel(ctx)).Model(&Message{}).
+		Where("id IN ?", ids(messages)).
+		UpdateColumn("locked_until", nil).Error
+}
+
+// deliver publishes msg to every sink and stores the result, ending its
+// lease; only a failure to store it is returned. The result is stored even
+// when ctx is canceled meanwhile, so that a stopping relay does not
+// redeliver.
+func (r *Relay) deliver(ctx context.Context, msg *Message) error {
+	env := msg.Envelope()
+
+	var errs []error
+	for _, sink := range r.sinks {
+		publishCtx, cancel := context.WithTimeout(ctx, r.publishTimeout)
+		err := sink.Publish(publishCtx, env)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+
+	now := r.now()
+	attempts := msg.Attempts + 1
+	columns := map[string]interface{}{"attempts": attempts, "locked_until": nil}
+
+	if err := errors.Join(errs...); err != nil {
+		columns["last_error"] = err.Error()
+		columns["next_attempt_at"] = now.Add(r.backoff(attempts))
+		if attempts >= r.maxAttempts {
+			r.logger.Error("Giving up on outbox message",
+				zap.String("event_id", msg.EventID),
+				zap.String("event_type", msg.EventType),
+				zap.Int("attempts", attempts),
+				zap.Error(err))
+		} else {
+			r.logger.Warn("Failed to deliver outbox message",
+				zap.String("event_id", msg.EventID),
+				zap.String("event_type", msg.EventType),
+				zap.Int("attempts", attempts),
+				zap.Error(err))
+		}
+	} else {
+		columns["published_at"] = now
+		columns["last_error"] = ""
+	}
+
+	return r.db.WithContext(context.WithoutCancel(ctx)).Model(&Message{}).Where("id = ?", msg.ID).UpdateColumns(columns).Error
+}
+
+func ids(messages []Message) []uint64 {
+	ids := make([]uint64, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+// backoff returns the delay before the next attempt after attempts failures.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.retryBackoff
+	for i := 1; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.maxBackoff {
+		delay = r.maxBackoff
+	}
+	return delay
+}
+
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intOr(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,223 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type fakeSink struct {
+	err       error
+	published []events.Envelope
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Publish(ctx context.Context, env events.Envelope) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.published = append(s.published, env)
+	return nil
+}
+
+func relayConfig() *config.Config {
+	return &config.Config{
+		Outbox: config.OutboxConfig{
+			BatchSize:      10,
+			MaxAttempts:    3,
+			RetryBackoff:   time.Second,
+			MaxBackoff:     3 * time.Second,
+			PublishTimeout: time.Second,
+		},
+	}
+}
+
+func insertMessage(t *testing.T, db *gorm.DB, eventID string, nextAttemptAt time.Time) {
+	t.Helper()
+	require.NoError(t, db.Create(&Message{
+		EventID:       eventID,
+		EventType:     events.TypeUserDeleted,
+		AggregateType: events.AggregateUser,
+		AggregateID:   "1",
+		Payload:       `{"user_id":1}`,
+		OccurredAt:    nextAttemptAt,
+		NextAttemptAt: nextAttemptAt,
+	}).Error)
+}
+
+func TestRelay_RelayBatch(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should deliver due messages in order and mark them published", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		sink := &fakeSink{}
+		relay := NewRelay(db, []Sink{sink}, relayConfig(), zap.NewNop())
+		relay.now = func() time.Time { return now }
+
+		// Given
+		insertMessage(t, db, "e1", now.Add(-time.Minute))
+		insertMessage(t, db, "e2", now)
+		insertMessage(t, db, "e3", now.Add(time.Minute))
+
+		// When
+		n, err := relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		require.Len(t, sink.published, 2)
+		assert.Equal(t, "e1", sink.published[0].ID)
+		assert.Equal(t, "e2", sink.published[1].ID)
+		assert.JSONEq(t, `{"user_id":1}`, string(sink.published[0].Payload))
+
+		var messages []Message
+		require.NoError(t, db.Order("id").Find(&messages).Error)
+		assert.NotNil(t, messages[0].PublishedAt)
+		assert.Equal(t, 1, messages[0].Attempts)
+		assert.NotNil(t, messages[1].PublishedAt)
+		assert.Nil(t, messages[2].PublishedAt)
+
+		// When
+		n, err = relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Len(t, sink.published, 2)
+	})
+
+	t.Run("should back off after a failed delivery and give up after max attempts", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		sink := &fakeSink{err: errors.New("sink unavailable")}
+		relay := NewRelay(db, []Sink{sink}, relayConfig(), zap.NewNop())
+		clock := now
+		relay.now = func() time.Time { return clock }
+
+		// Given
+		insertMessage(t, db, "e1", now)
+
+		// When
+		_, err := relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		var message Message
+		require.NoError(t, db.First(&message).Error)
+		assert.Equal(t, 1, message.Attempts)
+		assert.Equal(t, "fake: sink unavailable", message.LastError)
+		assert.True(t, message.NextAttemptAt.Equal(now.Add(time.Second)))
+		assert.Nil(t, message.PublishedAt)
+
+		// When
+		n, err := relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Zero(t, n, "message is not due yet")
+
+		// When
+		clock = now.Add(time.Second)
+		_, err = relay.RelayBatch(context.Background())
+		require.NoError(t, err)
+		clock = now.Add(time.Hour)
+		_, err = relay.RelayBatch(context.Background())
+		require.NoError(t, err)
+		n, err = relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Zero(t, n, "message is given up after max attempts")
+		require.NoError(t, db.First(&message).Error)
+		assert.Equal(t, 3, message.Attempts)
+		assert.Nil(t, message.PublishedAt)
+	})
+}
+
+func TestRelay_Lease(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should leave messages another relay leased until the lease runs out", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		sink := &fakeSink{}
+		relay := NewRelay(db, []Sink{sink}, relayConfig(), zap.NewNop())
+		clock := now
+		relay.now = func() time.Time { return clock }
+
+		// Given
+		insertMessage(t, db, "e1", now)
+		require.NoError(t, db.Model(&Message{}).Where("event_id = ?", "e1").UpdateColumn("locked_until", now.Add(time.Minute)).Error)
+
+		// When
+		leased, err := relay.RelayBatch(context.Background())
+		clock = now.Add(time.Minute)
+		expired, expiredErr := relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		require.NoError(t, expiredErr)
+		assert.Zero(t, leased)
+		assert.Equal(t, 1, expired)
+		require.Len(t, sink.published, 1)
+		var message Message
+		require.NoError(t, db.First(&message).Error)
+		assert.NotNil(t, message.PublishedAt)
+		assert.Nil(t, message.LockedUntil)
+	})
+
+	t.Run("should release the messages the lease leaves no time for", func(t *testing.T) {
+		// Setup
+		db := setupOutboxDB(t)
+		sink := &fakeSink{}
+		cfg := relayConfig()
+		cfg.Outbox.LeaseDuration = 3 * time.Second
+		relay := NewRelay(db, []Sink{sink}, cfg, zap.NewNop())
+		clock := now
+		relay.now = func() time.Time {
+			current := clock
+			clock = clock.Add(time.Second)
+			return current
+		}
+
+		// Given
+		insertMessage(t, db, "e1", now)
+		insertMessage(t, db, "e2", now)
+
+		// When
+		n, err := relay.RelayBatch(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		require.Len(t, sink.published, 1)
+		var messages []Message
+		require.NoError(t, db.Order("id").Find(&messages).Error)
+		assert.NotNil(t, messages[0].PublishedAt)
+		assert.Nil(t, messages[1].PublishedAt)
+		assert.Nil(t, messages[1].LockedUntil)
+		assert.Zero(t, messages[1].Attempts)
+	})
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	relay := NewRelay(nil, nil, relayConfig(), zap.NewNop())
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 3*time.Second, relay.backoff(3))
+	assert.Equal(t, 3*time.Second, relay.backoff(10))
+}
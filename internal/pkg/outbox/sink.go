@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sink receives relayed events. Delivery is at-least-once: a sink may see
+// the same envelope again after a failure anywhere in the batch.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, env events.Envelope) error
+}
+
+type busSink struct {
+	bus *events.Bus
+}
+
+// NewBusSink delivers events to the in-process subscribers of bus.
+func NewBusSink(bus *events.Bus) Sink {
+	return &busSink{bus: bus}
+}
+
+func (s *busSink) Name() string {
+	return "bus"
+}
+
+func (s *busSink) Publish(ctx context.Context, env events.Envelope) error {
+	return s.bus.Dispatch(ctx, env)
+}
+
+// StreamAdder is the part of a Redis client used by the stream sink.
+type StreamAdder interface {
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+}
+
+type redisStreamSink struct {
+	client StreamAdder
+	stream string
+}
+
+// NewRedisStreamSink adds every event to a Redis stream for consumers
+// outside this service. The entry has the fields id, type, aggregate_type,
+// aggregate_id, occurred_at and payload.
+func NewRedisStreamSink(client StreamAdder, stream string) Sink {
+	return &redisStreamSink{client: client, stream: stream}
+}
+
+func (s *redisStreamSink) Name() string {
+	return "redis:" + s.stream
+}
+
+func (s *redisStreamSink) Publish(ctx context.Context, env events.Envelope) error {
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		Values: map[string]interface{}{
+			"id":             env.ID,
+			"type":           env.Type,
+			"aggregate_type": env.AggregateType,
+			"aggregate_id":   env.AggregateID,
+			"occurred_at":    env.OccurredAt.Format(time.RFC3339Nano),
+			"payload":        string(env.Payload),
+		},
+	}).Err()
+}
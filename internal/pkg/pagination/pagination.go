@@ -0,0 +1,370 @@
+// Package pagination pages lists either by page number or by an opaque
+// cursor. A cursor resumes a list after the last row of the previous page, so
+// a deep page costs as much as the first one, where OFFSET reads and skips
+// every row before it. Counting the list is optional for the same reason.
+package pagination
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrInvalidCursor is returned for cursors that were not issued by the
+	// list.
+	ErrInvalidCursor = apperror.InvalidField("cursor", "is not a cursor of this list")
+	// ErrInvalidCount is returned for unknown ways of counting.
+	ErrInvalidCount = apperror.InvalidField("count", "must be one of exact, estimated or none")
+)
+
+// Count is how the total of a list is counted.
+type Count string
+
+const (
+	// CountExact counts every row of the list.
+	CountExact Count = "exact"
+	// CountEstimated reads the row estimate of the query planner on MySQL and
+	// PostgreSQL and counts exactly elsewhere.
+	CountEstimated Count = "estimated"
+	// CountNone leaves the total out.
+	CountNone Count = "none"
+)
+
+// Params are the paging options every list takes besides page and
+// page_size.
+type Params struct {
+	// Cursor is the next_cursor of the previous page. The page number is
+	// ignored when it is set.
+	Cursor string `json:"cursor,omitempty" form:"cursor"`
+	// Count defaults to exact for page numbers and to none for cursors.
+	Count Count `json:"count,omitempty" form:"count" binding:"omitempty,oneof=exact estimated none"`
+}
+
+// counting returns how the total is counted. Unless asked, clients paging
+// by cursor get none: they follow next_cursor rather than the total.
+func (p Params) counting() (Count, error) {
+	switch p.Count {
+	case CountExact, CountEstimated, CountNone:
+		return p.Count, nil
+	case "":
+		if p.Cursor != "" {
+			return CountNone, nil
+		}
+		return CountExact, nil
+	default:
+		return "", ErrInvalidCount
+	}
+}
+
+// Key is a column a list is ordered by. It must not be nullable, as rows
+// with NULL would drop out of the pages that follow a cursor.
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+// Order is the order a list is paged in: by each key, then by id in the
+// direction of the first key unless a key already is id. Every key needs an
+// index for cursors to seek rather than scan. The zero Order is by id.
+type Order []Key
+
+// keys returns the order with the id tie breaker.
+func (o Order) keys() Order {
+	for _, key := range o {
+		if key.Column == "id" {
+			return o
+		}
+	}
+	id := Key{Column: "id"}
+	if len(o) > 0 {
+		id.Desc = o[0].Desc
+	}
+	return append(o[:len(o):len(o)], id)
+}
+
+func (o Order) clause() string {
+	columns := make([]string, len(o))
+	for i, key := range o {
+		dir := "ASC"
+		if key.Desc {
+			dir = "DESC"
+		}
+		columns[i] = key.Column + " " + dir
+	}
+	return strings.Join(columns, ", ")
+}
+
+// after returns the condition selecting the rows that follow a row in the
+// order, such as (a > ? OR (a = ? AND id > ?)), and the values it binds.
+func (o Order) after(values []interface{}) (string, []interface{}) {
+	key, cmp := o[0], ">"
+	if key.Desc {
+		cmp = "<"
+	}
+	if len(o) == 1 {
+		return fmt.Sprintf("%s %s ?", key.Column, cmp), values[:1]
+	}
+	rest, vars := o[1:].after(values[1:])
+	return fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s))", key.Column, cmp, rest),
+		append([]interface{}{values[0], values[0]}, vars...)
+}
+
+// cursor is the position of a row in the order of its list: the order and
+// the values of its keys.
+type cursor struct {
+	Order  string            `json:"o"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// schemas caches the schemas of the listed models.
+var schemas sync.Map
+
+// fields returns the fields of T behind the keys of order.
+func fields[T any](query *gorm.DB, order Order) ([]*schema.Field, error) {
+	s, err := schema.Parse(new(T), &schemas, query.NamingStrategy)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*schema.Field, len(order))
+	for i, key := range order {
+		if result[i] = s.LookUpField(key.Column); result[i] == nil {
+			return nil, fmt.Errorf("pagination: %s has no column %s", s.Name, key.Column)
+		}
+	}
+	return result, nil
+}
+
+// encode returns the opaque cursor of row handed to clients.
+func encode(ctx context.Context, order Order, fields []*schema.Field, row reflect.Value) (string, error) {
+	c := cursor{Order: order.clause(), Values: make([]json.RawMessage, len(fields))}
+	for i, field := range fields {
+		value, _ := field.ValueOf(ctx, row)
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		c.Values[i] = b
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decode parses a cursor returned by encode into the values of the keys of
+// order, typed as the fields of the model. Cursors of another order are
+// rejected.
+func decode(s string, order Order, fields []*schema.Field) ([]interface{}, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(b, &c) != nil || c.Order != order.clause() || len(c.Values) != len(fields) {
+		return nil, ErrInvalidCursor
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(c.Values[i], value.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = value.Elem().Interface()
+	}
+	return values, nil
+}
+
+// Page describes one page of a list. Total is nil when the list was not
+// counted.
+type Page struct {
+	Total      *int64
+	Estimated  bool
+	NextCursor string
+}
+
+// Exact describes a page of a list of total rows.
+func Exact(total int64) Page {
+	return Page{Total: &total}
+}
+
+// TotalOrZero returns total, zero when the list was not counted, for the
+// messages that cannot leave it out.
+func TotalOrZero(total *int64) int64 {
+	if total == nil {
+		return 0
+	}
+	return *total
+}
+
+// TotalPages returns the number of pages of size rows, nil when the list was
+// not counted.
+func (p Page) TotalPages(size int) *int {
+	if p.Total == nil || size <= 0 {
+		return nil
+	}
+	pages := int((*p.Total + int64(size) - 1) / int64(size))
+	return &pages
+}
+
+// Find loads the page of query that params, page and size select, in order,
+// and counts the list as params ask.
+func Find[T any](query *gorm.DB, params Params, page, size int, order Order) ([]T, Page, error) {
+	counting, err := params.counting()
+	if err != nil {
+		return nil, Page{}, err
+	}
+	order = order.keys()
+	keyFields, err := fields[T](query, order)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	var result Page
+	switch counting {
+	case CountExact:
+		total, err := exact(query)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		result = Exact(total)
+	case CountEstimated:
+		total, estimated, err := estimate(query)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		result = Exact(total)
+		result.Estimated = estimated
+	}
+
+	query = query.Session(&gorm.Session{}).Order(order.clause())
+	if params.Cursor != "" {
+		values, err := decode(params.Cursor, order, keyFields)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		where, vars := order.after(values)
+		query = query.Where(where, vars...)
+	} else if page > 1 && size > 0 {
+		query = query.Offset((page - 1) * size)
+	}
+	if size > 0 {
+		// One more row than the page tells whether there is a next one.
+		query = query.Limit(size + 1)
+	}
+
+	var rows []T
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, Page{}, err
+	}
+	if size > 0 && len(rows) > size {
+		rows = rows[:size]
+		next, err := encode(query.Statement.Context, order, keyFields, reflect.ValueOf(rows[size-1]))
+		if err != nil {
+			return nil, Page{}, err
+		}
+		result.NextCursor = next
+	}
+	return rows, result, nil
+}
+
+func exact(query *gorm.DB) (int64, error) {
+	var total int64
+	err := query.Session(&gorm.Session{}).Count(&total).Error
+	return total, err
+}
+
+// estimate returns the number of rows the query planner of MySQL or
+// PostgreSQL expects query to return. Other databases are counted.
+func estimate(query *gorm.DB) (int64, bool, error) {
+	switch query.Dialector.Name() {
+	case "mysql":
+		return explainMySQL(query)
+	case "postgres":
+		return explainPostgres(query)
+	default:
+		total, err := exact(query)
+		return total, false, err
+	}
+}
+
+// explain runs EXPLAIN, with options, on the statement of query.
+func explain(query *gorm.DB, options string) (*sql.Rows, error) {
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]interface{}{}).Statement
+	// The statement is bound already, with the placeholders of the dialect
+	return stmt.ConnPool.QueryContext(stmt.Context, "EXPLAIN "+options+stmt.SQL.String(), stmt.Vars...)
+}
+
+// explainMySQL reads the rows and filtered columns of the first row of the
+// plan.
+func explainMySQL(query *gorm.DB) (int64, bool, error) {
+	rows, err := explain(query, "")
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, false, err
+	}
+	if !rows.Next() {
+		return 0, true, rows.Err()
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, false, err
+	}
+
+	estimated, filtered := 0.0, 100.0
+	for i, column := range columns {
+		switch column {
+		case "rows":
+			estimated, _ = strconv.ParseFloat(values[i].String, 64)
+		case "filtered":
+			if f, err := strconv.ParseFloat(values[i].String, 64); err == nil {
+				filtered = f
+			}
+		}
+	}
+	return int64(estimated * filtered / 100), true, nil
+}
+
+// explainPostgres reads the Plan Rows of the top node of the plan.
+func explainPostgres(query *gorm.DB) (int64, bool, error) {
+	rows, err := explain(query, "(FORMAT JSON) ")
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	var plan []byte
+	if !rows.Next() {
+		return 0, true, rows.Err()
+	}
+	if err := rows.Scan(&plan); err != nil {
+		return 0, false, err
+	}
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil || len(plans) == 0 {
+		return 0, false, fmt.Errorf("pagination: unexpected plan %s", plan)
+	}
+	return int64(plans[0].Plan.Rows), true, nil
+}
@@ -0,0 +1,144 @@
+package pagination_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+)
+
+func TestFind(t *testing.T) {
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	for i := 0; i < 25; i++ {
+		require.NoError(t, db.Create(&radcheckEntity.Radcheck{Username: "user", Attribute: "Cleartext-Password", Op: ":=", Value: "secret"}).Error)
+	}
+	query := func() *gorm.DB { return db.Model(&radcheckEntity.Radcheck{}) }
+
+	t.Run("should count pages by number", func(t *testing.T) {
+		// When
+		rows, page, err := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{}, 3, 10, pagination.Order{})
+
+		// Then
+		require.NoError(t, err)
+		require.Len(t, rows, 5)
+		assert.Equal(t, uint(21), rows[0].ID)
+		assert.Equal(t, int64(25), *page.Total)
+		assert.Equal(t, 3, *page.TotalPages(10))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("should walk the list by cursor", func(t *testing.T) {
+		// Setup
+		var ids []uint
+		params := pagination.Params{}
+
+		// When
+		for pages := 0; pages < 5; pages++ {
+			rows, page, err := pagination.Find[radcheckEntity.Radcheck](query(), params, 1, 10, pagination.Order{})
+			require.NoError(t, err)
+			if params.Cursor != "" {
+				assert.Nil(t, page.Total)
+			}
+			for _, row := range rows {
+				ids = append(ids, row.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+
+		// Then
+		require.Len(t, ids, 25)
+		for i, id := range ids {
+			assert.Equal(t, uint(i+1), id)
+		}
+	})
+
+	t.Run("should count as asked", func(t *testing.T) {
+		// Setup
+		_, first, err := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{}, 1, 10, pagination.Order{})
+		require.NoError(t, err)
+
+		// When
+		_, none, err := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{Count: pagination.CountNone}, 1, 10, pagination.Order{})
+		require.NoError(t, err)
+		_, estimated, err := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{Cursor: first.NextCursor, Count: pagination.CountEstimated}, 1, 10, pagination.Order{})
+		require.NoError(t, err)
+
+		// Then
+		assert.Nil(t, none.Total)
+		assert.Nil(t, none.TotalPages(10))
+		// SQLite has no planner estimate to read
+		assert.Equal(t, int64(25), *estimated.Total)
+		assert.False(t, estimated.Estimated)
+	})
+
+	t.Run("should reject cursors and counts it does not know", func(t *testing.T) {
+		// When
+		_, _, cursorErr := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{Cursor: "not-a-cursor"}, 1, 10, pagination.Order{})
+		_, _, countErr := pagination.Find[radcheckEntity.Radcheck](query(), pagination.Params{Count: "approximate"}, 1, 10, pagination.Order{})
+
+		// Then
+		assert.ErrorIs(t, cursorErr, pagination.ErrInvalidCursor)
+		assert.ErrorIs(t, countErr, pagination.ErrInvalidCount)
+	})
+}
+
+func TestFind_Column(t *testing.T) {
+	// Setup
+	db, err := testutil.SetupTestDB()
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		// Entries 2 to 4 share a timestamp, so the id has to break the tie
+		occurredAt := at.Add(time.Duration(min(i, 2)) * time.Minute)
+		if i == 4 {
+			occurredAt = at.Add(3 * time.Minute)
+		}
+		require.NoError(t, db.Create(&audit.Entry{OccurredAt: occurredAt, Actor: "admin", Source: "rest", EntityType: "nas", EntityID: "1", Action: "update", Changes: "{}"}).Error)
+	}
+	order := pagination.Order{{Column: "occurred_at", Desc: true}}
+
+	t.Run("should page by the column, then by id", func(t *testing.T) {
+		// Setup
+		var ids []uint64
+		params := pagination.Params{}
+
+		// When
+		for pages := 0; pages < 5; pages++ {
+			rows, page, err := pagination.Find[audit.Entry](db.Model(&audit.Entry{}), params, 1, 2, order)
+			require.NoError(t, err)
+			for _, row := range rows {
+				ids = append(ids, row.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+
+		// Then
+		assert.Equal(t, []uint64{5, 4, 3, 2, 1}, ids)
+	})
+
+	t.Run("should reject cursors of another order", func(t *testing.T) {
+		// Setup
+		_, first, err := pagination.Find[audit.Entry](db.Model(&audit.Entry{}), pagination.Params{}, 1, 2, pagination.Order{})
+		require.NoError(t, err)
+
+		// When
+		_, _, err = pagination.Find[audit.Entry](db.Model(&audit.Entry{}), pagination.Params{Cursor: first.NextCursor}, 1, 2, order)
+
+		// Then
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Correlation ties a task to the API call that enqueued it. Task payloads
+// embed it, as asynq tasks carry no headers. Trace holds the trace context
+// of the enqueue, in the form of traceparent headers.
+type Correlation struct {
+	RequestID string            `json:"request_id,omitempty"`
+	Trace     map[string]string `json:"trace,omitempty"`
+}
+
+// NewCorrelation returns the correlation of the tasks enqueued under ctx.
+func NewCorrelation(ctx context.Context) Correlation {
+	carrier := propagation.MapCarrier{}
+	tracing.Propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		carrier = nil
+	}
+	return Correlation{RequestID: requestid.FromContext(ctx), Trace: carrier}
+}
+
+// PropagateRequestID is task middleware giving the handler context the
+// request ID of the call that enqueued the task, or the task ID for tasks
+// not enqueued by a call, such as periodic ones.
+func PropagateRequestID(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		var correlation Correlation
+		_ = json.Unmarshal(task.Payload(), &correlation)
+		id := correlation.RequestID
+		if id == "" {
+			id, _ = asynq.GetTaskID(ctx)
+		}
+		return next.ProcessTask(requestid.WithID(ctx, id), task)
+	})
+}
@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropagateRequestID(t *testing.T) {
+	// Setup
+	var got string
+	handler := PropagateRequestID(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		got = requestid.FromContext(ctx)
+		return nil
+	}))
+
+	t.Run("should use the request ID of the payload", func(t *testing.T) {
+		// When
+		err := handler.ProcessTask(context.Background(), asynq.NewTask("payment:process", []byte(`{"payment_id":1,"request_id":"req-123"}`)))
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, "req-123", got)
+	})
+
+	t.Run("should leave tasks without a request ID alone", func(t *testing.T) {
+		// When
+		err := handler.ProcessTask(context.Background(), asynq.NewTask("nas:check_health", nil))
+
+		// Then
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
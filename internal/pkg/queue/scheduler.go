@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Scheduler enqueues periodic tasks. When several workers run a scheduler,
+// register tasks with asynq.Unique so that each period is processed once.
+type Scheduler struct {
+	scheduler *asynq.Scheduler
+	logger    *zap.Logger
+}
+
+func NewScheduler(cfg *config.Config, logger *zap.Logger) *Scheduler {
+	redisAddr := fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port)
+
+	redisOpt := asynq.RedisClientOpt{
+		Addr:     redisAddr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	}
+
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewAsynqLogger(logger),
+		EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+			logger.Warn("Failed to enqueue periodic task",
+				zap.String("task_type", task.Type()),
+				zap.Error(err))
+		},
+	})
+
+	logger.Info("Queue scheduler initialized", zap.String("redis_addr", redisAddr))
+
+	return &Scheduler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Register schedules task according to cronspec, e.g. "@every 1m".
+func (s *Scheduler) Register(cronspec string, task *asynq.Task, opts ...asynq.Option) error {
+	entryID, err := s.scheduler.Register(cronspec, task, opts...)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Periodic task registered",
+		zap.String("task_type", task.Type()),
+		zap.String("cronspec", cronspec),
+		zap.String("entry_id", entryID))
+	return nil
+}
+
+func (s *Scheduler) Start(lifecycle fx.Lifecycle) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			s.logger.Info("Starting queue scheduler")
+			return s.scheduler.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			s.logger.Info("Stopping queue scheduler")
+			s.scheduler.Shutdown()
+			return nil
+		},
+	})
+}
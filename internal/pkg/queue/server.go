@@ -62,6 +62,11 @@ func (s *Server) RegisterHandler(pattern string, handler asynq.Handler) {
 	s.mux.Handle(pattern, handler)
 }
 
+// Use installs middleware that wraps every registered handler.
+func (s *Server) Use(mws ...asynq.MiddlewareFunc) {
+	s.mux.Use(mws...)
+}
+
 func (s *Server) Start(lifecycle fx.Lifecycle) {
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
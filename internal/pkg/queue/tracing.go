@@ -0,0 +1,83 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const messagingSystem = "asynq"
+
+// StartEnqueue starts the producer span of enqueuing a task of taskType,
+// a child of the span of ctx. Build the payload with NewCorrelation under
+// the returned context, so that the task continues the trace of the span.
+// Without a span in ctx nothing is recorded.
+func StartEnqueue(ctx context.Context, taskType string) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(tracing.InstrumentationName)
+	return tracer.Start(ctx, "publish "+taskType,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem(messagingSystem),
+			semconv.MessagingOperationPublish,
+			attribute.String("messaging.asynq.task_type", taskType),
+		))
+}
+
+// RecordEnqueue records the outcome of an enqueue on its span.
+func RecordEnqueue(span trace.Span, info *asynq.TaskInfo, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(
+		semconv.MessagingMessageID(info.ID),
+		semconv.MessagingDestinationName(info.Queue),
+	)
+}
+
+// Trace returns task middleware giving every task a consumer span,
+// continuing the trace of the correlation in its payload. Tasks enqueued
+// outside of a trace, such as periodic ones, start a trace of their own.
+func Trace(tp trace.TracerProvider) asynq.MiddlewareFunc {
+	tracer := tp.Tracer(tracing.InstrumentationName)
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+			var correlation Correlation
+			_ = json.Unmarshal(task.Payload(), &correlation)
+			ctx = tracing.Propagator.Extract(ctx, propagation.MapCarrier(correlation.Trace))
+
+			taskID, _ := asynq.GetTaskID(ctx)
+			queueName, _ := asynq.GetQueueName(ctx)
+			retried, _ := asynq.GetRetryCount(ctx)
+			ctx, span := tracer.Start(ctx, "process "+task.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					semconv.MessagingSystem(messagingSystem),
+					semconv.MessagingOperationProcess,
+					semconv.MessagingMessageID(taskID),
+					semconv.MessagingDestinationName(queueName),
+					attribute.String("messaging.asynq.task_type", task.Type()),
+					attribute.Int("messaging.asynq.retry_count", retried),
+					attribute.String("request_id", requestid.FromContext(ctx)),
+				))
+			defer span.End()
+
+			err := next.ProcessTask(ctx, task)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTrace(t *testing.T) {
+	// Setup
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var processed trace.SpanContext
+	handler := Trace(tp)(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		processed = trace.SpanContextFromContext(ctx)
+		return nil
+	}))
+
+	t.Run("should continue the trace of the enqueue", func(t *testing.T) {
+		// Setup
+		ctx, call := tp.Tracer("test").Start(context.Background(), "POST /api/v1/payments")
+		ctx, enqueue := StartEnqueue(ctx, "payment:process")
+		payload, err := json.Marshal(struct {
+			PaymentID uint `json:"payment_id"`
+			Correlation
+		}{PaymentID: 1, Correlation: NewCorrelation(ctx)})
+		require.NoError(t, err)
+		enqueue.End()
+		call.End()
+
+		// When
+		err = handler.ProcessTask(context.Background(), asynq.NewTask("payment:process", payload))
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, call.SpanContext().TraceID(), processed.TraceID())
+		spans := recorder.Ended()
+		process := spans[len(spans)-1]
+		assert.Equal(t, "process payment:process", process.Name())
+		assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+		assert.Equal(t, enqueue.SpanContext().SpanID(), process.Parent().SpanID())
+	})
+
+	t.Run("should start a trace for tasks enqueued outside of one", func(t *testing.T) {
+		// When
+		err := handler.ProcessTask(context.Background(), asynq.NewTask("nas:check_health", nil))
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, processed.IsValid())
+		spans := recorder.Ended()
+		assert.False(t, spans[len(spans)-1].Parent().IsValid())
+	})
+}
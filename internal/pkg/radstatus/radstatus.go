@@ -0,0 +1,162 @@
+package radstatus
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	codeAccessAccept       = 2
+	codeAccountingResponse = 5
+	codeStatusServer       = 12
+
+	attrMessageAuthenticator = 80
+
+	headerLength = 20
+	// A Status-Server request carries only a Message-Authenticator.
+	requestLength = headerLength + 18
+	maxPacketSize = 4096
+)
+
+var (
+	ErrTimeout         = errors.New("radstatus: no response")
+	ErrInvalidResponse = errors.New("radstatus: invalid response")
+	ErrEmptySecret     = errors.New("radstatus: empty shared secret")
+)
+
+// Probe sends a RADIUS Status-Server request (RFC 5997) to addr ("host:port") and waits up to
+// timeout for each of attempts tries. It returns the round-trip time of the
+// first valid response.
+func Probe(ctx context.Context, addr, secret string, timeout time.Duration, attempts int) (time.Duration, error) {
+	if secret == "" {
+		return 0, ErrEmptySecret
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	request, err := newRequest(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		rtt, err := exchange(ctx, conn, request, secret, timeout)
+		if err == nil {
+			return rtt, nil
+		}
+
+		// Only timeouts are worth retrying; a bad reply will not improve.
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			return 0, err
+		}
+	}
+
+	return 0, ErrTimeout
+}
+
+func exchange(ctx context.Context, conn net.Conn, request []byte, secret string, timeout time.Duration) (time.Duration, error) {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, maxPacketSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+
+	if err := verifyResponse(request, buf[:n], secret); err != nil {
+		return 0, err
+	}
+	return rtt, nil
+}
+
+// newRequest builds a Status-Server packet with a random identifier and
+// Request Authenticator and a Message-Authenticator, which RFC 5997 requires.
+func newRequest(secret string) ([]byte, error) {
+	packet := make([]byte, requestLength)
+
+	// Random Identifier and Request Authenticator.
+	if _, err := rand.Read(packet[1:2]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(packet[4:headerLength]); err != nil {
+		return nil, err
+	}
+
+	packet[0] = codeStatusServer
+	binary.BigEndian.PutUint16(packet[2:4], requestLength)
+
+	packet[headerLength] = attrMessageAuthenticator
+	packet[headerLength+1] = 18
+
+	mac := hmac.New(md5.New, []byte(secret))
+	mac.Write(packet)
+	copy(packet[headerLength+2:], mac.Sum(nil))
+
+	return packet, nil
+}
+
+// verifyResponse checks the code, identifier, length and Response
+// Authenticator of a reply to request.
+func verifyResponse(request, response []byte, secret string) error {
+	if len(response) < headerLength {
+		return fmt.Errorf("%w: short packet", ErrInvalidResponse)
+	}
+
+	code := response[0]
+	if code != codeAccessAccept && code != codeAccountingResponse {
+		return fmt.Errorf("%w: unexpected code %d", ErrInvalidResponse, code)
+	}
+	if response[1] != request[1] {
+		return fmt.Errorf("%w: identifier mismatch", ErrInvalidResponse)
+	}
+
+	length := int(binary.BigEndian.Uint16(response[2:4]))
+	if length < headerLength || length > len(response) {
+		return fmt.Errorf("%w: bad length %d", ErrInvalidResponse, length)
+	}
+	response = response[:length]
+
+	hash := md5.New()
+	hash.Write(response[:4])
+	hash.Write(request[4:headerLength])
+	hash.Write(response[headerLength:])
+	hash.Write([]byte(secret))
+	if !bytes.Equal(hash.Sum(nil), response[4:headerLength]) {
+		return fmt.Errorf("%w: bad response authenticator (wrong secret?)", ErrInvalidResponse)
+	}
+
+	return nil
+}
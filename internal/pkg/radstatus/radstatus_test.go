@@ -0,0 +1,139 @@
+package radstatus
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer runs a fake RADIUS server that answers Status-Server requests
+// with an Access-Accept signed with secret. Requests are dropped while drop
+// returns true.
+func startServer(t *testing.T, secret string, drop func() bool) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, maxPacketSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if drop != nil && drop() {
+				continue
+			}
+			request := buf[:n]
+			if request[0] != codeStatusServer || !validMessageAuthenticator(request, secret) {
+				continue
+			}
+			conn.WriteTo(newResponse(request, secret), addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func validMessageAuthenticator(request []byte, secret string) bool {
+	signed := append([]byte(nil), request...)
+	copy(signed[headerLength+2:], make([]byte, 16))
+	mac := hmac.New(md5.New, []byte(secret))
+	mac.Write(signed)
+	return hmac.Equal(mac.Sum(nil), request[headerLength+2:headerLength+18])
+}
+
+func newResponse(request []byte, secret string) []byte {
+	response := make([]byte, headerLength)
+	response[0] = codeAccessAccept
+	response[1] = request[1]
+	binary.BigEndian.PutUint16(response[2:4], headerLength)
+
+	hash := md5.New()
+	hash.Write(response[:4])
+	hash.Write(request[4:headerLength])
+	hash.Write([]byte(secret))
+	copy(response[4:], hash.Sum(nil))
+	return response
+}
+
+func TestProbe(t *testing.T) {
+	t.Run("should return rtt for a valid response", func(t *testing.T) {
+		// Given
+		addr := startServer(t, "testing123", nil)
+
+		// When
+		rtt, err := Probe(context.Background(), addr, "testing123", time.Second, 1)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Greater(t, rtt, time.Duration(0))
+	})
+
+	t.Run("should retry after a lost request", func(t *testing.T) {
+		// Given
+		dropped := false
+		addr := startServer(t, "testing123", func() bool {
+			if dropped {
+				return false
+			}
+			dropped = true
+			return true
+		})
+
+		// When
+		_, err := Probe(context.Background(), addr, "testing123", 100*time.Millisecond, 2)
+
+		// Then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject response signed with another secret", func(t *testing.T) {
+		// Given
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+		go func() {
+			buf := make([]byte, maxPacketSize)
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteTo(newResponse(buf[:n], "other"), addr)
+		}()
+
+		// When
+		_, err = Probe(context.Background(), conn.LocalAddr().String(), "testing123", time.Second, 1)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("should time out when the server does not answer", func(t *testing.T) {
+		// Given
+		addr := startServer(t, "testing123", func() bool { return true })
+
+		// When
+		_, err := Probe(context.Background(), addr, "testing123", 50*time.Millisecond, 2)
+
+		// Then
+		assert.ErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("should refuse an empty secret", func(t *testing.T) {
+		// When
+		_, err := Probe(context.Background(), "127.0.0.1:1812", "", time.Second, 1)
+
+		// Then
+		assert.ErrorIs(t, err, ErrEmptySecret)
+	})
+}
@@ -0,0 +1,207 @@
+// Package ratelimit throttles API clients with token buckets. Every client,
+// identified by its API key, user or IP address, holds a bucket per limit:
+// a request takes a token, and tokens are refilled at Requests per Window up
+// to Burst. Buckets live in a Store, in process or in Redis so that API
+// replicas share them.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+var ErrInvalidLimit = errors.New("ratelimit: invalid limit")
+
+// ErrLimited is answered to clients whose bucket is empty.
+var ErrLimited = apperror.RateLimited("rate limit exceeded")
+
+// Limit refills Requests tokens per Window, holding at most Burst. A zero
+// Burst holds Requests tokens.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+	Burst    int
+}
+
+func (l Limit) validate() error {
+	if l.Requests <= 0 || l.Window <= 0 || l.Burst < 0 {
+		return fmt.Errorf("%w: %d requests per %s, burst %d", ErrInvalidLimit, l.Requests, l.Window, l.Burst)
+	}
+	return nil
+}
+
+func (l Limit) burst() int {
+	if l.Burst == 0 {
+		return l.Requests
+	}
+	return l.Burst
+}
+
+// rate is the number of tokens refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+// Result is the outcome of taking a token. Reset is the time until the
+// bucket is full again and RetryAfter, for denied requests, the time until
+// it holds a token.
+type Result struct {
+	Allowed    bool
+	Limit      Limit
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// newResult describes a bucket of limit left with tokens.
+func newResult(limit Limit, tokens float64, allowed bool) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.burst()) - tokens) / limit.rate()),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / limit.rate())
+	}
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(0, s) * float64(time.Second))
+}
+
+// Headers returns the RateLimit-* headers describing r, and Retry-After
+// when the request was denied. Durations are rounded up to whole seconds.
+func (r Result) Headers() map[string]string {
+	headers := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(r.Limit.burst()),
+		"RateLimit-Remaining": strconv.Itoa(r.Remaining),
+		"RateLimit-Reset":     strconv.Itoa(ceilSeconds(r.Reset)),
+		"RateLimit-Policy":    fmt.Sprintf("%d;w=%d;burst=%d", r.Limit.Requests, ceilSeconds(r.Limit.Window), r.Limit.burst()),
+	}
+	if !r.Allowed {
+		headers["Retry-After"] = strconv.Itoa(max(1, ceilSeconds(r.RetryAfter)))
+	}
+	return headers
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Store holds the buckets. Take removes a token from the bucket of key,
+// creating it full when missing.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limiter applies a default limit, and the limits of individual routes,
+// to the clients of the API. Routes with their own limit have their own
+// bucket; all other routes share one.
+type Limiter struct {
+	store    Store
+	fallback Limit
+	routes   map[string]Limit
+}
+
+// New returns a Limiter applying fallback to every route missing from
+// routes. Routes are keyed by "<METHOD> <path>", as in
+// "POST /api/v1/auth/login", or by gRPC method, as in
+// "/radcheck.RadcheckService/CreateRadcheck".
+func New(store Store, fallback Limit, routes map[string]Limit) (*Limiter, error) {
+	if err := fallback.validate(); err != nil {
+		return nil, err
+	}
+	for route, limit := range routes {
+		if err := limit.validate(); err != nil {
+			return nil, fmt.Errorf("route %s: %w", route, err)
+		}
+	}
+	return &Limiter{store: store, fallback: fallback, routes: routes}, nil
+}
+
+// NewLimiter builds a Limiter from the rate_limit section of the
+// configuration, returning nil when rate limiting is disabled. The redis
+// backend connects to the Redis server of the job queue.
+func NewLimiter(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) (*Limiter, error) {
+	c := cfg.RateLimit
+	if !c.Enabled {
+		return nil, nil
+	}
+
+	routes := make(map[string]Limit, len(c.Routes))
+	for _, route := range c.Routes {
+		routes[route.Route] = Limit(route.Limit)
+	}
+
+	var store Store
+	switch c.Backend {
+	case BackendMemory, "":
+		store = NewMemoryStore()
+	case BackendRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return client.Close()
+			},
+		})
+		store = NewRedisStore(client)
+	default:
+		return nil, fmt.Errorf("rate_limit.backend: unknown backend %q", c.Backend)
+	}
+
+	limiter, err := New(store, Limit(c.Default), routes)
+	if err != nil {
+		return nil, fmt.Errorf("rate_limit: %w", err)
+	}
+	logger.Info("Rate limiting API clients",
+		zap.String("backend", c.Backend),
+		zap.Int("requests", c.Default.Requests),
+		zap.Duration("window", c.Default.Window),
+		zap.Int("routes", len(routes)))
+	return limiter, nil
+}
+
+// Allow takes a token from the bucket client holds for route.
+func (l *Limiter) Allow(ctx context.Context, route, client string) (Result, error) {
+	limit, bucket := l.fallback, "*"
+	if routeLimit, ok := l.routes[route]; ok {
+		limit, bucket = routeLimit, route
+	}
+	return l.store.Take(ctx, bucket+"|"+client, limit)
+}
+
+// Client identifies the client of a request: its API key, its user, or
+// clientIP for requests without a principal.
+func Client(principal *identity.Principal, clientIP string) string {
+	switch {
+	case principal == nil:
+		return "ip:" + clientIP
+	case principal.APIKey != "":
+		return "key:" + principal.APIKey
+	default:
+		return "user:" + strconv.FormatUint(uint64(principal.UserID), 10)
+	}
+}
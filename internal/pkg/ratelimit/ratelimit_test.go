@@ -0,0 +1,217 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := Limit{Requests: 60, Window: time.Minute, Burst: 2}
+
+	t.Run("should deny requests once the burst is spent", func(t *testing.T) {
+		// Setup
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		store := newTestMemoryStore(&now)
+
+		// When
+		first, _ := store.Take(context.Background(), "user:1", limit)
+		second, _ := store.Take(context.Background(), "user:1", limit)
+		third, err := store.Take(context.Background(), "user:1", limit)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, first.Allowed)
+		assert.Equal(t, 1, first.Remaining)
+		assert.True(t, second.Allowed)
+		assert.Equal(t, 0, second.Remaining)
+		assert.False(t, third.Allowed)
+		assert.Equal(t, time.Second, third.RetryAfter)
+		assert.Equal(t, 2*time.Second, third.Reset)
+	})
+
+	t.Run("should refill tokens over time", func(t *testing.T) {
+		// Setup
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		store := newTestMemoryStore(&now)
+		_, _ = store.Take(context.Background(), "user:1", limit)
+		_, _ = store.Take(context.Background(), "user:1", limit)
+
+		// When
+		now = now.Add(1500 * time.Millisecond)
+		result, err := store.Take(context.Background(), "user:1", limit)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+	})
+
+	t.Run("should keep a bucket per key", func(t *testing.T) {
+		// Setup
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		store := newTestMemoryStore(&now)
+		_, _ = store.Take(context.Background(), "user:1", limit)
+		_, _ = store.Take(context.Background(), "user:1", limit)
+
+		// When
+		result, err := store.Take(context.Background(), "user:2", limit)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("should drop refilled buckets", func(t *testing.T) {
+		// Setup
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		store := newTestMemoryStore(&now)
+		_, _ = store.Take(context.Background(), "user:1", limit)
+
+		// When
+		now = now.Add(sweepInterval)
+		_, _ = store.Take(context.Background(), "user:2", limit)
+
+		// Then
+		assert.NotContains(t, store.buckets, "user:1")
+		assert.Len(t, store.buckets, 1)
+	})
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	// Setup
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter, err := New(newTestMemoryStore(&now),
+		Limit{Requests: 100, Window: time.Minute},
+		map[string]Limit{"POST /api/v1/auth/login": {Requests: 1, Window: time.Minute}},
+	)
+	require.NoError(t, err)
+
+	// When
+	login, _ := limiter.Allow(context.Background(), "POST /api/v1/auth/login", "ip:192.0.2.10")
+	again, _ := limiter.Allow(context.Background(), "POST /api/v1/auth/login", "ip:192.0.2.10")
+	other, err := limiter.Allow(context.Background(), "GET /api/v1/nas", "ip:192.0.2.10")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, login.Allowed)
+	assert.False(t, again.Allowed)
+	assert.True(t, other.Allowed)
+	assert.Equal(t, 99, other.Remaining)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should reject an invalid default", func(t *testing.T) {
+		// When
+		_, err := New(NewMemoryStore(), Limit{Requests: 10}, nil)
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("should reject an invalid route limit", func(t *testing.T) {
+		// When
+		_, err := New(NewMemoryStore(), Limit{Requests: 10, Window: time.Second}, map[string]Limit{
+			"GET /api/v1/nas": {Requests: 0, Window: time.Second},
+		})
+
+		// Then
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+		assert.Contains(t, err.Error(), "GET /api/v1/nas")
+	})
+}
+
+func TestResult_Headers(t *testing.T) {
+	// Setup
+	limit := Limit{Requests: 600, Window: time.Minute, Burst: 100}
+
+	// When
+	denied := newResult(limit, 0.5, false).Headers()
+
+	// Then
+	assert.Equal(t, map[string]string{
+		"RateLimit-Limit":     "100",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "10",
+		"RateLimit-Policy":    "600;w=60;burst=100",
+		"Retry-After":         "1",
+	}, denied)
+	assert.NotContains(t, newResult(limit, 99, true).Headers(), "Retry-After")
+}
+
+func TestClient(t *testing.T) {
+	assert.Equal(t, "ip:192.0.2.10", Client(nil, "192.0.2.10"))
+	assert.Equal(t, "user:7", Client(&identity.Principal{UserID: 7, Email: "john@example.com"}, "192.0.2.10"))
+	assert.Equal(t, "key:a1b2c3d4e5f6", Client(&identity.Principal{UserID: 7, APIKey: "a1b2c3d4e5f6"}, "192.0.2.10"))
+}
+
+// fakeScripter answers script calls with reply, as Redis would.
+type fakeScripter struct {
+	redis.Scripter
+	reply interface{}
+	err   error
+	keys  []string
+	args  []interface{}
+}
+
+func (s *fakeScripter) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	s.keys, s.args = keys, args
+	return redis.NewCmdResult(s.reply, s.err)
+}
+
+func TestRedisStore_Take(t *testing.T) {
+	limit := Limit{Requests: 60, Window: time.Minute, Burst: 10}
+
+	t.Run("should read the reply of the script", func(t *testing.T) {
+		// Setup
+		client := &fakeScripter{reply: []interface{}{int64(1), "8.5"}}
+		store := NewRedisStore(client)
+
+		// When
+		result, err := store.Take(context.Background(), "*|user:1", limit)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 8, result.Remaining)
+		assert.Equal(t, []string{"ratelimit:*|user:1"}, client.keys)
+		assert.Equal(t, []interface{}{"1", 10}, client.args)
+	})
+
+	t.Run("should deny when the script does", func(t *testing.T) {
+		// Setup
+		store := NewRedisStore(&fakeScripter{reply: []interface{}{int64(0), "0.25"}})
+
+		// When
+		result, err := store.Take(context.Background(), "*|user:1", limit)
+
+		// Then
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 750*time.Millisecond, result.RetryAfter)
+	})
+
+	t.Run("should return Redis errors", func(t *testing.T) {
+		// Setup
+		store := NewRedisStore(&fakeScripter{err: errors.New("connection refused")})
+
+		// When
+		_, err := store.Take(context.Background(), "*|user:1", limit)
+
+		// Then
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces the buckets in Redis.
+const keyPrefix = "ratelimit:"
+
+// takeScript refills and takes from the bucket in KEYS[1], a hash of its
+// tokens and the time in microseconds it was last updated, atomically. It
+// uses the clock of the Redis server, so that replicas with skewed clocks
+// agree. ARGV holds the refill rate per second and the burst; the script
+// returns whether the request is allowed and the tokens left, as a string
+// since Redis truncates numbers returned by scripts.
+var takeScript = redis.NewScript(`
+if redis.replicate_commands then redis.replicate_commands() end
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) / 1000000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in Redis, shared by every API replica.
+type RedisStore struct {
+	client redis.Scripter
+}
+
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	rate := strconv.FormatFloat(limit.rate(), 'g', -1, 64)
+	values, err := takeScript.Run(ctx, s.client, []string{keyPrefix + key}, rate, limit.burst()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: take from %s: %w", key, err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("ratelimit: take from %s: unexpected reply %v", key, values)
+	}
+
+	allowed, _ := values[0].(int64)
+	text, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(tokens) {
+		return Result{}, fmt.Errorf("ratelimit: take from %s: unexpected tokens %v", key, values[1])
+	}
+	return newResult(limit, tokens, allowed == 1), nil
+}
@@ -0,0 +1,222 @@
+// Package rbac decides which roles may use which parts of the admin API.
+// Every route and gRPC method requires one Permission; the Policy maps each
+// Role to the permissions it grants and can be replaced at runtime. API keys
+// are not bound to a role but carry their own scopes.
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+)
+
+// ErrForbidden is returned when the role of the caller lacks a permission.
+var ErrForbidden = apperror.PermissionDenied("forbidden")
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleOperator Role = "operator"
+	RoleBilling  Role = "billing"
+	RoleSupport  Role = "support"
+	RoleReadOnly Role = "readonly"
+)
+
+// Roles lists the assignable roles.
+var Roles = []Role{RoleAdmin, RoleOperator, RoleBilling, RoleSupport, RoleReadOnly}
+
+// ValidRole reports whether role is one of Roles.
+func ValidRole(role string) bool {
+	return slices.Contains(Roles, Role(role))
+}
+
+// Permission is "<resource>:<action>". A policy may grant "<resource>:*" for
+// every action on a resource and "*" for everything.
+type Permission string
+
+const (
+	// Authenticated is required by routes any logged in user may use, such
+	// as managing their own login sessions.
+	Authenticated Permission = ""
+
+	UsersRead     Permission = "users:read"
+	UsersWrite    Permission = "users:write"
+	UsersRoles    Permission = "users:roles"
+	PaymentsRead  Permission = "payments:read"
+	PaymentsWrite Permission = "payments:write"
+	NASRead       Permission = "nas:read"
+	NASWrite      Permission = "nas:write"
+	NASSecrets    Permission = "nas:secrets"
+	NASReencrypt  Permission = "nas:reencrypt"
+	RadcheckRead  Permission = "radcheck:read"
+	RadcheckWrite Permission = "radcheck:write"
+	RadreplyRead  Permission = "radreply:read"
+	RadreplyWrite Permission = "radreply:write"
+	SessionsRead  Permission = "sessions:read"
+	SessionsWrite Permission = "sessions:write"
+	WebhooksRead  Permission = "webhooks:read"
+	WebhooksWrite Permission = "webhooks:write"
+	AuditRead     Permission = "audit:read"
+	APIKeysRead   Permission = "apikeys:read"
+	APIKeysWrite  Permission = "apikeys:write"
+	TenantsRead   Permission = "tenants:read"
+	TenantsWrite  Permission = "tenants:write"
+)
+
+// allPermissions is granted to roles that may do everything.
+const allPermissions Permission = "*"
+
+// Permissions lists the permissions routes can require.
+var Permissions = []Permission{
+	UsersRead, UsersWrite, UsersRoles,
+	PaymentsRead, PaymentsWrite,
+	NASRead, NASWrite, NASSecrets, NASReencrypt,
+	RadcheckRead, RadcheckWrite,
+	RadreplyRead, RadreplyWrite,
+	SessionsRead, SessionsWrite,
+	WebhooksRead, WebhooksWrite,
+	AuditRead,
+	APIKeysRead, APIKeysWrite,
+	TenantsRead, TenantsWrite,
+}
+
+// deploymentWide permissions act on every tenant at once, so principals
+// bound to a tenant are never granted them.
+var deploymentWide = []Permission{NASReencrypt, TenantsWrite, WebhooksRead, WebhooksWrite}
+
+// Policy is the permission matrix. It is safe for concurrent use.
+type Policy struct {
+	mu     sync.RWMutex
+	grants map[Role][]Permission
+}
+
+// NewPolicy builds a policy from a matrix of role names to granted
+// permissions, as found in the rbac.roles config.
+func NewPolicy(matrix map[string][]string) (*Policy, error) {
+	grants, err := parse(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{grants: grants}, nil
+}
+
+// Replace swaps in a new matrix. An invalid matrix leaves the policy as it
+// was.
+func (p *Policy) Replace(matrix map[string][]string) error {
+	grants, err := parse(matrix)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.grants = grants
+	p.mu.Unlock()
+	return nil
+}
+
+// Allows reports whether role grants permission. Authenticated is granted to
+// every role.
+func (p *Policy) Allows(role string, permission Permission) bool {
+	if permission == Authenticated {
+		return true
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, granted := range p.grants[Role(role)] {
+		if grants(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether role grants every permission scope stands for, so
+// that nobody can hand out more than they hold.
+func (p *Policy) Covers(role, scope string) bool {
+	if !ValidScope(scope) {
+		return false
+	}
+	for _, permission := range Permissions {
+		if grants(Permission(scope), permission) && !p.Allows(role, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check is Allows returning ErrForbidden naming the missing permission.
+func (p *Policy) Check(role string, permission Permission) error {
+	if !p.Allows(role, permission) {
+		return fmt.Errorf("%w: requires %s", ErrForbidden, permission)
+	}
+	return nil
+}
+
+// Authorize checks permission for principal: an API key against its scopes,
+// a user against the policy of their role. API keys are never granted
+// Authenticated, since those routes act on a user's own login sessions, and
+// principals of a tenant are never granted deployment-wide permissions.
+func (p *Policy) Authorize(principal *identity.Principal, permission Permission) error {
+	if principal.TenantID != nil && slices.Contains(deploymentWide, permission) {
+		return fmt.Errorf("%w: %s is not available to tenants", ErrForbidden, permission)
+	}
+	if principal.APIKey == "" {
+		return p.Check(principal.Role, permission)
+	}
+	if permission != Authenticated {
+		for _, scope := range principal.Scopes {
+			if grants(Permission(scope), permission) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: api key lacks scope %s", ErrForbidden, permission)
+}
+
+// ValidScope reports whether scope is a permission, a "<resource>:*"
+// wildcard or "*".
+func ValidScope(scope string) bool {
+	return validGrant(Permission(scope))
+}
+
+// grants reports whether the granted permission or wildcard includes
+// permission.
+func grants(granted, permission Permission) bool {
+	resource, _, _ := strings.Cut(string(permission), ":")
+	return granted == permission || granted == allPermissions || granted == Permission(resource+":*")
+}
+
+// parse validates the matrix so that a typo in the config fails loudly
+// instead of silently denying or granting access.
+func parse(matrix map[string][]string) (map[Role][]Permission, error) {
+	grants := make(map[Role][]Permission, len(matrix))
+	for name, permissions := range matrix {
+		if !ValidRole(name) {
+			return nil, fmt.Errorf("rbac: unknown role %q", name)
+		}
+		for _, permission := range permissions {
+			if !validGrant(Permission(permission)) {
+				return nil, fmt.Errorf("rbac: role %s: unknown permission %q", name, permission)
+			}
+			grants[Role(name)] = append(grants[Role(name)], Permission(permission))
+		}
+	}
+	return grants, nil
+}
+
+func validGrant(permission Permission) bool {
+	if permission == allPermissions || slices.Contains(Permissions, permission) {
+		return true
+	}
+	resource, action, ok := strings.Cut(string(permission), ":")
+	if !ok || action != "*" {
+		return false
+	}
+	return slices.ContainsFunc(Permissions, func(p Permission) bool {
+		return strings.HasPrefix(string(p), resource+":")
+	})
+}
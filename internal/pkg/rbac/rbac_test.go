@@ -0,0 +1,174 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{
+		"admin":    {"*"},
+		"operator": {"nas:*", "users:read"},
+		"billing":  {"payments:write"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		role       string
+		permission Permission
+		allowed    bool
+	}{
+		{"admin", NASSecrets, true},
+		{"operator", NASSecrets, true},
+		{"operator", UsersRead, true},
+		{"operator", UsersWrite, false},
+		{"billing", PaymentsWrite, true},
+		{"billing", PaymentsRead, false},
+		{"support", NASRead, false},
+		{"", NASRead, false},
+		{"support", Authenticated, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role+" "+string(tt.permission), func(t *testing.T) {
+			// When
+			allowed := policy.Allows(tt.role, tt.permission)
+
+			// Then
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{"readonly": {"nas:read"}})
+	require.NoError(t, err)
+
+	// When
+	err = policy.Check("readonly", NASWrite)
+
+	// Then
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.EqualError(t, err, "forbidden: requires nas:write")
+	assert.NoError(t, policy.Check("readonly", NASRead))
+}
+
+func TestPolicy_Covers(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{
+		"admin":    {"*"},
+		"operator": {"nas:read", "radcheck:*"},
+	})
+	require.NoError(t, err)
+
+	// Then
+	assert.True(t, policy.Covers("admin", "*"))
+	assert.True(t, policy.Covers("operator", "radcheck:*"))
+	assert.True(t, policy.Covers("operator", "radcheck:write"))
+	assert.True(t, policy.Covers("operator", "nas:read"))
+	assert.False(t, policy.Covers("operator", "nas:*"))
+	assert.False(t, policy.Covers("operator", "*"))
+	assert.False(t, policy.Covers("operator", "nas:reboot"))
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{"readonly": {"nas:read"}})
+	require.NoError(t, err)
+	key := &identity.Principal{APIKey: "a1b2c3d4e5f6", Scopes: []string{"radcheck:*", "nas:read"}}
+
+	// Then
+	assert.NoError(t, policy.Authorize(&identity.Principal{Role: "readonly"}, NASRead))
+	assert.ErrorIs(t, policy.Authorize(&identity.Principal{Role: "readonly"}, NASWrite), ErrForbidden)
+	assert.NoError(t, policy.Authorize(key, RadcheckWrite))
+	assert.NoError(t, policy.Authorize(key, NASRead))
+	assert.EqualError(t, policy.Authorize(key, NASWrite), "forbidden: api key lacks scope nas:write")
+	assert.ErrorIs(t, policy.Authorize(key, Authenticated), ErrForbidden)
+}
+
+func TestPolicy_Authorize_Tenant(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{"admin": {"*"}})
+	require.NoError(t, err)
+	tenantID := uint(1)
+	admin := &identity.Principal{Role: "admin", TenantID: &tenantID}
+
+	// Then
+	assert.NoError(t, policy.Authorize(admin, NASRead))
+	assert.NoError(t, policy.Authorize(admin, TenantsRead))
+	assert.ErrorIs(t, policy.Authorize(admin, TenantsWrite), ErrForbidden)
+	assert.ErrorIs(t, policy.Authorize(admin, WebhooksRead), ErrForbidden)
+	assert.NoError(t, policy.Authorize(&identity.Principal{Role: "admin"}, TenantsWrite))
+}
+
+func TestPolicy_Authorize_TenantNASReencrypt(t *testing.T) {
+	// Setup
+	policy, err := NewPolicy(map[string][]string{"operator": {"nas:*"}})
+	require.NoError(t, err)
+	tenantID := uint(1)
+	operator := &identity.Principal{Role: "operator", TenantID: &tenantID}
+	key := &identity.Principal{APIKey: "a1b2c3d4e5f6", Scopes: []string{"nas:*"}, TenantID: &tenantID}
+
+	// Then
+	assert.NoError(t, policy.Authorize(operator, NASWrite))
+	assert.NoError(t, policy.Authorize(operator, NASSecrets))
+	assert.ErrorIs(t, policy.Authorize(operator, NASReencrypt), ErrForbidden)
+	assert.ErrorIs(t, policy.Authorize(key, NASReencrypt), ErrForbidden)
+	assert.NoError(t, policy.Authorize(&identity.Principal{Role: "operator"}, NASReencrypt))
+}
+
+func TestPolicy_Replace(t *testing.T) {
+	t.Run("should apply the new matrix", func(t *testing.T) {
+		// Setup
+		policy, err := NewPolicy(map[string][]string{"support": {"nas:read"}})
+		require.NoError(t, err)
+
+		// When
+		err = policy.Replace(map[string][]string{"support": {"radcheck:write"}})
+
+		// Then
+		assert.NoError(t, err)
+		assert.False(t, policy.Allows("support", NASRead))
+		assert.True(t, policy.Allows("support", RadcheckWrite))
+	})
+
+	t.Run("should keep the previous matrix when the new one is invalid", func(t *testing.T) {
+		// Setup
+		policy, err := NewPolicy(map[string][]string{"support": {"nas:read"}})
+		require.NoError(t, err)
+
+		// When
+		err = policy.Replace(map[string][]string{"support": {"nas:raed"}})
+
+		// Then
+		assert.EqualError(t, err, `rbac: role support: unknown permission "nas:raed"`)
+		assert.True(t, policy.Allows("support", NASRead))
+	})
+}
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix map[string][]string
+		err    string
+	}{
+		{"unknown role", map[string][]string{"root": {"*"}}, `rbac: unknown role "root"`},
+		{"unknown resource wildcard", map[string][]string{"admin": {"secrets:*"}}, `rbac: role admin: unknown permission "secrets:*"`},
+		{"unknown permission", map[string][]string{"admin": {"nas:delete"}}, `rbac: role admin: unknown permission "nas:delete"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			policy, err := NewPolicy(tt.matrix)
+
+			// Then
+			assert.EqualError(t, err, tt.err)
+			assert.Nil(t, policy)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Package requestid correlates the logs, audit entries and tasks caused by
+// one API call. The REST middleware and the gRPC interceptors accept the ID
+// sent by the client, or generate one, and carry it in the context; tasks
+// enqueued by the call carry it in their payload.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header carries the request ID of REST calls, both ways.
+	Header = "X-Request-ID"
+	// MetadataKey carries the request ID of gRPC calls, both ways.
+	MetadataKey = "x-request-id"
+)
+
+// maxLength bounds the IDs accepted from clients, which end up in every log
+// line of the call.
+const maxLength = 128
+
+type idKey struct{}
+
+// New generates a request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Accept returns id when a client may choose it, and a new ID when id is
+// empty, too long or holds anything but printable ASCII.
+func Accept(id string) string {
+	if id == "" || len(id) > maxLength {
+		return New()
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return New()
+		}
+	}
+	return id
+}
+
+// WithID returns a copy of ctx carrying the request ID id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// FromContext returns the request ID of ctx, empty when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
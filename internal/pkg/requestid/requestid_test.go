@@ -0,0 +1,18 @@
+package requestid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccept(t *testing.T) {
+	assert.Equal(t, "req-123", Accept("req-123"))
+
+	for _, id := range []string{"", "req 123", "req\n123", strings.Repeat("r", maxLength+1)} {
+		got := Accept(id)
+		assert.NotEqual(t, id, got)
+		assert.Len(t, got, 36, "should generate a UUID for %q", id)
+	}
+}
@@ -0,0 +1,158 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+)
+
+// prefix marks values produced by Encrypt. The key ID follows the prefix so
+// that values written under an older key stay readable after rotation. v1
+// values were sealed without associated data and are still opened.
+const (
+	prefix       = "enc:v2:"
+	legacyPrefix = "enc:v1:"
+)
+
+var ErrUnknownKey = errors.New("secretbox: unknown key id")
+
+// Keyring encrypts values with AES-GCM under its active key and decrypts
+// values written under any configured key. A Keyring without an active key
+// stores values in plaintext, which keeps existing deployments working until
+// a key is configured.
+type Keyring struct {
+	activeID string
+	aeads    map[string]cipher.AEAD
+}
+
+// NewKeyring builds a Keyring from the secrets section of the configuration.
+// Keys are base64 encoded and must be 16, 24 or 32 bytes long.
+func NewKeyring(cfg *config.Config) (*Keyring, error) {
+	keys := make(map[string][]byte, len(cfg.Secrets.Keys))
+	for id, encoded := range cfg.Secrets.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("secretbox: key %q is not valid base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return New(cfg.Secrets.ActiveKey, keys)
+}
+
+// New builds a Keyring from raw keys. activeID may be empty to disable encryption.
+func New(activeID string, keys map[string][]byte) (*Keyring, error) {
+	k := &Keyring{
+		activeID: activeID,
+		aeads:    make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("secretbox: invalid key id %q", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("secretbox: key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("secretbox: key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+
+	if activeID != "" {
+		if _, ok := k.aeads[activeID]; !ok {
+			return nil, fmt.Errorf("secretbox: active key %q is not configured", activeID)
+		}
+	}
+
+	return k, nil
+}
+
+// Enabled reports whether new values are encrypted.
+func (k *Keyring) Enabled() bool {
+	return k != nil && k.activeID != ""
+}
+
+// AAD is the associated data of a value stored in a row of a table. Binding a
+// value to its row keeps a value copied into another row from decrypting.
+func AAD(table string, id uint) string {
+	return fmt.Sprintf("%s:%d", table, id)
+}
+
+// Encrypt seals plaintext under the active key, bound to aad. It returns
+// plaintext unchanged when encryption is disabled.
+func (k *Keyring) Encrypt(plaintext, aad string) (string, error) {
+	if !k.Enabled() {
+		return plaintext, nil
+	}
+
+	aead := k.aeads[k.activeID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+	return prefix + k.activeID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt with the same aad. Values without
+// the encryption prefix are returned as is, so rows written before encryption
+// was enabled remain readable.
+func (k *Keyring) Decrypt(value, aad string) (string, error) {
+	var data []byte
+	switch {
+	case strings.HasPrefix(value, prefix):
+		value, data = strings.TrimPrefix(value, prefix), []byte(aad)
+	case strings.HasPrefix(value, legacyPrefix):
+		value = strings.TrimPrefix(value, legacyPrefix)
+	default:
+		return value, nil
+	}
+
+	id, payload, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", errors.New("secretbox: malformed value")
+	}
+
+	var aead cipher.AEAD
+	if k != nil {
+		aead = k.aeads[id]
+	}
+	if aead == nil {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("secretbox: malformed value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("secretbox: malformed value")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], data)
+	if err != nil {
+		return "", fmt.Errorf("secretbox: decrypt with key %q: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// IsCurrent reports whether value is already stored the way Encrypt would
+// store it now: under the active key with its associated data, or in
+// plaintext when encryption is disabled. Values that are not current need to
+// be re-encrypted.
+func (k *Keyring) IsCurrent(value string) bool {
+	if !k.Enabled() {
+		return !strings.HasPrefix(value, prefix) && !strings.HasPrefix(value, legacyPrefix)
+	}
+	return strings.HasPrefix(value, prefix+k.activeID+":")
+}
@@ -0,0 +1,182 @@
+package secretbox
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey = bytes.Repeat([]byte{0x01}, 32)
+	newKey = bytes.Repeat([]byte{0x02}, 32)
+)
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	t.Run("should round-trip under the active key", func(t *testing.T) {
+		// Given
+		keyring, err := New("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+
+		// When
+		encrypted, err := keyring.Encrypt("testing123", "nas:1")
+		require.NoError(t, err)
+		decrypted, err := keyring.Decrypt(encrypted, "nas:1")
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(encrypted, "enc:v2:k1:"))
+		assert.NotContains(t, encrypted, "testing123")
+		assert.Equal(t, "testing123", decrypted)
+		assert.True(t, keyring.IsCurrent(encrypted))
+	})
+
+	t.Run("should use a fresh nonce for every value", func(t *testing.T) {
+		// Given
+		keyring, err := New("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+
+		// When
+		first, _ := keyring.Encrypt("same", "nas:1")
+		second, _ := keyring.Encrypt("same", "nas:1")
+
+		// Then
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("should decrypt values written under a retired key", func(t *testing.T) {
+		// Given
+		oldRing, err := New("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+		encrypted, err := oldRing.Encrypt("legacy", "nas:1")
+		require.NoError(t, err)
+
+		rotated, err := New("k2", map[string][]byte{"k1": oldKey, "k2": newKey})
+		require.NoError(t, err)
+
+		// When
+		decrypted, err := rotated.Decrypt(encrypted, "nas:1")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "legacy", decrypted)
+		assert.False(t, rotated.IsCurrent(encrypted))
+	})
+
+	t.Run("should fail for unknown key", func(t *testing.T) {
+		// Given
+		oldRing, _ := New("k1", map[string][]byte{"k1": oldKey})
+		encrypted, _ := oldRing.Encrypt("secret", "nas:1")
+		other, _ := New("k2", map[string][]byte{"k2": newKey})
+
+		// When
+		_, err := other.Decrypt(encrypted, "nas:1")
+
+		// Then
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("should fail for tampered value", func(t *testing.T) {
+		// Given
+		keyring, _ := New("k1", map[string][]byte{"k1": oldKey})
+		encrypted, _ := keyring.Encrypt("secret", "nas:1")
+		tampered := encrypted[:len(encrypted)-4] + "AAAA"
+
+		// When
+		_, err := keyring.Decrypt(tampered, "nas:1")
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should not decrypt a value copied to another row", func(t *testing.T) {
+		// Given
+		keyring, _ := New("k1", map[string][]byte{"k1": oldKey})
+		encrypted, _ := keyring.Encrypt("secret", AAD("nas", 1))
+
+		// When
+		_, err := keyring.Decrypt(encrypted, AAD("nas", 2))
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should decrypt v1 values sealed without associated data", func(t *testing.T) {
+		// Given
+		keyring, _ := New("k1", map[string][]byte{"k1": oldKey})
+		aead := keyring.aeads["k1"]
+		nonce := make([]byte, aead.NonceSize())
+		sealed := aead.Seal(nonce, nonce, []byte("legacy"), nil)
+		encrypted := "enc:v1:k1:" + base64.StdEncoding.EncodeToString(sealed)
+
+		// When
+		decrypted, err := keyring.Decrypt(encrypted, AAD("nas", 1))
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "legacy", decrypted)
+		assert.False(t, keyring.IsCurrent(encrypted))
+	})
+
+	t.Run("should pass plaintext through when disabled", func(t *testing.T) {
+		// Given
+		keyring, err := New("", nil)
+		require.NoError(t, err)
+
+		// When
+		encrypted, err := keyring.Encrypt("plain", "nas:1")
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "plain", encrypted)
+		assert.False(t, keyring.Enabled())
+		assert.True(t, keyring.IsCurrent("plain"))
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	t.Run("should load base64 keys from config", func(t *testing.T) {
+		// Given
+		cfg := &config.Config{Secrets: config.SecretsConfig{
+			ActiveKey: "k1",
+			Keys:      map[string]string{"k1": base64.StdEncoding.EncodeToString(oldKey)},
+		}}
+
+		// When
+		keyring, err := NewKeyring(cfg)
+
+		// Then
+		require.NoError(t, err)
+		assert.True(t, keyring.Enabled())
+	})
+
+	t.Run("should reject missing active key", func(t *testing.T) {
+		// Given
+		cfg := &config.Config{Secrets: config.SecretsConfig{ActiveKey: "missing"}}
+
+		// When
+		_, err := NewKeyring(cfg)
+
+		// Then
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject invalid key length", func(t *testing.T) {
+		// Given
+		cfg := &config.Config{Secrets: config.SecretsConfig{
+			ActiveKey: "k1",
+			Keys:      map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))},
+		}}
+
+		// When
+		_, err := NewKeyring(cfg)
+
+		// Then
+		assert.Error(t, err)
+	})
+}
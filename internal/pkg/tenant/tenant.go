@@ -0,0 +1,156 @@
+// Package tenant isolates the ISPs sharing one deployment. A request acting
+// for a tenant carries its Scope in the context; repositories filter every
+// query by it and stamp it on the rows they create. Without a scope, as for
+// super-admins, workers and the CLI, queries see every tenant.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/listquery"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound is returned for tenant IDs that do not exist.
+	ErrNotFound = apperror.NotFound("tenant not found")
+	// ErrForeignTenant is returned when a principal bound to a tenant asks to
+	// act for another one.
+	ErrForeignTenant = apperror.PermissionDenied("cannot act for another tenant")
+	// ErrForeignRealm is returned for usernames in the realm of another
+	// tenant.
+	ErrForeignRealm = apperror.InvalidField("username", "belongs to another realm")
+	// ErrUsernameTooLong is returned for usernames that do not fit the
+	// FreeRADIUS tables once qualified.
+	ErrUsernameTooLong = apperror.InvalidField("username", fmt.Sprintf("exceeds %d characters", maxUsernameLength))
+)
+
+// maxUsernameLength is the size of the username column of the FreeRADIUS
+// tables.
+const maxUsernameLength = 64
+
+// Scope is the tenant a request acts for.
+type Scope struct {
+	ID    uint
+	Realm string
+}
+
+// Resolver looks up the scope of a tenant, returning ErrNotFound for
+// unknown IDs.
+type Resolver interface {
+	Resolve(ctx context.Context, id uint) (Scope, error)
+}
+
+type scopeKey struct{}
+
+// WithScope returns a copy of ctx acting for scope.
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// From returns the scope of ctx, if any.
+func From(ctx context.Context) (Scope, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(Scope)
+	return scope, ok
+}
+
+// ID returns the tenant ID new rows created under ctx belong to, nil when
+// ctx is not scoped.
+func ID(ctx context.Context) *uint {
+	scope, ok := From(ctx)
+	if !ok {
+		return nil
+	}
+	return &scope.ID
+}
+
+// Filter is a gorm scope restricting a query on a table with a tenant_id
+// column to the tenant of ctx.
+func Filter(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope, ok := From(ctx); ok {
+			return db.Where("tenant_id = ?", scope.ID)
+		}
+		return db
+	}
+}
+
+// Resolve returns the scope a request of principal acts in. A principal
+// bound to a tenant always acts for it; others act for the tenant they
+// request, or for none when requested is empty.
+func Resolve(ctx context.Context, resolver Resolver, principal *identity.Principal, requested string) (Scope, bool, error) {
+	var requestedID uint
+	if requested != "" {
+		id, err := strconv.ParseUint(requested, 10, 32)
+		if err != nil || id == 0 {
+			return Scope{}, false, fmt.Errorf("%w: invalid tenant id %q", ErrNotFound, requested)
+		}
+		requestedID = uint(id)
+	}
+
+	id := requestedID
+	if principal.TenantID != nil {
+		if requestedID != 0 && requestedID != *principal.TenantID {
+			return Scope{}, false, ErrForeignTenant
+		}
+		id = *principal.TenantID
+	}
+	if id == 0 {
+		return Scope{}, false, nil
+	}
+
+	scope, err := resolver.Resolve(ctx, id)
+	if err != nil {
+		return Scope{}, false, err
+	}
+	return scope, true, nil
+}
+
+// QualifyUsername places username in the realm of the tenant of ctx, so
+// that "john" becomes "john@isp-a.example". Usernames already in the realm
+// are kept and usernames in another realm rejected. Without a scope the
+// username is returned unchanged.
+func QualifyUsername(ctx context.Context, username string) (string, error) {
+	scope, ok := From(ctx)
+	if !ok || username == "" {
+		return username, nil
+	}
+
+	qualified := username
+	if strings.Contains(username, "@") {
+		if !InRealm(username, scope.Realm) {
+			return "", fmt.Errorf("%w: %s is not in %s", ErrForeignRealm, username, scope.Realm)
+		}
+	} else {
+		qualified = username + "@" + scope.Realm
+	}
+	if len(qualified) > maxUsernameLength {
+		return "", fmt.Errorf("%w: %s", ErrUsernameTooLong, qualified)
+	}
+	return qualified, nil
+}
+
+// InRealm reports whether username carries the realm suffix.
+func InRealm(username, realm string) bool {
+	at := strings.LastIndex(username, "@")
+	return at >= 0 && strings.EqualFold(username[at+1:], realm)
+}
+
+// RealmFilter is a gorm scope restricting a query on a username column to
+// the realm of the tenant of ctx, for tables such as the accounting log that
+// have no tenant_id. Realms match regardless of case, as in InRealm, on
+// databases whose LIKE is case sensitive too.
+func RealmFilter(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope, ok := From(ctx); ok {
+			return db.Where("LOWER(username) LIKE ? ESCAPE '!'", "%@"+listquery.EscapeLike(strings.ToLower(scope.Realm)))
+		}
+		return db
+	}
+}
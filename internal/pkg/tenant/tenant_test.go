@@ -0,0 +1,159 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fakeResolver map[uint]string
+
+func (r fakeResolver) Resolve(ctx context.Context, id uint) (Scope, error) {
+	realm, ok := r[id]
+	if !ok {
+		return Scope{}, ErrNotFound
+	}
+	return Scope{ID: id, Realm: realm}, nil
+}
+
+func TestResolve(t *testing.T) {
+	resolver := fakeResolver{1: "isp-a.example", 2: "isp-b.example"}
+	tenantID := uint(1)
+	bound := &identity.Principal{UserID: 2, Role: "admin", TenantID: &tenantID}
+	superAdmin := &identity.Principal{UserID: 1, Role: "admin"}
+
+	tests := []struct {
+		name      string
+		principal *identity.Principal
+		requested string
+		want      Scope
+		scoped    bool
+		wantErr   error
+	}{
+		{name: "bound principal", principal: bound, want: Scope{ID: 1, Realm: "isp-a.example"}, scoped: true},
+		{name: "bound principal naming its tenant", principal: bound, requested: "1", want: Scope{ID: 1, Realm: "isp-a.example"}, scoped: true},
+		{name: "bound principal naming another tenant", principal: bound, requested: "2", wantErr: ErrForeignTenant},
+		{name: "super-admin", principal: superAdmin},
+		{name: "super-admin naming a tenant", principal: superAdmin, requested: "2", want: Scope{ID: 2, Realm: "isp-b.example"}, scoped: true},
+		{name: "unknown tenant", principal: superAdmin, requested: "3", wantErr: ErrNotFound},
+		{name: "invalid tenant", principal: superAdmin, requested: "isp-a", wantErr: ErrNotFound},
+		{name: "zero tenant", principal: superAdmin, requested: "0", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			scope, scoped, err := Resolve(context.Background(), resolver, tt.principal, tt.requested)
+
+			// Then
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.scoped, scoped)
+			assert.Equal(t, tt.want, scope)
+		})
+	}
+}
+
+func TestQualifyUsername(t *testing.T) {
+	ctx := WithScope(context.Background(), Scope{ID: 1, Realm: "isp-a.example"})
+
+	tests := []struct {
+		name     string
+		username string
+		want     string
+		wantErr  error
+	}{
+		{name: "bare username", username: "john", want: "john@isp-a.example"},
+		{name: "username in the realm", username: "john@isp-a.example", want: "john@isp-a.example"},
+		{name: "realm in another case", username: "john@ISP-A.example", want: "john@ISP-A.example"},
+		{name: "username in another realm", username: "john@isp-b.example", wantErr: ErrForeignRealm},
+		{name: "realm as a suffix of another", username: "john@x.isp-a.example", wantErr: ErrForeignRealm},
+		{name: "empty", username: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			username, err := QualifyUsername(ctx, tt.username)
+
+			// Then
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, username)
+		})
+	}
+
+	t.Run("should keep usernames without a scope", func(t *testing.T) {
+		// When
+		username, err := QualifyUsername(context.Background(), "john@isp-b.example")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, "john@isp-b.example", username)
+	})
+
+	t.Run("should reject usernames too long for the realm", func(t *testing.T) {
+		// When
+		_, err := QualifyUsername(ctx, strings.Repeat("j", 60))
+
+		// Then
+		assert.ErrorIs(t, err, ErrUsernameTooLong)
+		assert.Equal(t, http.StatusBadRequest, apperror.HTTPStatus(err))
+		assert.Equal(t, codes.InvalidArgument, apperror.GRPCCode(err))
+	})
+}
+
+func TestFilter(t *testing.T) {
+	// Setup
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true, Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	type row struct {
+		ID       uint
+		Username string
+		TenantID *uint
+	}
+	scoped := WithScope(context.Background(), Scope{ID: 7, Realm: "isp-a.example"})
+
+	t.Run("should filter by the tenant of the context", func(t *testing.T) {
+		// When
+		stmt := db.Scopes(Filter(scoped)).Find(&[]row{}).Statement
+
+		// Then
+		assert.Contains(t, stmt.SQL.String(), "tenant_id = ?")
+		assert.Equal(t, []interface{}{uint(7)}, stmt.Vars)
+	})
+
+	t.Run("should filter by the realm of the context", func(t *testing.T) {
+		// When
+		stmt := db.Scopes(RealmFilter(scoped)).Find(&[]row{}).Statement
+
+		// Then
+		assert.Contains(t, stmt.SQL.String(), "LOWER(username) LIKE ? ESCAPE '!'")
+		assert.Equal(t, []interface{}{"%@isp-a.example"}, stmt.Vars)
+	})
+
+	t.Run("should not filter without a scope", func(t *testing.T) {
+		// When
+		stmt := db.Scopes(Filter(context.Background()), RealmFilter(context.Background())).Find(&[]row{}).Statement
+
+		// Then
+		assert.NotContains(t, stmt.SQL.String(), "WHERE")
+	})
+}
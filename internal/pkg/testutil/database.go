@@ -1,17 +1,43 @@
 package testutil
 
 import (
+	apikeyEntity "github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	authnEntity "github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
 	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
 	paymentEntity "github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
 	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	radreplyEntity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
+	sessionEntity "github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	tenantEntity "github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
 	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	webhookEntity "github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// Models returns a model of every table of the service.
+func Models() []interface{} {
+	return []interface{}{
+		&userEntity.User{},
+		&paymentEntity.Payment{},
+		&nasEntity.NAS{},
+		&radcheckEntity.Radcheck{},
+		&radreplyEntity.Radreply{},
+		&sessionEntity.SessionEvent{},
+		&outbox.Message{},
+		&webhookEntity.Subscription{},
+		&webhookEntity.Delivery{},
+		&audit.Entry{},
+		&authnEntity.Session{},
+		&apikeyEntity.APIKey{},
+		&tenantEntity.Tenant{},
+	}
+}
+
 // SetupTestDB creates an in-memory SQLite database for testing
 func SetupTestDB() (*gorm.DB, error) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
@@ -22,13 +48,7 @@ func SetupTestDB() (*gorm.DB, error) {
 	}
 
 	// Auto-migrate all entities
-	err = db.AutoMigrate(
-		&userEntity.User{},
-		&paymentEntity.Payment{},
-		&nasEntity.NAS{},
-		&radcheckEntity.Radcheck{},
-		&radreplyEntity.Radreply{},
-	)
+	err = db.AutoMigrate(Models()...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,5 +74,29 @@ func CleanDB(db *gorm.DB) error {
 	if err := db.Exec("DELETE FROM radreply").Error; err != nil {
 		return err
 	}
+	if err := db.Exec("DELETE FROM session_events").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM outbox_messages").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM webhook_deliveries").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM webhook_subscriptions").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM audit_entries").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM admin_sessions").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM api_keys").Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DELETE FROM tenants").Error; err != nil {
+		return err
+	}
 	return nil
 }
@@ -1,6 +1,10 @@
 package testutil
 
 import (
+	"time"
+
+	apikeyEntity "github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	authnEntity "github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
 	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
 	paymentDto "github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
@@ -11,6 +15,9 @@ import (
 	radreplyEntity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
 	userDto "github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	webhookDto "github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	webhookEntity "github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
 )
 
 // User fixtures
@@ -20,6 +27,7 @@ func CreateUserFixture() *userEntity.User {
 		Name:     "John Doe",
 		Email:    "john@example.com",
 		Password: "$2a$10$example.hashed.password",
+		Role:     "readonly",
 	}
 }
 
@@ -81,7 +89,7 @@ func CreateNASFixture() *nasEntity.NAS {
 	ports := 1812
 	return &nasEntity.NAS{
 		ID:              1,
-		NASName:         "test-nas-01",
+		NASName:         "192.168.1.10",
 		ShortName:       "test-nas",
 		Type:            "other",
 		Ports:           ports,
@@ -97,7 +105,7 @@ func CreateNASFixture() *nasEntity.NAS {
 func CreateNASRequestFixture() *nasDto.CreateNASRequest {
 	ports := 1812
 	return &nasDto.CreateNASRequest{
-		NASName:         "test-nas-01",
+		NASName:         "192.168.1.10",
 		ShortName:       "test-nas",
 		Type:            "other",
 		Ports:           &ports,
@@ -113,7 +121,7 @@ func CreateNASRequestFixture() *nasDto.CreateNASRequest {
 func CreateUpdateNASRequestFixture() *nasDto.UpdateNASRequest {
 	ports := 1813
 	return &nasDto.UpdateNASRequest{
-		NASName:     "updated-nas-01",
+		NASName:     "192.168.1.11",
 		Description: "Updated NAS",
 		Ports:       &ports,
 	}
@@ -205,3 +213,81 @@ func CreateRadreplyFilterFixture() *radreplyDto.RadreplyFilter {
 		PageSize:  10,
 	}
 }
+
+// Webhook fixtures
+func CreateWebhookSubscriptionFixture() *webhookEntity.Subscription {
+	return &webhookEntity.Subscription{
+		ID:          1,
+		URL:         "https://billing.example.com/hooks/radius",
+		EventTypes:  "payment.completed,subscriber.created",
+		Secret:      "whsec_0123456789abcdef0123456789abcdef",
+		Description: "Billing portal",
+		Active:      true,
+	}
+}
+
+func CreateWebhookDeliveryFixture() *webhookEntity.Delivery {
+	return &webhookEntity.Delivery{
+		ID:             1,
+		SubscriptionID: 1,
+		EventID:        "7b0f9c9e-3c1d-4f4e-9a51-6f3f4f7f2a10",
+		EventType:      "payment.completed",
+		Payload:        `{"id":"7b0f9c9e-3c1d-4f4e-9a51-6f3f4f7f2a10","type":"payment.completed"}`,
+		Status:         webhookEntity.DeliveryStatusPending,
+	}
+}
+
+func CreateWebhookRequestFixture() *webhookDto.CreateWebhookRequest {
+	return &webhookDto.CreateWebhookRequest{
+		URL:         "https://billing.example.com/hooks/radius",
+		EventTypes:  []string{"payment.completed", "subscriber.created"},
+		Description: "Billing portal",
+	}
+}
+
+// Audit fixtures
+func CreateAuditEntryFixture() *audit.Entry {
+	return &audit.Entry{
+		ID:         1,
+		OccurredAt: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		Actor:      "admin@example.com",
+		Source:     audit.SourceREST,
+		RequestID:  "req-1",
+		EntityType: audit.EntityNAS,
+		EntityID:   "1",
+		Action:     audit.ActionUpdate,
+		Changes:    `{"description":{"before":"Main router","after":"Core router"}}`,
+	}
+}
+
+// Login session fixtures
+func CreateAdminSessionFixture() *authnEntity.Session {
+	now := time.Now()
+	return &authnEntity.Session{
+		ID:         "3f6c1a52-9d7e-4b8a-a1f0-2c5d8e7b9a14",
+		UserID:     1,
+		UserAgent:  "curl/8.5.0",
+		ClientIP:   "192.0.2.10",
+		CreatedAt:  now.Add(-time.Hour),
+		LastUsedAt: now.Add(-time.Hour),
+		ExpiresAt:  now.Add(30 * 24 * time.Hour),
+	}
+}
+
+// API key fixtures
+
+// APIKeyFixtureKey is the key matching CreateAPIKeyFixture.
+const APIKeyFixtureKey = "frs_a1b2c3d4e5f6_c2VjcmV0LWtleS1tYXRlcmlhbC1mb3ItdGVzdHM"
+
+func CreateAPIKeyFixture() *apikeyEntity.APIKey {
+	return &apikeyEntity.APIKey{
+		ID:           1,
+		Name:         "Provisioning",
+		Prefix:       "a1b2c3d4e5f6",
+		SecretHash:   "5b8fc040454166bf3b4a5e11c21adc89901dfa70b545927d3251d3d2481bf7b4",
+		Scopes:       "radcheck:write,radreply:write",
+		AllowedCIDRs: "10.0.0.0/8",
+		CreatedBy:    1,
+		CreatedAt:    time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,21 @@
+package testutil
+
+import (
+	"bytes"
+
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/secretbox"
+)
+
+// TestKeyID is the active key ID of the keyring returned by NewTestKeyring
+const TestKeyID = "test"
+
+// NewTestKeyring creates a keyring with a fixed AES-256 key for testing
+func NewTestKeyring() *secretbox.Keyring {
+	keyring, err := secretbox.New(TestKeyID, map[string][]byte{
+		TestKeyID: bytes.Repeat([]byte{0x42}, 32),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return keyring
+}
@@ -2,7 +2,13 @@ package testutil
 
 import (
 	"context"
+	"time"
 
+	apikeyDto "github.com/novriyantoAli/freeradius-service/internal/application/apikey/dto"
+	apikeyEntity "github.com/novriyantoAli/freeradius-service/internal/application/apikey/entity"
+	auditDto "github.com/novriyantoAli/freeradius-service/internal/application/audit/dto"
+	authnDto "github.com/novriyantoAli/freeradius-service/internal/application/authn/dto"
+	authnEntity "github.com/novriyantoAli/freeradius-service/internal/application/authn/entity"
 	nasDto "github.com/novriyantoAli/freeradius-service/internal/application/nas/dto"
 	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
 	paymentDto "github.com/novriyantoAli/freeradius-service/internal/application/payment/dto"
@@ -11,8 +17,19 @@ import (
 	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
 	radreplyDto "github.com/novriyantoAli/freeradius-service/internal/application/radreply/dto"
 	radreplyEntity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
+	sessionDto "github.com/novriyantoAli/freeradius-service/internal/application/session/dto"
+	sessionEntity "github.com/novriyantoAli/freeradius-service/internal/application/session/entity"
+	tenantDto "github.com/novriyantoAli/freeradius-service/internal/application/tenant/dto"
+	tenantEntity "github.com/novriyantoAli/freeradius-service/internal/application/tenant/entity"
 	userDto "github.com/novriyantoAli/freeradius-service/internal/application/user/dto"
 	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	webhookDto "github.com/novriyantoAli/freeradius-service/internal/application/webhook/dto"
+	webhookEntity "github.com/novriyantoAli/freeradius-service/internal/application/webhook/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/pagination"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -22,100 +39,105 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) Create(user *userEntity.User) error {
-	args := m.Called(user)
+func (m *MockUserRepository) Create(ctx context.Context, user *userEntity.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetByID(id uint) (*userEntity.User, error) {
-	args := m.Called(id)
+func (m *MockUserRepository) GetByID(ctx context.Context, id uint) (*userEntity.User, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userEntity.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*userEntity.User, error) {
-	args := m.Called(email)
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*userEntity.User, error) {
+	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userEntity.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetAll(filter *userDto.UserFilter) ([]userEntity.User, int64, error) {
-	args := m.Called(filter)
+func (m *MockUserRepository) GetAll(ctx context.Context, filter *userDto.UserFilter) ([]userEntity.User, pagination.Page, error) {
+	args := m.Called(ctx, filter)
 	var users []userEntity.User
 	if args.Get(0) != nil {
 		users = args.Get(0).([]userEntity.User)
 	}
 
-	var count int64
+	var page pagination.Page
 	if args.Get(1) != nil {
-		count = args.Get(1).(int64)
+		page = args.Get(1).(pagination.Page)
 	}
-	return users, count, args.Error(2)
+	return users, page, args.Error(2)
 }
 
-func (m *MockUserRepository) Update(user *userEntity.User) error {
-	args := m.Called(user)
+func (m *MockUserRepository) Update(ctx context.Context, user *userEntity.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) EmailExists(email string) (bool, error) {
-	args := m.Called(email)
+func (m *MockUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockUserRepository) CountByRole(ctx context.Context, tenantID *uint, role string) (int64, error) {
+	args := m.Called(ctx, tenantID, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockPaymentRepository is a mock implementation of PaymentRepository
 type MockPaymentRepository struct {
 	mock.Mock
 }
 
-func (m *MockPaymentRepository) Create(payment *paymentEntity.Payment) error {
-	args := m.Called(payment)
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *paymentEntity.Payment) error {
+	args := m.Called(ctx, payment)
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetByID(id uint) (*paymentEntity.Payment, error) {
-	args := m.Called(id)
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id uint) (*paymentEntity.Payment, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*paymentEntity.Payment), args.Error(1)
 }
 
-func (m *MockPaymentRepository) GetAll(filter *paymentDto.PaymentFilter) ([]paymentEntity.Payment, int64, error) {
-	args := m.Called(filter)
+func (m *MockPaymentRepository) GetAll(ctx context.Context, filter *paymentDto.PaymentFilter) ([]paymentEntity.Payment, pagination.Page, error) {
+	args := m.Called(ctx, filter)
 	var payments []paymentEntity.Payment
 	if args.Get(0) != nil {
 		payments = args.Get(0).([]paymentEntity.Payment)
 	}
 
-	var count int64
+	var page pagination.Page
 	if args.Get(1) != nil {
-		count = args.Get(1).(int64)
+		page = args.Get(1).(pagination.Page)
 	}
-	return payments, count, args.Error(2)
+	return payments, page, args.Error(2)
 }
 
-func (m *MockPaymentRepository) Update(payment *paymentEntity.Payment) error {
-	args := m.Called(payment)
+func (m *MockPaymentRepository) Update(ctx context.Context, payment *paymentEntity.Payment) error {
+	args := m.Called(ctx, payment)
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockPaymentRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetByUserID(userID uint) ([]paymentEntity.Payment, error) {
-	args := m.Called(userID)
+func (m *MockPaymentRepository) GetByUserID(ctx context.Context, userID uint) ([]paymentEntity.Payment, error) {
+	args := m.Called(ctx, userID)
 	var payments []paymentEntity.Payment
 	if args.Get(0) != nil {
 		payments = args.Get(0).([]paymentEntity.Payment)
@@ -128,48 +150,90 @@ type MockNASRepository struct {
 	mock.Mock
 }
 
-func (m *MockNASRepository) Create(nas *nasEntity.NAS) error {
-	args := m.Called(nas)
+func (m *MockNASRepository) Create(ctx context.Context, nas *nasEntity.NAS) error {
+	args := m.Called(ctx, nas)
 	return args.Error(0)
 }
 
-func (m *MockNASRepository) GetByID(id uint) (*nasEntity.NAS, error) {
-	args := m.Called(id)
+func (m *MockNASRepository) GetByID(ctx context.Context, id uint) (*nasEntity.NAS, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasEntity.NAS), args.Error(1)
 }
 
-func (m *MockNASRepository) GetByNASName(nasname string) (*nasEntity.NAS, error) {
-	args := m.Called(nasname)
+func (m *MockNASRepository) GetByNASName(ctx context.Context, nasname string) (*nasEntity.NAS, error) {
+	args := m.Called(ctx, nasname)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasEntity.NAS), args.Error(1)
 }
 
-func (m *MockNASRepository) GetAll(filter *nasDto.NASFilter) ([]nasEntity.NAS, int64, error) {
-	args := m.Called(filter)
+func (m *MockNASRepository) GetAll(ctx context.Context, filter *nasDto.NASFilter) ([]nasEntity.NAS, pagination.Page, error) {
+	args := m.Called(ctx, filter)
 	var nasList []nasEntity.NAS
 	if args.Get(0) != nil {
 		nasList = args.Get(0).([]nasEntity.NAS)
 	}
 
-	var count int64
+	var page pagination.Page
 	if args.Get(1) != nil {
-		count = args.Get(1).(int64)
+		page = args.Get(1).(pagination.Page)
 	}
-	return nasList, count, args.Error(2)
+	return nasList, page, args.Error(2)
 }
 
-func (m *MockNASRepository) Update(nas *nasEntity.NAS) error {
-	args := m.Called(nas)
+func (m *MockNASRepository) ListAll(ctx context.Context) ([]nasEntity.NAS, error) {
+	args := m.Called(ctx)
+	var nasList []nasEntity.NAS
+	if args.Get(0) != nil {
+		nasList = args.Get(0).([]nasEntity.NAS)
+	}
+	return nasList, args.Error(1)
+}
+
+func (m *MockNASRepository) ReencryptSecrets(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNASRepository) ListAddresses(ctx context.Context) ([]nasEntity.NAS, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]nasEntity.NAS), args.Error(1)
+}
+
+func (m *MockNASRepository) ListHealthCheckTargets(ctx context.Context) ([]nasEntity.NAS, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]nasEntity.NAS), args.Error(1)
+}
+
+func (m *MockNASRepository) UpdateHealth(
+	ctx context.Context,
+	id uint,
+	status string,
+	lastSeenAt *time.Time,
+	checkedAt time.Time,
+	rttMs int64,
+) error {
+	args := m.Called(ctx, id, status, lastSeenAt, checkedAt, rttMs)
 	return args.Error(0)
 }
 
-func (m *MockNASRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockNASRepository) Update(ctx context.Context, nas *nasEntity.NAS) error {
+	args := m.Called(ctx, nas)
+	return args.Error(0)
+}
+
+func (m *MockNASRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
@@ -178,53 +242,61 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) CreateUser(req *userDto.CreateUserRequest) (*userDto.UserResponse, error) {
-	args := m.Called(req)
+func (m *MockUserService) CreateUser(ctx context.Context, req *userDto.CreateUserRequest) (*userDto.UserResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetUserByID(id uint) (*userDto.UserResponse, error) {
-	args := m.Called(id)
+func (m *MockUserService) GetUserByID(ctx context.Context, id uint) (*userDto.UserResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetUserByEmail(email string) (*userDto.UserResponse, error) {
-	args := m.Called(email)
+func (m *MockUserService) GetUserByEmail(ctx context.Context, email string) (*userDto.UserResponse, error) {
+	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetUsers(filter *userDto.UserFilter) (*userDto.UserListResponse, error) {
-	args := m.Called(filter)
+func (m *MockUserService) GetUsers(ctx context.Context, filter *userDto.UserFilter) (*userDto.UserListResponse, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDto.UserListResponse), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUser(id uint, req *userDto.UpdateUserRequest) (*userDto.UserResponse, error) {
-	args := m.Called(id, req)
+func (m *MockUserService) UpdateUser(ctx context.Context, id uint, req *userDto.UpdateUserRequest) (*userDto.UserResponse, error) {
+	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUserPassword(id uint, req *userDto.UpdateUserPasswordRequest) error {
-	args := m.Called(id, req)
+func (m *MockUserService) UpdateUserPassword(ctx context.Context, id uint, req *userDto.UpdateUserPasswordRequest) error {
+	args := m.Called(ctx, id, req)
 	return args.Error(0)
 }
 
-func (m *MockUserService) DeleteUser(id uint) error {
-	args := m.Called(id)
+func (m *MockUserService) UpdateUserRole(ctx context.Context, id uint, req *userDto.UpdateUserRoleRequest) (*userDto.UserResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDto.UserResponse), args.Error(1)
+}
+
+func (m *MockUserService) DeleteUser(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
@@ -233,40 +305,106 @@ type MockNASService struct {
 	mock.Mock
 }
 
-func (m *MockNASService) CreateNAS(req *nasDto.CreateNASRequest) (*nasDto.NASResponse, error) {
-	args := m.Called(req)
+func (m *MockNASService) CreateNAS(ctx context.Context, req *nasDto.CreateNASRequest) (*nasDto.NASResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasDto.NASResponse), args.Error(1)
 }
 
-func (m *MockNASService) GetNASByID(id uint) (*nasDto.NASResponse, error) {
-	args := m.Called(id)
+func (m *MockNASService) GetNASByID(ctx context.Context, id uint) (*nasDto.NASResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasDto.NASResponse), args.Error(1)
 }
 
-func (m *MockNASService) ListNAS(filter *nasDto.NASFilter) (*nasDto.ListNASResponse, error) {
-	args := m.Called(filter)
+func (m *MockNASService) ListNAS(ctx context.Context, filter *nasDto.NASFilter) (*nasDto.ListNASResponse, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasDto.ListNASResponse), args.Error(1)
 }
 
-func (m *MockNASService) UpdateNAS(id uint, req *nasDto.UpdateNASRequest) (*nasDto.NASResponse, error) {
-	args := m.Called(id, req)
+func (m *MockNASService) UpdateNAS(ctx context.Context, id uint, req *nasDto.UpdateNASRequest) (*nasDto.NASResponse, error) {
+	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*nasDto.NASResponse), args.Error(1)
 }
 
-func (m *MockNASService) DeleteNAS(id uint) error {
-	args := m.Called(id)
+func (m *MockNASService) DeleteNAS(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockNASService) GenerateClientsConf(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockNASService) WriteClientsConf(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
+
+func (m *MockNASService) ImportClientsConf(ctx context.Context, data []byte, dryRun bool) (*nasDto.ImportClientsConfResponse, error) {
+	args := m.Called(ctx, data, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nasDto.ImportClientsConfResponse), args.Error(1)
+}
+
+func (m *MockNASService) RevealNASSecret(ctx context.Context, id uint) (*nasDto.NASSecretResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nasDto.NASSecretResponse), args.Error(1)
+}
+
+func (m *MockNASService) RotateNASSecret(ctx context.Context, id uint) (*nasDto.NASSecretResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nasDto.NASSecretResponse), args.Error(1)
+}
+
+func (m *MockNASService) ReencryptNASSecrets(ctx context.Context) (*nasDto.ReencryptNASSecretsResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nasDto.ReencryptNASSecretsResponse), args.Error(1)
+}
+
+func (m *MockNASService) MatchNAS(ctx context.Context, ip string) (*nasDto.NASMatchResponse, error) {
+	args := m.Called(ctx, ip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nasDto.NASMatchResponse), args.Error(1)
+}
+
+func (m *MockNASService) GetHealthCheckTargets(ctx context.Context) ([]nasDto.NASHealthTarget, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]nasDto.NASHealthTarget), args.Error(1)
+}
+
+func (m *MockNASService) RecordNASHealth(ctx context.Context, id uint, up bool, rtt time.Duration, checkedAt time.Time) error {
+	args := m.Called(ctx, id, up, rtt, checkedAt)
 	return args.Error(0)
 }
 
@@ -296,18 +434,18 @@ func (m *MockRadcheckRepository) GetByUsernameAndAttribute(ctx context.Context,
 	return args.Get(0).(*radcheckEntity.Radcheck), args.Error(1)
 }
 
-func (m *MockRadcheckRepository) GetAll(ctx context.Context, filter *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, int64, error) {
+func (m *MockRadcheckRepository) GetAll(ctx context.Context, filter *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, pagination.Page, error) {
 	args := m.Called(ctx, filter)
 	var radchecks []radcheckEntity.Radcheck
 	if args.Get(0) != nil {
 		radchecks = args.Get(0).([]radcheckEntity.Radcheck)
 	}
 
-	var count int64
+	var page pagination.Page
 	if args.Get(1) != nil {
-		count = args.Get(1).(int64)
+		page = args.Get(1).(pagination.Page)
 	}
-	return radchecks, count, args.Error(2)
+	return radchecks, page, args.Error(2)
 }
 
 func (m *MockRadcheckRepository) Update(ctx context.Context, radcheck *radcheckEntity.Radcheck) error {
@@ -325,7 +463,7 @@ type MockRadcheckRepositoryWithFn struct {
 	CreateFn                    func(context.Context, *radcheckEntity.Radcheck) error
 	GetByIDFn                   func(context.Context, uint) (*radcheckEntity.Radcheck, error)
 	GetByUsernameAndAttributeFn func(context.Context, string, string) (*radcheckEntity.Radcheck, error)
-	GetAllFn                    func(context.Context, *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, int64, error)
+	GetAllFn                    func(context.Context, *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, pagination.Page, error)
 	UpdateFn                    func(context.Context, *radcheckEntity.Radcheck) error
 	DeleteFn                    func(context.Context, uint) error
 }
@@ -356,11 +494,11 @@ func (m *MockRadcheckRepositoryWithFn) GetByUsernameAndAttribute(ctx context.Con
 	return CreateRadcheckFixture(), nil
 }
 
-func (m *MockRadcheckRepositoryWithFn) GetAll(ctx context.Context, filter *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, int64, error) {
+func (m *MockRadcheckRepositoryWithFn) GetAll(ctx context.Context, filter *radcheckDto.RadcheckFilter) ([]radcheckEntity.Radcheck, pagination.Page, error) {
 	if m.GetAllFn != nil {
 		return m.GetAllFn(ctx, filter)
 	}
-	return []radcheckEntity.Radcheck{*CreateRadcheckFixture()}, 1, nil
+	return []radcheckEntity.Radcheck{*CreateRadcheckFixture()}, pagination.Exact(1), nil
 }
 
 func (m *MockRadcheckRepositoryWithFn) Update(ctx context.Context, radcheck *radcheckEntity.Radcheck) error {
@@ -432,7 +570,7 @@ type MockRadreplyRepository struct {
 	CreateFn                    func(context.Context, *radreplyEntity.Radreply) error
 	GetByIDFn                   func(context.Context, uint) (*radreplyEntity.Radreply, error)
 	GetByUsernameAndAttributeFn func(context.Context, string, string) (*radreplyEntity.Radreply, error)
-	GetAllFn                    func(context.Context, *radreplyDto.RadreplyFilter) ([]radreplyEntity.Radreply, int64, error)
+	GetAllFn                    func(context.Context, *radreplyDto.RadreplyFilter) ([]radreplyEntity.Radreply, pagination.Page, error)
 	UpdateFn                    func(context.Context, *radreplyEntity.Radreply) error
 	DeleteFn                    func(context.Context, uint) error
 }
@@ -463,11 +601,11 @@ func (m *MockRadreplyRepository) GetByUsernameAndAttribute(ctx context.Context,
 	return CreateRadreplyFixture(), nil
 }
 
-func (m *MockRadreplyRepository) GetAll(ctx context.Context, filter *radreplyDto.RadreplyFilter) ([]radreplyEntity.Radreply, int64, error) {
+func (m *MockRadreplyRepository) GetAll(ctx context.Context, filter *radreplyDto.RadreplyFilter) ([]radreplyEntity.Radreply, pagination.Page, error) {
 	if m.GetAllFn != nil {
 		return m.GetAllFn(ctx, filter)
 	}
-	return []radreplyEntity.Radreply{*CreateRadreplyFixture()}, 1, nil
+	return []radreplyEntity.Radreply{*CreateRadreplyFixture()}, pagination.Exact(1), nil
 }
 
 func (m *MockRadreplyRepository) Update(ctx context.Context, radreply *radreplyEntity.Radreply) error {
@@ -554,10 +692,10 @@ func (m *MockRadreplyService) ListRadreply(ctx context.Context, filter *radreply
 				Value:     fixture.Value,
 			},
 		},
-		Total:     1,
+		Total:     pagination.Exact(1).Total,
 		Page:      filter.Page,
 		PageSize:  filter.PageSize,
-		TotalPage: 1,
+		TotalPage: pagination.Exact(1).TotalPages(filter.PageSize),
 	}, nil
 }
 
@@ -592,3 +730,563 @@ func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(
 	}
 	return fn(ctx)
 }
+
+// MockOutbox is a mock implementation of outbox.Outbox that records the
+// events added to it
+type MockOutbox struct {
+	AddFn  func(ctx context.Context, evts ...events.Event) error
+	Events []events.Event
+}
+
+func (m *MockOutbox) Add(ctx context.Context, evts ...events.Event) error {
+	if m.AddFn != nil {
+		if err := m.AddFn(ctx, evts...); err != nil {
+			return err
+		}
+	}
+	m.Events = append(m.Events, evts...)
+	return nil
+}
+
+// MockAuditRecorder is a mock implementation of audit.Recorder that records
+// the changes passed to it
+type MockAuditRecorder struct {
+	RecordFn func(ctx context.Context, changes ...audit.Change) error
+	Changes  []audit.Change
+}
+
+func (m *MockAuditRecorder) Record(ctx context.Context, changes ...audit.Change) error {
+	if m.RecordFn != nil {
+		if err := m.RecordFn(ctx, changes...); err != nil {
+			return err
+		}
+	}
+	m.Changes = append(m.Changes, changes...)
+	return nil
+}
+
+// MockSessionRepository is a mock implementation of SessionRepository
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, event *sessionEntity.SessionEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) LatestID(ctx context.Context) (uint64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *MockSessionRepository) ListAfter(ctx context.Context, cursor uint64, limit int) ([]sessionEntity.SessionEvent, error) {
+	args := m.Called(ctx, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sessionEntity.SessionEvent), args.Error(1)
+}
+
+func (m *MockSessionRepository) ListRange(
+	ctx context.Context,
+	after, upTo uint64,
+	filter *sessionDto.SessionWatchFilter,
+	limit int,
+) ([]sessionEntity.SessionEvent, error) {
+	args := m.Called(ctx, after, upTo, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sessionEntity.SessionEvent), args.Error(1)
+}
+
+// MockSessionService is a mock implementation of SessionService
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) RecordEvent(ctx context.Context, req *sessionDto.RecordSessionEventRequest) (*sessionDto.SessionEventResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sessionDto.SessionEventResponse), args.Error(1)
+}
+
+func (m *MockSessionService) WatchSessions(
+	ctx context.Context,
+	filter *sessionDto.SessionWatchFilter,
+	cursor uint64,
+	send func(*sessionDto.SessionEventResponse) error,
+) error {
+	args := m.Called(ctx, filter, cursor, send)
+	return args.Error(0)
+}
+
+// MockWebhookRepository is a mock implementation of WebhookRepository
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, sub *webhookEntity.Subscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetSubscriptionByID(ctx context.Context, id uint) (*webhookEntity.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookEntity.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListSubscriptions(
+	ctx context.Context,
+	filter *webhookDto.WebhookFilter,
+) ([]webhookEntity.Subscription, pagination.Page, error) {
+	args := m.Called(ctx, filter)
+	var subs []webhookEntity.Subscription
+	if args.Get(0) != nil {
+		subs = args.Get(0).([]webhookEntity.Subscription)
+	}
+	return subs, args.Get(1).(pagination.Page), args.Error(2)
+}
+
+func (m *MockWebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]webhookEntity.Subscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhookEntity.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateSubscription(ctx context.Context, sub *webhookEntity.Subscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *webhookEntity.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveryByID(ctx context.Context, id uint) (*webhookEntity.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookEntity.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uint,
+	filter *webhookDto.DeliveryFilter,
+) ([]webhookEntity.Delivery, pagination.Page, error) {
+	args := m.Called(ctx, subscriptionID, filter)
+	var deliveries []webhookEntity.Delivery
+	if args.Get(0) != nil {
+		deliveries = args.Get(0).([]webhookEntity.Delivery)
+	}
+	return deliveries, args.Get(1).(pagination.Page), args.Error(2)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *webhookEntity.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// MockWebhookService is a mock implementation of WebhookService
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, req *webhookDto.CreateWebhookRequest) (*webhookDto.WebhookResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhookByID(ctx context.Context, id uint) (*webhookDto.WebhookResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) ListWebhooks(ctx context.Context, filter *webhookDto.WebhookFilter) (*webhookDto.ListWebhooksResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.ListWebhooksResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateWebhook(
+	ctx context.Context,
+	id uint,
+	req *webhookDto.UpdateWebhookRequest,
+) (*webhookDto.WebhookResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.WebhookResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(
+	ctx context.Context,
+	webhookID uint,
+	filter *webhookDto.DeliveryFilter,
+) (*webhookDto.ListDeliveriesResponse, error) {
+	args := m.Called(ctx, webhookID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.ListDeliveriesResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint) (*webhookDto.DeliveryResponse, error) {
+	args := m.Called(ctx, webhookID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhookDto.DeliveryResponse), args.Error(1)
+}
+
+func (m *MockWebhookService) DispatchEvent(ctx context.Context, env events.Envelope) error {
+	args := m.Called(ctx, env)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) DeliverWebhook(ctx context.Context, deliveryID uint, attempt int) error {
+	args := m.Called(ctx, deliveryID, attempt)
+	return args.Error(0)
+}
+
+// EnqueuedDelivery is a delivery attempt scheduled on a MockDeliveryQueue
+type EnqueuedDelivery struct {
+	DeliveryID uint
+	Attempt    int
+	Delay      time.Duration
+}
+
+// MockDeliveryQueue is a mock implementation of the webhook DeliveryQueue
+// that records the scheduled attempts
+type MockDeliveryQueue struct {
+	EnqueueDeliveryFn func(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error
+	Enqueued          []EnqueuedDelivery
+	Redelivered       []uint
+}
+
+func (m *MockDeliveryQueue) EnqueueDelivery(ctx context.Context, deliveryID uint, attempt int, delay time.Duration) error {
+	if m.EnqueueDeliveryFn != nil {
+		if err := m.EnqueueDeliveryFn(ctx, deliveryID, attempt, delay); err != nil {
+			return err
+		}
+	}
+	m.Enqueued = append(m.Enqueued, EnqueuedDelivery{DeliveryID: deliveryID, Attempt: attempt, Delay: delay})
+	return nil
+}
+
+func (m *MockDeliveryQueue) EnqueueRedelivery(ctx context.Context, deliveryID uint) error {
+	m.Redelivered = append(m.Redelivered, deliveryID)
+	return nil
+}
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter *auditDto.AuditFilter) ([]audit.Entry, pagination.Page, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(pagination.Page), args.Error(2)
+	}
+	return args.Get(0).([]audit.Entry), args.Get(1).(pagination.Page), args.Error(2)
+}
+
+// MockAuditService is a mock implementation of AuditService
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListEntries(ctx context.Context, filter *auditDto.AuditFilter) (*auditDto.ListAuditEntriesResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auditDto.ListAuditEntriesResponse), args.Error(1)
+}
+
+// MockAdminSessionRepository is a mock implementation of the login
+// SessionRepository
+type MockAdminSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminSessionRepository) Create(ctx context.Context, session *authnEntity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockAdminSessionRepository) GetByID(ctx context.Context, id string) (*authnEntity.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authnEntity.Session), args.Error(1)
+}
+
+func (m *MockAdminSessionRepository) ListActiveByUser(ctx context.Context, userID uint, now time.Time) ([]authnEntity.Session, error) {
+	args := m.Called(ctx, userID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]authnEntity.Session), args.Error(1)
+}
+
+func (m *MockAdminSessionRepository) Update(ctx context.Context, session *authnEntity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockAdminSessionRepository) Rotate(ctx context.Context, session *authnEntity.Session, currentHash string) (bool, error) {
+	args := m.Called(ctx, session, currentHash)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockAuthnService is a mock implementation of AuthnService
+type MockAuthnService struct {
+	mock.Mock
+}
+
+func (m *MockAuthnService) Authenticate(ctx context.Context, accessToken string) (*identity.Principal, error) {
+	args := m.Called(ctx, accessToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*identity.Principal), args.Error(1)
+}
+
+func (m *MockAuthnService) VerifyCredentials(ctx context.Context, email, password string) (*identity.Principal, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*identity.Principal), args.Error(1)
+}
+
+func (m *MockAuthnService) Login(ctx context.Context, req *authnDto.LoginRequest, client authnDto.ClientInfo) (*authnDto.TokenResponse, error) {
+	args := m.Called(ctx, req, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authnDto.TokenResponse), args.Error(1)
+}
+
+func (m *MockAuthnService) Refresh(ctx context.Context, req *authnDto.RefreshRequest, client authnDto.ClientInfo) (*authnDto.TokenResponse, error) {
+	args := m.Called(ctx, req, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authnDto.TokenResponse), args.Error(1)
+}
+
+func (m *MockAuthnService) Logout(ctx context.Context, principal *identity.Principal) error {
+	args := m.Called(ctx, principal)
+	return args.Error(0)
+}
+
+func (m *MockAuthnService) ListSessions(ctx context.Context, principal *identity.Principal) (*authnDto.ListSessionsResponse, error) {
+	args := m.Called(ctx, principal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authnDto.ListSessionsResponse), args.Error(1)
+}
+
+func (m *MockAuthnService) RevokeSession(ctx context.Context, principal *identity.Principal, sessionID string) error {
+	args := m.Called(ctx, principal, sessionID)
+	return args.Error(0)
+}
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *apikeyEntity.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id uint) (*apikeyEntity.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikeyEntity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*apikeyEntity.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikeyEntity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, filter *apikeyDto.APIKeyFilter, now time.Time) ([]apikeyEntity.APIKey, pagination.Page, error) {
+	args := m.Called(ctx, filter, now)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(pagination.Page), args.Error(2)
+	}
+	return args.Get(0).([]apikeyEntity.APIKey), args.Get(1).(pagination.Page), args.Error(2)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *apikeyEntity.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Touch(ctx context.Context, id uint, at time.Time, ip string) error {
+	args := m.Called(ctx, id, at, ip)
+	return args.Error(0)
+}
+
+// MockAPIKeyService is a mock implementation of APIKeyService
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) AuthenticateKey(ctx context.Context, key, clientIP string) (*identity.Principal, error) {
+	args := m.Called(ctx, key, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*identity.Principal), args.Error(1)
+}
+
+func (m *MockAPIKeyService) CreateKey(ctx context.Context, principal *identity.Principal, req *apikeyDto.CreateAPIKeyRequest) (*apikeyDto.CreateAPIKeyResponse, error) {
+	args := m.Called(ctx, principal, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikeyDto.CreateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyService) GetKey(ctx context.Context, id uint) (*apikeyDto.APIKeyResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikeyDto.APIKeyResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyService) ListKeys(ctx context.Context, filter *apikeyDto.APIKeyFilter) (*apikeyDto.ListAPIKeysResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikeyDto.ListAPIKeysResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeKey(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockTenantRepository is a mock implementation of TenantRepository
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) Create(ctx context.Context, t *tenantEntity.Tenant) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockTenantRepository) GetByID(ctx context.Context, id uint) (*tenantEntity.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenantEntity.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) RealmExists(ctx context.Context, realm string) (bool, error) {
+	args := m.Called(ctx, realm)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTenantRepository) List(ctx context.Context, filter *tenantDto.TenantFilter) ([]tenantEntity.Tenant, pagination.Page, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(pagination.Page), args.Error(2)
+	}
+	return args.Get(0).([]tenantEntity.Tenant), args.Get(1).(pagination.Page), args.Error(2)
+}
+
+func (m *MockTenantRepository) Update(ctx context.Context, t *tenantEntity.Tenant) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+// MockTenantService is a mock implementation of TenantService
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) Resolve(ctx context.Context, id uint) (tenant.Scope, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(tenant.Scope), args.Error(1)
+}
+
+func (m *MockTenantService) CreateTenant(ctx context.Context, req *tenantDto.CreateTenantRequest) (*tenantDto.TenantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenantDto.TenantResponse), args.Error(1)
+}
+
+func (m *MockTenantService) GetTenant(ctx context.Context, id uint) (*tenantDto.TenantResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenantDto.TenantResponse), args.Error(1)
+}
+
+func (m *MockTenantService) ListTenants(ctx context.Context, filter *tenantDto.TenantFilter) (*tenantDto.ListTenantsResponse, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenantDto.ListTenantsResponse), args.Error(1)
+}
+
+func (m *MockTenantService) UpdateTenant(ctx context.Context, id uint, req *tenantDto.UpdateTenantRequest) (*tenantDto.TenantResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenantDto.TenantResponse), args.Error(1)
+}
@@ -0,0 +1,129 @@
+// Package tracing sets up OpenTelemetry tracing. REST calls, gRPC calls,
+// database queries and tasks each get a span; the trace context travels in
+// W3C traceparent headers, gRPC metadata and task payloads, so that a call
+// and the tasks it caused show up as one trace.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// InstrumentationName names the tracer of every span started by the service.
+const InstrumentationName = "github.com/novriyantoAli/freeradius-service"
+
+// Exporters accepted by TracingConfig.Exporter.
+const (
+	ExporterOTLP     = "otlp"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterStdout   = "stdout"
+	ExporterFile     = "file"
+)
+
+// Propagator reads and writes the trace context of calls and tasks.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// NewTracerProvider returns the tracer provider configured by cfg.Tracing,
+// flushing pending spans on stop. When tracing is disabled spans are not
+// recorded at all.
+func NewTracerProvider(lc fx.Lifecycle, cfg *config.Config, logger *zap.Logger) (trace.TracerProvider, error) {
+	if !cfg.Tracing.Enabled {
+		return trace.NewNoopTracerProvider(), nil
+	}
+
+	ctx := context.Background()
+	exporter, closer, err := newExporter(ctx, cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.Tracing.ServiceName)),
+		resource.WithProcessExecutableName(),
+		resource.WithHost(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(Propagator)
+
+	logger.Info("Tracing enabled",
+		zap.String("exporter", cfg.Tracing.Exporter),
+		zap.Float64("sample_ratio", cfg.Tracing.SampleRatio))
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			err := provider.Shutdown(ctx)
+			if closer != nil {
+				if closeErr := closer.Close(); err == nil {
+					err = closeErr
+				}
+			}
+			return err
+		},
+	})
+	return provider, nil
+}
+
+// newExporter returns the span exporter of cfg, and the file it writes to
+// for the file exporter.
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+}
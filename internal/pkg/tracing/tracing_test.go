@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewExporter(t *testing.T) {
+	t.Run("should write spans to the file exporter", func(t *testing.T) {
+		// Setup
+		path := filepath.Join(t.TempDir(), "traces.json")
+		exporter, closer, err := newExporter(context.Background(), config.TracingConfig{Exporter: ExporterFile, FilePath: path})
+		require.NoError(t, err)
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		// When
+		_, span := tp.Tracer("test").Start(context.Background(), "process payment:process")
+		span.End()
+		require.NoError(t, tp.Shutdown(context.Background()))
+		require.NoError(t, closer.Close())
+
+		// Then
+		written, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(written), `"Name":"process payment:process"`)
+	})
+
+	t.Run("should reject unknown exporters", func(t *testing.T) {
+		// When
+		_, _, err := newExporter(context.Background(), config.TracingConfig{Exporter: "zipkin"})
+
+		// Then
+		assert.EqualError(t, err, `unknown exporter "zipkin"`)
+	})
+}
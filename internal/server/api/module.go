@@ -4,15 +4,28 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	apikeyHandler "github.com/novriyantoAli/freeradius-service/internal/application/apikey/handler"
+	auditHandler "github.com/novriyantoAli/freeradius-service/internal/application/audit/handler"
 	authHandler "github.com/novriyantoAli/freeradius-service/internal/application/auth/handler"
+	authnHandler "github.com/novriyantoAli/freeradius-service/internal/application/authn/handler"
 	nasHandler "github.com/novriyantoAli/freeradius-service/internal/application/nas/handler"
 	paymentHandler "github.com/novriyantoAli/freeradius-service/internal/application/payment/handler"
 	radcheckHandler "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/handler"
 	radreplyHandler "github.com/novriyantoAli/freeradius-service/internal/application/radreply/handler"
+	sessionHandler "github.com/novriyantoAli/freeradius-service/internal/application/session/handler"
+	tenantHandler "github.com/novriyantoAli/freeradius-service/internal/application/tenant/handler"
 	userHandler "github.com/novriyantoAli/freeradius-service/internal/application/user/handler"
+	webhookHandler "github.com/novriyantoAli/freeradius-service/internal/application/webhook/handler"
 	"github.com/novriyantoAli/freeradius-service/internal/middleware"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
 
 	_ "github.com/novriyantoAli/freeradius-service/docs" // This will be generated by swag
 )
@@ -23,7 +36,22 @@ type Server struct {
 	nasHandler      *nasHandler.NASHandler
 	radcheckHandler *radcheckHandler.RadcheckHandler
 	radreplyHandler *radreplyHandler.RadreplyHandler
+	sessionHandler  *sessionHandler.SessionHandler
 	authHandler     *authHandler.AuthHandler
+	webhookHandler  *webhookHandler.WebhookHandler
+	auditHandler    *auditHandler.AuditHandler
+	authnHandler    *authnHandler.AuthnHandler
+	apikeyHandler   *apikeyHandler.APIKeyHandler
+	tenantHandler   *tenantHandler.TenantHandler
+	authenticator   identity.Authenticator
+	keys            identity.KeyAuthenticator
+	tenants         tenant.Resolver
+	verifier        identity.CredentialVerifier
+	policy          *rbac.Policy
+	limiter         *ratelimit.Limiter
+	metrics         *metrics.HTTP
+	tracer          trace.TracerProvider
+	checker         *health.Checker
 	logger          *zap.Logger
 }
 
@@ -33,7 +61,22 @@ func NewServer(
 	nasHandler *nasHandler.NASHandler,
 	radcheckHandler *radcheckHandler.RadcheckHandler,
 	radreplyHandler *radreplyHandler.RadreplyHandler,
+	sessionHandler *sessionHandler.SessionHandler,
 	authHandler *authHandler.AuthHandler,
+	webhookHandler *webhookHandler.WebhookHandler,
+	auditHandler *auditHandler.AuditHandler,
+	authnHandler *authnHandler.AuthnHandler,
+	apikeyHandler *apikeyHandler.APIKeyHandler,
+	tenantHandler *tenantHandler.TenantHandler,
+	authenticator identity.Authenticator,
+	keys identity.KeyAuthenticator,
+	tenants tenant.Resolver,
+	verifier identity.CredentialVerifier,
+	policy *rbac.Policy,
+	limiter *ratelimit.Limiter,
+	metrics *metrics.HTTP,
+	tracer trace.TracerProvider,
+	checker *health.Checker,
 	logger *zap.Logger,
 ) *Server {
 	return &Server{
@@ -42,33 +85,72 @@ func NewServer(
 		nasHandler:      nasHandler,
 		radcheckHandler: radcheckHandler,
 		radreplyHandler: radreplyHandler,
+		sessionHandler:  sessionHandler,
 		authHandler:     authHandler,
+		webhookHandler:  webhookHandler,
+		auditHandler:    auditHandler,
+		authnHandler:    authnHandler,
+		apikeyHandler:   apikeyHandler,
+		tenantHandler:   tenantHandler,
+		authenticator:   authenticator,
+		keys:            keys,
+		tenants:         tenants,
+		verifier:        verifier,
+		policy:          policy,
+		limiter:         limiter,
+		metrics:         metrics,
+		tracer:          tracer,
+		checker:         checker,
 		logger:          logger,
 	}
 }
 
 func (s *Server) SetupRoutes(router *gin.Engine) {
 	// Apply global middleware
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing(s.tracer))
 	router.Use(middleware.Logger(s.logger))
+	router.Use(middleware.Metrics(s.metrics))
 	router.Use(middleware.Recovery(s.logger))
 	router.Use(middleware.CORS())
+	router.Use(middleware.AuditMetadata())
 
-	// Swagger documentation routes
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Swagger documentation routes, behind basic auth since browsers cannot
+	// send a bearer token when opening them
+	router.GET("/swagger/*any", middleware.BasicAuth(s.verifier, s.logger), ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.GET("/docs", func(c *gin.Context) {
 		c.Redirect(302, "/swagger/index.html")
 	})
 
-	// Register API routes
+	// Register public API routes; logins are rate limited by client IP
 	api := router.Group("/api/v1")
 	{
 		s.registerHealthRoutes(api)
-		s.userHandler.RegisterRoutes(api)
-		s.paymentHandler.RegisterRoutes(api)
-		s.radcheckHandler.RegisterRoutes(api)
-		s.radreplyHandler.RegisterRoutes(api)
-		s.authHandler.RegisterRoutes(api)
-		s.nasHandler.RegisterRoutes(router)
+		s.authnHandler.RegisterPublicRoutes(api.Group("", middleware.RateLimit(s.limiter, s.logger)))
+	}
+
+	// Register API routes requiring an access token and the permission
+	// routePermissions assigns them, rate limited per caller and acting for
+	// the caller's tenant
+	protected := api.Group("",
+		middleware.Authenticate(s.authenticator, s.keys, s.logger),
+		middleware.RateLimit(s.limiter, s.logger),
+		middleware.Authorize(s.policy, routePermissions, s.logger),
+		middleware.Scope(s.tenants, s.logger),
+	)
+	{
+		s.authnHandler.RegisterRoutes(protected)
+		s.userHandler.RegisterRoutes(protected)
+		s.paymentHandler.RegisterRoutes(protected)
+		s.radcheckHandler.RegisterRoutes(protected)
+		s.radreplyHandler.RegisterRoutes(protected)
+		s.sessionHandler.RegisterRoutes(protected)
+		s.authHandler.RegisterRoutes(protected)
+		s.webhookHandler.RegisterRoutes(protected)
+		s.auditHandler.RegisterRoutes(protected)
+		s.apikeyHandler.RegisterRoutes(protected)
+		s.tenantHandler.RegisterRoutes(protected)
+		s.nasHandler.RegisterRoutes(protected)
 	}
 }
 
@@ -98,18 +180,14 @@ func (s *Server) healthCheck(c *gin.Context) {
 
 // ReadinessCheck godoc
 // @Summary Show the readiness of server.
-// @Description get the readiness of server.
+// @Description Probe the database and Redis, reporting the status and latency of each. Results are cached for health.cache_ttl.
 // @Tags health
 // @Accept */*
 // @Produce json
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report "A critical dependency is down"
 // @Router /health/ready [get]
 func (s *Server) readinessCheck(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status": "ready",
-		"checks": gin.H{
-			"database": "ok",
-			"cache":    "ok",
-		},
-	})
+	report := s.checker.Report(c.Request.Context())
+	c.JSON(health.StatusCode(report), report)
 }
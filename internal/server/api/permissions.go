@@ -0,0 +1,75 @@
+package api
+
+import "github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+
+// routePermissions maps every route behind authentication to the permission
+// it requires. A route missing here is denied to everyone.
+var routePermissions = map[string]rbac.Permission{
+	"POST /api/v1/auth/logout":         rbac.Authenticated,
+	"GET /api/v1/auth/sessions":        rbac.Authenticated,
+	"DELETE /api/v1/auth/sessions/:id": rbac.Authenticated,
+
+	"POST /api/v1/users":             rbac.UsersWrite,
+	"GET /api/v1/users":              rbac.UsersRead,
+	"GET /api/v1/users/:id":          rbac.UsersRead,
+	"PUT /api/v1/users/:id":          rbac.UsersWrite,
+	"DELETE /api/v1/users/:id":       rbac.UsersWrite,
+	"PUT /api/v1/users/:id/password": rbac.UsersWrite,
+	"PUT /api/v1/users/:id/role":     rbac.UsersRoles,
+	"GET /api/v1/users/:id/payments": rbac.PaymentsRead,
+
+	"POST /api/v1/payments":       rbac.PaymentsWrite,
+	"GET /api/v1/payments":        rbac.PaymentsRead,
+	"GET /api/v1/payments/:id":    rbac.PaymentsRead,
+	"PUT /api/v1/payments/:id":    rbac.PaymentsWrite,
+	"DELETE /api/v1/payments/:id": rbac.PaymentsWrite,
+
+	"POST /api/v1/nas":                   rbac.NASWrite,
+	"GET /api/v1/nas":                    rbac.NASRead,
+	"GET /api/v1/nas/match":              rbac.NASRead,
+	"GET /api/v1/nas/clients.conf":       rbac.NASSecrets,
+	"POST /api/v1/nas/clients.conf":      rbac.NASWrite,
+	"POST /api/v1/nas/secrets/reencrypt": rbac.NASReencrypt,
+	"GET /api/v1/nas/:id":                rbac.NASRead,
+	"PUT /api/v1/nas/:id":                rbac.NASWrite,
+	"DELETE /api/v1/nas/:id":             rbac.NASWrite,
+	"GET /api/v1/nas/:id/secret":         rbac.NASSecrets,
+	"POST /api/v1/nas/:id/secret/rotate": rbac.NASSecrets,
+
+	"POST /api/v1/radcheck":       rbac.RadcheckWrite,
+	"GET /api/v1/radcheck":        rbac.RadcheckRead,
+	"GET /api/v1/radcheck/:id":    rbac.RadcheckRead,
+	"PUT /api/v1/radcheck/:id":    rbac.RadcheckWrite,
+	"DELETE /api/v1/radcheck/:id": rbac.RadcheckWrite,
+
+	"POST /api/v1/radreply":       rbac.RadreplyWrite,
+	"GET /api/v1/radreply":        rbac.RadreplyRead,
+	"GET /api/v1/radreply/:id":    rbac.RadreplyRead,
+	"PUT /api/v1/radreply/:id":    rbac.RadreplyWrite,
+	"DELETE /api/v1/radreply/:id": rbac.RadreplyWrite,
+
+	// Creates a subscriber's radcheck entries
+	"POST /api/v1/auth": rbac.RadcheckWrite,
+
+	"POST /api/v1/sessions/events": rbac.SessionsWrite,
+
+	"POST /api/v1/webhooks":                                      rbac.WebhooksWrite,
+	"GET /api/v1/webhooks":                                       rbac.WebhooksRead,
+	"GET /api/v1/webhooks/:id":                                   rbac.WebhooksRead,
+	"PUT /api/v1/webhooks/:id":                                   rbac.WebhooksWrite,
+	"DELETE /api/v1/webhooks/:id":                                rbac.WebhooksWrite,
+	"GET /api/v1/webhooks/:id/deliveries":                        rbac.WebhooksRead,
+	"POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver": rbac.WebhooksWrite,
+
+	"GET /api/v1/audit": rbac.AuditRead,
+
+	"POST /api/v1/api-keys":       rbac.APIKeysWrite,
+	"GET /api/v1/api-keys":        rbac.APIKeysRead,
+	"GET /api/v1/api-keys/:id":    rbac.APIKeysRead,
+	"DELETE /api/v1/api-keys/:id": rbac.APIKeysWrite,
+
+	"POST /api/v1/tenants":    rbac.TenantsWrite,
+	"GET /api/v1/tenants":     rbac.TenantsRead,
+	"GET /api/v1/tenants/:id": rbac.TenantsRead,
+	"PUT /api/v1/tenants/:id": rbac.TenantsWrite,
+}
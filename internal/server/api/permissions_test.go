@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apikeyHandler "github.com/novriyantoAli/freeradius-service/internal/application/apikey/handler"
+	auditHandler "github.com/novriyantoAli/freeradius-service/internal/application/audit/handler"
+	authHandler "github.com/novriyantoAli/freeradius-service/internal/application/auth/handler"
+	authnHandler "github.com/novriyantoAli/freeradius-service/internal/application/authn/handler"
+	nasHandler "github.com/novriyantoAli/freeradius-service/internal/application/nas/handler"
+	paymentHandler "github.com/novriyantoAli/freeradius-service/internal/application/payment/handler"
+	radcheckHandler "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/handler"
+	radreplyHandler "github.com/novriyantoAli/freeradius-service/internal/application/radreply/handler"
+	sessionHandler "github.com/novriyantoAli/freeradius-service/internal/application/session/handler"
+	tenantHandler "github.com/novriyantoAli/freeradius-service/internal/application/tenant/handler"
+	userHandler "github.com/novriyantoAli/freeradius-service/internal/application/user/handler"
+	webhookHandler "github.com/novriyantoAli/freeradius-service/internal/application/webhook/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// publicRoutes are served without an access token.
+var publicRoutes = map[string]bool{
+	"GET /swagger/*any":         true,
+	"GET /docs":                 true,
+	"GET /api/v1/health":        true,
+	"GET /api/v1/health/ready":  true,
+	"POST /api/v1/auth/login":   true,
+	"POST /api/v1/auth/refresh": true,
+}
+
+// setupRouter registers the routes of handlers that are never reached, since
+// the tests only exercise authentication and authorization.
+func setupRouter(t *testing.T, authenticator identity.Authenticator, keys identity.KeyAuthenticator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	policy, err := rbac.NewPolicy(cfg.RBAC.Roles)
+	require.NoError(t, err)
+
+	server := NewServer(
+		&userHandler.UserHandler{},
+		&paymentHandler.PaymentHandler{},
+		&nasHandler.NASHandler{},
+		&radcheckHandler.RadcheckHandler{},
+		&radreplyHandler.RadreplyHandler{},
+		&sessionHandler.SessionHandler{},
+		&authHandler.AuthHandler{},
+		&webhookHandler.WebhookHandler{},
+		&auditHandler.AuditHandler{},
+		&authnHandler.AuthnHandler{},
+		&apikeyHandler.APIKeyHandler{},
+		&tenantHandler.TenantHandler{},
+		authenticator,
+		keys,
+		&testutil.MockTenantService{},
+		&testutil.MockAuthnService{},
+		policy,
+		nil,
+		nil,
+		trace.NewNoopTracerProvider(),
+		health.NewChecker(cfg.Health),
+		testutil.NewSilentLogger(),
+	)
+	router := gin.New()
+	server.SetupRoutes(router)
+	return router
+}
+
+func TestRoutePermissions(t *testing.T) {
+	// Setup
+	router := setupRouter(t, &testutil.MockAuthnService{}, &testutil.MockAPIKeyService{})
+
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+
+		// Then
+		if !publicRoutes[key] {
+			assert.Contains(t, routePermissions, key, "route has no permission assigned")
+		}
+	}
+	for key := range routePermissions {
+		assert.True(t, registered[key], "permission assigned to unknown route %s", key)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("should forbid a route the role lacks the permission for", func(t *testing.T) {
+		// Setup
+		authenticator := &testutil.MockAuthnService{}
+		router := setupRouter(t, authenticator, &testutil.MockAPIKeyService{})
+
+		// Mock expectations
+		authenticator.On("Authenticate", mock.Anything, "token").
+			Return(&identity.Principal{UserID: 2, Email: "viewer@example.com", Role: "readonly"}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/nas/1", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assertProblem(t, w, "forbidden: requires nas:write")
+	})
+
+	t.Run("should forbid revealing NAS secrets to support", func(t *testing.T) {
+		// Setup
+		authenticator := &testutil.MockAuthnService{}
+		router := setupRouter(t, authenticator, &testutil.MockAPIKeyService{})
+
+		// Mock expectations
+		authenticator.On("Authenticate", mock.Anything, "token").
+			Return(&identity.Principal{UserID: 3, Email: "support@example.com", Role: "support"}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/nas/1/secret", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("should require authentication before authorization", func(t *testing.T) {
+		// Setup
+		router := setupRouter(t, &testutil.MockAuthnService{}, &testutil.MockAPIKeyService{})
+
+		// When
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/nas/1", nil))
+
+		// Then
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("should authorize API keys by their scopes", func(t *testing.T) {
+		// Setup
+		keys := &testutil.MockAPIKeyService{}
+		router := setupRouter(t, &testutil.MockAuthnService{}, keys)
+
+		// Mock expectations
+		keys.On("AuthenticateKey", mock.Anything, testutil.APIKeyFixtureKey, "192.0.2.10").
+			Return(&identity.Principal{APIKey: "a1b2c3d4e5f6", Scopes: []string{"radcheck:write"}}, nil)
+
+		tests := []struct {
+			method string
+			path   string
+			status int
+		}{
+			{http.MethodDelete, "/api/v1/nas/1", http.StatusForbidden},
+			// Login sessions belong to users, not keys
+			{http.MethodPost, "/api/v1/auth/logout", http.StatusForbidden},
+		}
+		for _, tt := range tests {
+			// When
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = "192.0.2.10:51000"
+			req.Header.Set("X-API-Key", testutil.APIKeyFixtureKey)
+			router.ServeHTTP(w, req)
+
+			// Then
+			assert.Equal(t, tt.status, w.Code, tt.path)
+		}
+		keys.AssertExpectations(t)
+	})
+}
+
+func TestTenantScope(t *testing.T) {
+	tenantID := uint(1)
+
+	t.Run("should not let tenants act for another tenant", func(t *testing.T) {
+		// Setup
+		authenticator := &testutil.MockAuthnService{}
+		router := setupRouter(t, authenticator, &testutil.MockAPIKeyService{})
+
+		// Mock expectations
+		authenticator.On("Authenticate", mock.Anything, "token").
+			Return(&identity.Principal{UserID: 4, Email: "admin@isp-a.example", Role: "admin", TenantID: &tenantID}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/nas", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Tenant-ID", "2")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assertProblem(t, w, "cannot act for another tenant")
+	})
+
+	t.Run("should forbid managing tenants to tenants", func(t *testing.T) {
+		// Setup
+		authenticator := &testutil.MockAuthnService{}
+		router := setupRouter(t, authenticator, &testutil.MockAPIKeyService{})
+
+		// Mock expectations
+		authenticator.On("Authenticate", mock.Anything, "token").
+			Return(&identity.Principal{UserID: 4, Email: "admin@isp-a.example", Role: "admin", TenantID: &tenantID}, nil)
+
+		// When
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/tenants", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		router.ServeHTTP(w, req)
+
+		// Then
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assertProblem(t, w, "forbidden: tenants:write is not available to tenants")
+	})
+}
+
+// assertProblem asserts that w holds a permission denied problem with detail.
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, detail string) {
+	t.Helper()
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "urn:freeradius-service:problem:permission_denied", problem.Type)
+	assert.Equal(t, http.StatusForbidden, problem.Status)
+	assert.Equal(t, detail, problem.Detail)
+	assert.NotEmpty(t, problem.RequestID)
+}
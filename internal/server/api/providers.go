@@ -1,14 +1,26 @@
 package api
 
 import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey"
+	"github.com/novriyantoAli/freeradius-service/internal/application/audit"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn"
 	"github.com/novriyantoAli/freeradius-service/internal/application/nas"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck"
 	"github.com/novriyantoAli/freeradius-service/internal/application/radreply"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 var Module = fx.Options(
@@ -18,8 +30,30 @@ var Module = fx.Options(
 	nas.Module,
 	radcheck.Module,
 	radreply.Module,
+	session.Module,
 	auth.Module,
+	webhook.Module,
+	audit.Module,
+	authn.Module,
+	apikey.Module,
+	tenant.Module,
 
 	// API api
-	fx.Provide(NewServer),
+	fx.Provide(metrics.NewHTTP, provideHealthChecker, NewServer),
+	fx.Invoke(registerDomainMetrics),
 )
+
+// registerDomainMetrics adds the RADIUS data gauges to the metrics of the
+// API, the one binary always deployed.
+func registerDomainMetrics(registry *prometheus.Registry, db *gorm.DB, cfg *config.Config, logger *zap.Logger) error {
+	return registry.Register(metrics.NewDomain(db, cfg.Metrics.DomainRefresh, logger))
+}
+
+// provideHealthChecker checks the dependencies the API cannot serve
+// without: the database, and Redis, which holds the job queue.
+func provideHealthChecker(lc fx.Lifecycle, cfg *config.Config, db *gorm.DB) *health.Checker {
+	return health.NewChecker(cfg.Health,
+		health.Check{Name: "database", Critical: true, Probe: health.Database(db)},
+		health.Check{Name: "redis", Critical: true, Probe: health.NewRedisProbe(lc, cfg)},
+	)
+}
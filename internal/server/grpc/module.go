@@ -2,25 +2,62 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"net"
+	"strings"
+	"time"
 
 	"github.com/novriyantoAli/freeradius-service/api/proto/auth"
+	"github.com/novriyantoAli/freeradius-service/api/proto/nas"
 	"github.com/novriyantoAli/freeradius-service/api/proto/payment"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radcheck"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radreply"
+	"github.com/novriyantoAli/freeradius-service/api/proto/session"
 	"github.com/novriyantoAli/freeradius-service/api/proto/user"
 	authHandler "github.com/novriyantoAli/freeradius-service/internal/application/auth/handler"
+	nasHandler "github.com/novriyantoAli/freeradius-service/internal/application/nas/handler"
 	paymentHandler "github.com/novriyantoAli/freeradius-service/internal/application/payment/handler"
+	radcheckHandler "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/handler"
+	radreplyHandler "github.com/novriyantoAli/freeradius-service/internal/application/radreply/handler"
+	sessionHandler "github.com/novriyantoAli/freeradius-service/internal/application/session/handler"
+	sessionService "github.com/novriyantoAli/freeradius-service/internal/application/session/service"
 	userHandler "github.com/novriyantoAli/freeradius-service/internal/application/user/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/apperror"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tenant"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/tracing"
 
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
-	server         *grpc.Server
-	logger         *zap.Logger
-	authHandler    *authHandler.AuthGrpcHandler
-	userHandler    *userHandler.UserGrpcHandler
-	paymentHandler *paymentHandler.PaymentGrpcHandler
+	server          *grpc.Server
+	logger          *zap.Logger
+	authHandler     *authHandler.AuthGrpcHandler
+	userHandler     *userHandler.UserGrpcHandler
+	paymentHandler  *paymentHandler.PaymentGrpcHandler
+	nasHandler      *nasHandler.NASGrpcHandler
+	radcheckHandler *radcheckHandler.RadcheckGrpcHandler
+	radreplyHandler *radreplyHandler.RadreplyGrpcHandler
+	sessionHandler  *sessionHandler.SessionGrpcHandler
+	sessionBroker   *sessionService.EventBroker
+	healthServer    *health.GRPCServer
 }
 
 func NewServer(
@@ -28,18 +65,54 @@ func NewServer(
 	authHandler *authHandler.AuthGrpcHandler,
 	userHandler *userHandler.UserGrpcHandler,
 	paymentHandler *paymentHandler.PaymentGrpcHandler,
+	nasHandler *nasHandler.NASGrpcHandler,
+	radcheckHandler *radcheckHandler.RadcheckGrpcHandler,
+	radreplyHandler *radreplyHandler.RadreplyGrpcHandler,
+	sessionHandler *sessionHandler.SessionGrpcHandler,
+	sessionBroker *sessionService.EventBroker,
+	authenticator identity.Authenticator,
+	keys identity.KeyAuthenticator,
+	tenants tenant.Resolver,
+	policy *rbac.Policy,
+	limiter *ratelimit.Limiter,
+	grpcMetrics *metrics.GRPC,
+	tp trace.TracerProvider,
+	checker *health.Checker,
 ) *Server {
 	// Create gRPC api with options
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(unaryLoggingInterceptor(logger)),
+		grpc.ChainUnaryInterceptor(
+			unaryMetricsInterceptor(grpcMetrics),
+			unaryRequestIDInterceptor(),
+			unaryTracingInterceptor(tp),
+			unaryLoggingInterceptor(logger),
+			unaryAuditInterceptor(),
+			unaryAuthInterceptor(authenticator, keys, tenants, logger),
+			unaryRateLimitInterceptor(limiter, logger),
+			unaryAuthorizeInterceptor(policy, logger),
+		),
+		grpc.ChainStreamInterceptor(
+			streamMetricsInterceptor(grpcMetrics),
+			streamRequestIDInterceptor(),
+			streamTracingInterceptor(tp),
+			streamAuthInterceptor(authenticator, keys, tenants, logger),
+			streamRateLimitInterceptor(limiter, logger),
+			streamAuthorizeInterceptor(policy, logger),
+		),
 	)
 
 	return &Server{
-		server:         server,
-		logger:         logger,
-		authHandler:    authHandler,
-		userHandler:    userHandler,
-		paymentHandler: paymentHandler,
+		server:          server,
+		logger:          logger,
+		authHandler:     authHandler,
+		userHandler:     userHandler,
+		paymentHandler:  paymentHandler,
+		nasHandler:      nasHandler,
+		radcheckHandler: radcheckHandler,
+		radreplyHandler: radreplyHandler,
+		sessionHandler:  sessionHandler,
+		sessionBroker:   sessionBroker,
+		healthServer:    health.NewGRPCServer(checker),
 	}
 }
 
@@ -58,6 +131,26 @@ func (s *Server) RegisterServices() {
 	payment.RegisterPaymentServiceServer(s.server, s.paymentHandler)
 	s.logger.Info("Payment service registered")
 
+	// Register NAS service
+	nas.RegisterNASServiceServer(s.server, s.nasHandler)
+	s.logger.Info("NAS service registered")
+
+	// Register radcheck service
+	radcheck.RegisterRadcheckServiceServer(s.server, s.radcheckHandler)
+	s.logger.Info("Radcheck service registered")
+
+	// Register radreply service
+	radreply.RegisterRadreplyServiceServer(s.server, s.radreplyHandler)
+	s.logger.Info("Radreply service registered")
+
+	// Register session service
+	session.RegisterSessionServiceServer(s.server, s.sessionHandler)
+	s.logger.Info("Session service registered")
+
+	// Register the standard health service for Kubernetes probes
+	healthpb.RegisterHealthServer(s.server, s.healthServer)
+	s.logger.Info("Health service registered")
+
 	s.logger.Info("gRPC services registered successfully")
 }
 
@@ -78,9 +171,146 @@ func (s *Server) Start(port string) error {
 
 func (s *Server) Stop() {
 	s.logger.Info("Stopping gRPC api")
+	// End WatchSessions streams first, GracefulStop waits for open streams
+	s.sessionBroker.Close()
 	s.server.GracefulStop()
 }
 
+// unaryMetricsInterceptor records the latency and status code of every call.
+func unaryMetricsInterceptor(m *metrics.GRPC) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.Observe(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// streamMetricsInterceptor is unaryMetricsInterceptor for streaming calls,
+// which are observed when they end.
+func streamMetricsInterceptor(m *metrics.GRPC) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.Observe(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// unaryRequestIDInterceptor tags the call with the x-request-id metadata sent
+// by the client, or a generated ID, and echoes it in the response header.
+func unaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, id := withRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		return handler(ctx, req)
+	}
+}
+
+// streamRequestIDInterceptor is unaryRequestIDInterceptor for streaming
+// calls.
+func streamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, id := withRequestID(stream.Context())
+		_ = stream.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := requestid.Accept(firstValue(md, requestid.MetadataKey))
+	return requestid.WithID(ctx, id), id
+}
+
+// unaryTracingInterceptor gives every call a server span, continuing the
+// trace of the traceparent metadata sent by the client.
+func unaryTracingInterceptor(tp trace.TracerProvider) grpc.UnaryServerInterceptor {
+	tracer := tp.Tracer(tracing.InstrumentationName)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, span := startCallSpan(ctx, tracer, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		endCallSpan(span, err)
+		return resp, err
+	}
+}
+
+// streamTracingInterceptor is unaryTracingInterceptor for streaming calls,
+// whose span lasts as long as the stream.
+func streamTracingInterceptor(tp trace.TracerProvider) grpc.StreamServerInterceptor {
+	tracer := tp.Tracer(tracing.InstrumentationName)
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, span := startCallSpan(stream.Context(), tracer, info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+		endCallSpan(span, err)
+		return err
+	}
+}
+
+// startCallSpan starts the span of a call of method, a full method name such
+// as "/nas.NASService/GetNAS".
+func startCallSpan(ctx context.Context, tracer trace.Tracer, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = tracing.Propagator.Extract(ctx, tracing.MetadataCarrier(md))
+
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(name),
+			attribute.String("request_id", requestid.FromContext(ctx)),
+		))
+}
+
+// endCallSpan records the status code of the call, marking the span failed
+// on server errors only, as REST spans are on 5xx statuses.
+func endCallSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+}
+
 // unaryLoggingInterceptor logs gRPC calls
 func unaryLoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(
@@ -89,7 +319,286 @@ func unaryLoggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		logger.Info("gRPC call", zap.String("method", info.FullMethod))
+		logger.Info("gRPC call",
+			zap.String("method", info.FullMethod),
+			zap.String("request_id", requestid.FromContext(ctx)))
+		return handler(ctx, req)
+	}
+}
+
+// unaryAuditInterceptor tags the changes made by a call with its source and
+// request ID. The auth interceptor adds the actor.
+func unaryAuditInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx = audit.WithMetadata(ctx, audit.Metadata{
+			Source:    audit.SourceGRPC,
+			RequestID: requestid.FromContext(ctx),
+		})
+		return handler(ctx, req)
+	}
+}
+
+// unaryAuthInterceptor requires an "authorization: Bearer <access token>" or
+// an "x-api-key" metadata entry and attaches the resolved principal to the
+// context, along with the tenant it acts for. Super-admins may name one in
+// an "x-tenant-id" metadata entry.
+func unaryAuthInterceptor(
+	authenticator identity.Authenticator,
+	keys identity.KeyAuthenticator,
+	tenants tenant.Resolver,
+	logger *zap.Logger,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, authenticator, keys, tenants, logger)
+		if err != nil {
+			return nil, err
+		}
 		return handler(ctx, req)
 	}
 }
+
+// streamAuthInterceptor is unaryAuthInterceptor for streaming calls.
+func streamAuthInterceptor(
+	authenticator identity.Authenticator,
+	keys identity.KeyAuthenticator,
+	tenants tenant.Resolver,
+	logger *zap.Logger,
+) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+		ctx, err := authenticate(stream.Context(), authenticator, keys, tenants, logger)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// unaryRateLimitInterceptor throttles calls by the API key or user that made
+// them, failing with RESOURCE_EXHAUSTED and a retry-after header once the
+// caller's bucket is empty. Every call carries the ratelimit-* headers.
+// Calls are let through when the limiter fails, and always with a nil
+// limiter.
+func unaryRateLimitInterceptor(limiter *ratelimit.Limiter, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if err := rateLimit(ctx, limiter, info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		}, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamRateLimitInterceptor is unaryRateLimitInterceptor for streaming
+// calls; a stream takes a single token when opened.
+func streamRateLimitInterceptor(limiter *ratelimit.Limiter, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+		if err := rateLimit(stream.Context(), limiter, info.FullMethod, stream.SetHeader, logger); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func rateLimit(
+	ctx context.Context,
+	limiter *ratelimit.Limiter,
+	method string,
+	setHeader func(metadata.MD) error,
+	logger *zap.Logger,
+) error {
+	if limiter == nil {
+		return nil
+	}
+
+	principal, _ := identity.PrincipalFrom(ctx)
+	client := ratelimit.Client(principal, peerIP(ctx))
+	result, err := limiter.Allow(ctx, method, client)
+	if err != nil {
+		logger.Error("Failed to rate limit call", zap.String("client", client), zap.Error(err))
+		return nil
+	}
+
+	if err := setHeader(metadata.New(result.Headers())); err != nil {
+		logger.Warn("Failed to set rate limit headers", zap.Error(err))
+	}
+	if !result.Allowed {
+		logger.Warn("Rate limit exceeded", zap.String("client", client), zap.String("method", method))
+		return apperror.GRPCStatus(ratelimit.ErrLimited)
+	}
+	return nil
+}
+
+// unaryAuthorizeInterceptor requires the role of the principal to grant the
+// permission methodPermissions maps the method to.
+func unaryAuthorizeInterceptor(policy *rbac.Policy, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if err := authorize(ctx, policy, info.FullMethod, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthorizeInterceptor is unaryAuthorizeInterceptor for streaming
+// calls.
+func streamAuthorizeInterceptor(policy *rbac.Policy, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+		if err := authorize(stream.Context(), policy, info.FullMethod, logger); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorize(ctx context.Context, policy *rbac.Policy, method string, logger *zap.Logger) error {
+	principal, ok := identity.PrincipalFrom(ctx)
+	if !ok {
+		return apperror.GRPCStatus(identity.ErrUnauthenticated)
+	}
+
+	permission, ok := methodPermissions[method]
+	if !ok {
+		logger.Error("Method has no permission assigned", zap.String("method", method))
+		return apperror.GRPCStatus(rbac.ErrForbidden)
+	}
+	if err := policy.Authorize(principal, permission); err != nil {
+		logger.Warn("Permission denied",
+			zap.String("method", method),
+			zap.String("actor", principal.Actor()),
+			zap.String("role", principal.Role))
+		return apperror.GRPCStatus(err)
+	}
+	return nil
+}
+
+func authenticate(
+	ctx context.Context,
+	authenticator identity.Authenticator,
+	keys identity.KeyAuthenticator,
+	tenants tenant.Resolver,
+	logger *zap.Logger,
+) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var principal *identity.Principal
+	var err error
+	if key := firstValue(md, "x-api-key"); key != "" {
+		principal, err = keys.AuthenticateKey(ctx, key, peerIP(ctx))
+	} else {
+		scheme, token, ok := strings.Cut(firstValue(md, "authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, apperror.GRPCStatus(identity.ErrUnauthenticated)
+		}
+		principal, err = authenticator.Authenticate(ctx, token)
+	}
+	if err != nil {
+		if !errors.Is(err, identity.ErrUnauthenticated) {
+			logger.Error("Failed to authenticate call", zap.Error(err))
+		}
+		return nil, apperror.GRPCStatus(err)
+	}
+
+	scope, scoped, err := tenant.Resolve(ctx, tenants, principal, firstValue(md, "x-tenant-id"))
+	switch {
+	case errors.Is(err, tenant.ErrNotFound):
+		return nil, apperror.GRPCStatus(apperror.Wrap(apperror.KindValidation, err))
+	case errors.Is(err, tenant.ErrForeignTenant):
+		logger.Warn("Tenant access denied", zap.String("actor", principal.Actor()))
+		return nil, apperror.GRPCStatus(err)
+	case err != nil:
+		logger.Error("Failed to resolve tenant", zap.Error(err))
+		return nil, apperror.GRPCStatus(err)
+	}
+	if scoped {
+		ctx = tenant.WithScope(ctx, scope)
+	}
+
+	ctx = identity.WithPrincipal(ctx, principal)
+	auditMD := audit.MetadataFrom(ctx)
+	auditMD.Actor = principal.Actor()
+	return audit.WithMetadata(ctx, auditMD), nil
+}
+
+// authenticatedStream overrides the context of a stream.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// peerIP returns the IP address of the client, empty when unknown.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
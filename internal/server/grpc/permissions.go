@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"github.com/novriyantoAli/freeradius-service/api/proto/auth"
+	"github.com/novriyantoAli/freeradius-service/api/proto/nas"
+	"github.com/novriyantoAli/freeradius-service/api/proto/payment"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radcheck"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radreply"
+	"github.com/novriyantoAli/freeradius-service/api/proto/session"
+	"github.com/novriyantoAli/freeradius-service/api/proto/user"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// publicMethods are served without authentication, authorization or rate
+// limiting, so that probes need no credentials.
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// methodPermissions maps every gRPC method to the permission it requires. A
+// method missing here is denied to everyone.
+var methodPermissions = map[string]rbac.Permission{
+	auth.AuthService_CreateAuth_FullMethodName: rbac.RadcheckWrite,
+
+	user.UserService_CreateUser_FullMethodName:         rbac.UsersWrite,
+	user.UserService_GetUser_FullMethodName:            rbac.UsersRead,
+	user.UserService_ListUsers_FullMethodName:          rbac.UsersRead,
+	user.UserService_UpdateUser_FullMethodName:         rbac.UsersWrite,
+	user.UserService_DeleteUser_FullMethodName:         rbac.UsersWrite,
+	user.UserService_UpdateUserPassword_FullMethodName: rbac.UsersWrite,
+	user.UserService_UpdateUserRole_FullMethodName:     rbac.UsersRoles,
+
+	payment.PaymentService_CreatePayment_FullMethodName:   rbac.PaymentsWrite,
+	payment.PaymentService_GetPayment_FullMethodName:      rbac.PaymentsRead,
+	payment.PaymentService_ListPayments_FullMethodName:    rbac.PaymentsRead,
+	payment.PaymentService_UpdatePayment_FullMethodName:   rbac.PaymentsWrite,
+	payment.PaymentService_DeletePayment_FullMethodName:   rbac.PaymentsWrite,
+	payment.PaymentService_GetUserPayments_FullMethodName: rbac.PaymentsRead,
+
+	nas.NASService_CreateNAS_FullMethodName:           rbac.NASWrite,
+	nas.NASService_GetNAS_FullMethodName:              rbac.NASRead,
+	nas.NASService_ListNAS_FullMethodName:             rbac.NASRead,
+	nas.NASService_UpdateNAS_FullMethodName:           rbac.NASWrite,
+	nas.NASService_DeleteNAS_FullMethodName:           rbac.NASWrite,
+	nas.NASService_RevealNASSecret_FullMethodName:     rbac.NASSecrets,
+	nas.NASService_RotateNASSecret_FullMethodName:     rbac.NASSecrets,
+	nas.NASService_MatchNAS_FullMethodName:            rbac.NASRead,
+	nas.NASService_GetClientsConf_FullMethodName:      rbac.NASSecrets,
+	nas.NASService_ImportClientsConf_FullMethodName:   rbac.NASWrite,
+	nas.NASService_ReencryptNASSecrets_FullMethodName: rbac.NASReencrypt,
+
+	radcheck.RadcheckService_CreateRadcheck_FullMethodName: rbac.RadcheckWrite,
+	radcheck.RadcheckService_GetRadcheck_FullMethodName:    rbac.RadcheckRead,
+	radcheck.RadcheckService_ListRadcheck_FullMethodName:   rbac.RadcheckRead,
+	radcheck.RadcheckService_UpdateRadcheck_FullMethodName: rbac.RadcheckWrite,
+	radcheck.RadcheckService_DeleteRadcheck_FullMethodName: rbac.RadcheckWrite,
+
+	radreply.RadreplyService_CreateRadreply_FullMethodName: rbac.RadreplyWrite,
+	radreply.RadreplyService_GetRadreply_FullMethodName:    rbac.RadreplyRead,
+	radreply.RadreplyService_ListRadreply_FullMethodName:   rbac.RadreplyRead,
+	radreply.RadreplyService_UpdateRadreply_FullMethodName: rbac.RadreplyWrite,
+	radreply.RadreplyService_DeleteRadreply_FullMethodName: rbac.RadreplyWrite,
+
+	session.SessionService_WatchSessions_FullMethodName: rbac.SessionsRead,
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/novriyantoAli/freeradius-service/api/proto/auth"
+	"github.com/novriyantoAli/freeradius-service/api/proto/nas"
+	"github.com/novriyantoAli/freeradius-service/api/proto/payment"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radcheck"
+	"github.com/novriyantoAli/freeradius-service/api/proto/radreply"
+	"github.com/novriyantoAli/freeradius-service/api/proto/session"
+	"github.com/novriyantoAli/freeradius-service/api/proto/user"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/identity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMethodPermissions(t *testing.T) {
+	descs := []grpc.ServiceDesc{
+		auth.AuthService_ServiceDesc,
+		user.UserService_ServiceDesc,
+		payment.PaymentService_ServiceDesc,
+		nas.NASService_ServiceDesc,
+		radcheck.RadcheckService_ServiceDesc,
+		radreply.RadreplyService_ServiceDesc,
+		session.SessionService_ServiceDesc,
+	}
+
+	count := 0
+	for _, desc := range descs {
+		for _, method := range desc.Methods {
+			count++
+			assert.Contains(t, methodPermissions, "/"+desc.ServiceName+"/"+method.MethodName)
+		}
+		for _, stream := range desc.Streams {
+			count++
+			assert.Contains(t, methodPermissions, "/"+desc.ServiceName+"/"+stream.StreamName)
+		}
+	}
+	assert.Len(t, methodPermissions, count)
+}
+
+func TestAuthorize(t *testing.T) {
+	// Setup
+	policy, err := rbac.NewPolicy(map[string][]string{"billing": {"payments:*"}})
+	require.NoError(t, err)
+	logger := testutil.NewSilentLogger()
+	ctx := identity.WithPrincipal(context.Background(), &identity.Principal{UserID: 4, Role: "billing"})
+
+	t.Run("should allow a granted method", func(t *testing.T) {
+		// When
+		err := authorize(ctx, policy, payment.PaymentService_UpdatePayment_FullMethodName, logger)
+
+		// Then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should deny a method the role lacks the permission for", func(t *testing.T) {
+		// When
+		err := authorize(ctx, policy, nas.NASService_RevealNASSecret_FullMethodName, logger)
+
+		// Then
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("should deny re-encrypting NAS secrets to a tenant", func(t *testing.T) {
+		// Setup
+		policy, err := rbac.NewPolicy(map[string][]string{"operator": {"nas:*"}})
+		require.NoError(t, err)
+		tenantID := uint(1)
+		ctx := identity.WithPrincipal(context.Background(), &identity.Principal{UserID: 5, Role: "operator", TenantID: &tenantID})
+
+		// When
+		err = authorize(ctx, policy, nas.NASService_ReencryptNASSecrets_FullMethodName, logger)
+
+		// Then
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("should deny an unknown method", func(t *testing.T) {
+		// When
+		err := authorize(ctx, policy, "/payment.PaymentService/RefundPayment", logger)
+
+		// Then
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("should require a principal", func(t *testing.T) {
+		// When
+		err := authorize(context.Background(), policy, payment.PaymentService_GetPayment_FullMethodName, logger)
+
+		// Then
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -1,27 +1,76 @@
 package grpc
 
 import (
+	"github.com/novriyantoAli/freeradius-service/internal/application/apikey"
 	"github.com/novriyantoAli/freeradius-service/internal/application/auth"
-	authHandler "github.com/novriyantoAli/freeradius-service/internal/application/auth/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/authn"
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas"
+	nasHandler "github.com/novriyantoAli/freeradius-service/internal/application/nas/handler"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment"
 	paymentHandler "github.com/novriyantoAli/freeradius-service/internal/application/payment/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radcheck"
+	radcheckHandler "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/radreply"
+	radreplyHandler "github.com/novriyantoAli/freeradius-service/internal/application/radreply/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/session"
+	sessionHandler "github.com/novriyantoAli/freeradius-service/internal/application/session/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/application/tenant"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user"
 	userHandler "github.com/novriyantoAli/freeradius-service/internal/application/user/handler"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/ratelimit"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 var Module = fx.Options(
-	// Include domain modules
+	// Include domain modules; auth.Module also provides its gRPC handler
 	auth.Module,
+	authn.Module,
+	apikey.Module,
+	tenant.Module,
 	user.Module,
 	payment.Module,
+	nas.Module,
+	radcheck.Module,
+	radreply.Module,
+	session.Module,
 
 	// gRPC handlers
 	fx.Provide(
-		authHandler.NewAuthGrpcHandler,
 		userHandler.NewUserGrpcHandler,
 		paymentHandler.NewPaymentGrpcHandler,
+		nasHandler.NewNASGrpcHandler,
+		radcheckHandler.NewRadcheckGrpcHandler,
+		radreplyHandler.NewRadreplyGrpcHandler,
+		sessionHandler.NewSessionGrpcHandler,
+		metrics.NewGRPC,
+		provideHealthChecker,
 		NewServer,
 	),
+	fx.Invoke(serveMetrics),
 )
+
+// serveMetrics serves the metrics of the gRPC server on
+// metrics.grpc_address.
+func serveMetrics(lc fx.Lifecycle, registry *prometheus.Registry, cfg *config.Config, logger *zap.Logger) {
+	if cfg.Metrics.Enabled {
+		metrics.Serve(lc, registry, cfg.Metrics.GRPCAddress, logger)
+	}
+}
+
+// provideHealthChecker checks the database, and Redis when it holds the
+// rate limit buckets. Rate limiting lets calls through without Redis, so
+// the gRPC server is then degraded rather than not ready.
+func provideHealthChecker(lc fx.Lifecycle, cfg *config.Config, db *gorm.DB) *health.Checker {
+	checks := []health.Check{{Name: "database", Critical: true, Probe: health.Database(db)}}
+	if cfg.RateLimit.Enabled && cfg.RateLimit.Backend == ratelimit.BackendRedis {
+		checks = append(checks, health.Check{Name: "redis", Probe: health.NewRedisProbe(lc, cfg)})
+	}
+	return health.NewChecker(cfg.Health, checks...)
+}
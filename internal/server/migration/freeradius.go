@@ -0,0 +1,137 @@
+package migration
+
+import (
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/migrate"
+)
+
+// attributeTable is a check or reply table, of users or groups as key says.
+func attributeTable(name, key string) migrate.Table {
+	return migrate.Table{
+		Name:       name,
+		PrimaryKey: "id",
+		Columns: []migrate.Column{
+			{Name: "id", Kind: migrate.Integer},
+			{Name: key, Kind: migrate.Text, Length: 64},
+			{Name: "attribute", Kind: migrate.Text, Length: 64},
+			{Name: "op", Kind: migrate.Text, Length: 2},
+			{Name: "value", Kind: migrate.Text, Length: 253},
+		},
+		Indexes: []migrate.Index{{Columns: []string{key}}},
+	}
+}
+
+// radacctIndexes differ between the MySQL and SQLite schemas and the
+// PostgreSQL one, which indexes for its bulk close and timeout queries.
+var radacctIndexes = func() []migrate.Index {
+	indexes := []migrate.Index{
+		{Columns: []string{"acctuniqueid"}, Unique: true},
+		{Columns: []string{"class"}},
+		{Columns: []string{"nasipaddress", "acctstarttime"}, Drivers: []string{"postgres"}},
+		{Columns: []string{"acctstoptime", "acctupdatetime"}, Drivers: []string{"postgres"}},
+		{Columns: []string{"acctstarttime", "username"}, Drivers: []string{"postgres"}},
+	}
+	for _, column := range []string{
+		"username", "framedipaddress", "framedipv6address", "framedipv6prefix", "framedinterfaceid",
+		"delegatedipv6prefix", "acctsessionid", "acctsessiontime", "acctstarttime", "acctinterval",
+		"acctstoptime", "nasipaddress",
+	} {
+		indexes = append(indexes, migrate.Index{Columns: []string{column}, Drivers: []string{"mysql", "sqlite"}})
+	}
+	return indexes
+}()
+
+// freeradiusSchema is the FreeRADIUS schema as upstream ships it, the tables
+// FreeRADIUS reads and writes. Widths are the MySQL ones; PostgreSQL declares
+// most columns text.
+var freeradiusSchema = []migrate.Table{
+	attributeTable("radcheck", "username"),
+	attributeTable("radreply", "username"),
+	attributeTable("radgroupcheck", "groupname"),
+	attributeTable("radgroupreply", "groupname"),
+	{
+		Name:       "radusergroup",
+		PrimaryKey: "id",
+		Columns: []migrate.Column{
+			{Name: "id", Kind: migrate.Integer},
+			{Name: "username", Kind: migrate.Text, Length: 64},
+			{Name: "groupname", Kind: migrate.Text, Length: 64},
+			{Name: "priority", Kind: migrate.Integer},
+		},
+		Indexes: []migrate.Index{{Columns: []string{"username"}}},
+	},
+	{
+		Name:       "radacct",
+		PrimaryKey: "radacctid",
+		Columns: []migrate.Column{
+			{Name: "radacctid", Kind: migrate.Integer},
+			{Name: "acctsessionid", Kind: migrate.Text, Length: 64},
+			{Name: "acctuniqueid", Kind: migrate.Text, Length: 32},
+			{Name: "username", Kind: migrate.Text, Length: 64},
+			{Name: "realm", Kind: migrate.Text, Length: 64},
+			{Name: "nasipaddress", Kind: migrate.Address, Length: 15},
+			{Name: "nasportid", Kind: migrate.Text, Length: 32},
+			{Name: "nasporttype", Kind: migrate.Text, Length: 32},
+			{Name: "acctstarttime", Kind: migrate.Time},
+			{Name: "acctupdatetime", Kind: migrate.Time},
+			{Name: "acctstoptime", Kind: migrate.Time},
+			{Name: "acctinterval", Kind: migrate.Integer},
+			{Name: "acctsessiontime", Kind: migrate.Integer},
+			{Name: "acctauthentic", Kind: migrate.Text, Length: 32},
+			{Name: "connectinfo_start", Kind: migrate.Text, Length: 128},
+			{Name: "connectinfo_stop", Kind: migrate.Text, Length: 128},
+			{Name: "acctinputoctets", Kind: migrate.Integer},
+			{Name: "acctoutputoctets", Kind: migrate.Integer},
+			{Name: "calledstationid", Kind: migrate.Text, Length: 50},
+			{Name: "callingstationid", Kind: migrate.Text, Length: 50},
+			{Name: "acctterminatecause", Kind: migrate.Text, Length: 32},
+			{Name: "servicetype", Kind: migrate.Text, Length: 32},
+			{Name: "framedprotocol", Kind: migrate.Text, Length: 32},
+			{Name: "framedipaddress", Kind: migrate.Address, Length: 15},
+			{Name: "framedipv6address", Kind: migrate.Address, Length: 45},
+			{Name: "framedipv6prefix", Kind: migrate.Address, Length: 45},
+			{Name: "framedinterfaceid", Kind: migrate.Text, Length: 44},
+			{Name: "delegatedipv6prefix", Kind: migrate.Address, Length: 45},
+			{Name: "class", Kind: migrate.Text, Length: 64},
+		},
+		Indexes: radacctIndexes,
+	},
+	{
+		Name:       "radpostauth",
+		PrimaryKey: "id",
+		Columns: []migrate.Column{
+			{Name: "id", Kind: migrate.Integer},
+			{Name: "username", Kind: migrate.Text, Length: 64},
+			{Name: "pass", Kind: migrate.Text, Length: 64},
+			{Name: "reply", Kind: migrate.Text, Length: 32},
+			{Name: "authdate", Kind: migrate.Time},
+			{Name: "class", Kind: migrate.Text, Length: 64},
+		},
+		Indexes: []migrate.Index{{Columns: []string{"username"}}, {Columns: []string{"class"}}},
+	},
+	{
+		Name:       "nas",
+		PrimaryKey: "id",
+		Columns: []migrate.Column{
+			{Name: "id", Kind: migrate.Integer},
+			{Name: "nasname", Kind: migrate.Text, Length: 128},
+			{Name: "shortname", Kind: migrate.Text, Length: 32},
+			{Name: "type", Kind: migrate.Text, Length: 30},
+			{Name: "ports", Kind: migrate.Integer},
+			{Name: "secret", Kind: migrate.Text, Length: 60},
+			{Name: "server", Kind: migrate.Text, Length: 64},
+			{Name: "community", Kind: migrate.Text, Length: 50},
+			{Name: "description", Kind: migrate.Text, Length: 200},
+			{Name: "require_ma", Kind: migrate.Text, Length: 4},
+			{Name: "limit_proxy_state", Kind: migrate.Text, Length: 4},
+		},
+		Indexes: []migrate.Index{{Columns: []string{"nasname"}}},
+	},
+	{
+		Name:       "nasreload",
+		PrimaryKey: "nasipaddress",
+		Columns: []migrate.Column{
+			{Name: "nasipaddress", Kind: migrate.Address, Length: 15},
+			{Name: "reloadtime", Kind: migrate.Time},
+		},
+	},
+}
@@ -0,0 +1,219 @@
+package migration
+
+import (
+	"embed"
+	"io/fs"
+
+	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	paymentEntity "github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	radreplyEntity "github.com/novriyantoAli/freeradius-service/internal/application/radreply/entity"
+	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// sqlFiles holds the SQL migrations, a directory per driver.
+//
+//go:embed sql
+var sqlFiles embed.FS
+
+// migrations returns the migrations of the driver of db: its SQL files, and
+// the migrations that need to look at the schema first.
+func migrations(db *gorm.DB) ([]migrate.Migration, error) {
+	dir, err := fs.Sub(sqlFiles, "sql/"+db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := migrate.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range loaded {
+		if loaded[i].Version == 1 {
+			loaded[i].Up = adoptBaseline(loaded[i].Up)
+		}
+	}
+	return append(loaded,
+		migrate.Migration{
+			Version: 3,
+			Name:    "radius_tenant_columns",
+			Up:      addTenantColumns,
+			Down:    dropTenantColumns,
+		},
+		migrate.Migration{
+			Version: 5,
+			Name:    "nas_freeradius_columns",
+			Up:      alignNAS,
+			Down:    unalignNAS,
+		},
+	), nil
+}
+
+// baselineTables are the tables of the release that predates the
+// migrations, with the columns added to them since.
+var baselineTables = []struct {
+	model  interface{}
+	fields []string
+}{
+	{&userEntity.User{}, []string{"Role", "TenantID"}},
+	{&paymentEntity.Payment{}, []string{"TenantID"}},
+}
+
+// adoptBaseline wraps the up of 0001 for a database that release created:
+// the columns 0001 indexes are added first, and the indexes it declares
+// inline on MySQL, which CREATE TABLE IF NOT EXISTS skips, after.
+func adoptBaseline(up func(tx *gorm.DB) error) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		var adopted []interface{}
+		for _, table := range baselineTables {
+			if !migrator.HasTable(table.model) {
+				continue
+			}
+			adopted = append(adopted, table.model)
+			for _, field := range table.fields {
+				if migrator.HasColumn(table.model, field) {
+					continue
+				}
+				if err := migrator.AddColumn(table.model, field); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := up(tx); err != nil {
+			return err
+		}
+
+		for _, model := range adopted {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+			for _, index := range stmt.Schema.ParseIndexes() {
+				if migrator.HasIndex(model, index.Name) {
+					continue
+				}
+				if err := migrator.CreateIndex(model, index.Name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// radiusModels are the FreeRADIUS tables the service adds a tenant to.
+var radiusModels = []interface{}{&radcheckEntity.Radcheck{}, &radreplyEntity.Radreply{}}
+
+// addTenantColumns adds the tenant_id column to the FreeRADIUS tables, with
+// the (tenant_id, id) index their lists are paged by, unless a release that
+// predates the migrations already did.
+func addTenantColumns(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, model := range radiusModels {
+		if !migrator.HasColumn(model, "TenantID") {
+			if err := migrator.AddColumn(model, "TenantID"); err != nil {
+				return err
+			}
+		}
+		if !migrator.HasIndex(model, "TenantID") {
+			if err := migrator.CreateIndex(model, "TenantID"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func dropTenantColumns(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, model := range radiusModels {
+		if migrator.HasIndex(model, "TenantID") {
+			if err := migrator.DropIndex(model, "TenantID"); err != nil {
+				return err
+			}
+		}
+		if migrator.HasColumn(model, "TenantID") {
+			if err := migrator.DropColumn(model, "TenantID"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nasRenames are the columns of nas the service named apart from upstream.
+var nasRenames = [][2]string{{"nas_name", "nasname"}, {"short_name", "shortname"}}
+
+// alignNAS gives the nas table the upstream column names FreeRADIUS reads,
+// and the columns and indexes the service keeps: 0001 leaves the indexes to
+// it on every driver, as FreeRADIUS may have created nas without their
+// columns. Secrets are stored encrypted, longer than the upstream varchar(60)
+// allows.
+func alignNAS(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	model := &nasEntity.NAS{}
+	ours := false
+	for _, rename := range nasRenames {
+		if migrator.HasColumn(model, rename[0]) && !migrator.HasColumn(model, rename[1]) {
+			if err := migrator.RenameColumn(model, rename[0], rename[1]); err != nil {
+				return err
+			}
+			ours = true
+		}
+	}
+
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || migrator.HasColumn(model, field.DBName) {
+			continue
+		}
+		if err := migrator.AddColumn(model, field.Name); err != nil {
+			return err
+		}
+	}
+	for _, index := range stmt.Schema.ParseIndexes() {
+		// Upstream indexes nasname already, and its rows may not be unique
+		if index.Class == "UNIQUE" && !ours || migrator.HasIndex(model, index.Name) {
+			continue
+		}
+		if err := migrator.CreateIndex(model, index.Name); err != nil {
+			return err
+		}
+	}
+
+	// SQLite does not enforce lengths, and PostgreSQL's upstream secret is text
+	if tx.Dialector.Name() != "mysql" {
+		return nil
+	}
+	columns, err := migrator.ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if length, ok := column.Length(); ok && column.Name() == "secret" && length < 191 {
+			return migrator.AlterColumn(model, "Secret")
+		}
+	}
+	return nil
+}
+
+// unalignNAS restores the column names of the service. The columns added to
+// a nas table FreeRADIUS created stay.
+func unalignNAS(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, rename := range nasRenames {
+		if migrator.HasColumn("nas", rename[1]) && !migrator.HasColumn("nas", rename[0]) {
+			if err := migrator.RenameColumn("nas", rename[1], rename[0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package migration_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
+	paymentEntity "github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	radcheckEntity "github.com/novriyantoAli/freeradius-service/internal/application/radcheck/entity"
+	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
+	"github.com/novriyantoAli/freeradius-service/internal/server/migration"
+)
+
+func setupServer(t *testing.T) (*migration.Server, *gorm.DB) {
+	t.Helper()
+	dsn := "file:" + filepath.Join(t.TempDir(), "radius.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	cfg := &config.Config{Database: config.DatabaseConfig{MigrationLockTimeout: time.Second}}
+	return migration.NewServer(db, cfg, zap.NewNop()), db
+}
+
+func TestServer_RunMigrations(t *testing.T) {
+	t.Run("should create every column the entities map", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+
+		// When
+		err := server.RunMigrations(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		for _, model := range testutil.Models() {
+			stmt := &gorm.Statement{DB: db}
+			require.NoError(t, stmt.Parse(model))
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName != "" {
+					assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+				}
+			}
+		}
+		assert.True(t, db.Migrator().HasIndex(&radcheckEntity.Radcheck{}, "TenantID"))
+		assert.True(t, db.Migrator().HasIndex(&nasEntity.NAS{}, "idx_nas_nas_name"))
+		assert.True(t, db.Migrator().HasIndex(&nasEntity.NAS{}, "idx_nas_tenant_id"))
+	})
+
+	t.Run("should store entities with the column defaults", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, server.RunMigrations(context.Background()))
+		nas := &nasEntity.NAS{NASName: "10.0.0.1", Secret: "testing123"}
+
+		// When
+		err := db.Create(nas).Error
+		checkErr := db.Create(&radcheckEntity.Radcheck{Username: "alice", Attribute: "Cleartext-Password", Op: ":=", Value: "secret"}).Error
+
+		// Then
+		require.NoError(t, err)
+		require.NoError(t, checkErr)
+		var stored nasEntity.NAS
+		require.NoError(t, db.First(&stored, nas.ID).Error)
+		assert.Equal(t, "other", stored.Type)
+		assert.Equal(t, "unknown", stored.HealthStatus)
+	})
+
+	t.Run("should upgrade a database of the release before the migrations", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, db.AutoMigrate(&baselineUser{}, &baselinePayment{}, &baselineNAS{}))
+		require.NoError(t, db.Create(&baselineUser{Name: "admin", Email: "admin@example.com", Password: "hash"}).Error)
+		require.NoError(t, db.Create(&baselineNAS{NASName: "10.0.0.1", Secret: "testing123"}).Error)
+
+		// When
+		err := server.RunMigrations(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		statuses, err := server.MigrationStatus(context.Background())
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied && !status.Dirty, "%04d_%s", status.Version, status.Name)
+		}
+		for _, model := range testutil.Models() {
+			stmt := &gorm.Statement{DB: db}
+			require.NoError(t, stmt.Parse(model))
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName != "" {
+					assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+				}
+			}
+		}
+		assert.True(t, db.Migrator().HasIndex(&userEntity.User{}, "idx_users_tenant_id"))
+		assert.True(t, db.Migrator().HasIndex(&paymentEntity.Payment{}, "idx_payments_tenant_id_id"))
+		var user userEntity.User
+		require.NoError(t, db.Where("email = ?", "admin@example.com").First(&user).Error)
+		assert.Equal(t, "readonly", user.Role)
+		var nas nasEntity.NAS
+		require.NoError(t, db.Where("nasname = ?", "10.0.0.1").First(&nas).Error)
+	})
+}
+
+// The entities of the release before the migrations, which AutoMigrated them.
+type baselineUser struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"not null"`
+	Email     string `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Password  string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselinePayment struct {
+	ID          uint    `gorm:"primaryKey"`
+	Amount      float64 `gorm:"not null"`
+	Currency    string  `gorm:"size:3;not null"`
+	Status      string  `gorm:"default:pending"`
+	Description string  `gorm:"size:500"`
+	UserID      uint    `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselinePayment) TableName() string { return "payments" }
+
+type baselineNAS struct {
+	ID              uint   `gorm:"primaryKey"`
+	NASName         string `gorm:"index;uniqueIndex;not null;size:128"`
+	ShortName       string `gorm:"size:32"`
+	Type            string `gorm:"size:30;default:'other'"`
+	Ports           int
+	Secret          string `gorm:"not null;default:'secret'"`
+	Server          string `gorm:"size:64"`
+	Community       string `gorm:"size:50"`
+	Description     string `gorm:"size:200;default:'RADIUS Client'"`
+	RequireMa       string `gorm:"size:4;default:'auto'"`
+	LimitProxyState string `gorm:"size:4;default:'auto'"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineNAS) TableName() string { return "nas" }
+
+func TestServer_VerifySchema(t *testing.T) {
+	t.Run("should find the migrated schema matching FreeRADIUS", func(t *testing.T) {
+		// Setup
+		server, _ := setupServer(t)
+		require.NoError(t, server.RunMigrations(context.Background()))
+
+		// When
+		drifts, err := server.VerifySchema(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("should report the tables FreeRADIUS lacks before migrating", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, db.AutoMigrate(testutil.Models()...))
+		require.NoError(t, db.Exec("ALTER TABLE nas RENAME COLUMN nasname TO nas_name").Error)
+
+		// When
+		drifts, err := server.VerifySchema(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		var report []string
+		for _, drift := range drifts {
+			report = append(report, drift.String())
+		}
+		assert.Contains(t, report, "radacct: table is missing")
+		assert.Contains(t, report, "nas.nasname: column is missing")
+		assert.Contains(t, report, "nas.(nasname): index is missing")
+		assert.NotContains(t, report, "radcheck: table is missing")
+	})
+
+	t.Run("should adopt a database FreeRADIUS created", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, db.Exec(`CREATE TABLE nas (
+  id INTEGER PRIMARY KEY,
+  nasname varchar(128) NOT NULL,
+  shortname varchar(32),
+  type varchar(30) DEFAULT 'other',
+  ports int(5),
+  secret varchar(60) DEFAULT 'secret' NOT NULL,
+  server varchar(64),
+  community varchar(50),
+  description varchar(200) DEFAULT 'RADIUS Client',
+  require_ma varchar(4) DEFAULT 'auto',
+  limit_proxy_state varchar(4) DEFAULT 'auto'
+)`).Error)
+		require.NoError(t, db.Exec("CREATE INDEX nasname ON nas (nasname)").Error)
+		require.NoError(t, db.Exec("INSERT INTO nas (nasname, shortname, secret) VALUES ('10.0.0.1', 'edge-1', 'testing123')").Error)
+
+		// When
+		err := server.RunMigrations(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		drifts, err := server.VerifySchema(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, drifts)
+		var stored nasEntity.NAS
+		require.NoError(t, db.Where("nasname = ?", "10.0.0.1").First(&stored).Error)
+		assert.Equal(t, "edge-1", stored.ShortName)
+		assert.Equal(t, "other", stored.Type)
+		require.NoError(t, db.Create(&nasEntity.NAS{NASName: "10.0.0.2", Secret: "testing123"}).Error)
+	})
+}
+
+func TestServer_DropTables(t *testing.T) {
+	t.Run("should revert every migration", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, server.RunMigrations(context.Background()))
+
+		// When
+		err := server.DropTables(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		for _, model := range testutil.Models() {
+			assert.False(t, db.Migrator().HasTable(model))
+		}
+	})
+
+	t.Run("should keep the tables FreeRADIUS created", func(t *testing.T) {
+		// Setup
+		server, db := setupServer(t)
+		require.NoError(t, db.Exec(`CREATE TABLE radcheck (
+  id INTEGER PRIMARY KEY,
+  username varchar(64) NOT NULL DEFAULT '',
+  attribute varchar(64) NOT NULL DEFAULT '',
+  op char(2) NOT NULL DEFAULT '==',
+  value varchar(253) NOT NULL DEFAULT ''
+)`).Error)
+		require.NoError(t, db.Exec("INSERT INTO radcheck (username, attribute, op, value) VALUES ('alice', 'Cleartext-Password', ':=', 'secret')").Error)
+		require.NoError(t, db.Exec(`CREATE TABLE radacct (
+  radacctid INTEGER PRIMARY KEY AUTOINCREMENT,
+  acctsessionid varchar(64) NOT NULL default '',
+  acctuniqueid varchar(32) NOT NULL default '',
+  username varchar(64) NOT NULL default '',
+  realm varchar(64) default '',
+  nasipaddress varchar(15) NOT NULL default '',
+  nasportid varchar(15) default NULL,
+  nasporttype varchar(32) default NULL,
+  acctstarttime datetime NULL default NULL,
+  acctupdatetime datetime NULL default NULL,
+  acctstoptime datetime NULL default NULL,
+  acctinterval int(12) default NULL,
+  acctsessiontime int(12) default NULL,
+  acctauthentic varchar(32) default NULL,
+  connectinfo_start varchar(50) default NULL,
+  connectinfo_stop varchar(50) default NULL,
+  acctinputoctets bigint(20) default NULL,
+  acctoutputoctets bigint(20) default NULL,
+  calledstationid varchar(50) NOT NULL default '',
+  callingstationid varchar(50) NOT NULL default '',
+  acctterminatecause varchar(32) NOT NULL default '',
+  servicetype varchar(32) default NULL,
+  framedprotocol varchar(32) default NULL,
+  framedipaddress varchar(15) NOT NULL default '',
+  framedipv6address varchar(45) NOT NULL default '',
+  framedipv6prefix varchar(45) NOT NULL default '',
+  framedinterfaceid varchar(44) NOT NULL default '',
+  delegatedipv6prefix varchar(45) NOT NULL default '',
+  class varchar(64) default NULL
+)`).Error)
+		require.NoError(t, server.RunMigrations(context.Background()))
+
+		// When
+		err := server.DropTables(context.Background())
+
+		// Then
+		require.NoError(t, err)
+		var count int64
+		require.NoError(t, db.Table("radcheck").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+		assert.True(t, db.Migrator().HasTable("radacct"))
+		assert.False(t, db.Migrator().HasTable("radpostauth"))
+		assert.False(t, db.Migrator().HasTable("radreply"))
+		assert.False(t, db.Migrator().HasTable(&userEntity.User{}))
+	})
+}
@@ -1,62 +1,196 @@
 package migration
 
 import (
-	nasEntity "github.com/novriyantoAli/freeradius-service/internal/application/nas/entity"
-	"github.com/novriyantoAli/freeradius-service/internal/application/payment/entity"
+	"context"
+	"errors"
+	"math"
+	"time"
+
 	userEntity "github.com/novriyantoAli/freeradius-service/internal/application/user/entity"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/migrate"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/rbac"
 
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type Server struct {
 	db     *gorm.DB
+	cfg    *config.Config
 	logger *zap.Logger
 }
 
-func NewServer(db *gorm.DB, logger *zap.Logger) *Server {
+func NewServer(db *gorm.DB, cfg *config.Config, logger *zap.Logger) *Server {
 	return &Server{
 		db:     db,
+		cfg:    cfg,
 		logger: logger,
 	}
 }
 
-func (s *Server) RunMigrations() error {
+// RunMigrations applies the pending migrations.
+func (s *Server) RunMigrations(ctx context.Context) error {
 	s.logger.Info("Starting database migrations")
 
-	// Run auto migrations for all entities
-	err := s.db.AutoMigrate(
-		&userEntity.User{},
-		&entity.Payment{},
-		&nasEntity.NAS{},
-	)
+	migrator, err := s.migrator()
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
 	if err != nil {
 		s.logger.Error("Failed to run database migrations", zap.Error(err))
 		return err
 	}
 
-	s.logger.Info("Database migrations completed successfully")
+	s.logger.Info("Database migrations completed successfully", zap.Int("applied", applied))
 	return nil
 }
 
+// Rollback reverts the last steps applied migrations.
+func (s *Server) Rollback(ctx context.Context, steps int) error {
+	s.logger.Warn("Reverting database migrations", zap.Int("steps", steps))
+
+	migrator, err := s.migrator()
+	if err != nil {
+		return err
+	}
+	reverted, err := migrator.Down(ctx, steps)
+	if err != nil {
+		s.logger.Error("Failed to revert database migrations", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Database migrations reverted successfully", zap.Int("reverted", reverted))
+	return nil
+}
+
+// MigrationStatus returns the state of every migration.
+func (s *Server) MigrationStatus(ctx context.Context) ([]migrate.Status, error) {
+	migrator, err := s.migrator()
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Status(ctx)
+}
+
+// ForceVersion records the migrations up to version as applied without
+// running them.
+func (s *Server) ForceVersion(ctx context.Context, version int64) error {
+	migrator, err := s.migrator()
+	if err != nil {
+		return err
+	}
+	return migrator.Force(ctx, version)
+}
+
+// ReleaseLock removes a SQLite migrations lock taken more than staleAfter
+// ago, left behind by a killed run.
+func (s *Server) ReleaseLock(ctx context.Context, staleAfter time.Duration) error {
+	migrator, err := s.migrator()
+	if err != nil {
+		return err
+	}
+	return migrator.ReleaseLock(ctx, staleAfter)
+}
+
+// VerifySchema compares the FreeRADIUS tables of the database with the
+// upstream schema and returns the differences.
+func (s *Server) VerifySchema(ctx context.Context) ([]migrate.Drift, error) {
+	drifts, err := migrate.Verify(ctx, s.db, freeradiusSchema)
+	if err != nil {
+		s.logger.Error("Failed to verify the database schema", zap.Error(err))
+		return nil, err
+	}
+	return drifts, nil
+}
+
+func (s *Server) migrator() (*migrate.Migrator, error) {
+	all, err := migrations(s.db)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(s.db, all, s.cfg.Database.MigrationLockTimeout, s.logger)
+}
+
 func (s *Server) SeedData() error {
 	s.logger.Info("Starting data seeding")
 
-	// Add any initial data seeding here
-	// Example: Create default admin user, initial payment statuses, etc.
+	if err := s.seedBootstrapUser(); err != nil {
+		s.logger.Error("Failed to seed bootstrap user", zap.Error(err))
+		return err
+	}
 
 	s.logger.Info("Data seeding completed successfully")
 	return nil
 }
 
-func (s *Server) DropTables() error {
+// seedBootstrapUser creates the configured first user as a super-admin, who
+// can then log in and create the others. Once users exist it only makes sure
+// there is a super-admin, promoting the bootstrap user of databases that
+// predate roles.
+func (s *Server) seedBootstrapUser() error {
+	email, password := s.cfg.Auth.BootstrapEmail, s.cfg.Auth.BootstrapPassword
+	if email == "" {
+		s.logger.Info("No bootstrap user configured")
+		return nil
+	}
+	if len(password) < 8 {
+		return errors.New("auth.bootstrap_password must be at least 8 characters")
+	}
+
+	var count int64
+	if err := s.db.Model(&userEntity.User{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return s.ensureAdmin(email)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user := &userEntity.User{Name: "Administrator", Email: email, Password: string(hash), Role: string(rbac.RoleAdmin)}
+	if err := s.db.Create(user).Error; err != nil {
+		return err
+	}
+	s.logger.Info("Bootstrap user created", zap.String("email", email))
+	return nil
+}
+
+func (s *Server) ensureAdmin(email string) error {
+	var admins int64
+	if err := s.db.Model(&userEntity.User{}).Where("role = ? AND tenant_id IS NULL", rbac.RoleAdmin).Count(&admins).Error; err != nil {
+		return err
+	}
+	if admins > 0 {
+		s.logger.Info("Users exist, skipping bootstrap user")
+		return nil
+	}
+
+	result := s.db.Model(&userEntity.User{}).Where("email = ? AND tenant_id IS NULL", email).Update("role", rbac.RoleAdmin)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		s.logger.Warn("No admin exists and the bootstrap user was not found", zap.String("email", email))
+		return nil
+	}
+	s.logger.Info("Bootstrap user promoted to admin", zap.String("email", email))
+	return nil
+}
+
+// DropTables reverts every migration, dropping the tables they created.
+func (s *Server) DropTables(ctx context.Context) error {
 	s.logger.Warn("Dropping all database tables")
 
-	err := s.db.Migrator().DropTable(
-		&userEntity.User{},
-		&entity.Payment{},
-	)
+	migrator, err := s.migrator()
 	if err != nil {
+		return err
+	}
+	if _, err := migrator.Down(ctx, math.MaxInt); err != nil {
 		s.logger.Error("Failed to drop database tables", zap.Error(err))
 		return err
 	}
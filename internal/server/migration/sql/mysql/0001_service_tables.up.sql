@@ -0,0 +1,200 @@
+-- Tables of the service itself, next to the FreeRADIUS ones.
+
+CREATE TABLE IF NOT EXISTS users (
+  id bigint unsigned AUTO_INCREMENT,
+  name longtext NOT NULL,
+  email varchar(255) NOT NULL,
+  password longtext NOT NULL,
+  role varchar(32) NOT NULL DEFAULT 'readonly',
+  tenant_id bigint unsigned,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  deleted_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  UNIQUE INDEX idx_users_email (email),
+  INDEX idx_users_tenant_id (tenant_id),
+  INDEX idx_users_deleted_at (deleted_at)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS payments (
+  id bigint unsigned AUTO_INCREMENT,
+  amount double NOT NULL,
+  currency varchar(3) NOT NULL,
+  status varchar(191) DEFAULT 'pending',
+  description varchar(500),
+  user_id bigint unsigned NOT NULL,
+  tenant_id bigint unsigned,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  deleted_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  INDEX idx_payments_user_id_id (user_id,id),
+  INDEX idx_payments_tenant_id_id (tenant_id,id),
+  INDEX idx_payments_deleted_at (deleted_at)
+) ENGINE=InnoDB;
+
+-- Indexed by 0005, as FreeRADIUS may have created nas without these columns
+CREATE TABLE IF NOT EXISTS nas (
+  id bigint unsigned AUTO_INCREMENT,
+  nas_name varchar(128) NOT NULL,
+  short_name varchar(32),
+  type varchar(30) DEFAULT 'other',
+  ports bigint,
+  secret varchar(191) NOT NULL DEFAULT 'secret',
+  server varchar(64),
+  community varchar(50),
+  description varchar(200) DEFAULT 'RADIUS Client',
+  require_ma varchar(4) DEFAULT 'auto',
+  limit_proxy_state varchar(4) DEFAULT 'auto',
+  secret_rotated_at datetime(3) NULL,
+  health_check boolean DEFAULT false,
+  health_check_port bigint DEFAULT 0,
+  health_status varchar(8) DEFAULT 'unknown',
+  last_seen_at datetime(3) NULL,
+  last_checked_at datetime(3) NULL,
+  last_rtt_ms bigint,
+  tenant_id bigint unsigned,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  deleted_at datetime(3) NULL,
+  PRIMARY KEY (id)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS session_events (
+  id bigint unsigned AUTO_INCREMENT,
+  event_type varchar(8) NOT NULL,
+  acct_session_id varchar(64) NOT NULL,
+  acct_unique_id varchar(32),
+  username varchar(64) NOT NULL,
+  group_name varchar(64),
+  nas_ip_address varchar(45) NOT NULL,
+  nas_port_id varchar(32),
+  framed_ip_address varchar(45),
+  session_time bigint,
+  input_octets bigint,
+  output_octets bigint,
+  terminate_cause varchar(32),
+  event_time datetime(3) NOT NULL,
+  created_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  INDEX idx_session_events_acct_session_id (acct_session_id),
+  INDEX idx_session_events_username (username),
+  INDEX idx_session_events_nas_ip_address (nas_ip_address)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+  id bigint unsigned AUTO_INCREMENT,
+  event_id varchar(36) NOT NULL,
+  event_type varchar(64) NOT NULL,
+  aggregate_type varchar(32) NOT NULL,
+  aggregate_id varchar(64) NOT NULL,
+  payload text NOT NULL,
+  occurred_at datetime(3) NOT NULL,
+  attempts bigint NOT NULL DEFAULT 0,
+  next_attempt_at datetime(3) NOT NULL,
+  last_error text,
+  published_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  UNIQUE INDEX idx_outbox_messages_event_id (event_id),
+  INDEX idx_outbox_messages_event_type (event_type),
+  INDEX idx_outbox_messages_next_attempt_at (next_attempt_at),
+  INDEX idx_outbox_messages_published_at (published_at)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id bigint unsigned AUTO_INCREMENT,
+  url varchar(2048) NOT NULL,
+  event_types varchar(1024) NOT NULL,
+  secret longtext NOT NULL,
+  description varchar(200),
+  active boolean DEFAULT true,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  INDEX idx_webhook_subscriptions_active (active)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id bigint unsigned AUTO_INCREMENT,
+  subscription_id bigint unsigned NOT NULL,
+  event_id varchar(36) NOT NULL,
+  event_type varchar(64) NOT NULL,
+  payload text NOT NULL,
+  status varchar(16) NOT NULL DEFAULT 'pending',
+  attempts bigint NOT NULL DEFAULT 0,
+  response_status bigint,
+  response_body text,
+  last_error text,
+  next_attempt_at datetime(3) NULL,
+  delivered_at datetime(3) NULL,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  INDEX idx_webhook_deliveries_subscription_id_id (subscription_id,id),
+  UNIQUE INDEX idx_webhook_deliveries_event (subscription_id,event_id),
+  INDEX idx_webhook_deliveries_status (status)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS audit_entries (
+  id bigint unsigned AUTO_INCREMENT,
+  occurred_at datetime(3) NOT NULL,
+  actor varchar(128) NOT NULL,
+  source varchar(16) NOT NULL,
+  request_id varchar(64),
+  tenant_id bigint unsigned,
+  entity_type varchar(32) NOT NULL,
+  entity_id varchar(64) NOT NULL,
+  action varchar(16) NOT NULL,
+  changes text NOT NULL,
+  PRIMARY KEY (id),
+  INDEX idx_audit_entries_occurred_at_id (occurred_at,id),
+  INDEX idx_audit_entries_tenant_id_occurred_at_id (tenant_id,occurred_at,id),
+  INDEX idx_audit_entries_actor (actor),
+  INDEX idx_audit_entries_request_id (request_id),
+  INDEX idx_audit_entries_entity (entity_type,entity_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS admin_sessions (
+  id varchar(36),
+  user_id bigint unsigned NOT NULL,
+  refresh_token_hash varchar(64) NOT NULL,
+  user_agent varchar(255),
+  client_ip varchar(64),
+  created_at datetime(3) NULL,
+  last_used_at datetime(3) NULL,
+  expires_at datetime(3) NOT NULL,
+  revoked_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  INDEX idx_admin_sessions_user_id (user_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+  id bigint unsigned AUTO_INCREMENT,
+  name varchar(100) NOT NULL,
+  prefix varchar(16) NOT NULL,
+  secret_hash varchar(64) NOT NULL,
+  scopes varchar(1024) NOT NULL,
+  allowed_c_id_rs varchar(1024),
+  created_by bigint unsigned,
+  tenant_id bigint unsigned,
+  expires_at datetime(3) NULL,
+  last_used_at datetime(3) NULL,
+  last_used_ip varchar(45),
+  revoked_at datetime(3) NULL,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  UNIQUE INDEX idx_api_keys_prefix (prefix),
+  INDEX idx_api_keys_created_by (created_by),
+  INDEX idx_api_keys_tenant_id (tenant_id)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS tenants (
+  id bigint unsigned AUTO_INCREMENT,
+  name varchar(100) NOT NULL,
+  realm varchar(48) NOT NULL,
+  created_at datetime(3) NULL,
+  updated_at datetime(3) NULL,
+  PRIMARY KEY (id),
+  UNIQUE INDEX idx_tenants_realm (realm)
+) ENGINE=InnoDB;
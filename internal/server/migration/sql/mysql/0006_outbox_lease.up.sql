@@ -0,0 +1,3 @@
+-- The lease a relay holds on the messages it is delivering.
+
+ALTER TABLE outbox_messages ADD COLUMN locked_until datetime(3) NULL;
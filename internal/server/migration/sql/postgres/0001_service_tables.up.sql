@@ -0,0 +1,200 @@
+-- Tables of the service itself, next to the FreeRADIUS ones.
+
+CREATE TABLE IF NOT EXISTS users (
+  id bigserial,
+  name text NOT NULL,
+  email varchar(255) NOT NULL,
+  password text NOT NULL,
+  role varchar(32) NOT NULL DEFAULT 'readonly',
+  tenant_id bigint,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS payments (
+  id bigserial,
+  amount decimal NOT NULL,
+  currency varchar(3) NOT NULL,
+  status text DEFAULT 'pending',
+  description varchar(500),
+  user_id bigint NOT NULL,
+  tenant_id bigint,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_payments_deleted_at ON payments (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payments_tenant_id_id ON payments (tenant_id,id);
+CREATE INDEX IF NOT EXISTS idx_payments_user_id_id ON payments (user_id,id);
+
+-- Indexed by 0005, as FreeRADIUS may have created nas without these columns
+CREATE TABLE IF NOT EXISTS nas (
+  id bigserial,
+  nas_name varchar(128) NOT NULL,
+  short_name varchar(32),
+  type varchar(30) DEFAULT 'other',
+  ports bigint,
+  secret text NOT NULL DEFAULT 'secret',
+  server varchar(64),
+  community varchar(50),
+  description varchar(200) DEFAULT 'RADIUS Client',
+  require_ma varchar(4) DEFAULT 'auto',
+  limit_proxy_state varchar(4) DEFAULT 'auto',
+  secret_rotated_at timestamptz,
+  health_check boolean DEFAULT false,
+  health_check_port bigint DEFAULT 0,
+  health_status varchar(8) DEFAULT 'unknown',
+  last_seen_at timestamptz,
+  last_checked_at timestamptz,
+  last_rtt_ms bigint,
+  tenant_id bigint,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS session_events (
+  id bigserial,
+  event_type varchar(8) NOT NULL,
+  acct_session_id varchar(64) NOT NULL,
+  acct_unique_id varchar(32),
+  username varchar(64) NOT NULL,
+  group_name varchar(64),
+  nas_ip_address varchar(45) NOT NULL,
+  nas_port_id varchar(32),
+  framed_ip_address varchar(45),
+  session_time bigint,
+  input_octets bigint,
+  output_octets bigint,
+  terminate_cause varchar(32),
+  event_time timestamptz NOT NULL,
+  created_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_session_events_nas_ip_address ON session_events (nas_ip_address);
+CREATE INDEX IF NOT EXISTS idx_session_events_username ON session_events (username);
+CREATE INDEX IF NOT EXISTS idx_session_events_acct_session_id ON session_events (acct_session_id);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+  id bigserial,
+  event_id varchar(36) NOT NULL,
+  event_type varchar(64) NOT NULL,
+  aggregate_type varchar(32) NOT NULL,
+  aggregate_id varchar(64) NOT NULL,
+  payload text NOT NULL,
+  occurred_at timestamptz NOT NULL,
+  attempts bigint NOT NULL DEFAULT 0,
+  next_attempt_at timestamptz NOT NULL,
+  last_error text,
+  published_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_next_attempt_at ON outbox_messages (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_event_type ON outbox_messages (event_type);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_event_id ON outbox_messages (event_id);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id bigserial,
+  url varchar(2048) NOT NULL,
+  event_types varchar(1024) NOT NULL,
+  secret text NOT NULL,
+  description varchar(200),
+  active boolean DEFAULT true,
+  created_at timestamptz,
+  updated_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions (active);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id bigserial,
+  subscription_id bigint NOT NULL,
+  event_id varchar(36) NOT NULL,
+  event_type varchar(64) NOT NULL,
+  payload text NOT NULL,
+  status varchar(16) NOT NULL DEFAULT 'pending',
+  attempts bigint NOT NULL DEFAULT 0,
+  response_status bigint,
+  response_body text,
+  last_error text,
+  next_attempt_at timestamptz,
+  delivered_at timestamptz,
+  created_at timestamptz,
+  updated_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id,event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id_id ON webhook_deliveries (subscription_id,id);
+
+CREATE TABLE IF NOT EXISTS audit_entries (
+  id bigserial,
+  occurred_at timestamptz NOT NULL,
+  actor varchar(128) NOT NULL,
+  source varchar(16) NOT NULL,
+  request_id varchar(64),
+  tenant_id bigint,
+  entity_type varchar(32) NOT NULL,
+  entity_id varchar(64) NOT NULL,
+  action varchar(16) NOT NULL,
+  changes text NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type,entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_request_id ON audit_entries (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_entries (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_tenant_id_occurred_at_id ON audit_entries (tenant_id,occurred_at,id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_occurred_at_id ON audit_entries (occurred_at,id);
+
+CREATE TABLE IF NOT EXISTS admin_sessions (
+  id varchar(36),
+  user_id bigint NOT NULL,
+  refresh_token_hash varchar(64) NOT NULL,
+  user_agent varchar(255),
+  client_ip varchar(64),
+  created_at timestamptz,
+  last_used_at timestamptz,
+  expires_at timestamptz NOT NULL,
+  revoked_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_admin_sessions_user_id ON admin_sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+  id bigserial,
+  name varchar(100) NOT NULL,
+  prefix varchar(16) NOT NULL,
+  secret_hash varchar(64) NOT NULL,
+  scopes varchar(1024) NOT NULL,
+  allowed_c_id_rs varchar(1024),
+  created_by bigint,
+  tenant_id bigint,
+  expires_at timestamptz,
+  last_used_at timestamptz,
+  last_used_ip varchar(45),
+  revoked_at timestamptz,
+  created_at timestamptz,
+  updated_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_id ON api_keys (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_by ON api_keys (created_by);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+
+CREATE TABLE IF NOT EXISTS tenants (
+  id bigserial,
+  name varchar(100) NOT NULL,
+  realm varchar(48) NOT NULL,
+  created_at timestamptz,
+  updated_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenants_realm ON tenants (realm);
@@ -0,0 +1,20 @@
+-- The check and reply tables of the FreeRADIUS schema, left alone where
+-- FreeRADIUS already created them.
+
+CREATE TABLE IF NOT EXISTS radcheck (
+  id serial PRIMARY KEY,
+  username text NOT NULL DEFAULT '',
+  attribute text NOT NULL DEFAULT '',
+  op varchar(2) NOT NULL DEFAULT '==',
+  value text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS radcheck_username ON radcheck (username,attribute);
+
+CREATE TABLE IF NOT EXISTS radreply (
+  id serial PRIMARY KEY,
+  username text NOT NULL DEFAULT '',
+  attribute text NOT NULL DEFAULT '',
+  op varchar(2) NOT NULL DEFAULT '=',
+  value text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS radreply_username ON radreply (username,attribute);
@@ -0,0 +1,190 @@
+-- Tables of the service itself, next to the FreeRADIUS ones.
+
+CREATE TABLE IF NOT EXISTS users (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name text NOT NULL,
+  email varchar(255) NOT NULL,
+  password text NOT NULL,
+  role text NOT NULL DEFAULT 'readonly',
+  tenant_id integer,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users (tenant_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS payments (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  amount real NOT NULL,
+  currency text NOT NULL,
+  status text DEFAULT 'pending',
+  description text,
+  user_id integer NOT NULL,
+  tenant_id integer,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_payments_deleted_at ON payments (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payments_tenant_id_id ON payments (tenant_id,id);
+CREATE INDEX IF NOT EXISTS idx_payments_user_id_id ON payments (user_id,id);
+
+-- Indexed by 0005, as FreeRADIUS may have created nas without these columns
+CREATE TABLE IF NOT EXISTS nas (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  nas_name text NOT NULL,
+  short_name text,
+  type text DEFAULT 'other',
+  ports integer,
+  secret text NOT NULL DEFAULT 'secret',
+  server text,
+  community text,
+  description text DEFAULT 'RADIUS Client',
+  require_ma text DEFAULT 'auto',
+  limit_proxy_state text DEFAULT 'auto',
+  secret_rotated_at datetime,
+  health_check numeric DEFAULT false,
+  health_check_port integer DEFAULT 0,
+  health_status text DEFAULT 'unknown',
+  last_seen_at datetime,
+  last_checked_at datetime,
+  last_rtt_ms integer,
+  tenant_id integer,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS session_events (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  event_type text NOT NULL,
+  acct_session_id text NOT NULL,
+  acct_unique_id text,
+  username text NOT NULL,
+  group_name text,
+  nas_ip_address text NOT NULL,
+  nas_port_id text,
+  framed_ip_address text,
+  session_time integer,
+  input_octets integer,
+  output_octets integer,
+  terminate_cause text,
+  event_time datetime NOT NULL,
+  created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_session_events_nas_ip_address ON session_events (nas_ip_address);
+CREATE INDEX IF NOT EXISTS idx_session_events_username ON session_events (username);
+CREATE INDEX IF NOT EXISTS idx_session_events_acct_session_id ON session_events (acct_session_id);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  event_id text NOT NULL,
+  event_type text NOT NULL,
+  aggregate_type text NOT NULL,
+  aggregate_id text NOT NULL,
+  payload text NOT NULL,
+  occurred_at datetime NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at datetime NOT NULL,
+  last_error text,
+  published_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_next_attempt_at ON outbox_messages (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_event_type ON outbox_messages (event_type);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_event_id ON outbox_messages (event_id);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  url text NOT NULL,
+  event_types text NOT NULL,
+  secret text NOT NULL,
+  description text,
+  active numeric DEFAULT true,
+  created_at datetime,
+  updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions (active);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  subscription_id integer NOT NULL,
+  event_id text NOT NULL,
+  event_type text NOT NULL,
+  payload text NOT NULL,
+  status text NOT NULL DEFAULT 'pending',
+  attempts integer NOT NULL DEFAULT 0,
+  response_status integer,
+  response_body text,
+  last_error text,
+  next_attempt_at datetime,
+  delivered_at datetime,
+  created_at datetime,
+  updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id,event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id_id ON webhook_deliveries (subscription_id,id);
+
+CREATE TABLE IF NOT EXISTS audit_entries (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  occurred_at datetime NOT NULL,
+  actor text NOT NULL,
+  source text NOT NULL,
+  request_id text,
+  tenant_id integer,
+  entity_type text NOT NULL,
+  entity_id text NOT NULL,
+  action text NOT NULL,
+  changes text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type,entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_request_id ON audit_entries (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_entries (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_tenant_id_occurred_at_id ON audit_entries (tenant_id,occurred_at,id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_occurred_at_id ON audit_entries (occurred_at,id);
+
+CREATE TABLE IF NOT EXISTS admin_sessions (
+  id text,
+  user_id integer NOT NULL,
+  refresh_token_hash text NOT NULL,
+  user_agent text,
+  client_ip text,
+  created_at datetime,
+  last_used_at datetime,
+  expires_at datetime NOT NULL,
+  revoked_at datetime,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_admin_sessions_user_id ON admin_sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name text NOT NULL,
+  prefix text NOT NULL,
+  secret_hash text NOT NULL,
+  scopes text NOT NULL,
+  allowed_c_id_rs text,
+  created_by integer,
+  tenant_id integer,
+  expires_at datetime,
+  last_used_at datetime,
+  last_used_ip text,
+  revoked_at datetime,
+  created_at datetime,
+  updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_id ON api_keys (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_by ON api_keys (created_by);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+
+CREATE TABLE IF NOT EXISTS tenants (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name text NOT NULL,
+  realm text NOT NULL,
+  created_at datetime,
+  updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenants_realm ON tenants (realm);
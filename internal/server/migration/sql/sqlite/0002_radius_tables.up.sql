@@ -0,0 +1,20 @@
+-- The check and reply tables of the FreeRADIUS schema, left alone where
+-- FreeRADIUS already created them.
+
+CREATE TABLE IF NOT EXISTS radcheck (
+  id INTEGER PRIMARY KEY,
+  username varchar(64) NOT NULL default '',
+  attribute varchar(64) NOT NULL default '',
+  op char(2) NOT NULL DEFAULT '==',
+  value varchar(253) NOT NULL default ''
+);
+CREATE INDEX IF NOT EXISTS radcheck_username ON radcheck (username,attribute);
+
+CREATE TABLE IF NOT EXISTS radreply (
+  id INTEGER PRIMARY KEY,
+  username varchar(64) NOT NULL default '',
+  attribute varchar(64) NOT NULL default '',
+  op char(2) NOT NULL DEFAULT '=',
+  value varchar(253) NOT NULL default ''
+);
+CREATE INDEX IF NOT EXISTS radreply_username ON radreply (username,attribute);
@@ -0,0 +1,90 @@
+-- The rest of the FreeRADIUS schema, as upstream ships it, left alone where
+-- FreeRADIUS already created it.
+
+CREATE TABLE IF NOT EXISTS radgroupcheck (
+  id INTEGER PRIMARY KEY,
+  groupname varchar(64) NOT NULL default '',
+  attribute varchar(64) NOT NULL default '',
+  op char(2) NOT NULL DEFAULT '==',
+  value varchar(253) NOT NULL default ''
+);
+CREATE INDEX IF NOT EXISTS radgroupcheck_groupname ON radgroupcheck (groupname,attribute);
+
+CREATE TABLE IF NOT EXISTS radgroupreply (
+  id INTEGER PRIMARY KEY,
+  groupname varchar(64) NOT NULL default '',
+  attribute varchar(64) NOT NULL default '',
+  op char(2) NOT NULL DEFAULT '=',
+  value varchar(253) NOT NULL default ''
+);
+CREATE INDEX IF NOT EXISTS radgroupreply_groupname ON radgroupreply (groupname,attribute);
+
+CREATE TABLE IF NOT EXISTS radusergroup (
+  id INTEGER PRIMARY KEY,
+  username varchar(64) NOT NULL default '',
+  groupname varchar(64) NOT NULL default '',
+  priority int(11) NOT NULL default '1'
+);
+CREATE INDEX IF NOT EXISTS radusergroup_username ON radusergroup (username);
+
+CREATE TABLE IF NOT EXISTS radacct (
+  radacctid INTEGER PRIMARY KEY AUTOINCREMENT,
+  acctsessionid varchar(64) NOT NULL default '',
+  acctuniqueid varchar(32) NOT NULL default '',
+  username varchar(64) NOT NULL default '',
+  realm varchar(64) default '',
+  nasipaddress varchar(15) NOT NULL default '',
+  nasportid varchar(15) default NULL,
+  nasporttype varchar(32) default NULL,
+  acctstarttime datetime NULL default NULL,
+  acctupdatetime datetime NULL default NULL,
+  acctstoptime datetime NULL default NULL,
+  acctinterval int(12) default NULL,
+  acctsessiontime int(12) default NULL,
+  acctauthentic varchar(32) default NULL,
+  connectinfo_start varchar(50) default NULL,
+  connectinfo_stop varchar(50) default NULL,
+  acctinputoctets bigint(20) default NULL,
+  acctoutputoctets bigint(20) default NULL,
+  calledstationid varchar(50) NOT NULL default '',
+  callingstationid varchar(50) NOT NULL default '',
+  acctterminatecause varchar(32) NOT NULL default '',
+  servicetype varchar(32) default NULL,
+  framedprotocol varchar(32) default NULL,
+  framedipaddress varchar(15) NOT NULL default '',
+  framedipv6address varchar(45) NOT NULL default '',
+  framedipv6prefix varchar(45) NOT NULL default '',
+  framedinterfaceid varchar(44) NOT NULL default '',
+  delegatedipv6prefix varchar(45) NOT NULL default '',
+  class varchar(64) default NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS acctuniqueid ON radacct (acctuniqueid);
+CREATE INDEX IF NOT EXISTS username ON radacct (username);
+CREATE INDEX IF NOT EXISTS framedipaddress ON radacct (framedipaddress);
+CREATE INDEX IF NOT EXISTS framedipv6address ON radacct (framedipv6address);
+CREATE INDEX IF NOT EXISTS framedipv6prefix ON radacct (framedipv6prefix);
+CREATE INDEX IF NOT EXISTS framedinterfaceid ON radacct (framedinterfaceid);
+CREATE INDEX IF NOT EXISTS delegatedipv6prefix ON radacct (delegatedipv6prefix);
+CREATE INDEX IF NOT EXISTS acctsessionid ON radacct (acctsessionid);
+CREATE INDEX IF NOT EXISTS acctsessiontime ON radacct (acctsessiontime);
+CREATE INDEX IF NOT EXISTS acctstarttime ON radacct (acctstarttime);
+CREATE INDEX IF NOT EXISTS acctinterval ON radacct (acctinterval);
+CREATE INDEX IF NOT EXISTS acctstoptime ON radacct (acctstoptime);
+CREATE INDEX IF NOT EXISTS nasipaddress ON radacct (nasipaddress);
+CREATE INDEX IF NOT EXISTS class ON radacct (class);
+
+CREATE TABLE IF NOT EXISTS radpostauth (
+  id INTEGER PRIMARY KEY,
+  username varchar(64) NOT NULL default '',
+  pass varchar(64) NOT NULL default '',
+  reply varchar(32) NOT NULL default '',
+  authdate timestamp NOT NULL,
+  class varchar(64) default NULL
+);
+CREATE INDEX IF NOT EXISTS radpostauth_username ON radpostauth (username);
+CREATE INDEX IF NOT EXISTS radpostauth_class ON radpostauth (class);
+
+CREATE TABLE IF NOT EXISTS nasreload (
+  nasipaddress varchar(15) PRIMARY KEY,
+  reloadtime datetime NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE admin_sessions DROP COLUMN previous_refresh_token_hash;
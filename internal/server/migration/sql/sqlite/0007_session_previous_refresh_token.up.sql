@@ -0,0 +1,3 @@
+-- The hash of the refresh token the current one replaced, to detect its reuse.
+
+ALTER TABLE admin_sessions ADD COLUMN previous_refresh_token_hash text NOT NULL DEFAULT '';
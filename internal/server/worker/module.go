@@ -1,27 +1,51 @@
 package worker
 
 import (
+	"context"
+
+	nasWorker "github.com/novriyantoAli/freeradius-service/internal/application/nas/worker"
 	paymentWorker "github.com/novriyantoAli/freeradius-service/internal/application/payment/worker"
+	webhookWorker "github.com/novriyantoAli/freeradius-service/internal/application/webhook/worker"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/queue"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/requestid"
 
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 type Server struct {
 	paymentWorker *paymentWorker.PaymentWorker
+	healthWorker  *nasWorker.HealthWorker
+	webhookWorker *webhookWorker.DeliveryWorker
+	bus           *events.Bus
 	queueServer   *queue.Server
+	tasks         *metrics.Tasks
+	tracer        trace.TracerProvider
 	logger        *zap.Logger
 }
 
 func NewServer(
 	paymentWorker *paymentWorker.PaymentWorker,
+	healthWorker *nasWorker.HealthWorker,
+	webhookWorker *webhookWorker.DeliveryWorker,
+	bus *events.Bus,
 	queueServer *queue.Server,
+	tasks *metrics.Tasks,
+	tracer trace.TracerProvider,
 	logger *zap.Logger,
 ) *Server {
 	return &Server{
 		paymentWorker: paymentWorker,
+		healthWorker:  healthWorker,
+		webhookWorker: webhookWorker,
+		bus:           bus,
 		queueServer:   queueServer,
+		tasks:         tasks,
+		tracer:        tracer,
 		logger:        logger,
 	}
 }
@@ -29,6 +53,8 @@ func NewServer(
 func (s *Server) RegisterHandlers() {
 	s.logger.Info("Registering worker handlers")
 
+	s.queueServer.Use(s.tasks.Middleware, queue.PropagateRequestID, queue.Trace(s.tracer), auditMetadata)
+
 	// Register payment workers
 	s.queueServer.RegisterHandler(
 		paymentWorker.TypeCheckPaymentStatus,
@@ -40,5 +66,43 @@ func (s *Server) RegisterHandlers() {
 		asynq.HandlerFunc(s.paymentWorker.HandleProcessPayment),
 	)
 
+	// Register NAS workers
+	s.queueServer.RegisterHandler(
+		nasWorker.TypeCheckNASHealth,
+		asynq.HandlerFunc(s.healthWorker.HandleCheckNASHealth),
+	)
+
+	// Register webhook workers and dispatch relayed events to webhooks
+	s.queueServer.RegisterHandler(
+		webhookWorker.TypeDeliverWebhook,
+		asynq.HandlerFunc(s.webhookWorker.HandleDeliverWebhook),
+	)
+	s.webhookWorker.Subscribe(s.bus)
+
 	s.logger.Info("Worker handlers registered successfully")
 }
+
+// RegisterPeriodicTasks schedules the recurring worker tasks.
+func (s *Server) RegisterPeriodicTasks(scheduler *queue.Scheduler) error {
+	s.logger.Info("Registering periodic tasks")
+
+	if err := s.healthWorker.RegisterPeriodicTasks(scheduler); err != nil {
+		return err
+	}
+
+	s.logger.Info("Periodic tasks registered successfully")
+	return nil
+}
+
+// auditMetadata attributes the changes made by a task to the worker and to
+// the request ID queue.PropagateRequestID gave it.
+func auditMetadata(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx = audit.WithMetadata(ctx, audit.Metadata{
+			Actor:     audit.WorkerActor,
+			Source:    audit.SourceWorker,
+			RequestID: requestid.FromContext(ctx),
+		})
+		return next.ProcessTask(ctx, task)
+	})
+}
@@ -1,17 +1,86 @@
 package worker
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/novriyantoAli/freeradius-service/internal/application/nas"
 	"github.com/novriyantoAli/freeradius-service/internal/application/payment"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user"
+	"github.com/novriyantoAli/freeradius-service/internal/application/webhook"
+	"github.com/novriyantoAli/freeradius-service/internal/config"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/events"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/health"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/metrics"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 var Module = fx.Options(
 	// Include domain worker modules
 	payment.WorkerModule,
+	nas.WorkerModule,
 	user.WorkerModule,
+	webhook.WorkerModule,
+
+	// Outbox relay and event subscribers
+	fx.Provide(
+		events.NewBus,
+		provideOutboxSinks,
+		outbox.NewRelay,
+	),
 
 	// Worker api
-	fx.Provide(NewServer),
+	fx.Provide(metrics.NewTasks, provideHealthChecker, NewServer),
+	fx.Invoke(serveMetrics, serveHealth),
 )
+
+// serveMetrics serves the metrics of the worker on metrics.worker_address.
+func serveMetrics(lc fx.Lifecycle, registry *prometheus.Registry, cfg *config.Config, logger *zap.Logger) {
+	if cfg.Metrics.Enabled {
+		metrics.Serve(lc, registry, cfg.Metrics.WorkerAddress, logger)
+	}
+}
+
+// provideHealthChecker checks the dependencies the worker cannot process
+// tasks without: the database, Redis and the task queue it holds.
+func provideHealthChecker(lc fx.Lifecycle, cfg *config.Config, db *gorm.DB) *health.Checker {
+	return health.NewChecker(cfg.Health,
+		health.Check{Name: "database", Critical: true, Probe: health.Database(db)},
+		health.Check{Name: "redis", Critical: true, Probe: health.NewRedisProbe(lc, cfg)},
+		health.Check{Name: "queue", Critical: true, Probe: health.NewQueueProbe(lc, cfg)},
+	)
+}
+
+// serveHealth serves the checks of the worker on health.worker_address.
+func serveHealth(lc fx.Lifecycle, checker *health.Checker, cfg *config.Config, logger *zap.Logger) {
+	health.Serve(lc, checker, cfg.Health.WorkerAddress, logger)
+}
+
+// provideOutboxSinks returns the destinations of relayed events: the
+// in-process bus, and a Redis stream when outbox.redis_stream is set.
+func provideOutboxSinks(lifecycle fx.Lifecycle, cfg *config.Config, bus *events.Bus, logger *zap.Logger) []outbox.Sink {
+	sinks := []outbox.Sink{outbox.NewBusSink(bus)}
+
+	if cfg.Outbox.RedisStream != "" {
+		client := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return client.Close()
+			},
+		})
+		sinks = append(sinks, outbox.NewRedisStreamSink(client, cfg.Outbox.RedisStream))
+		logger.Info("Relaying outbox events to Redis stream", zap.String("stream", cfg.Outbox.RedisStream))
+	}
+
+	return sinks
+}
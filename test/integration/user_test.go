@@ -11,6 +11,9 @@ import (
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/handler"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/repository"
 	"github.com/novriyantoAli/freeradius-service/internal/application/user/service"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/audit"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/database"
+	"github.com/novriyantoAli/freeradius-service/internal/pkg/outbox"
 	"github.com/novriyantoAli/freeradius-service/internal/pkg/testutil"
 
 	"github.com/gin-gonic/gin"
@@ -29,7 +32,7 @@ func setupUserIntegration(t *testing.T) (*gin.Engine, func()) {
 
 	// Create real instances (no mocks)
 	userRepo := repository.NewUserRepository(db, logger)
-	userService := service.NewUserService(userRepo, logger)
+	userService := service.NewUserService(userRepo, database.NewTransactionManager(db), outbox.NewOutbox(db), audit.NewRecorder(db), logger)
 	userHandler := handler.NewUserHandler(userService, logger)
 
 	// Setup Gin router
@@ -123,7 +126,7 @@ func TestUserIntegration_CreateDuplicateEmail(t *testing.T) {
 	var errorResp map[string]interface{}
 	err := json.Unmarshal(w2.Body.Bytes(), &errorResp)
 	require.NoError(t, err)
-	assert.Contains(t, errorResp["error"], "email already exists")
+	assert.Equal(t, "email already exists", errorResp["detail"])
 }
 
 func TestUserIntegration_GetUsers(t *testing.T) {
@@ -161,7 +164,7 @@ func TestUserIntegration_GetUsers(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Len(t, response.Data, 3)
-	assert.Equal(t, int64(3), response.TotalCount)
+	assert.Equal(t, int64(3), *response.TotalCount)
 	assert.Equal(t, 1, response.Page)
 	assert.Equal(t, 10, response.PageSize)
 }